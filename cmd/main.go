@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -76,14 +77,64 @@ func main() {
 	// Initialize repositories
 	appComponentRepo := repository.NewAppComponentRepository(mongoClient.Database())
 	countryRepo := repository.NewCountryRepository(mongoClient.Database())
+	moduleRepo := repository.NewSaaSModuleRepository(mongoClient.Database())
+	packageRepo := repository.NewServicePackageRepository(mongoClient.Database())
+	menuRepo := repository.NewAdminMenuRepository(mongoClient.Database())
+	permissionRepo := repository.NewPermissionRepository(mongoClient.Database())
+	roleRepo := repository.NewRoleRepository(mongoClient.Database())
+	provinceRepo := repository.NewProvinceRepository(mongoClient.Database())
+	districtRepo := repository.NewDistrictRepository(mongoClient.Database())
+	wardRepo := repository.NewWardRepository(mongoClient.Database())
+	currencyRepo := repository.NewCurrencyRepository(mongoClient.Database())
+	exchangeRateRepo := repository.NewExchangeRateRepository(mongoClient.Database())
+	ethnicityRepo := repository.NewEthnicityRepository(mongoClient.Database())
+	configRepo := repository.NewConfigRepository(mongoClient.Database())
+	configVersionRepo := repository.NewConfigVersionRepository(mongoClient.Database())
+	configOverrideRepo := repository.NewConfigOverrideRepository(mongoClient.Database())
+	configRuleRepo := repository.NewConfigRuleRepository(mongoClient.Database())
+	configSchemaRepo := repository.NewConfigSchemaRepository(mongoClient.Database())
+	transactor := repository.NewTransactor(mongoClient.Database())
 
 	// Initialize services
-	appComponentService := service.NewAppComponentService(appComponentRepo, redisClient, log)
-	countryService := service.NewCountryService(countryRepo, redisClient, log)
+	configRuleService := service.NewConfigRuleService(configRuleRepo, redisClient, log)
+	configSchemaService := service.NewConfigSchemaService(configSchemaRepo, redisClient, log)
+	appComponentService := service.NewAppComponentService(appComponentRepo, configVersionRepo, transactor, configRuleService, configSchemaService, redisClient, log)
+	countryService := service.NewCountryService(countryRepo, provinceRepo, currencyRepo, ethnicityRepo, redisClient, log)
+	moduleService := service.NewSaaSModuleService(moduleRepo, packageRepo, redisClient, log)
+	packageService := service.NewServicePackageService(packageRepo, moduleService, configSchemaService, log)
+	menuService := service.NewAdminMenuService(menuRepo, transactor, redisClient, log)
+	permissionService := service.NewPermissionService(permissionRepo, redisClient, log)
+	roleService := service.NewRoleService(roleRepo, permissionService, redisClient, log)
+	tenantService := service.NewTenantService(roleService, moduleService, menuService, log)
+	locationService := service.NewLocationService(countryRepo, provinceRepo, districtRepo, wardRepo, transactor, redisClient, log)
+	currencyService := service.NewCurrencyService(currencyRepo, countryRepo, packageRepo, redisClient, log)
+	exchangeRateService := service.NewExchangeRateService(exchangeRateRepo, currencyRepo, log)
+	ethnicityService := service.NewEthnicityService(ethnicityRepo, countryRepo, redisClient, log)
+	configService := service.NewConfigService(configRepo, configVersionRepo, configOverrideRepo, configRuleService, redisClient, log)
+
+	supportedLocales := os.Getenv("SUPPORTED_LOCALES")
+	if supportedLocales == "" {
+		supportedLocales = "en,vi"
+	}
+	translationService := service.NewTranslationService(countryService, currencyService, ethnicityService, locationService, menuService, strings.Split(supportedLocales, ","), log)
 
 	// Initialize handlers
 	appComponentHandler := handler.NewAppComponentHandler(appComponentService, log)
 	countryHandler := handler.NewCountryHandler(countryService, log)
+	moduleHandler := handler.NewSaaSModuleHandler(moduleService, log)
+	packageHandler := handler.NewServicePackageHandler(packageService, log)
+	menuHandler := handler.NewAdminMenuHandler(menuService, log)
+	permissionHandler := handler.NewPermissionHandler(permissionService, log)
+	roleHandler := handler.NewRoleHandler(roleService, log)
+	tenantHandler := handler.NewTenantHandler(tenantService, log)
+	locationHandler := handler.NewLocationHandler(locationService, log)
+	currencyHandler := handler.NewCurrencyHandler(currencyService, log)
+	exchangeRateHandler := handler.NewExchangeRateHandler(exchangeRateService, log)
+	ethnicityHandler := handler.NewEthnicityHandler(ethnicityService, log)
+	translationHandler := handler.NewTranslationHandler(translationService, log)
+	configHandler := handler.NewConfigHandler(configService, log)
+	configRuleHandler := handler.NewConfigRuleHandler(configRuleService, log)
+	configSchemaHandler := handler.NewConfigSchemaHandler(configSchemaService, log)
 
 	// Start gRPC server
 	grpcPort := os.Getenv("SYSTEM_CONFIG_SERVICE_PORT")
@@ -97,7 +148,9 @@ func main() {
 	if httpPort == "" {
 		httpPort = "8085"
 	}
-	startHTTPServer(appComponentHandler, countryHandler, log, httpPort)
+	gin.SetMode(gin.ReleaseMode)
+	r := router.SetupRouter(appComponentHandler, countryHandler, moduleHandler, packageHandler, menuHandler, permissionHandler, roleHandler, tenantHandler, locationHandler, currencyHandler, exchangeRateHandler, ethnicityHandler, translationHandler, configHandler, configRuleHandler, configSchemaHandler, log)
+	startHTTPServer(r, log, httpPort)
 }
 
 func startGRPCServer(log *logger.Logger, port string) {
@@ -119,9 +172,7 @@ func startGRPCServer(log *logger.Logger, port string) {
 	}
 }
 
-func startHTTPServer(appComponentHandler *handler.AppComponentHandler, countryHandler *handler.CountryHandler, log *logger.Logger, port string) {
-	gin.SetMode(gin.ReleaseMode)
-	r := router.SetupRouter(appComponentHandler, countryHandler, log)
+func startHTTPServer(r *gin.Engine, log *logger.Logger, port string) {
 
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%s", port),
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -25,3 +26,52 @@ type AdminMenu struct {
 	CreatedAt   time.Time          `json:"created_at" bson:"createdAt"`
 	UpdatedAt   time.Time          `json:"updated_at" bson:"updatedAt"`
 }
+
+// Validate validates the admin menu data
+func (m *AdminMenu) Validate() error {
+	if m.Code == "" {
+		return errors.New("code is required")
+	}
+	if m.Name == "" && len(m.Title) == 0 {
+		return errors.New("name or title is required")
+	}
+	if m.ParentID != "" {
+		if !primitive.IsValidObjectID(m.ParentID) {
+			return errors.New("parent_id must be a valid ID")
+		}
+		if m.ParentID == m.ID.Hex() {
+			return errors.New("menu cannot be its own parent")
+		}
+	}
+	return nil
+}
+
+// MenuPosition places a menu item under a parent at a sibling order
+type MenuPosition struct {
+	ID       primitive.ObjectID `json:"id"`
+	ParentID string             `json:"parent_id"`
+	Order    int                `json:"order"`
+}
+
+// MenuNode is a rendered admin menu item with its localized title and visible children
+type MenuNode struct {
+	ID          string      `json:"id"`
+	Code        string      `json:"code"`
+	ModuleCode  string      `json:"module_code"`
+	Title       string      `json:"title"`
+	Icon        string      `json:"icon"`
+	Path        string      `json:"path"`
+	Component   string      `json:"component"`
+	Order       int         `json:"order"`
+	Permissions []string    `json:"permissions"`
+	Children    []*MenuNode `json:"children"`
+}
+
+// MenuTree is a ready-to-render navigation tree.
+// Orphans and Cycles list the codes of menu items that could not be attached to the tree.
+type MenuTree struct {
+	Locale  string      `json:"locale"`
+	Items   []*MenuNode `json:"items"`
+	Orphans []string    `json:"orphans,omitempty"`
+	Cycles  [][]string  `json:"cycles,omitempty"`
+}
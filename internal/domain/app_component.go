@@ -22,6 +22,9 @@ type AppComponent struct {
 	UpdatedAt   time.Time              `json:"updated_at" bson:"updatedAt"`
 	CreatedBy   string                 `json:"created_by" bson:"createdBy"`
 	UpdatedBy   string                 `json:"updated_by" bson:"updatedBy"`
+
+	// ChangeReason explains a change to Config; it is kept in the config history, not stored here
+	ChangeReason string `json:"change_reason,omitempty" bson:"-"`
 }
 
 // Validate validates the app component data
@@ -0,0 +1,288 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Config value types
+const (
+	ConfigTypeString   = "string"
+	ConfigTypeInt      = "int"
+	ConfigTypeFloat    = "float"
+	ConfigTypeBool     = "bool"
+	ConfigTypeDuration = "duration" // Go duration string such as "30s"
+	ConfigTypeJSON     = "json"     // any JSON object or array
+)
+
+// ConfigTypes lists the supported config value types
+var ConfigTypes = []string{ConfigTypeString, ConfigTypeInt, ConfigTypeFloat, ConfigTypeBool, ConfigTypeDuration, ConfigTypeJSON}
+
+var (
+	configKeyPattern         = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,255}$`)
+	configEnvironmentPattern = regexp.MustCompile(`^[a-z][a-z0-9-]{0,31}$`)
+)
+
+// ConfigEntry is a configuration key of a tenant in an environment with the value of its active
+// version. An empty TenantID is the global config shared by every tenant, and an empty
+// Environment applies to every environment. Secret entries take precedence over every other
+// config when configs are resolved.
+type ConfigEntry struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	TenantID      string             `json:"tenant_id" bson:"tenantId"`
+	Key           string             `json:"key" bson:"configKey"`
+	Environment   string             `json:"environment" bson:"environment"`
+	Description   string             `json:"description" bson:"description"`
+	ValueType     string             `json:"value_type" bson:"valueType"`
+	Value         interface{}        `json:"value" bson:"value"`
+	Secret        bool               `json:"secret" bson:"secret"`
+	ActiveVersion int                `json:"active_version" bson:"activeVersion"`
+	LatestVersion int                `json:"latest_version" bson:"latestVersion"`
+	CreatedAt     time.Time          `json:"created_at" bson:"createdAt"`
+	UpdatedAt     time.Time          `json:"updated_at" bson:"updatedAt"`
+	CreatedBy     string             `json:"created_by" bson:"createdBy"`
+	UpdatedBy     string             `json:"updated_by" bson:"updatedBy"`
+}
+
+// MaskedConfigValue stands in for the values of secret config entries in responses that do not
+// reveal them
+const MaskedConfigValue = "********"
+
+// MaskSecret replaces the value of a secret entry with MaskedConfigValue
+func (e *ConfigEntry) MaskSecret() {
+	if e.Secret && e.Value != nil {
+		e.Value = MaskedConfigValue
+	}
+}
+
+// ConfigVersion is an immutable value written to a config entry. Versions are numbered from 1
+// per entry. The versions of app component configs are stored the same way, with the component
+// ID as ConfigID and its code as Key.
+type ConfigVersion struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ConfigID    primitive.ObjectID `json:"config_id" bson:"configId"`
+	TenantID    string             `json:"tenant_id" bson:"tenantId"`
+	Key         string             `json:"key" bson:"configKey"`
+	Environment string             `json:"environment" bson:"environment"`
+	Version     int                `json:"version" bson:"versionNumber"`
+	ValueType   string             `json:"value_type" bson:"valueType"`
+	Value       interface{}        `json:"value" bson:"value"`
+	Reason      string             `json:"reason,omitempty" bson:"reason,omitempty"`
+	RollbackOf  int                `json:"rollback_of,omitempty" bson:"rollbackOf,omitempty"` // version restored by a rollback
+	CreatedAt   time.Time          `json:"created_at" bson:"createdAt"`
+	CreatedBy   string             `json:"created_by" bson:"createdBy"`
+
+	// Active marks the active version in histories; it is not stored
+	Active bool `json:"active" bson:"-"`
+}
+
+// Mask replaces the value of a version of a secret entry with MaskedConfigValue
+func (v *ConfigVersion) Mask() {
+	v.Value = MaskedConfigValue
+}
+
+// ConfigWriteRequest writes a new version of a config entry. Without ValueType the type is
+// inferred from the JSON value; a draft version is stored without being activated.
+type ConfigWriteRequest struct {
+	Key         string      `json:"key"`
+	Environment string      `json:"environment"`
+	Description *string     `json:"description"` // kept as is when nil on updates
+	Secret      *bool       `json:"secret"`      // kept as is when nil on updates
+	ValueType   string      `json:"value_type"`
+	Value       interface{} `json:"value"`
+	Reason      string      `json:"reason"` // why the value changed, kept with the version
+	Draft       bool        `json:"draft"`
+}
+
+// Normalize trims the key and lower-cases the environment and value type
+func (r *ConfigWriteRequest) Normalize() {
+	r.Key = strings.TrimSpace(r.Key)
+	r.Environment = strings.ToLower(strings.TrimSpace(r.Environment))
+	r.ValueType = strings.ToLower(strings.TrimSpace(r.ValueType))
+}
+
+// Validate validates the write and normalizes its value to its type
+func (r *ConfigWriteRequest) Validate() error {
+	if err := ValidateConfigKey(r.Key); err != nil {
+		return err
+	}
+	if err := ValidateConfigEnvironment(r.Environment); err != nil {
+		return err
+	}
+	valueType, value, err := NormalizeConfigValue(r.ValueType, r.Value)
+	if err != nil {
+		return err
+	}
+	r.ValueType, r.Value = valueType, value
+	return nil
+}
+
+// ConfigActivateRequest activates a stored version of a config entry
+type ConfigActivateRequest struct {
+	Version int `json:"version" binding:"required"`
+}
+
+// ConfigRollbackRequest restores the value of an earlier version as a new active version
+type ConfigRollbackRequest struct {
+	Version int    `json:"version" binding:"required"`
+	Reason  string `json:"reason"`
+}
+
+// ConfigQuery selects config entries of a tenant
+type ConfigQuery struct {
+	TenantID    string `form:"-"`
+	Environment string `form:"environment"`
+	Prefix      string `form:"prefix"` // key prefix such as "db."
+}
+
+// ValidateConfigKey checks that a key is a dotted name such as "db.timeout"
+func ValidateConfigKey(key string) error {
+	if key == "" {
+		return errors.New("key is required")
+	}
+	if !configKeyPattern.MatchString(key) {
+		return errors.New("key may only contain letters, digits, '.', '_' and '-' and must start with a letter or digit")
+	}
+	return nil
+}
+
+// ValidateConfigEnvironment checks an environment name; empty means every environment
+func ValidateConfigEnvironment(environment string) error {
+	if environment != "" && !configEnvironmentPattern.MatchString(environment) {
+		return errors.New("environment must be a lower-case name such as production")
+	}
+	return nil
+}
+
+// NormalizeConfigValue checks a value against a config type, inferring the type from the value
+// when valueType is empty. Numbers are returned as int64 or float64 and JSON values as deep
+// copies made of maps, slices and scalars, whichever way they were decoded.
+func NormalizeConfigValue(valueType string, value interface{}) (string, interface{}, error) {
+	if value == nil {
+		return "", nil, errors.New("value is required")
+	}
+	plain, err := PlainConfigValue(value)
+	if err != nil {
+		return "", nil, err
+	}
+	if valueType == "" {
+		valueType = inferConfigType(plain)
+	}
+
+	switch valueType {
+	case ConfigTypeString:
+		if s, ok := plain.(string); ok {
+			return valueType, s, nil
+		}
+	case ConfigTypeInt:
+		if f, ok := plain.(float64); ok && f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+			return valueType, int64(f), nil
+		}
+		if i, ok := plain.(int64); ok {
+			return valueType, i, nil
+		}
+	case ConfigTypeFloat:
+		switch n := plain.(type) {
+		case float64:
+			return valueType, n, nil
+		case int64:
+			return valueType, float64(n), nil
+		}
+	case ConfigTypeBool:
+		if b, ok := plain.(bool); ok {
+			return valueType, b, nil
+		}
+	case ConfigTypeDuration:
+		if s, ok := plain.(string); ok {
+			if _, err := time.ParseDuration(s); err == nil {
+				return valueType, s, nil
+			}
+		}
+	case ConfigTypeJSON:
+		switch plain.(type) {
+		case map[string]interface{}, []interface{}:
+			return valueType, plain, nil
+		}
+	default:
+		return "", nil, fmt.Errorf("value_type must be one of %s", strings.Join(ConfigTypes, ", "))
+	}
+	return "", nil, fmt.Errorf("value is not a valid %s", valueType)
+}
+
+// inferConfigType returns the type of a plain value
+func inferConfigType(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return ConfigTypeString
+	case bool:
+		return ConfigTypeBool
+	case int64:
+		return ConfigTypeInt
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return ConfigTypeInt
+		}
+		return ConfigTypeFloat
+	default:
+		return ConfigTypeJSON
+	}
+}
+
+// PlainConfigValue deep-copies a value into maps, slices, strings, bools, int64 and float64.
+// It accepts JSON decoded values, BSON documents and arrays, and Go integers.
+func PlainConfigValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil, string, bool, float64, int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case float32:
+		return float64(v), nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		return v.Float64()
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			plain, err := PlainConfigValue(item)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = plain
+		}
+		return out, nil
+	case primitive.M:
+		return PlainConfigValue(map[string]interface{}(v))
+	case primitive.D:
+		m := make(map[string]interface{}, len(v))
+		for _, element := range v {
+			m[element.Key] = element.Value
+		}
+		return PlainConfigValue(m)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			plain, err := PlainConfigValue(item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = plain
+		}
+		return out, nil
+	case primitive.A:
+		return PlainConfigValue([]interface{}(v))
+	default:
+		return nil, fmt.Errorf("value of type %T is not supported", value)
+	}
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Config change operations
+const (
+	ConfigChangeAdded   = "added"
+	ConfigChangeRemoved = "removed"
+	ConfigChangeChanged = "changed"
+)
+
+// ConfigChange is a difference between two config values at a JSON pointer path, "" being the
+// whole value
+type ConfigChange struct {
+	Path string      `json:"path"`
+	Op   string      `json:"op"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// ConfigDiff lists the changes from one version of a config to another
+type ConfigDiff struct {
+	From     int            `json:"from"`
+	To       int            `json:"to"`
+	FromType string         `json:"from_type"`
+	ToType   string         `json:"to_type"`
+	Changes  []ConfigChange `json:"changes"`
+}
+
+// Mask replaces the old and new values of a diff between versions of a secret entry with
+// MaskedConfigValue, keeping the paths that changed
+func (d *ConfigDiff) Mask() {
+	for i := range d.Changes {
+		if d.Changes[i].Old != nil {
+			d.Changes[i].Old = MaskedConfigValue
+		}
+		if d.Changes[i].New != nil {
+			d.Changes[i].New = MaskedConfigValue
+		}
+	}
+}
+
+// DiffConfigValues compares two plain config values structurally. Objects are compared key by
+// key and arrays index by index, down to individual scalars; values of different kinds are
+// reported as one change. Object keys are visited in sorted order and array items by index.
+func DiffConfigValues(old, new interface{}) []ConfigChange {
+	changes := []ConfigChange{}
+	diffConfigValues("", old, new, &changes)
+	return changes
+}
+
+func diffConfigValues(path string, old, new interface{}, changes *[]ConfigChange) {
+	switch o := old.(type) {
+	case map[string]interface{}:
+		if n, ok := new.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(o)+len(n))
+			for key := range o {
+				keys = append(keys, key)
+			}
+			for key := range n {
+				if _, ok := o[key]; !ok {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				child := path + "/" + escapeJSONPointer(key)
+				oldValue, inOld := o[key]
+				newValue, inNew := n[key]
+				switch {
+				case !inNew:
+					*changes = append(*changes, ConfigChange{Path: child, Op: ConfigChangeRemoved, Old: oldValue})
+				case !inOld:
+					*changes = append(*changes, ConfigChange{Path: child, Op: ConfigChangeAdded, New: newValue})
+				default:
+					diffConfigValues(child, oldValue, newValue, changes)
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if n, ok := new.([]interface{}); ok {
+			for i := 0; i < len(o) || i < len(n); i++ {
+				child := path + "/" + strconv.Itoa(i)
+				switch {
+				case i >= len(n):
+					*changes = append(*changes, ConfigChange{Path: child, Op: ConfigChangeRemoved, Old: o[i]})
+				case i >= len(o):
+					*changes = append(*changes, ConfigChange{Path: child, Op: ConfigChangeAdded, New: n[i]})
+				default:
+					diffConfigValues(child, o[i], n[i], changes)
+				}
+			}
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(old, new) {
+		*changes = append(*changes, ConfigChange{Path: path, Op: ConfigChangeChanged, Old: old, New: new})
+	}
+}
+
+// escapeJSONPointer escapes a key for use as a JSON pointer reference token (RFC 6901)
+func escapeJSONPointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Config resolution layers, lowest priority first. Within a layer tenant values win over global
+// ones and environment values over those for every environment.
+const (
+	ConfigLayerDefault     = "default"     // global config for every environment
+	ConfigLayerGlobal      = "global"      // global config of the environment
+	ConfigLayerTenant      = "tenant"      // tenant config for every environment
+	ConfigLayerEnvironment = "environment" // tenant config of the environment
+	ConfigLayerSecret      = "secret"      // secret config entries of any of the above
+	ConfigLayerOverride    = "override"    // runtime overrides
+)
+
+// ConfigLayers lists the config resolution layers, lowest priority first
+var ConfigLayers = []string{ConfigLayerDefault, ConfigLayerGlobal, ConfigLayerTenant, ConfigLayerEnvironment, ConfigLayerSecret, ConfigLayerOverride}
+
+// ConfigOverride is a runtime override of a config key for a tenant and environment. Overrides
+// are not versioned; they take precedence over every config entry until they are removed or
+// expire.
+type ConfigOverride struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	TenantID    string             `json:"tenant_id" bson:"tenantId"`
+	Key         string             `json:"key" bson:"configKey"`
+	Environment string             `json:"environment" bson:"environment"`
+	ValueType   string             `json:"value_type" bson:"valueType"`
+	Value       interface{}        `json:"value" bson:"value"`
+	Reason      string             `json:"reason,omitempty" bson:"reason,omitempty"`
+	ExpiresAt   *time.Time         `json:"expires_at,omitempty" bson:"expiresAt,omitempty"`
+	CreatedAt   time.Time          `json:"created_at" bson:"createdAt"`
+	CreatedBy   string             `json:"created_by" bson:"createdBy"`
+}
+
+// Expired reports whether the override has expired at the given time
+func (o *ConfigOverride) Expired(now time.Time) bool {
+	return o.ExpiresAt != nil && !now.Before(*o.ExpiresAt)
+}
+
+// ConfigOverrideRequest sets the runtime override of a config key. TTL is a Go duration such as
+// "15m" after which the override expires; without one it stays until removed.
+type ConfigOverrideRequest struct {
+	Environment string      `json:"environment"`
+	ValueType   string      `json:"value_type"`
+	Value       interface{} `json:"value"`
+	Reason      string      `json:"reason"`
+	TTL         string      `json:"ttl"`
+}
+
+// Normalize lower-cases the environment and value type and trims the reason and TTL
+func (r *ConfigOverrideRequest) Normalize() {
+	r.Environment = strings.ToLower(strings.TrimSpace(r.Environment))
+	r.ValueType = strings.ToLower(strings.TrimSpace(r.ValueType))
+	r.Reason = strings.TrimSpace(r.Reason)
+	r.TTL = strings.TrimSpace(r.TTL)
+}
+
+// Validate validates the override and normalizes its value to its type
+func (r *ConfigOverrideRequest) Validate() error {
+	if err := ValidateConfigEnvironment(r.Environment); err != nil {
+		return err
+	}
+	if r.TTL != "" {
+		ttl, err := time.ParseDuration(r.TTL)
+		if err != nil || ttl <= 0 {
+			return errors.New("ttl must be a positive duration such as 15m")
+		}
+	}
+	valueType, value, err := NormalizeConfigValue(r.ValueType, r.Value)
+	if err != nil {
+		return err
+	}
+	r.ValueType, r.Value = valueType, value
+	return nil
+}
+
+// ConfigResolveQuery selects the configs to resolve for the request tenant
+type ConfigResolveQuery struct {
+	Environment string `form:"environment"`
+	Prefix      string `form:"prefix"` // key prefix such as "db."
+}
+
+// Normalize lower-cases the environment and trims the prefix
+func (q *ConfigResolveQuery) Normalize() {
+	q.Environment = strings.ToLower(strings.TrimSpace(q.Environment))
+	q.Prefix = strings.TrimSpace(q.Prefix)
+}
+
+// ConfigLayerValue is the value a layer supplies for a config key, with where it comes from:
+// the tenant and environment of the entry or override, the active version of an entry and the
+// expiry of an override
+type ConfigLayerValue struct {
+	Layer       string      `json:"layer"`
+	TenantID    string      `json:"tenant_id"`
+	Environment string      `json:"environment"`
+	ValueType   string      `json:"value_type"`
+	Value       interface{} `json:"value"`
+	Version     int         `json:"version,omitempty"`
+	ExpiresAt   *time.Time  `json:"expires_at,omitempty"`
+}
+
+// ResolvedConfigValue is the effective value of a config key with the layer that supplied it.
+// Shadowed lists the values of the lower layers it hides, highest first.
+type ResolvedConfigValue struct {
+	Key string `json:"key"`
+	ConfigLayerValue
+	Shadowed []ConfigLayerValue `json:"shadowed,omitempty"`
+}
+
+// MaskSecret replaces every value of a key with MaskedConfigValue when any of them comes from the
+// secret layer, since the other layers of the key stand in for or sit beside the secret
+func (v *ResolvedConfigValue) MaskSecret() {
+	secret := v.Layer == ConfigLayerSecret
+	for _, shadowed := range v.Shadowed {
+		secret = secret || shadowed.Layer == ConfigLayerSecret
+	}
+	if !secret {
+		return
+	}
+
+	v.Value = MaskedConfigValue
+	for i := range v.Shadowed {
+		v.Shadowed[i].Value = MaskedConfigValue
+	}
+}
+
+// ResolvedConfig is the effective config of a tenant in an environment, ordered by key
+type ResolvedConfig struct {
+	TenantID    string                `json:"tenant_id"`
+	Environment string                `json:"environment"`
+	Prefix      string                `json:"prefix"`
+	Values      []ResolvedConfigValue `json:"values"`
+}
@@ -0,0 +1,365 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/vhvplatform/go-system-config-service/pkg/jsonschema"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Config validation rule types
+const (
+	ConfigRuleTypeString   = "string"
+	ConfigRuleTypeInt      = "int"
+	ConfigRuleTypeBool     = "bool"
+	ConfigRuleTypeDuration = "duration" // Go duration string such as "30s"
+	ConfigRuleTypeURL      = "url"      // absolute URL with a scheme and host
+	ConfigRuleTypeEmail    = "email"
+)
+
+// ConfigRuleTypes lists the supported config validation rule types
+var ConfigRuleTypes = []string{ConfigRuleTypeString, ConfigRuleTypeInt, ConfigRuleTypeBool, ConfigRuleTypeDuration, ConfigRuleTypeURL, ConfigRuleTypeEmail}
+
+// Field error codes of config validation rules
+const (
+	ConfigRuleRequired = "required"
+	ConfigRuleType     = "type"
+	ConfigRuleMin      = "min"
+	ConfigRuleMax      = "max"
+	ConfigRulePattern  = "pattern"
+	ConfigRuleAllowed  = "allowed"
+)
+
+var configKeyPatternPattern = regexp.MustCompile(`^[A-Za-z0-9*?][A-Za-z0-9._*?-]{0,255}$`)
+
+// ConfigValidationRule constrains the values written under the config keys matching KeyPattern,
+// in which "*" matches any run of characters and "?" a single one. JSON values are checked field
+// by field as dotted keys below their config key, and app component configs as dotted keys below
+// the component code, so "dashboard.theme" constrains the theme field of the config of the
+// dashboard component.
+//
+// Min and Max bound numbers for int rules, durations such as "1s" for duration rules and the
+// length of the value for string, url and email rules. Rules of the global tenant apply to every
+// tenant.
+type ConfigValidationRule struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	TenantID      string             `json:"tenant_id" bson:"tenantId"`
+	KeyPattern    string             `json:"key_pattern" bson:"keyPattern"`
+	Description   string             `json:"description" bson:"description"`
+	Type          string             `json:"type" bson:"type"`
+	Required      bool               `json:"required" bson:"required"`
+	Min           interface{}        `json:"min,omitempty" bson:"min,omitempty"`
+	Max           interface{}        `json:"max,omitempty" bson:"max,omitempty"`
+	Pattern       string             `json:"pattern,omitempty" bson:"pattern,omitempty"`
+	AllowedValues []string           `json:"allowed_values,omitempty" bson:"allowedValues,omitempty"`
+	CreatedAt     time.Time          `json:"created_at" bson:"createdAt"`
+	UpdatedAt     time.Time          `json:"updated_at" bson:"updatedAt"`
+	CreatedBy     string             `json:"created_by" bson:"createdBy"`
+	UpdatedBy     string             `json:"updated_by" bson:"updatedBy"`
+}
+
+// Normalize trims the key pattern, lower-cases the type and drops empty allowed values
+func (r *ConfigValidationRule) Normalize() {
+	r.KeyPattern = strings.TrimSpace(r.KeyPattern)
+	r.Type = strings.ToLower(strings.TrimSpace(r.Type))
+	allowed := r.AllowedValues[:0]
+	for _, value := range r.AllowedValues {
+		if value = strings.TrimSpace(value); value != "" {
+			allowed = append(allowed, value)
+		}
+	}
+	r.AllowedValues = allowed
+}
+
+// Validate validates the rule and that its bounds suit its type
+func (r *ConfigValidationRule) Validate() error {
+	if r.KeyPattern == "" {
+		return errors.New("key_pattern is required")
+	}
+	if !configKeyPatternPattern.MatchString(r.KeyPattern) {
+		return errors.New("key_pattern may only contain letters, digits, '.', '_', '-' and the wildcards '*' and '?'")
+	}
+	if !isConfigRuleType(r.Type) {
+		return fmt.Errorf("type must be one of %s", strings.Join(ConfigRuleTypes, ", "))
+	}
+	if r.Pattern != "" {
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("pattern is not a valid regular expression: %w", err)
+		}
+	}
+
+	for _, bound := range []struct {
+		name  string
+		value interface{}
+	}{{"min", r.Min}, {"max", r.Max}} {
+		if bound.value == nil {
+			continue
+		}
+		if _, err := r.bound(bound.value); err != nil {
+			return fmt.Errorf("%s %w", bound.name, err)
+		}
+	}
+	if r.Min != nil && r.Max != nil {
+		min, _ := r.bound(r.Min)
+		max, _ := r.bound(r.Max)
+		if min > max {
+			return errors.New("min must not be greater than max")
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the rule applies to a config key
+func (r *ConfigValidationRule) Matches(key string) bool {
+	matched, err := path.Match(r.KeyPattern, key)
+	return err == nil && matched
+}
+
+// literal reports whether the key pattern names a single key
+func (r *ConfigValidationRule) literal() bool {
+	return !strings.ContainsAny(r.KeyPattern, "*?")
+}
+
+// bound converts a min or max to the quantity compared for the rule type: a number, a duration
+// in nanoseconds or a length
+func (r *ConfigValidationRule) bound(value interface{}) (float64, error) {
+	switch r.Type {
+	case ConfigRuleTypeInt:
+		if n, ok := configNumber(value); ok {
+			return n, nil
+		}
+		return 0, errors.New("must be a number for int rules")
+	case ConfigRuleTypeDuration:
+		if s, ok := value.(string); ok {
+			if d, err := time.ParseDuration(s); err == nil {
+				return float64(d), nil
+			}
+		}
+		return 0, errors.New("must be a duration such as 1s for duration rules")
+	case ConfigRuleTypeBool:
+		return 0, errors.New("is not supported for bool rules")
+	default:
+		if n, ok := configNumber(value); ok && n >= 0 && n == math.Trunc(n) {
+			return n, nil
+		}
+		return 0, fmt.Errorf("must be a length for %s rules", r.Type)
+	}
+}
+
+// check checks a value found under a key matching the rule. Objects and arrays only fail rules
+// naming their key exactly; wildcard rules apply to the scalars inside them.
+func (r *ConfigValidationRule) check(field, pointer string, value interface{}) []FieldError {
+	fail := func(code, message string) []FieldError {
+		return []FieldError{{Field: field, Pointer: pointer, Code: code, Message: message, Rule: r.KeyPattern}}
+	}
+
+	switch value.(type) {
+	case nil:
+		if r.Required {
+			return fail(ConfigRuleRequired, "is required")
+		}
+		return nil
+	case map[string]interface{}, []interface{}:
+		if r.literal() {
+			return fail(ConfigRuleType, "must be "+configRuleTypeName(r.Type))
+		}
+		return nil
+	}
+
+	quantity, ok := r.quantity(value)
+	if !ok {
+		return fail(ConfigRuleType, "must be "+configRuleTypeName(r.Type))
+	}
+
+	var errs []FieldError
+	if r.Min != nil {
+		if min, err := r.bound(r.Min); err == nil && quantity < min {
+			errs = append(errs, fail(ConfigRuleMin, "must be at least "+r.describeBound(r.Min))...)
+		}
+	}
+	if r.Max != nil {
+		if max, err := r.bound(r.Max); err == nil && quantity > max {
+			errs = append(errs, fail(ConfigRuleMax, "must be at most "+r.describeBound(r.Max))...)
+		}
+	}
+
+	text := configText(value)
+	if r.Pattern != "" {
+		if pattern, err := regexp.Compile(r.Pattern); err == nil && !pattern.MatchString(text) {
+			errs = append(errs, fail(ConfigRulePattern, "must match "+r.Pattern)...)
+		}
+	}
+	if len(r.AllowedValues) > 0 {
+		allowed := false
+		for _, candidate := range r.AllowedValues {
+			allowed = allowed || candidate == text
+		}
+		if !allowed {
+			errs = append(errs, fail(ConfigRuleAllowed, "must be one of "+strings.Join(r.AllowedValues, ", "))...)
+		}
+	}
+	return errs
+}
+
+// quantity checks the type of a scalar and returns what its bounds compare: the number, the
+// duration or the length
+func (r *ConfigValidationRule) quantity(value interface{}) (float64, bool) {
+	if r.Type == ConfigRuleTypeInt {
+		n, ok := configNumber(value)
+		return n, ok && n == math.Trunc(n)
+	}
+	if r.Type == ConfigRuleTypeBool {
+		_, ok := value.(bool)
+		return 0, ok
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return 0, false
+	}
+	switch r.Type {
+	case ConfigRuleTypeDuration:
+		d, err := time.ParseDuration(s)
+		return float64(d), err == nil
+	case ConfigRuleTypeURL:
+		u, err := url.Parse(s)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return 0, false
+		}
+	case ConfigRuleTypeEmail:
+		address, err := mail.ParseAddress(s)
+		if err != nil || address.Address != s {
+			return 0, false
+		}
+	}
+	return float64(utf8.RuneCountInString(s)), true
+}
+
+// describeBound formats a bound for messages, adding the unit of length bounds
+func (r *ConfigValidationRule) describeBound(value interface{}) string {
+	switch r.Type {
+	case ConfigRuleTypeInt, ConfigRuleTypeDuration:
+		return configText(value)
+	default:
+		return configText(value) + " characters long"
+	}
+}
+
+// ValidateConfigFields checks a value written under a config key against the rules matching the
+// key and the dotted keys of its fields, and reports the required fields it lacks. field names
+// the value in the request and prefixes the fields of the errors, so the errors of the value of
+// "db.pool" read "value.size" when field is "value".
+func ValidateConfigFields(rules []*ConfigValidationRule, key, field string, value interface{}) []FieldError {
+	var errs []FieldError
+	present := make(map[string]bool)
+	walkConfigFields(key, field, jsonschema.Pointer(field), value, func(key, field, pointer string, value interface{}) {
+		present[key] = value != nil
+		for _, rule := range rules {
+			if rule.Matches(key) {
+				errs = append(errs, rule.check(field, pointer, value)...)
+			}
+		}
+	})
+
+	for _, rule := range rules {
+		if !rule.Required || !rule.literal() || !strings.HasPrefix(rule.KeyPattern, key+".") {
+			continue
+		}
+		if _, seen := present[rule.KeyPattern]; !seen {
+			names := strings.Split(strings.TrimPrefix(rule.KeyPattern, key+"."), ".")
+			errs = append(errs, FieldError{
+				Field:   field + strings.TrimPrefix(rule.KeyPattern, key),
+				Pointer: jsonschema.Pointer(append([]string{field}, names...)...),
+				Code:    ConfigRuleRequired,
+				Message: "is required",
+				Rule:    rule.KeyPattern,
+			})
+		}
+	}
+	return errs
+}
+
+// walkConfigFields visits a value and, depth first, the fields of its objects in key order and
+// the items of its arrays
+func walkConfigFields(key, field, pointer string, value interface{}, visit func(key, field, pointer string, value interface{})) {
+	visit(key, field, pointer, value)
+	switch v := value.(type) {
+	case map[string]interface{}:
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			walkConfigFields(key+"."+name, field+"."+name, pointer+jsonschema.Pointer(name), v[name], visit)
+		}
+	case []interface{}:
+		for i, item := range v {
+			index := strconv.Itoa(i)
+			walkConfigFields(key+"."+index, field+"."+index, pointer+jsonschema.Pointer(index), item, visit)
+		}
+	}
+}
+
+func isConfigRuleType(ruleType string) bool {
+	for _, known := range ConfigRuleTypes {
+		if ruleType == known {
+			return true
+		}
+	}
+	return false
+}
+
+// configRuleTypeName describes a rule type in messages
+func configRuleTypeName(ruleType string) string {
+	switch ruleType {
+	case ConfigRuleTypeInt:
+		return "a whole number"
+	case ConfigRuleTypeBool:
+		return "a boolean"
+	case ConfigRuleTypeDuration:
+		return "a duration such as 30s"
+	case ConfigRuleTypeURL:
+		return "a URL with a scheme and host"
+	case ConfigRuleTypeEmail:
+		return "an email address"
+	default:
+		return "a " + ruleType
+	}
+}
+
+// configNumber reads a number however it was decoded
+func configNumber(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// configText formats a scalar the way it is written in JSON, for patterns and allowed values
+func configText(value interface{}) string {
+	if n, ok := configNumber(value); ok {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/pkg/jsonschema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Config schema targets
+const (
+	SchemaTargetAppComponent  = "app_component"  // the Config of the app components with the schema code
+	SchemaTargetPackageLimits = "package_limits" // the Limits of every service package
+)
+
+// SchemaTargets lists the documents that config schemas can describe
+var SchemaTargets = []string{SchemaTargetAppComponent, SchemaTargetPackageLimits}
+
+// JSONSchema is a JSON Schema document. It is stored as JSON text, since keywords such as
+// "$schema" are not valid MongoDB field names.
+type JSONSchema map[string]interface{}
+
+// MarshalBSONValue stores the schema as JSON text
+func (s JSONSchema) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	data, err := json.Marshal(map[string]interface{}(s))
+	if err != nil {
+		return 0, nil, err
+	}
+	return bson.MarshalValue(string(data))
+}
+
+// UnmarshalBSONValue reads a schema stored as JSON text
+func (s *JSONSchema) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	text, ok := bson.RawValue{Type: t, Value: data}.StringValueOK()
+	if !ok {
+		return fmt.Errorf("schema must be stored as a string, not %s", t)
+	}
+	return json.Unmarshal([]byte(text), (*map[string]interface{})(s))
+}
+
+// ConfigSchema is a JSON Schema that the config of the app components with a code, or the limits
+// of every service package, must conform to on create and update. Schemas are shared by every
+// tenant. Version counts the changes to the schema; stored documents are only brought in line
+// with a new version by a migration.
+type ConfigSchema struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Target      string             `json:"target" bson:"target"`
+	Code        string             `json:"code" bson:"code"` // component code of app_component schemas
+	Description string             `json:"description" bson:"description"`
+	Schema      JSONSchema         `json:"schema" bson:"schema"`
+	Version     int                `json:"version" bson:"version"`
+	CreatedAt   time.Time          `json:"created_at" bson:"createdAt"`
+	UpdatedAt   time.Time          `json:"updated_at" bson:"updatedAt"`
+	CreatedBy   string             `json:"created_by" bson:"createdBy"`
+	UpdatedBy   string             `json:"updated_by" bson:"updatedBy"`
+}
+
+// Normalize lower-cases the target and trims the code
+func (s *ConfigSchema) Normalize() {
+	s.Target = strings.ToLower(strings.TrimSpace(s.Target))
+	s.Code = strings.TrimSpace(s.Code)
+}
+
+// Validate validates the target of the schema and that the schema compiles
+func (s *ConfigSchema) Validate() error {
+	switch s.Target {
+	case SchemaTargetAppComponent:
+		if s.Code == "" {
+			return errors.New("code is required for app_component schemas")
+		}
+	case SchemaTargetPackageLimits:
+		if s.Code != "" {
+			return errors.New("code must be empty for package_limits schemas")
+		}
+	default:
+		return fmt.Errorf("target must be one of %s", strings.Join(SchemaTargets, ", "))
+	}
+	if s.Schema == nil {
+		return errors.New("schema is required")
+	}
+	if _, err := s.Compile(); err != nil {
+		return fmt.Errorf("schema is invalid: %w", err)
+	}
+	return nil
+}
+
+// Compile compiles the schema document
+func (s *ConfigSchema) Compile() (*jsonschema.Schema, error) {
+	doc, err := PlainConfigValue(map[string]interface{}(s.Schema))
+	if err != nil {
+		return nil, err
+	}
+	return jsonschema.Compile(doc)
+}
+
+// Subject names the documents the schema describes, such as "app_component:dashboard"
+func (s *ConfigSchema) Subject() string {
+	if s.Code == "" {
+		return s.Target
+	}
+	return s.Target + ":" + s.Code
+}
+
+// Violations checks a value against a compiled schema. field names the value in the request and
+// prefixes the fields and pointers of the errors, so a violation at "/theme" of the value of
+// "config" reads "config.theme" at "/config/theme".
+func (s *ConfigSchema) Violations(compiled *jsonschema.Schema, field string, value interface{}) []FieldError {
+	var errs []FieldError
+	for _, violation := range compiled.Validate(value) {
+		tokens := append([]string{field}, jsonschema.Tokens(violation.Path)...)
+		errs = append(errs, FieldError{
+			Field:   strings.Join(tokens, "."),
+			Pointer: jsonschema.Pointer(tokens...),
+			Code:    violation.Keyword,
+			Message: violation.Message,
+			Rule:    s.Subject(),
+		})
+	}
+	return errs
+}
+
+// SchemaMigrationOptions controls the migration of the documents of a schema
+type SchemaMigrationOptions struct {
+	DryRun bool `form:"dry_run"` // report without writing
+}
+
+// SchemaNonconformance lists the violations of a stored document that does not conform to its
+// schema
+type SchemaNonconformance struct {
+	ID         string       `json:"id"`
+	TenantID   string       `json:"tenant_id"`
+	Code       string       `json:"code"`
+	Violations []FieldError `json:"violations"`
+}
+
+// SchemaMigrationReport summarizes the migration of the stored documents of a schema. Documents
+// missing properties with schema defaults gain them when they then conform; the others are left
+// as they are and reported.
+type SchemaMigrationReport struct {
+	DryRun        bool                   `json:"dry_run"`
+	Schema        string                 `json:"schema"` // subject of the schema
+	SchemaVersion int                    `json:"schema_version"`
+	Checked       int                    `json:"checked"`
+	Migrated      int                    `json:"migrated"` // documents that gained defaults
+	Nonconforming []SchemaNonconformance `json:"nonconforming"`
+}
@@ -21,6 +21,9 @@ type Country struct {
 	Status     string             `json:"status" bson:"status"`
 	CreatedAt  time.Time          `json:"created_at" bson:"createdAt"`
 	UpdatedAt  time.Time          `json:"updated_at" bson:"updatedAt"`
+
+	// LocalizedName is Name in the locale of the request; it is not stored
+	LocalizedName string `json:"localized_name,omitempty" bson:"-"`
 }
 
 // Validate validates the country data
@@ -31,5 +34,18 @@ func (c *Country) Validate() error {
 	if len(c.Name) == 0 {
 		return errors.New("name is required")
 	}
+	if c.Currency != "" && !IsCurrencyCode(c.Currency) {
+		return errors.New("currency must be a three letter ISO 4217 code")
+	}
 	return nil
 }
+
+// Translations returns the i18n name of the country
+func (c *Country) Translations() map[string]string {
+	return c.Name
+}
+
+// SetLocalizedName sets the name of the country in the locale of the request
+func (c *Country) SetLocalizedName(name string) {
+	c.LocalizedName = name
+}
@@ -1,8 +1,12 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
 	"time"
 
+	"github.com/vhvplatform/go-system-config-service/pkg/money"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -17,4 +21,79 @@ type Currency struct {
 	Status        string             `json:"status" bson:"status"`
 	CreatedAt     time.Time          `json:"created_at" bson:"createdAt"`
 	UpdatedAt     time.Time          `json:"updated_at" bson:"updatedAt"`
+
+	// LocalizedName is Name in the locale of the request; it is not stored
+	LocalizedName string `json:"localized_name,omitempty" bson:"-"`
+}
+
+// MaxDecimalDigits is the largest number of minor unit digits ISO 4217 assigns to a currency
+const MaxDecimalDigits = 4
+
+var (
+	currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	countryCodePattern  = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// IsCurrencyCode reports whether code is shaped like an ISO 4217 alphabetic code
+func IsCurrencyCode(code string) bool {
+	return currencyCodePattern.MatchString(code)
+}
+
+// Validate validates the currency data
+func (c *Currency) Validate() error {
+	if !IsCurrencyCode(c.Code) {
+		return errors.New("code must be a three letter ISO 4217 code")
+	}
+	if len(c.Name) == 0 {
+		return errors.New("name is required")
+	}
+	if c.DecimalDigits < 0 || c.DecimalDigits > MaxDecimalDigits {
+		return fmt.Errorf("decimal_digits must be between 0 and %d", MaxDecimalDigits)
+	}
+	for _, country := range c.Countries {
+		if !countryCodePattern.MatchString(country) {
+			return fmt.Errorf("countries: '%s' is not an ISO 3166-1 alpha-2 code", country)
+		}
+	}
+	switch c.Status {
+	case "", "active", "inactive":
+	default:
+		return errors.New("status must be one of active, inactive")
+	}
+	return nil
+}
+
+// Round rounds an amount half away from zero to the decimal digits of the currency
+func (c *Currency) Round(amount float64) float64 {
+	return money.Round(amount, c.DecimalDigits)
+}
+
+// Money returns what the money package needs to format and parse amounts of the currency
+func (c *Currency) Money() money.Currency {
+	return money.Currency{Code: c.Code, Symbol: c.Symbol, DecimalDigits: c.DecimalDigits}
+}
+
+// FormattedAmount is an amount of a currency rounded to its decimal digits and written in a locale
+type FormattedAmount struct {
+	Currency  string  `json:"currency"`
+	Locale    string  `json:"locale"` // the locale whose conventions were applied
+	Amount    float64 `json:"amount"`
+	Formatted string  `json:"formatted"`
+}
+
+// MoneyFormatRequest asks for an amount to be formatted, or text to be parsed, in a locale
+type MoneyFormatRequest struct {
+	Amount *float64 `form:"amount" json:"amount"`
+	Text   string   `form:"text" json:"text"`
+	Locale string   `form:"locale" json:"locale"`
+}
+
+// Translations returns the i18n name of the currency
+func (c *Currency) Translations() map[string]string {
+	return c.Name
+}
+
+// SetLocalizedName sets the name of the currency in the locale of the request
+func (c *Currency) SetLocalizedName(name string) {
+	c.LocalizedName = name
 }
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -12,8 +13,39 @@ type District struct {
 	Code         string             `json:"code" bson:"code"`
 	Name         map[string]string  `json:"name" bson:"name"` // i18n
 	ProvinceCode string             `json:"province_code" bson:"provinceCode"`
-	Type         string             `json:"type" bson:"type"` // district, county, etc.
+	CountryCode  string             `json:"-" bson:"countryCode"` // country of the province, kept for search scoping
+	Type         string             `json:"type" bson:"type"`     // district, county, etc.
 	Status       string             `json:"status" bson:"status"`
+	SearchText   []string           `json:"-" bson:"searchText"` // folded names, see SearchTerms
 	CreatedAt    time.Time          `json:"created_at" bson:"createdAt"`
 	UpdatedAt    time.Time          `json:"updated_at" bson:"updatedAt"`
+
+	LocationValidity `bson:",inline"`
+
+	// LocalizedName is Name in the locale of the request; it is not stored
+	LocalizedName string `json:"localized_name,omitempty" bson:"-"`
+}
+
+// Validate validates the district data
+func (d *District) Validate() error {
+	if d.Code == "" {
+		return errors.New("code is required")
+	}
+	if len(d.Name) == 0 {
+		return errors.New("name is required")
+	}
+	if d.ProvinceCode == "" {
+		return errors.New("province_code is required")
+	}
+	return d.LocationValidity.Validate()
+}
+
+// Translations returns the i18n name of the district
+func (d *District) Translations() map[string]string {
+	return d.Name
+}
+
+// SetLocalizedName sets the name of the district in the locale of the request
+func (d *District) SetLocalizedName(name string) {
+	d.LocalizedName = name
 }
@@ -2,8 +2,12 @@ package domain
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/pkg/jsonschema"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -54,6 +58,76 @@ func TestCountry_Validation(t *testing.T) {
 	}
 }
 
+func TestCurrency_Validation(t *testing.T) {
+	valid := Currency{Code: "VND", Name: map[string]string{"vi": "Đồng Việt Nam"}, Countries: []string{"VN"}}
+	assert.NoError(t, valid.Validate())
+
+	for _, code := range []string{"", "vnd", "VN", "VNDX", "V1D"} {
+		invalid := valid
+		invalid.Code = code
+		assert.Error(t, invalid.Validate(), code)
+	}
+
+	noName := valid
+	noName.Name = nil
+	assert.Error(t, noName.Validate())
+
+	for digits, wantErr := range map[int]bool{0: false, 2: false, 4: false, -1: true, 5: true} {
+		currency := valid
+		currency.DecimalDigits = digits
+		assert.Equal(t, wantErr, currency.Validate() != nil, digits)
+	}
+
+	badCountry := valid
+	badCountry.Countries = []string{"VNM"}
+	assert.Error(t, badCountry.Validate())
+
+	assert.Error(t, (&Country{Code: "VN", Name: map[string]string{"en": "Vietnam"}, Currency: "dong"}).Validate())
+}
+
+func TestCurrency_Round(t *testing.T) {
+	assert.Equal(t, 1235.0, (&Currency{DecimalDigits: 0}).Round(1234.5))
+	assert.Equal(t, 12.35, (&Currency{DecimalDigits: 2}).Round(12.345))
+	assert.Equal(t, -12.35, (&Currency{DecimalDigits: 2}).Round(-12.345))
+	assert.Equal(t, 1.235, (&Currency{DecimalDigits: 3}).Round(1.23456))
+	assert.Equal(t, 1.01, (&Currency{DecimalDigits: 2}).Round(1.005))
+}
+
+func TestEthnicity_Validation(t *testing.T) {
+	valid := Ethnicity{Code: "01", Name: map[string]string{"vi": "Kinh"}, CountryCode: "VN"}
+	assert.NoError(t, valid.Validate())
+
+	noCode := valid
+	noCode.Code = ""
+	assert.Error(t, noCode.Validate())
+
+	noName := valid
+	noName.Name = nil
+	assert.Error(t, noName.Validate())
+
+	for _, country := range []string{"", "vn", "VNM"} {
+		invalid := valid
+		invalid.CountryCode = country
+		assert.Error(t, invalid.Validate(), country)
+	}
+
+	badStatus := valid
+	badStatus.Status = "deleted"
+	assert.Error(t, badStatus.Validate())
+}
+
+func TestExchangeRate_Validation(t *testing.T) {
+	day := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, (&ExchangeRate{Base: "USD", Quote: "VND", Rate: 25000, Date: day}).Validate())
+	assert.Error(t, (&ExchangeRate{Base: "USD", Quote: "USD", Rate: 1, Date: day}).Validate())
+	assert.Error(t, (&ExchangeRate{Base: "USD", Quote: "VND", Rate: -1, Date: day}).Validate())
+	assert.Error(t, (&ExchangeRate{Base: "USD", Quote: "VND", Rate: 25000}).Validate())
+	assert.Error(t, (&ExchangeRate{Base: "usd", Quote: "VND", Rate: 25000, Date: day}).Validate())
+
+	assert.Equal(t, day, RateDay(time.Date(2025, 1, 2, 23, 59, 0, 0, time.UTC)))
+	assert.Equal(t, day, RateDay(time.Date(2025, 1, 3, 6, 0, 0, 0, time.FixedZone("ICT", 7*3600))))
+}
+
 func TestAppComponent_Validation(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -140,3 +214,561 @@ func TestPaginationRequest_MaxPerPage(t *testing.T) {
 	assert.Equal(t, 1, req.Page)
 	assert.LessOrEqual(t, req.PerPage, 100)
 }
+
+func TestSaaSModule_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		module  SaaSModule
+		wantErr bool
+	}{
+		{
+			name:    "Valid module",
+			module:  SaaSModule{Code: "crm", Name: "CRM", Category: "addon", Dependencies: []string{"core"}},
+			wantErr: false,
+		},
+		{
+			name:    "Empty code",
+			module:  SaaSModule{Name: "CRM"},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown category",
+			module:  SaaSModule{Code: "crm", Name: "CRM", Category: "other"},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown status",
+			module:  SaaSModule{Code: "crm", Name: "CRM", Status: "archived"},
+			wantErr: true,
+		},
+		{
+			name:    "Self dependency",
+			module:  SaaSModule{Code: "crm", Name: "CRM", Dependencies: []string{"crm"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.module.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParsePackageLimits(t *testing.T) {
+	limits, err := ParsePackageLimits(map[string]interface{}{
+		"users":     float64(10),
+		"storage":   "10GB",
+		"api_calls": "unlimited",
+		"projects":  5,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), limits.Users)
+	assert.Equal(t, int64(10<<30), limits.Storage)
+	assert.Equal(t, Unlimited, limits.APICalls)
+	assert.Equal(t, 5, limits.Other["projects"])
+
+	limits, err = ParsePackageLimits(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, Unlimited, limits.Users)
+
+	limits, err = ParsePackageLimits(map[string]interface{}{"users": int32(-1), "storage": int64(1024)})
+	assert.NoError(t, err)
+	assert.Equal(t, Unlimited, limits.Users)
+	assert.Equal(t, int64(1024), limits.Storage)
+
+	_, err = ParsePackageLimits(map[string]interface{}{"users": 2.5})
+	assert.Error(t, err)
+
+	_, err = ParsePackageLimits(map[string]interface{}{"api_calls": "lots"})
+	assert.Error(t, err)
+
+	_, err = ParsePackageLimits(map[string]interface{}{"storage": "10XB"})
+	assert.Error(t, err)
+}
+
+func TestServicePackage_Validation(t *testing.T) {
+	valid := ServicePackage{Code: "pro", Name: "Pro", Tier: "professional", BillingCycle: "monthly"}
+	assert.NoError(t, valid.Validate())
+
+	invalid := valid
+	invalid.Tier = "gold"
+	assert.Error(t, invalid.Validate())
+
+	invalid = valid
+	invalid.Price = -1
+	assert.Error(t, invalid.Validate())
+
+	invalid = valid
+	invalid.Limits = map[string]interface{}{"users": "many"}
+	assert.Error(t, invalid.Validate())
+}
+
+func TestLocalize(t *testing.T) {
+	values := map[string]string{"en": "Vietnam", "vi": "Việt Nam"}
+
+	assert.Equal(t, "Việt Nam", Localize(values, "vi"))
+	assert.Equal(t, "Việt Nam", Localize(values, "vi-VN"))
+	assert.Equal(t, "Vietnam", Localize(values, "fr"))
+	assert.Equal(t, "Nihon", Localize(map[string]string{"ja": "Nihon"}, "vi"))
+	assert.Equal(t, "", Localize(nil, "vi"))
+
+	// Each preferred locale is tried before the default
+	assert.Equal(t, "Việt Nam", Localize(values, "fr-FR", "vi-VN"))
+	assert.Equal(t, "Vietnam", Localize(values))
+}
+
+func TestLocalizeAll(t *testing.T) {
+	names := []string{"Ê-đê", "Dao", "Đan", "Ba-na"}
+	ethnicities := func() []*Ethnicity {
+		out := make([]*Ethnicity, 0, len(names))
+		for _, name := range names {
+			out = append(out, &Ethnicity{Name: map[string]string{"vi": name, "fr": name + " (fr)"}})
+		}
+		return out
+	}
+	localized := func(items []*Ethnicity) []string {
+		out := make([]string, 0, len(items))
+		for _, item := range items {
+			out = append(out, item.LocalizedName)
+		}
+		return out
+	}
+
+	// Without sorting the order is kept
+	items := ethnicities()
+	LocalizeAll(items, Localization{Locales: []string{"vi"}})
+	assert.Equal(t, names, localized(items))
+
+	// Vietnamese collation places Đ after D, most others treat it as a variant of D
+	items = ethnicities()
+	LocalizeAll(items, Localization{Locales: []string{"vi-VN"}, SortByName: true})
+	assert.Equal(t, []string{"Ba-na", "Dao", "Đan", "Ê-đê"}, localized(items))
+
+	items = ethnicities()
+	LocalizeAll(items, Localization{Locales: []string{"de", "vi"}, SortByName: true})
+	assert.Equal(t, []string{"Ba-na", "Đan", "Dao", "Ê-đê"}, localized(items))
+
+	country := &Country{Name: map[string]string{"en": "Vietnam", "vi": "Việt Nam"}}
+	Localization{Locales: []string{"ja"}}.Apply(country)
+	assert.Equal(t, "Vietnam", country.LocalizedName)
+}
+
+func TestPermission_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		permission Permission
+		wantErr    bool
+	}{
+		{
+			name:       "Valid permission",
+			permission: Permission{ModuleCode: "users", Code: "users.read", Resource: "users", Action: "read", Category: "data"},
+			wantErr:    false,
+		},
+		{
+			name:       "Nested resource",
+			permission: Permission{ModuleCode: "admin", Code: "admin.users.export", Resource: "admin.users", Action: "export"},
+			wantErr:    false,
+		},
+		{
+			name:       "Code does not match resource and action",
+			permission: Permission{ModuleCode: "users", Code: "users.edit", Resource: "users", Action: "update"},
+			wantErr:    true,
+		},
+		{
+			name:       "Wildcard action",
+			permission: Permission{ModuleCode: "users", Code: "users.*", Resource: "users", Action: "*"},
+			wantErr:    true,
+		},
+		{
+			name:       "Missing module",
+			permission: Permission{Code: "users.read", Resource: "users", Action: "read"},
+			wantErr:    true,
+		},
+		{
+			name:       "Unknown category",
+			permission: Permission{ModuleCode: "users", Code: "users.read", Resource: "users", Action: "read", Category: "other"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.permission.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateGrant(t *testing.T) {
+	for _, grant := range []string{"*", "admin.*", "admin.users.*", "users.read", "admin.users.export"} {
+		assert.NoError(t, ValidateGrant(grant), grant)
+	}
+	for _, grant := range []string{"", "users", "users.", ".read", "users.*.read", "Users.Read", "*.read"} {
+		assert.Error(t, ValidateGrant(grant), grant)
+	}
+}
+
+func TestRole_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    Role
+		wantErr bool
+	}{
+		{
+			name:    "Valid role",
+			role:    Role{Code: "editor", Name: "Editor", Level: 3, ParentCode: "viewer"},
+			wantErr: false,
+		},
+		{
+			name:    "Missing level",
+			role:    Role{Code: "editor", Name: "Editor"},
+			wantErr: true,
+		},
+		{
+			name:    "Inherits from itself",
+			role:    Role{Code: "editor", Name: "Editor", Level: 3, ParentCode: "editor"},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown status",
+			role:    Role{Code: "editor", Name: "Editor", Level: 3, Status: "archived"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.role.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLocation_Validation(t *testing.T) {
+	name := map[string]string{"vi": "Hà Nội"}
+
+	assert.NoError(t, (&Province{Code: "01", Name: name, CountryCode: "VN"}).Validate())
+	assert.Error(t, (&Province{Code: "01", Name: name}).Validate())
+	assert.Error(t, (&Province{Code: "01", CountryCode: "VN"}).Validate())
+
+	assert.NoError(t, (&District{Code: "001", Name: name, ProvinceCode: "01"}).Validate())
+	assert.Error(t, (&District{Name: name, ProvinceCode: "01"}).Validate())
+	assert.Error(t, (&District{Code: "001", Name: name}).Validate())
+
+	assert.NoError(t, (&Ward{Code: "00001", Name: name, DistrictCode: "001"}).Validate())
+	assert.Error(t, (&Ward{Code: "00001", Name: name}).Validate())
+}
+
+func TestFoldText(t *testing.T) {
+	tests := map[string]string{
+		"Hà Nội":               "ha noi",
+		"  HA   NOI ":          "ha noi",
+		"Đà Nẵng":              "da nang",
+		"Bà Rịa - Vũng Tàu":    "ba ria vung tau",
+		"Thừa Thiên Huế":       "thua thien hue",
+		"Quận 1":               "quan 1",
+		"Hồ Chí Minh (TP.HCM)": "ho chi minh tp hcm",
+	}
+	for input, want := range tests {
+		assert.Equal(t, want, FoldText(input), input)
+	}
+
+	assert.Equal(t, []string{"ha noi", "hanoi"}, SearchTerms(map[string]string{"vi": "Hà Nội", "en": "Hanoi", "fr": "Ha Noi"}))
+}
+
+func TestLocationValidity(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	assert.True(t, LocationValidity{}.IsCurrentAt(now))
+	assert.True(t, LocationValidity{EffectiveFrom: &past, EffectiveTo: &future}.IsCurrentAt(now))
+	assert.False(t, LocationValidity{EffectiveFrom: &future}.IsCurrentAt(now))
+	assert.False(t, LocationValidity{EffectiveTo: &past}.IsCurrentAt(now))
+	assert.False(t, LocationValidity{EffectiveTo: &now}.IsCurrentAt(now), "effective_to is exclusive")
+
+	assert.Nil(t, LocationValidity{}.NextChangeAfter(now))
+	assert.Equal(t, &future, LocationValidity{EffectiveFrom: &past, EffectiveTo: &future}.NextChangeAfter(now))
+	assert.Equal(t, &future, LocationValidity{EffectiveFrom: &future}.NextChangeAfter(now))
+	assert.Nil(t, LocationValidity{EffectiveFrom: &past, EffectiveTo: &now}.NextChangeAfter(now))
+
+	assert.NoError(t, LocationValidity{EffectiveFrom: &past, EffectiveTo: &future}.Validate())
+	assert.Error(t, LocationValidity{EffectiveFrom: &future, EffectiveTo: &past}.Validate())
+}
+
+func TestReorganizationDecree_Validation(t *testing.T) {
+	valid := ReorganizationDecree{
+		Reference:     "1656/NQ-UBTVQH15",
+		EffectiveDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		Changes:       []LocationChange{{Level: LocationLevelWard, From: []string{"00001", "00004"}, To: []LocationUnit{{Code: "00001"}}}},
+	}
+	assert.NoError(t, valid.Validate())
+
+	missingDate := valid
+	missingDate.EffectiveDate = time.Time{}
+	assert.Error(t, missingDate.Validate())
+
+	badLevel := valid
+	badLevel.Changes = []LocationChange{{Level: LocationLevelCountry, To: []LocationUnit{{Code: "VN"}}}}
+	assert.Error(t, badLevel.Validate())
+
+	noSuccessor := valid
+	noSuccessor.Changes = []LocationChange{{Level: LocationLevelWard, From: []string{"00001"}}}
+	assert.Error(t, noSuccessor.Validate())
+}
+
+func TestTranslationUnit(t *testing.T) {
+	unit := TranslationUnit{Entity: TranslationEntityEthnicity, Key: "VN:01", Field: "name", Values: map[string]string{"en": "Kinh", "vi": " "}}
+	assert.Equal(t, "ethnicity/VN:01/name", unit.ID())
+	assert.Equal(t, []string{"vi", "fr"}, unit.Missing([]string{"en", "vi", "fr"}))
+
+	entity, key, field, err := ParseTranslationUnitID("admin_menu/reports/sales/title")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"admin_menu", "reports/sales", "title"}, []string{entity, key, field})
+
+	for _, id := range []string{"", "country", "country/VN", "/name"} {
+		_, _, _, err := ParseTranslationUnitID(id)
+		assert.Error(t, err, id)
+	}
+}
+
+func TestTranslationQuery_Validation(t *testing.T) {
+	query := TranslationQuery{Entities: []string{" Country", "country", ""}, Locales: []string{"EN", "vi"}, Format: "XLIFF", Target: "VI"}
+	query.Normalize()
+	assert.Equal(t, []string{"country"}, query.Entities)
+	assert.Equal(t, []string{"en", "vi"}, query.Locales)
+	assert.NoError(t, query.Validate())
+
+	query.Target = ""
+	assert.Error(t, query.Validate(), "XLIFF exports need a target")
+	assert.Error(t, (&TranslationQuery{Format: "json"}).Validate())
+	assert.Error(t, (&TranslationQuery{Entities: []string{"planet"}}).Validate())
+}
+
+func TestNormalizeConfigValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		valueType string
+		value     interface{}
+		wantType  string
+		want      interface{}
+		wantErr   bool
+	}{
+		{"inferred string", "", "hello", ConfigTypeString, "hello", false},
+		{"inferred int", "", 30.0, ConfigTypeInt, int64(30), false},
+		{"inferred float", "", 0.5, ConfigTypeFloat, 0.5, false},
+		{"inferred bool", "", true, ConfigTypeBool, true, false},
+		{"inferred json", "", []interface{}{1.0}, ConfigTypeJSON, []interface{}{1.0}, false},
+		{"int from float", ConfigTypeFloat, 3.0, ConfigTypeFloat, 3.0, false},
+		{"fractional int", ConfigTypeInt, 2.5, "", nil, true},
+		{"duration", ConfigTypeDuration, "1m30s", ConfigTypeDuration, "1m30s", false},
+		{"bad duration", ConfigTypeDuration, "90", "", nil, true},
+		{"bson document", ConfigTypeJSON, primitive.D{{Key: "size", Value: int32(5)}}, ConfigTypeJSON, map[string]interface{}{"size": int64(5)}, false},
+		{"scalar json", ConfigTypeJSON, "x", "", nil, true},
+		{"unknown type", "yaml", "x", "", nil, true},
+		{"missing value", ConfigTypeString, nil, "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valueType, value, err := NormalizeConfigValue(tt.valueType, tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantType, valueType)
+			assert.Equal(t, tt.want, value)
+		})
+	}
+
+	assert.NoError(t, ValidateConfigKey("db.pool_size-max"))
+	assert.Error(t, ValidateConfigKey(".db"))
+	assert.NoError(t, ValidateConfigEnvironment(""))
+	assert.Error(t, ValidateConfigEnvironment("Prod"))
+}
+
+func TestDiffConfigValues(t *testing.T) {
+	old := map[string]interface{}{
+		"pool":  map[string]interface{}{"size": int64(5), "idle": "30s"},
+		"hosts": []interface{}{"a", "b"},
+		"a/b":   true,
+	}
+	new := map[string]interface{}{
+		"pool":  map[string]interface{}{"size": int64(10), "max~": int64(1)},
+		"hosts": []interface{}{"a"},
+		"a/b":   "yes",
+	}
+
+	assert.Equal(t, []ConfigChange{
+		{Path: "/a~1b", Op: ConfigChangeChanged, Old: true, New: "yes"},
+		{Path: "/hosts/1", Op: ConfigChangeRemoved, Old: "b"},
+		{Path: "/pool/idle", Op: ConfigChangeRemoved, Old: "30s"},
+		{Path: "/pool/max~0", Op: ConfigChangeAdded, New: int64(1)},
+		{Path: "/pool/size", Op: ConfigChangeChanged, Old: int64(5), New: int64(10)},
+	}, DiffConfigValues(old, new))
+
+	assert.Equal(t, []ConfigChange{{Path: "", Op: ConfigChangeChanged, Old: "5s", New: int64(5)}}, DiffConfigValues("5s", int64(5)))
+	assert.Empty(t, DiffConfigValues(old, old))
+}
+
+func TestConfigOverrideRequest_Validation(t *testing.T) {
+	req := &ConfigOverrideRequest{Environment: " Production ", Value: 5.0, TTL: " 15m "}
+	req.Normalize()
+	assert.NoError(t, req.Validate())
+	assert.Equal(t, "production", req.Environment)
+	assert.Equal(t, ConfigTypeInt, req.ValueType)
+	assert.Equal(t, int64(5), req.Value)
+
+	assert.Error(t, (&ConfigOverrideRequest{Value: "x", TTL: "soon"}).Validate())
+	assert.Error(t, (&ConfigOverrideRequest{Value: "x", TTL: "0s"}).Validate())
+	assert.Error(t, (&ConfigOverrideRequest{}).Validate())
+
+	now := time.Now()
+	expiresAt := now.Add(time.Minute)
+	override := &ConfigOverride{ExpiresAt: &expiresAt}
+	assert.False(t, override.Expired(now))
+	assert.True(t, override.Expired(expiresAt))
+	assert.False(t, (&ConfigOverride{}).Expired(now))
+}
+
+func TestConfigValidationRule_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    ConfigValidationRule
+		wantErr bool
+	}{
+		{"int bounds", ConfigValidationRule{KeyPattern: "api.rate_limit", Type: "INT", Min: 10.0, Max: 10000.0}, false},
+		{"duration bounds", ConfigValidationRule{KeyPattern: "db.*", Type: "duration", Min: "1s", Max: "60s"}, false},
+		{"length bounds", ConfigValidationRule{KeyPattern: "*.name", Type: "string", Max: 64.0, Pattern: "^[a-z]+$"}, false},
+		{"missing pattern", ConfigValidationRule{Type: "int"}, true},
+		{"bad key pattern", ConfigValidationRule{KeyPattern: "db/[a]", Type: "int"}, true},
+		{"unknown type", ConfigValidationRule{KeyPattern: "db.pool", Type: "float"}, true},
+		{"bad regex", ConfigValidationRule{KeyPattern: "db.pool", Type: "string", Pattern: "("}, true},
+		{"numeric duration", ConfigValidationRule{KeyPattern: "db.timeout", Type: "duration", Min: 1.0}, true},
+		{"negative length", ConfigValidationRule{KeyPattern: "db.name", Type: "string", Min: -1.0}, true},
+		{"bool bounds", ConfigValidationRule{KeyPattern: "feature.on", Type: "bool", Max: 1.0}, true},
+		{"min above max", ConfigValidationRule{KeyPattern: "db.pool", Type: "int", Min: 5.0, Max: 1.0}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rule.Normalize()
+			err := tt.rule.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateConfigFields(t *testing.T) {
+	rules := []*ConfigValidationRule{
+		{KeyPattern: "db.timeout", Type: ConfigRuleTypeDuration, Min: "1s", Max: "60s"},
+		{KeyPattern: "db.pool.size", Type: ConfigRuleTypeInt, Required: true, Min: 1.0, Max: 100.0},
+		{KeyPattern: "db.pool.mode", Type: ConfigRuleTypeString, AllowedValues: []string{"lazy", "eager"}},
+		{KeyPattern: "*.url", Type: ConfigRuleTypeURL},
+		{KeyPattern: "dashboard.owner", Type: ConfigRuleTypeEmail, Required: true},
+	}
+
+	assert.Empty(t, ValidateConfigFields(rules, "db.timeout", "value", "30s"))
+	assert.Equal(t, []FieldError{{Field: "value", Pointer: "/value", Code: ConfigRuleMax, Message: "must be at most 60s", Rule: "db.timeout"}},
+		ValidateConfigFields(rules, "db.timeout", "value", "2m"))
+	assert.Equal(t, []FieldError{{Field: "value", Pointer: "/value", Code: ConfigRuleType, Message: "must be a duration such as 30s", Rule: "db.timeout"}},
+		ValidateConfigFields(rules, "db.timeout", "value", int64(30)))
+
+	// Fields of JSON values are checked as dotted keys below the config key
+	errs := ValidateConfigFields(rules, "db.pool", "value", map[string]interface{}{
+		"size": 2.5, "mode": "busy", "hosts": []interface{}{map[string]interface{}{"url": "not a url"}},
+	})
+	assert.Equal(t, []FieldError{
+		{Field: "value.hosts.0.url", Pointer: "/value/hosts/0/url", Code: ConfigRuleType, Message: "must be a URL with a scheme and host", Rule: "*.url"},
+		{Field: "value.mode", Pointer: "/value/mode", Code: ConfigRuleAllowed, Message: "must be one of lazy, eager", Rule: "db.pool.mode"},
+		{Field: "value.size", Pointer: "/value/size", Code: ConfigRuleType, Message: "must be a whole number", Rule: "db.pool.size"},
+	}, errs)
+
+	errs = ValidateConfigFields(rules, "db.pool", "value", map[string]interface{}{"mode": "lazy"})
+	assert.Equal(t, []FieldError{{Field: "value.size", Pointer: "/value/size", Code: ConfigRuleRequired, Message: "is required", Rule: "db.pool.size"}}, errs)
+
+	// A rule naming a key exactly rejects objects; wildcard rules only check scalars
+	errs = ValidateConfigFields(rules, "db.timeout", "value", map[string]interface{}{"url": "https://db.example.com"})
+	assert.Equal(t, []FieldError{{Field: "value", Pointer: "/value", Code: ConfigRuleType, Message: "must be a duration such as 30s", Rule: "db.timeout"}}, errs)
+
+	errs = ValidateConfigFields(rules, "dashboard", "config", map[string]interface{}{"owner": "Ops <ops@example.com>"})
+	assert.Equal(t, []FieldError{{Field: "config.owner", Pointer: "/config/owner", Code: ConfigRuleType, Message: "must be an email address", Rule: "dashboard.owner"}}, errs)
+	assert.Empty(t, ValidateConfigFields(rules, "dashboard", "config", map[string]interface{}{"owner": "ops@example.com"}))
+}
+
+func TestConfigSchema_Validation(t *testing.T) {
+	object := JSONSchema{"type": "object"}
+	tests := []struct {
+		name    string
+		schema  ConfigSchema
+		wantErr bool
+	}{
+		{"component schema", ConfigSchema{Target: " App_Component ", Code: "dashboard", Schema: object}, false},
+		{"limits schema", ConfigSchema{Target: "package_limits", Schema: object}, false},
+		{"unknown target", ConfigSchema{Target: "tenant", Schema: object}, true},
+		{"component without code", ConfigSchema{Target: "app_component", Schema: object}, true},
+		{"limits with code", ConfigSchema{Target: "package_limits", Code: "pro", Schema: object}, true},
+		{"missing schema", ConfigSchema{Target: "package_limits"}, true},
+		{"unsupported keyword", ConfigSchema{Target: "package_limits", Schema: JSONSchema{"$ref": "#/$defs/limits"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.schema.Normalize()
+			err := tt.schema.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfigSchema_BSON(t *testing.T) {
+	schema := ConfigSchema{Target: "package_limits", Schema: JSONSchema{
+		"$schema":    "https://json-schema.org/draft/2020-12/schema",
+		"properties": map[string]interface{}{"users": map[string]interface{}{"type": "integer"}},
+	}}
+
+	data, err := bson.Marshal(schema)
+	require.NoError(t, err)
+	var stored bson.M
+	require.NoError(t, bson.Unmarshal(data, &stored))
+	assert.IsType(t, "", stored["schema"], "schemas are stored as JSON text")
+
+	var decoded ConfigSchema
+	require.NoError(t, bson.Unmarshal(data, &decoded))
+	assert.Equal(t, schema.Schema, decoded.Schema)
+
+	violations := decoded.Violations(mustCompile(t, &decoded), "limits", map[string]interface{}{"users": "many"})
+	assert.Equal(t, []FieldError{{
+		Field: "limits.users", Pointer: "/limits/users", Code: "type", Message: "must be of type integer", Rule: "package_limits",
+	}}, violations)
+}
+
+func mustCompile(t *testing.T, schema *ConfigSchema) *jsonschema.Schema {
+	t.Helper()
+
+	compiled, err := schema.Compile()
+	require.NoError(t, err)
+	return compiled
+}
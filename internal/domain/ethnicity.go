@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -9,10 +10,42 @@ import (
 // Ethnicity represents an ethnicity in the system
 type Ethnicity struct {
 	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	Code        string             `json:"code" bson:"code"`
+	Code        string             `json:"code" bson:"code"` // official code, unique within the country
 	Name        map[string]string  `json:"name" bson:"name"` // i18n
 	CountryCode string             `json:"country_code" bson:"countryCode"`
 	Status      string             `json:"status" bson:"status"`
 	CreatedAt   time.Time          `json:"created_at" bson:"createdAt"`
 	UpdatedAt   time.Time          `json:"updated_at" bson:"updatedAt"`
+
+	// LocalizedName is Name in the locale of the request; it is not stored
+	LocalizedName string `json:"localized_name,omitempty" bson:"-"`
+}
+
+// Validate validates the ethnicity data
+func (e *Ethnicity) Validate() error {
+	if e.Code == "" {
+		return errors.New("code is required")
+	}
+	if len(e.Name) == 0 {
+		return errors.New("name is required")
+	}
+	if !countryCodePattern.MatchString(e.CountryCode) {
+		return errors.New("country_code must be an ISO 3166-1 alpha-2 code")
+	}
+	switch e.Status {
+	case "", "active", "inactive":
+	default:
+		return errors.New("status must be one of active, inactive")
+	}
+	return nil
+}
+
+// Translations returns the i18n name of the ethnicity
+func (e *Ethnicity) Translations() map[string]string {
+	return e.Name
+}
+
+// SetLocalizedName sets the name of the ethnicity in the locale of the request
+func (e *Ethnicity) SetLocalizedName(name string) {
+	e.LocalizedName = name
 }
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ExchangeRateSourceManual marks rates entered by hand rather than pushed by a provider
+const ExchangeRateSourceManual = "manual"
+
+// ExchangeRate is the price of one unit of Base in Quote on a given day
+type ExchangeRate struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Base      string             `json:"base" bson:"base"`   // ISO 4217
+	Quote     string             `json:"quote" bson:"quote"` // ISO 4217
+	Rate      float64            `json:"rate" bson:"rate"`
+	Date      time.Time          `json:"date" bson:"date"`     // midnight UTC of the day the rate applies from
+	Source    string             `json:"source" bson:"source"` // manual or the ingesting provider
+	CreatedAt time.Time          `json:"created_at" bson:"createdAt"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updatedAt"`
+}
+
+// Validate validates the exchange rate data
+func (r *ExchangeRate) Validate() error {
+	if !IsCurrencyCode(r.Base) || !IsCurrencyCode(r.Quote) {
+		return errors.New("base and quote must be three letter ISO 4217 codes")
+	}
+	if r.Base == r.Quote {
+		return errors.New("base and quote must differ")
+	}
+	if r.Rate <= 0 || math.IsInf(r.Rate, 0) || math.IsNaN(r.Rate) {
+		return errors.New("rate must be a positive number")
+	}
+	if r.Date.IsZero() {
+		return errors.New("date is required")
+	}
+	return nil
+}
+
+// RateDay returns midnight UTC of the day t falls on, the granularity rates are stored at
+func RateDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// ExchangeRateFilter selects the stored rates of a currency pair, optionally within [From, To]
+type ExchangeRateFilter struct {
+	Base  string     `form:"base"`
+	Quote string     `form:"quote"`
+	From  *time.Time `form:"from" time_format:"2006-01-02" time_utc:"1"`
+	To    *time.Time `form:"to" time_format:"2006-01-02" time_utc:"1"`
+}
+
+// ExchangeRateIngestRequest is a batch of rates pushed by a provider
+type ExchangeRateIngestRequest struct {
+	Source string          `json:"source" binding:"required"`
+	Rates  []*ExchangeRate `json:"rates" binding:"required"`
+}
+
+// ExchangeRateIngestReport tallies how an ingested batch changed the stored rates
+type ExchangeRateIngestReport struct {
+	Source  string `json:"source"`
+	Created int    `json:"created"`
+	Updated int    `json:"updated"`
+}
+
+// ExchangeRateQuote answers "what was the rate from Base to Quote on Date". RateDate is the day of
+// the stored rate used, the most recent one on or before Date; Inverted is set when only the
+// opposite pair was stored and Rate is its reciprocal.
+type ExchangeRateQuote struct {
+	Base     string    `json:"base"`
+	Quote    string    `json:"quote"`
+	Rate     float64   `json:"rate"`
+	Date     time.Time `json:"date"`
+	RateDate time.Time `json:"rate_date"`
+	Source   string    `json:"source"`
+	Inverted bool      `json:"inverted"`
+}
+
+// ConversionRequest asks for Amount in From expressed in To at the rate in effect on Date
+type ConversionRequest struct {
+	Amount float64    `form:"amount" json:"amount"`
+	From   string     `form:"from" json:"from" binding:"required"`
+	To     string     `form:"to" json:"to" binding:"required"`
+	Date   *time.Time `form:"date" json:"date" time_format:"2006-01-02" time_utc:"1"`
+}
+
+// Validate validates the conversion request
+func (r *ConversionRequest) Validate() error {
+	if math.IsInf(r.Amount, 0) || math.IsNaN(r.Amount) {
+		return errors.New("amount must be a finite number")
+	}
+	if !IsCurrencyCode(r.From) || !IsCurrencyCode(r.To) {
+		return errors.New("from and to must be three letter ISO 4217 codes")
+	}
+	return nil
+}
+
+// Conversion is the result of a ConversionRequest; Converted is rounded to the decimal digits of To
+type Conversion struct {
+	Amount    float64            `json:"amount"`
+	From      string             `json:"from"`
+	To        string             `json:"to"`
+	Converted float64            `json:"converted"`
+	Rate      *ExchangeRateQuote `json:"rate"`
+}
+
+// String identifies the pair and day of a rate, as in "USD/VND@2025-01-02"
+func (r *ExchangeRate) String() string {
+	return fmt.Sprintf("%s/%s@%s", r.Base, r.Quote, r.Date.Format(time.DateOnly))
+}
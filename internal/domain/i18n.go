@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale is the locale used when a requested translation is missing
+const DefaultLocale = "en"
+
+// Localize picks the value of an i18n map for the first of the locales that has one.
+// Each locale falls back to its base language (vi-VN -> vi); after all of them come
+// DefaultLocale and then any available value.
+func Localize(values map[string]string, locales ...string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	for _, locale := range locales {
+		locale = strings.ToLower(strings.TrimSpace(locale))
+		if value := values[locale]; value != "" {
+			return value
+		}
+		if base, _, found := strings.Cut(locale, "-"); found {
+			if value := values[base]; value != "" {
+				return value
+			}
+		}
+	}
+	if value := values[DefaultLocale]; value != "" {
+		return value
+	}
+
+	keys := make([]string, 0, len(values))
+	for key, value := range values {
+		if value != "" {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+	return values[keys[0]]
+}
+
+// Localizable is master data with an i18n name that responses flatten into a localized name
+type Localizable interface {
+	Translations() map[string]string
+	SetLocalizedName(name string)
+}
+
+// Localization is the locale preference of a request, most preferred first, and whether
+// listings should be ordered by localized name instead of by code
+type Localization struct {
+	Locales    []string
+	SortByName bool
+}
+
+// Locale returns the most preferred locale, or DefaultLocale when there is no preference
+func (l Localization) Locale() string {
+	if len(l.Locales) == 0 {
+		return DefaultLocale
+	}
+	return l.Locales[0]
+}
+
+// Apply sets the localized name of an item
+func (l Localization) Apply(item Localizable) {
+	item.SetLocalizedName(Localize(item.Translations(), l.Locales...))
+}
+
+// LocalizeAll sets the localized name of each item and, if requested, orders the items by it
+// with the collation of the preferred locale. Items with equal names keep their order.
+func LocalizeAll[T Localizable](items []T, l Localization) {
+	names := make(map[Localizable]string, len(items))
+	for _, item := range items {
+		name := Localize(item.Translations(), l.Locales...)
+		item.SetLocalizedName(name)
+		names[item] = name
+	}
+	if !l.SortByName {
+		return
+	}
+
+	tag, err := language.Parse(l.Locale())
+	if err != nil {
+		tag = language.English
+	}
+	collator := collate.New(tag, collate.IgnoreWidth)
+	sort.SliceStable(items, func(i, j int) bool {
+		return collator.CompareString(names[items[i]], names[items[j]]) < 0
+	})
+}
@@ -0,0 +1,280 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Location levels, from the top of the administrative hierarchy down
+const (
+	LocationLevelCountry  = "country"
+	LocationLevelProvince = "province"
+	LocationLevelDistrict = "district"
+	LocationLevelWard     = "ward"
+)
+
+// LocationValidity is the period an administrative unit is in effect and, once it has been
+// retired by a reorganization, the codes of the units at the same level that replaced it
+type LocationValidity struct {
+	EffectiveFrom  *time.Time `json:"effective_from,omitempty" bson:"effectiveFrom,omitempty"`
+	EffectiveTo    *time.Time `json:"effective_to,omitempty" bson:"effectiveTo,omitempty"`
+	SuccessorCodes []string   `json:"successor_codes,omitempty" bson:"successorCodes,omitempty"`
+}
+
+// IsCurrentAt reports whether the unit is in effect at t
+func (v LocationValidity) IsCurrentAt(t time.Time) bool {
+	if v.EffectiveFrom != nil && v.EffectiveFrom.After(t) {
+		return false
+	}
+	return v.EffectiveTo == nil || v.EffectiveTo.After(t)
+}
+
+// NextChangeAfter returns the first boundary of the period after t, where the unit comes into
+// or goes out of effect, or nil when the period does not change after t
+func (v LocationValidity) NextChangeAfter(t time.Time) *time.Time {
+	for _, boundary := range []*time.Time{v.EffectiveFrom, v.EffectiveTo} {
+		if boundary != nil && boundary.After(t) {
+			next := *boundary
+			return &next
+		}
+	}
+	return nil
+}
+
+// Validate validates the validity period
+func (v LocationValidity) Validate() error {
+	if v.EffectiveFrom != nil && v.EffectiveTo != nil && !v.EffectiveTo.After(*v.EffectiveFrom) {
+		return errors.New("effective_to must be after effective_from")
+	}
+	return nil
+}
+
+// LocationRef identifies an administrative unit on an ancestor path
+type LocationRef struct {
+	Level         string            `json:"level"`
+	Code          string            `json:"code"`
+	Name          map[string]string `json:"name"`
+	LocalizedName string            `json:"localized_name,omitempty"`
+}
+
+// Translations returns the i18n name of the unit
+func (r *LocationRef) Translations() map[string]string {
+	return r.Name
+}
+
+// SetLocalizedName sets the name of the unit in the locale of the request
+func (r *LocationRef) SetLocalizedName(name string) {
+	r.LocalizedName = name
+}
+
+// LocationHit is a search match together with its ancestors, country first
+type LocationHit struct {
+	LocationRef
+	Type string        `json:"type"`
+	Path []LocationRef `json:"path"`
+}
+
+// LocationSearchRequest represents a type-ahead search across provinces, districts and wards
+type LocationSearchRequest struct {
+	Query      string `form:"q"`
+	Level      string `form:"level"`       // province, district or ward; all levels when empty
+	ParentCode string `form:"parent_code"` // country, province or district the hits must lie under
+	Limit      int    `form:"limit"`
+}
+
+// SetDefaults sets default values for a location search
+func (r *LocationSearchRequest) SetDefaults() {
+	if r.Limit < 1 {
+		r.Limit = 20
+	}
+	if r.Limit > 100 {
+		r.Limit = 100
+	}
+}
+
+// Validate validates the location search request
+func (r *LocationSearchRequest) Validate() error {
+	if FoldText(r.Query) == "" {
+		return errors.New("q is required")
+	}
+	switch r.Level {
+	case "", LocationLevelProvince, LocationLevelDistrict, LocationLevelWard:
+	default:
+		return errors.New("level must be one of province, district, ward")
+	}
+	return nil
+}
+
+// LocationQuery is a repository search over the folded names of one location level
+type LocationQuery struct {
+	Text         string // folded text, see FoldText
+	Prefix       bool   // only names starting with Text, otherwise names containing it
+	CountryCode  string // restrict to units in this country when set
+	ProvinceCode string // restrict districts and wards to this province when set
+	DistrictCode string // restrict wards to this district when set
+	Limit        int
+}
+
+// FoldText lower-cases s, strips diacritics and collapses punctuation and spacing,
+// so "Hà Nội", "HA NOI" and "ha  noi" all fold to "ha noi"
+func FoldText(s string) string {
+	stripped, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+	if err != nil {
+		stripped = s
+	}
+
+	folded := strings.Map(func(r rune) rune {
+		switch {
+		case r == 'đ' || r == 'Đ':
+			return 'd'
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		default:
+			return ' '
+		}
+	}, stripped)
+	return strings.Join(strings.Fields(folded), " ")
+}
+
+// SearchTerms returns the distinct folded forms of a localized name across all locales
+func SearchTerms(name map[string]string) []string {
+	seen := make(map[string]bool, len(name))
+	terms := make([]string, 0, len(name))
+	for _, value := range name {
+		term := FoldText(value)
+		if term == "" || seen[term] {
+			continue
+		}
+		seen[term] = true
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+	return terms
+}
+
+// ReorganizationDecree is a batch of boundary changes that take effect on the same date
+type ReorganizationDecree struct {
+	Reference     string           `json:"reference"` // e.g. the resolution number
+	EffectiveDate time.Time        `json:"effective_date"`
+	Changes       []LocationChange `json:"changes"`
+}
+
+// LocationChange replaces the From units of one level with the To units.
+// From units that are not also listed in To are retired with the To codes as successors.
+// To units that do not exist yet are created; existing ones take the name, type and
+// parent given for them, which is also how a unit is moved under another parent.
+type LocationChange struct {
+	Level string         `json:"level"`
+	From  []string       `json:"from"`
+	To    []LocationUnit `json:"to"`
+}
+
+// LocationUnit describes a unit created or updated by a reorganization
+type LocationUnit struct {
+	Code       string            `json:"code"`
+	Name       map[string]string `json:"name,omitempty"`
+	Type       string            `json:"type,omitempty"`
+	ParentCode string            `json:"parent_code,omitempty"`
+}
+
+// Validate validates the decree
+func (d *ReorganizationDecree) Validate() error {
+	if d.Reference == "" {
+		return errors.New("reference is required")
+	}
+	if d.EffectiveDate.IsZero() {
+		return errors.New("effective_date is required")
+	}
+	if len(d.Changes) == 0 {
+		return errors.New("changes are required")
+	}
+	for i, change := range d.Changes {
+		switch change.Level {
+		case LocationLevelProvince, LocationLevelDistrict, LocationLevelWard:
+		default:
+			return fmt.Errorf("changes[%d]: level must be one of province, district, ward", i)
+		}
+		if len(change.To) == 0 {
+			return fmt.Errorf("changes[%d]: to is required", i)
+		}
+		for _, unit := range change.To {
+			if unit.Code == "" {
+				return fmt.Errorf("changes[%d]: every unit in to needs a code", i)
+			}
+		}
+	}
+	return nil
+}
+
+// ReorganizationReport lists the units a decree created, updated, retired and moved
+type ReorganizationReport struct {
+	Reference     string        `json:"reference"`
+	EffectiveDate time.Time     `json:"effective_date"`
+	Created       []LocationRef `json:"created"`
+	Updated       []LocationRef `json:"updated"`
+	Retired       []LocationRef `json:"retired"`
+	Moved         []LocationRef `json:"moved"` // children of retired units re-parented to the successor
+}
+
+// LocationResolution maps a possibly retired code to the units in effect at a date
+type LocationResolution struct {
+	Code     string        `json:"code"`
+	Level    string        `json:"level"`
+	Current  bool          `json:"current"`  // whether the code itself is in effect
+	Via      []string      `json:"via"`      // retired codes followed to reach the result
+	Resolved []LocationRef `json:"resolved"` // empty when the unit was retired without successors
+}
+
+// Location import formats
+const (
+	LocationImportCSV  = "csv"
+	LocationImportJSON = "json"
+)
+
+// LocationImportOptions controls an administrative unit import
+type LocationImportOptions struct {
+	Format      string `form:"format"`       // csv or json
+	DryRun      bool   `form:"dry_run"`      // validate and report without writing
+	CountryCode string `form:"country_code"` // parent of provinces in files that do not name it; defaults to VN
+}
+
+// LocationImportRow is one administrative unit read from an import file
+type LocationImportRow struct {
+	Line       int               `json:"-"`
+	Level      string            `json:"level"`
+	Code       string            `json:"code"`
+	ParentCode string            `json:"parent_code"`
+	Type       string            `json:"type"`
+	Name       map[string]string `json:"name"`
+}
+
+// LocationImportCounts tallies the outcome of the rows of one level
+type LocationImportCounts struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Rejected  int `json:"rejected"`
+}
+
+// LocationImportRejection explains why a row was not imported
+type LocationImportRejection struct {
+	Line   int    `json:"line"`
+	Level  string `json:"level"`
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+}
+
+// LocationImportReport summarizes an import, per level
+type LocationImportReport struct {
+	DryRun   bool                             `json:"dry_run"`
+	Levels   map[string]*LocationImportCounts `json:"levels"`
+	Rejected []LocationImportRejection        `json:"rejected"`
+}
@@ -1,6 +1,10 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -21,3 +25,77 @@ type Permission struct {
 	CreatedAt   time.Time          `json:"created_at" bson:"createdAt"`
 	UpdatedAt   time.Time          `json:"updated_at" bson:"updatedAt"`
 }
+
+var (
+	permissionResourcePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*(\.[a-z][a-z0-9_-]*)*$`)
+	permissionActionPattern   = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+)
+
+// Validate validates the permission data.
+// The code must be "<resource>.<action>" built from the Resource and Action fields.
+func (p *Permission) Validate() error {
+	if p.Code == "" {
+		return errors.New("code is required")
+	}
+	if p.ModuleCode == "" {
+		return errors.New("module_code is required")
+	}
+	if !permissionResourcePattern.MatchString(p.Resource) {
+		return errors.New("resource must be lowercase dot separated words")
+	}
+	if !permissionActionPattern.MatchString(p.Action) {
+		return errors.New("action must be a lowercase word")
+	}
+	if p.Code != p.Resource+"."+p.Action {
+		return fmt.Errorf("code '%s' must be resource.action ('%s.%s')", p.Code, p.Resource, p.Action)
+	}
+	switch p.Category {
+	case "", "data", "feature", "admin":
+	default:
+		return errors.New("category must be one of data, feature, admin")
+	}
+	switch p.Status {
+	case "", "active", "inactive":
+	default:
+		return errors.New("status must be one of active, inactive")
+	}
+	return nil
+}
+
+// PermissionManifest is the set of permissions a module registers at startup
+type PermissionManifest struct {
+	ModuleCode  string        `json:"module_code" binding:"required"`
+	Permissions []*Permission `json:"permissions"`
+}
+
+// PermissionSyncReport describes how a manifest compared to the stored permissions of its module
+type PermissionSyncReport struct {
+	ModuleCode string   `json:"module_code"`
+	Added      []string `json:"added"`
+	Changed    []string `json:"changed"`
+	Unchanged  []string `json:"unchanged"`
+	Stale      []string `json:"stale"` // stored for the module but missing from the manifest
+}
+
+// WildcardGrant grants every permission
+const WildcardGrant = "*"
+
+// ValidateGrant validates a permission grant as held by a role.
+// A grant is either a permission code, "<prefix>.*" covering every code under the prefix, or "*".
+func ValidateGrant(grant string) error {
+	if grant == WildcardGrant {
+		return nil
+	}
+	if prefix, ok := strings.CutSuffix(grant, ".*"); ok {
+		if !permissionResourcePattern.MatchString(prefix) {
+			return fmt.Errorf("grant '%s' must be a permission code, '<prefix>.*' or '*'", grant)
+		}
+		return nil
+	}
+
+	dot := strings.LastIndex(grant, ".")
+	if dot < 0 || !permissionResourcePattern.MatchString(grant[:dot]) || !permissionActionPattern.MatchString(grant[dot+1:]) {
+		return fmt.Errorf("grant '%s' must be a permission code, '<prefix>.*' or '*'", grant)
+	}
+	return nil
+}
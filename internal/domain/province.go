@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -14,6 +15,36 @@ type Province struct {
 	CountryCode string             `json:"country_code" bson:"countryCode"`
 	Type        string             `json:"type" bson:"type"` // province, city, state
 	Status      string             `json:"status" bson:"status"`
+	SearchText  []string           `json:"-" bson:"searchText"` // folded names, see SearchTerms
 	CreatedAt   time.Time          `json:"created_at" bson:"createdAt"`
 	UpdatedAt   time.Time          `json:"updated_at" bson:"updatedAt"`
+
+	LocationValidity `bson:",inline"`
+
+	// LocalizedName is Name in the locale of the request; it is not stored
+	LocalizedName string `json:"localized_name,omitempty" bson:"-"`
+}
+
+// Validate validates the province data
+func (p *Province) Validate() error {
+	if p.Code == "" {
+		return errors.New("code is required")
+	}
+	if len(p.Name) == 0 {
+		return errors.New("name is required")
+	}
+	if p.CountryCode == "" {
+		return errors.New("country_code is required")
+	}
+	return p.LocationValidity.Validate()
+}
+
+// Translations returns the i18n name of the province
+func (p *Province) Translations() map[string]string {
+	return p.Name
+}
+
+// SetLocalizedName sets the name of the province in the locale of the request
+func (p *Province) SetLocalizedName(name string) {
+	p.LocalizedName = name
 }
@@ -1,5 +1,7 @@
 package domain
 
+import "strings"
+
 // Common request/response structures
 
 // PaginationRequest represents pagination parameters
@@ -50,11 +52,35 @@ type DeleteResponse struct {
 	ID string `json:"id"`
 }
 
-// ErrorResponse represents an error response
+// ErrorResponse represents an error response. Validation failures list their field-level
+// errors as details.
 type ErrorResponse struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
-	Details string `json:"details,omitempty"`
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// FieldError represents a validation failure of one field of a request
+type FieldError struct {
+	Field   string `json:"field"`             // path of the field in the request, such as "config.theme"
+	Pointer string `json:"pointer,omitempty"` // JSON pointer of the field, such as "/config/theme"
+	Code    string `json:"code"`              // constraint that failed, such as "type" or "max"
+	Message string `json:"message"`           // what the value must be
+	Rule    string `json:"rule,omitempty"`    // rule or schema that was violated
+}
+
+// ValidationError reports the fields of a request that failed validation
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Error lists the failed fields with their messages
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Field+" "+field.Message)
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
 }
 
 // HealthResponse represents a health check response
@@ -63,3 +89,15 @@ type HealthResponse struct {
 	Service string `json:"service"`
 	Version string `json:"version"`
 }
+
+// ResolveModulesRequest represents a request to resolve module dependencies
+type ResolveModulesRequest struct {
+	Codes []string `json:"codes" binding:"required"`
+}
+
+// MoveMenuRequest represents a request to move a menu item and its subtree.
+// Position is the zero-based index among the new siblings; when omitted the item is appended.
+type MoveMenuRequest struct {
+	ParentID string `json:"parent_id"`
+	Position *int   `json:"position"`
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -15,8 +16,70 @@ type Role struct {
 	Description string             `json:"description" bson:"description"`
 	IsSystem    bool               `json:"is_system" bson:"isSystem"`      // system roles can't be deleted
 	Level       int                `json:"level" bson:"level"`             // hierarchy level
+	ParentCode  string             `json:"parent_code" bson:"parentCode"`  // role whose permissions are inherited
 	Permissions []string           `json:"permissions" bson:"permissions"` // permission codes
 	Status      string             `json:"status" bson:"status"`
 	CreatedAt   time.Time          `json:"created_at" bson:"createdAt"`
 	UpdatedAt   time.Time          `json:"updated_at" bson:"updatedAt"`
 }
+
+// Validate validates the role data
+func (r *Role) Validate() error {
+	if r.Code == "" {
+		return errors.New("code is required")
+	}
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	if r.Level < 1 {
+		return errors.New("level must be at least 1")
+	}
+	if r.ParentCode == r.Code {
+		return errors.New("role cannot inherit from itself")
+	}
+	switch r.Status {
+	case "", "active", "inactive":
+	default:
+		return errors.New("status must be one of active, inactive")
+	}
+	return nil
+}
+
+// RolePermissionsRequest represents a request to replace the permission grants of a role
+type RolePermissionsRequest struct {
+	Permissions []string `json:"permissions"`
+}
+
+// EffectivePermissions are the concrete registry permissions a role's grants expand to
+type EffectivePermissions struct {
+	RoleCode    string   `json:"role_code"`
+	Chain       []string `json:"chain"`       // the role followed by the roles it inherits from
+	Grants      []string `json:"grants"`      // of the whole chain, possibly with wildcards
+	Permissions []string `json:"permissions"` // registered permission codes covered by the grants
+	Unmatched   []string `json:"unmatched"`   // grants that cover no registered permission
+}
+
+// PermissionCheckRequest asks whether any of the given roles grants a permission
+type PermissionCheckRequest struct {
+	RoleCodes  []string `json:"role_codes" binding:"required"`
+	Permission string   `json:"permission" binding:"required"`
+}
+
+// PermissionCheckResult is the decision for a permission check.
+// Role and Grant name the requested role and the grant that allowed the permission;
+// InheritedFrom names the ancestor role holding the grant when it was inherited.
+type PermissionCheckResult struct {
+	Permission    string `json:"permission"`
+	Allowed       bool   `json:"allowed"`
+	Role          string `json:"role,omitempty"`
+	Grant         string `json:"grant,omitempty"`
+	InheritedFrom string `json:"inherited_from,omitempty"`
+}
+
+// CloneRoleRequest represents a request to copy a role into a tenant under a new code.
+// TenantID is only honoured for platform callers; tenant callers clone into their own tenant.
+type CloneRoleRequest struct {
+	TenantID string `json:"tenant_id"`
+	Code     string `json:"code" binding:"required"`
+	Name     string `json:"name"`
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -23,3 +24,36 @@ type SaaSModule struct {
 	CreatedAt    time.Time          `json:"created_at" bson:"createdAt"`
 	UpdatedAt    time.Time          `json:"updated_at" bson:"updatedAt"`
 }
+
+// Validate validates the SaaS module data
+func (m *SaaSModule) Validate() error {
+	if m.Code == "" {
+		return errors.New("code is required")
+	}
+	if m.Name == "" {
+		return errors.New("name is required")
+	}
+	switch m.Category {
+	case "", "core", "addon", "premium":
+	default:
+		return errors.New("category must be one of core, addon, premium")
+	}
+	switch m.Status {
+	case "", "active", "inactive", "deprecated":
+	default:
+		return errors.New("status must be one of active, inactive, deprecated")
+	}
+	for _, dependency := range m.Dependencies {
+		if dependency == m.Code {
+			return errors.New("module cannot depend on itself")
+		}
+	}
+	return nil
+}
+
+// ModuleResolution is the result of resolving a set of module codes through their dependencies
+type ModuleResolution struct {
+	Requested    []string      `json:"requested"`
+	InstallOrder []string      `json:"install_order"` // dependencies before dependents
+	Modules      []*SaaSModule `json:"modules"`       // transitive closure in install order
+}
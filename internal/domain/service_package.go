@@ -1,6 +1,11 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -25,3 +30,149 @@ type ServicePackage struct {
 	CreatedAt    time.Time              `json:"created_at" bson:"createdAt"`
 	UpdatedAt    time.Time              `json:"updated_at" bson:"updatedAt"`
 }
+
+// Validate validates the service package data
+func (p *ServicePackage) Validate() error {
+	if p.Code == "" {
+		return errors.New("code is required")
+	}
+	if p.Name == "" {
+		return errors.New("name is required")
+	}
+	switch p.Tier {
+	case "", "free", "basic", "professional", "enterprise":
+	default:
+		return errors.New("tier must be one of free, basic, professional, enterprise")
+	}
+	switch p.BillingCycle {
+	case "", "monthly", "yearly":
+	default:
+		return errors.New("billing_cycle must be one of monthly, yearly")
+	}
+	if p.Price < 0 {
+		return errors.New("price must not be negative")
+	}
+	if _, err := ParsePackageLimits(p.Limits); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Unlimited marks a limit without an upper bound
+const Unlimited int64 = -1
+
+// PackageLimits are the typed limits of a service package.
+// A limit that is absent or set to "unlimited" (or -1) is reported as Unlimited.
+type PackageLimits struct {
+	Users    int64                  `json:"users"`
+	Storage  int64                  `json:"storage"` // bytes
+	APICalls int64                  `json:"api_calls"`
+	Other    map[string]interface{} `json:"other,omitempty"` // limits without a typed field
+}
+
+// ParsePackageLimits converts the free-form Limits map into typed limits.
+// Counts must be non-negative integers; storage may also be a size string such as "10GB".
+func ParsePackageLimits(limits map[string]interface{}) (PackageLimits, error) {
+	parsed := PackageLimits{Users: Unlimited, Storage: Unlimited, APICalls: Unlimited}
+
+	for key, value := range limits {
+		var err error
+		switch key {
+		case "users":
+			parsed.Users, err = parseLimit(value, false)
+		case "storage":
+			parsed.Storage, err = parseLimit(value, true)
+		case "api_calls":
+			parsed.APICalls, err = parseLimit(value, false)
+		default:
+			if parsed.Other == nil {
+				parsed.Other = make(map[string]interface{})
+			}
+			parsed.Other[key] = value
+		}
+		if err != nil {
+			return PackageLimits{}, fmt.Errorf("limit '%s' %v", key, err)
+		}
+	}
+
+	return parsed, nil
+}
+
+// parseLimit parses a single limit value
+func parseLimit(value interface{}, allowSize bool) (int64, error) {
+	var number float64
+	switch v := value.(type) {
+	case nil:
+		return Unlimited, nil
+	case int:
+		number = float64(v)
+	case int32:
+		number = float64(v)
+	case int64:
+		number = float64(v)
+	case float64:
+		number = v
+	case string:
+		s := strings.TrimSpace(strings.ToLower(v))
+		if s == "unlimited" {
+			return Unlimited, nil
+		}
+		if allowSize {
+			return parseSize(s)
+		}
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return 0, errors.New("must be an integer or 'unlimited'")
+		}
+		number = float64(n)
+	default:
+		return 0, errors.New("must be an integer or 'unlimited'")
+	}
+
+	if number == float64(Unlimited) {
+		return Unlimited, nil
+	}
+	if number < 0 || number != math.Trunc(number) {
+		return 0, errors.New("must be a non-negative integer or 'unlimited'")
+	}
+	return int64(number), nil
+}
+
+// sizeUnits are the binary units accepted in storage limits
+var sizeUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"tb", 1 << 40},
+	{"gb", 1 << 30},
+	{"mb", 1 << 20},
+	{"kb", 1 << 10},
+	{"b", 1},
+}
+
+// parseSize parses a size string such as "500MB" or "10GB" into bytes
+func parseSize(s string) (int64, error) {
+	multiplier := int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(s, unit.suffix) {
+			multiplier = unit.multiplier
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 {
+		return 0, errors.New("must be a size such as 500MB, 10GB or 'unlimited'")
+	}
+	return int64(n * float64(multiplier)), nil
+}
+
+// Entitlements are the effective modules, features and limits granted by a service package
+type Entitlements struct {
+	PackageCode string        `json:"package_code"`
+	Tier        string        `json:"tier"`
+	Modules     []string      `json:"modules"`  // package modules and their dependencies, in install order
+	Features    []string      `json:"features"` // union of package and module features
+	Limits      PackageLimits `json:"limits"`
+}
@@ -0,0 +1,15 @@
+package domain
+
+// ProvisionedItems lists the codes provisioning created and the codes the tenant already had
+type ProvisionedItems struct {
+	Created  []string `json:"created"`
+	Existing []string `json:"existing"`
+}
+
+// TenantProvisioning reports what provisioning materialized for a tenant
+type TenantProvisioning struct {
+	TenantID string           `json:"tenant_id"`
+	Roles    ProvisionedItems `json:"roles"`
+	Modules  ProvisionedItems `json:"modules"`
+	Menus    ProvisionedItems `json:"menus"`
+}
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Entity types whose i18n fields are covered by translation reports, imports and exports
+const (
+	TranslationEntityCountry   = "country"
+	TranslationEntityCurrency  = "currency"
+	TranslationEntityEthnicity = "ethnicity"
+	TranslationEntityProvince  = "province"
+	TranslationEntityDistrict  = "district"
+	TranslationEntityWard      = "ward"
+	TranslationEntityAdminMenu = "admin_menu"
+)
+
+// TranslationEntities lists the translatable entity types in report order
+var TranslationEntities = []string{
+	TranslationEntityCountry,
+	TranslationEntityCurrency,
+	TranslationEntityEthnicity,
+	TranslationEntityProvince,
+	TranslationEntityDistrict,
+	TranslationEntityWard,
+	TranslationEntityAdminMenu,
+}
+
+// Translation file formats
+const (
+	TranslationFormatCSV   = "csv"
+	TranslationFormatXLIFF = "xliff"
+)
+
+// TranslationUnit is one translatable field of a record, e.g. the name of country VN.
+// Ethnicities are keyed by country and code ("VN:01"), every other entity by its code.
+type TranslationUnit struct {
+	Entity string            `json:"entity"`
+	Key    string            `json:"key"`
+	Field  string            `json:"field"` // name, or title for admin menus
+	Values map[string]string `json:"values"`
+}
+
+// ID identifies the unit across exports and imports as entity/key/field
+func (u TranslationUnit) ID() string {
+	return u.Entity + "/" + u.Key + "/" + u.Field
+}
+
+// ParseTranslationUnitID splits an ID made by TranslationUnit.ID. Keys may contain slashes.
+func ParseTranslationUnitID(id string) (entity, key, field string, err error) {
+	entity, rest, ok := strings.Cut(id, "/")
+	if ok {
+		if i := strings.LastIndex(rest, "/"); i > 0 {
+			return entity, rest[:i], rest[i+1:], nil
+		}
+	}
+	return "", "", "", fmt.Errorf("'%s' is not an entity/key/field translation id", id)
+}
+
+// Missing returns the locales the unit has no value for
+func (u TranslationUnit) Missing(locales []string) []string {
+	var missing []string
+	for _, locale := range locales {
+		if strings.TrimSpace(u.Values[locale]) == "" {
+			missing = append(missing, locale)
+		}
+	}
+	return missing
+}
+
+// TranslationQuery selects the units and locales of a report or export
+type TranslationQuery struct {
+	Entities    []string `form:"entity" collection_format:"csv"`  // all entity types when empty
+	Locales     []string `form:"locales" collection_format:"csv"` // the configured locales when empty
+	MissingOnly bool     `form:"missing"`                         // only units missing one of the locales
+	Format      string   `form:"format"`                          // csv or xliff, for exports
+	Source      string   `form:"source"`                          // XLIFF source locale; DefaultLocale when empty
+	Target      string   `form:"target"`                          // XLIFF target locale
+}
+
+// Normalize lower-cases and de-duplicates the entity types and locales of the query
+func (q *TranslationQuery) Normalize() {
+	q.Entities = normalizeList(q.Entities)
+	q.Locales = normalizeList(q.Locales)
+	q.Format = strings.ToLower(strings.TrimSpace(q.Format))
+	q.Source = strings.ToLower(strings.TrimSpace(q.Source))
+	q.Target = strings.ToLower(strings.TrimSpace(q.Target))
+}
+
+// Validate validates the translation query
+func (q *TranslationQuery) Validate() error {
+	for _, entity := range q.Entities {
+		if !slices.Contains(TranslationEntities, entity) {
+			return fmt.Errorf("entity must be one of %s", strings.Join(TranslationEntities, ", "))
+		}
+	}
+	switch q.Format {
+	case "", TranslationFormatCSV:
+	case TranslationFormatXLIFF:
+		if q.Target == "" {
+			return errors.New("target is required for XLIFF exports")
+		}
+	default:
+		return errors.New("format must be one of csv, xliff")
+	}
+	return nil
+}
+
+// TranslationCoverage is how much of an entity type is translated into one locale
+type TranslationCoverage struct {
+	Translated int      `json:"translated"`
+	Percent    float64  `json:"percent"`
+	Missing    []string `json:"missing"` // keys of the units without a translation
+}
+
+// TranslationEntityReport is the translation coverage of an entity type per locale
+type TranslationEntityReport struct {
+	Entity  string                          `json:"entity"`
+	Total   int                             `json:"total"`
+	Locales map[string]*TranslationCoverage `json:"locales"`
+}
+
+// TranslationReport is the translation coverage of every entity type requested
+type TranslationReport struct {
+	Locales  []string                   `json:"locales"`
+	Entities []*TranslationEntityReport `json:"entities"`
+}
+
+// TranslationImportOptions controls a translation import
+type TranslationImportOptions struct {
+	Format string `form:"format"`  // csv or xliff
+	DryRun bool   `form:"dry_run"` // validate and report without writing
+}
+
+// TranslationImportRejection explains why a translation was not imported
+type TranslationImportRejection struct {
+	Line   int    `json:"line,omitempty"` // CSV line
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+}
+
+// TranslationImportReport summarizes a translation import
+type TranslationImportReport struct {
+	DryRun    bool                         `json:"dry_run"`
+	Updated   int                          `json:"updated"`   // units that gained or changed translations
+	Unchanged int                          `json:"unchanged"` // units whose translations already matched
+	Rejected  []TranslationImportRejection `json:"rejected"`
+}
+
+// normalizeList trims, lower-cases and de-duplicates values, dropping empty ones
+func normalizeList(values []string) []string {
+	var out []string
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value != "" && !slices.Contains(out, value) {
+			out = append(out, value)
+		}
+	}
+	return out
+}
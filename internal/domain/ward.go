@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -12,8 +13,40 @@ type Ward struct {
 	Code         string             `json:"code" bson:"code"`
 	Name         map[string]string  `json:"name" bson:"name"` // i18n
 	DistrictCode string             `json:"district_code" bson:"districtCode"`
-	Type         string             `json:"type" bson:"type"` // ward, commune, etc.
+	ProvinceCode string             `json:"-" bson:"provinceCode"` // province of the district, kept for search scoping
+	CountryCode  string             `json:"-" bson:"countryCode"`  // country of the district, kept for search scoping
+	Type         string             `json:"type" bson:"type"`      // ward, commune, etc.
 	Status       string             `json:"status" bson:"status"`
+	SearchText   []string           `json:"-" bson:"searchText"` // folded names, see SearchTerms
 	CreatedAt    time.Time          `json:"created_at" bson:"createdAt"`
 	UpdatedAt    time.Time          `json:"updated_at" bson:"updatedAt"`
+
+	LocationValidity `bson:",inline"`
+
+	// LocalizedName is Name in the locale of the request; it is not stored
+	LocalizedName string `json:"localized_name,omitempty" bson:"-"`
+}
+
+// Validate validates the ward data
+func (w *Ward) Validate() error {
+	if w.Code == "" {
+		return errors.New("code is required")
+	}
+	if len(w.Name) == 0 {
+		return errors.New("name is required")
+	}
+	if w.DistrictCode == "" {
+		return errors.New("district_code is required")
+	}
+	return w.LocationValidity.Validate()
+}
+
+// Translations returns the i18n name of the ward
+func (w *Ward) Translations() map[string]string {
+	return w.Name
+}
+
+// SetLocalizedName sets the name of the ward in the locale of the request
+func (w *Ward) SetLocalizedName(name string) {
+	w.LocalizedName = name
 }
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// AdminMenuHandler handles HTTP requests for admin menus.
+// Requests without a tenant operate on the global default menus.
+type AdminMenuHandler struct {
+	service *service.AdminMenuService
+	logger  *logger.Logger
+}
+
+// NewAdminMenuHandler creates a new admin menu handler
+func NewAdminMenuHandler(service *service.AdminMenuService, log *logger.Logger) *AdminMenuHandler {
+	return &AdminMenuHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+// Create handles creating a new admin menu item
+func (h *AdminMenuHandler) Create(c *gin.Context) {
+	var menu domain.AdminMenu
+	if err := c.ShouldBindJSON(&menu); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+	menu.TenantID = c.GetString("tenant_id")
+
+	if err := h.service.Create(c.Request.Context(), &menu); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": menu})
+}
+
+// GetByID handles getting an admin menu item by ID
+func (h *AdminMenuHandler) GetByID(c *gin.Context) {
+	menu, err := h.service.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": menu})
+}
+
+// List handles listing admin menu items
+func (h *AdminMenuHandler) List(c *gin.Context) {
+	var req domain.PaginationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		req.Page = 1
+		req.PerPage = 30
+	}
+	req.SetDefaults()
+
+	menus, total, err := h.service.List(c.Request.Context(), c.GetString("tenant_id"), req.Page, req.PerPage)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	totalPages := int(total) / req.PerPage
+	if int(total)%req.PerPage > 0 {
+		totalPages++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": menus,
+		"pagination": domain.PaginationResponse{
+			Page:       req.Page,
+			PerPage:    req.PerPage,
+			TotalPages: totalPages,
+			TotalItems: total,
+		},
+	})
+}
+
+// ListByModule handles listing the admin menu items of a module
+func (h *AdminMenuHandler) ListByModule(c *gin.Context) {
+	menus, err := h.service.ListByModule(c.Request.Context(), c.GetString("tenant_id"), c.Param("module_code"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": menus})
+}
+
+// Tree handles rendering the menu tree for the caller.
+// Permissions are taken from the request context when an auth middleware set them,
+// otherwise from the comma separated "permissions" query parameter.
+func (h *AdminMenuHandler) Tree(c *gin.Context) {
+	permissions := c.GetStringSlice("permissions")
+	if len(permissions) == 0 && c.Query("permissions") != "" {
+		permissions = strings.Split(c.Query("permissions"), ",")
+	}
+
+	tree, err := h.service.Tree(c.Request.Context(), c.GetString("tenant_id"), requestLocale(c, ""), permissions)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": tree})
+}
+
+// Update handles updating an admin menu item
+func (h *AdminMenuHandler) Update(c *gin.Context) {
+	var menu domain.AdminMenu
+	if err := c.ShouldBindJSON(&menu); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		h.respondError(c, errors.BadRequest("Invalid ID format"))
+		return
+	}
+	menu.ID = objectID
+	menu.TenantID = c.GetString("tenant_id")
+
+	if err := h.service.Update(c.Request.Context(), &menu); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": menu})
+}
+
+// Move handles moving an admin menu item and its subtree to a new parent and position
+func (h *AdminMenuHandler) Move(c *gin.Context) {
+	var req domain.MoveMenuRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	siblings, err := h.service.Move(c.Request.Context(), c.Param("id"), c.GetString("tenant_id"), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": siblings})
+}
+
+// Delete handles deleting an admin menu item
+func (h *AdminMenuHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Request.Context(), c.Param("id"), c.GetString("tenant_id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Menu deleted successfully"})
+}
+
+// respondError responds with an error
+func (h *AdminMenuHandler) respondError(c *gin.Context, err error) {
+	appErr, body := errorResponse(err)
+	h.logger.Error("Request failed",
+		zap.String("path", c.Request.URL.Path),
+		zap.String("method", c.Request.Method),
+		zap.String("error", appErr.Message),
+	)
+	c.JSON(appErr.StatusCode, gin.H{"error": body})
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/vhvplatform/go-shared/errors"
@@ -41,6 +42,9 @@ func (h *AppComponentHandler) Create(c *gin.Context) {
 		return
 	}
 	component.TenantID = tenantID
+	if userID := c.GetString("user_id"); userID != "" {
+		component.CreatedBy = userID
+	}
 
 	if err := h.service.Create(c.Request.Context(), &component); err != nil {
 		h.respondError(c, err)
@@ -74,6 +78,7 @@ func (h *AppComponentHandler) List(c *gin.Context) {
 		req.Page = 1
 		req.PerPage = 30
 	}
+	req.SetDefaults()
 
 	tenantID := c.GetString("tenant_id")
 	if tenantID == "" {
@@ -124,6 +129,9 @@ func (h *AppComponentHandler) Update(c *gin.Context) {
 		return
 	}
 	component.ID = objectID
+	if userID := c.GetString("user_id"); userID != "" {
+		component.UpdatedBy = userID
+	}
 
 	if err := h.service.Update(c.Request.Context(), &component); err != nil {
 		h.respondError(c, err)
@@ -150,13 +158,107 @@ func (h *AppComponentHandler) Delete(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "App component deleted successfully"})
 }
 
+// History handles listing the versions of the config of an app component, newest first, with their author, time and reason
+func (h *AppComponentHandler) History(c *gin.Context) {
+	var req domain.PaginationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		req.Page = 1
+		req.PerPage = 30
+	}
+	req.SetDefaults()
+
+	versions, total, err := h.service.History(c.Request.Context(), c.Param("id"), c.GetString("tenant_id"), req.Page, req.PerPage)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	totalPages := int(total) / req.PerPage
+	if int(total)%req.PerPage > 0 {
+		totalPages++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": versions,
+		"pagination": domain.PaginationResponse{
+			Page:       req.Page,
+			PerPage:    req.PerPage,
+			TotalPages: totalPages,
+			TotalItems: total,
+		},
+	})
+}
+
+// Diff handles comparing two versions of the config of an app component given as ?from= and ?to=
+func (h *AppComponentHandler) Diff(c *gin.Context) {
+	from, err := strconv.Atoi(c.Query("from"))
+	if err != nil {
+		h.respondError(c, errors.BadRequest("from must be a version number"))
+		return
+	}
+	to, err := strconv.Atoi(c.Query("to"))
+	if err != nil {
+		h.respondError(c, errors.BadRequest("to must be a version number"))
+		return
+	}
+
+	diff, err := h.service.Diff(c.Request.Context(), c.Param("id"), c.GetString("tenant_id"), from, to)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": diff})
+}
+
+// Rollback handles restoring an earlier version of the config of an app component as a new version
+func (h *AppComponentHandler) Rollback(c *gin.Context) {
+	var req domain.ConfigRollbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, errors.BadRequest("version is required"))
+		return
+	}
+
+	component, err := h.service.Rollback(c.Request.Context(), c.Param("id"), c.GetString("tenant_id"), c.GetString("user_id"), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": component})
+}
+
+// MigrateConfigs handles migrating the configs of the components with a ?code= to the current
+// version of their schema
+func (h *AppComponentHandler) MigrateConfigs(c *gin.Context) {
+	code := c.Query("code")
+	if code == "" {
+		h.respondError(c, errors.BadRequest("code is required"))
+		return
+	}
+
+	var opts domain.SchemaMigrationOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid migration options"))
+		return
+	}
+
+	report, err := h.service.MigrateConfigs(c.Request.Context(), code, c.GetString("user_id"), &opts)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
 // respondError responds with an error
 func (h *AppComponentHandler) respondError(c *gin.Context, err error) {
-	appErr := errors.FromError(err)
+	appErr, body := errorResponse(err)
 	h.logger.Error("Request failed",
 		zap.String("path", c.Request.URL.Path),
 		zap.String("method", c.Request.Method),
 		zap.String("error", appErr.Message),
 	)
-	c.JSON(appErr.StatusCode, gin.H{"error": appErr})
+	c.JSON(appErr.StatusCode, gin.H{"error": body})
 }
@@ -0,0 +1,288 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// ConfigHandler handles HTTP requests for the versioned config store.
+// Configs belong to the request tenant, or are global without one; ?environment= selects the
+// environment of an entry, every environment when omitted. Versions are attributed to the
+// user_id set by the gateway. Values of secret entries are masked unless a read passes ?reveal=true.
+type ConfigHandler struct {
+	service *service.ConfigService
+	logger  *logger.Logger
+}
+
+// NewConfigHandler creates a new config handler
+func NewConfigHandler(service *service.ConfigService, log *logger.Logger) *ConfigHandler {
+	return &ConfigHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+// Create handles creating a config entry with its first version
+func (h *ConfigHandler) Create(c *gin.Context) {
+	var req domain.ConfigWriteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	entry, err := h.service.Create(c.Request.Context(), c.GetString("tenant_id"), c.GetString("user_id"), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": entry})
+}
+
+// Get handles getting a config entry, or one of its versions with ?version=
+func (h *ConfigHandler) Get(c *gin.Context) {
+	if raw := c.Query("version"); raw != "" {
+		number, err := strconv.Atoi(raw)
+		if err != nil {
+			h.respondError(c, errors.BadRequest("version must be a positive number"))
+			return
+		}
+		version, err := h.service.GetVersion(c.Request.Context(), c.GetString("tenant_id"), c.Param("key"), c.Query("environment"), number, revealSecrets(c))
+		if err != nil {
+			h.respondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"data": version})
+		return
+	}
+
+	entry, err := h.service.Get(c.Request.Context(), c.GetString("tenant_id"), c.Param("key"), c.Query("environment"), revealSecrets(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": entry})
+}
+
+// List handles listing config entries
+func (h *ConfigHandler) List(c *gin.Context) {
+	var req domain.PaginationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		req.Page = 1
+		req.PerPage = 30
+	}
+	req.SetDefaults()
+
+	var query domain.ConfigQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid query parameters"))
+		return
+	}
+	query.TenantID = c.GetString("tenant_id")
+
+	entries, total, err := h.service.List(c.Request.Context(), query, req.Page, req.PerPage, revealSecrets(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	totalPages := int(total) / req.PerPage
+	if int(total)%req.PerPage > 0 {
+		totalPages++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": entries,
+		"pagination": domain.PaginationResponse{
+			Page:       req.Page,
+			PerPage:    req.PerPage,
+			TotalPages: totalPages,
+			TotalItems: total,
+		},
+	})
+}
+
+// Update handles writing a new version of a config entry
+func (h *ConfigHandler) Update(c *gin.Context) {
+	var req domain.ConfigWriteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+	req.Key = c.Param("key")
+
+	entry, err := h.service.Update(c.Request.Context(), c.GetString("tenant_id"), c.GetString("user_id"), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": entry})
+}
+
+// Activate handles activating a stored version of a config entry
+func (h *ConfigHandler) Activate(c *gin.Context) {
+	var req domain.ConfigActivateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, errors.BadRequest("version is required"))
+		return
+	}
+
+	entry, err := h.service.Activate(c.Request.Context(), c.GetString("tenant_id"), c.GetString("user_id"), c.Param("key"), c.Query("environment"), req.Version)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": entry})
+}
+
+// History handles listing the versions of a config entry, newest first, with their author, time and reason
+func (h *ConfigHandler) History(c *gin.Context) {
+	var req domain.PaginationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		req.Page = 1
+		req.PerPage = 30
+	}
+	req.SetDefaults()
+
+	versions, total, err := h.service.History(c.Request.Context(), c.GetString("tenant_id"), c.Param("key"), c.Query("environment"), req.Page, req.PerPage, revealSecrets(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	totalPages := int(total) / req.PerPage
+	if int(total)%req.PerPage > 0 {
+		totalPages++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": versions,
+		"pagination": domain.PaginationResponse{
+			Page:       req.Page,
+			PerPage:    req.PerPage,
+			TotalPages: totalPages,
+			TotalItems: total,
+		},
+	})
+}
+
+// Diff handles comparing two versions of a config entry given as ?from= and ?to=
+func (h *ConfigHandler) Diff(c *gin.Context) {
+	from, err := strconv.Atoi(c.Query("from"))
+	if err != nil {
+		h.respondError(c, errors.BadRequest("from must be a version number"))
+		return
+	}
+	to, err := strconv.Atoi(c.Query("to"))
+	if err != nil {
+		h.respondError(c, errors.BadRequest("to must be a version number"))
+		return
+	}
+
+	diff, err := h.service.Diff(c.Request.Context(), c.GetString("tenant_id"), c.Param("key"), c.Query("environment"), from, to, revealSecrets(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": diff})
+}
+
+// Rollback handles restoring an earlier version of a config entry as a new version
+func (h *ConfigHandler) Rollback(c *gin.Context) {
+	var req domain.ConfigRollbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, errors.BadRequest("version is required"))
+		return
+	}
+
+	entry, err := h.service.Rollback(c.Request.Context(), c.GetString("tenant_id"), c.GetString("user_id"), c.Param("key"), c.Query("environment"), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": entry})
+}
+
+// Resolve handles merging the configs the request tenant sees in ?environment=, optionally for
+// keys starting with ?prefix=, annotated with the layer that supplied each value
+func (h *ConfigHandler) Resolve(c *gin.Context) {
+	var query domain.ConfigResolveQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid query parameters"))
+		return
+	}
+
+	resolved, err := h.service.Resolve(c.Request.Context(), c.GetString("tenant_id"), &query, revealSecrets(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": resolved})
+}
+
+// SetOverride handles setting the runtime override of a config key
+func (h *ConfigHandler) SetOverride(c *gin.Context) {
+	var req domain.ConfigOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	override, err := h.service.SetOverride(c.Request.Context(), c.GetString("tenant_id"), c.GetString("user_id"), c.Param("key"), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": override})
+}
+
+// DeleteOverride handles removing the runtime override of a config key
+func (h *ConfigHandler) DeleteOverride(c *gin.Context) {
+	if err := h.service.DeleteOverride(c.Request.Context(), c.GetString("tenant_id"), c.Param("key"), c.Query("environment")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Config override removed successfully"})
+}
+
+// Delete handles deleting a config entry with all of its versions
+func (h *ConfigHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Request.Context(), c.GetString("tenant_id"), c.Param("key"), c.Query("environment")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Config deleted successfully"})
+}
+
+// revealSecrets reports whether the request asks for the values of secret entries with ?reveal=true
+func revealSecrets(c *gin.Context) bool {
+	reveal, _ := strconv.ParseBool(c.Query("reveal"))
+	return reveal
+}
+
+// respondError responds with an error
+func (h *ConfigHandler) respondError(c *gin.Context, err error) {
+	appErr, body := errorResponse(err)
+	h.logger.Error("Request failed",
+		zap.String("path", c.Request.URL.Path),
+		zap.String("method", c.Request.Method),
+		zap.String("error", appErr.Message),
+	)
+	c.JSON(appErr.StatusCode, gin.H{"error": body})
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// ConfigRuleHandler handles HTTP requests for config validation rules.
+// Rules belong to the request tenant, or are global without one.
+type ConfigRuleHandler struct {
+	service *service.ConfigRuleService
+	logger  *logger.Logger
+}
+
+// NewConfigRuleHandler creates a new config validation rule handler
+func NewConfigRuleHandler(service *service.ConfigRuleService, log *logger.Logger) *ConfigRuleHandler {
+	return &ConfigRuleHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+// Create handles creating a new rule
+func (h *ConfigRuleHandler) Create(c *gin.Context) {
+	var rule domain.ConfigValidationRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+	rule.TenantID = c.GetString("tenant_id")
+	rule.CreatedBy = c.GetString("user_id")
+	rule.UpdatedBy = rule.CreatedBy
+
+	if err := h.service.Create(c.Request.Context(), &rule); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": rule})
+}
+
+// GetByID handles getting a rule by ID
+func (h *ConfigRuleHandler) GetByID(c *gin.Context) {
+	rule, err := h.service.GetByID(c.Request.Context(), c.Param("id"), c.GetString("tenant_id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rule})
+}
+
+// List handles listing rules
+func (h *ConfigRuleHandler) List(c *gin.Context) {
+	var req domain.PaginationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		req.Page = 1
+		req.PerPage = 30
+	}
+	req.SetDefaults()
+
+	rules, total, err := h.service.List(c.Request.Context(), c.GetString("tenant_id"), req.Page, req.PerPage)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	totalPages := int(total) / req.PerPage
+	if int(total)%req.PerPage > 0 {
+		totalPages++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": rules,
+		"pagination": domain.PaginationResponse{
+			Page:       req.Page,
+			PerPage:    req.PerPage,
+			TotalPages: totalPages,
+			TotalItems: total,
+		},
+	})
+}
+
+// Update handles updating a rule
+func (h *ConfigRuleHandler) Update(c *gin.Context) {
+	var rule domain.ConfigValidationRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		h.respondError(c, errors.BadRequest("Invalid ID format"))
+		return
+	}
+	rule.ID = objectID
+	rule.TenantID = c.GetString("tenant_id")
+	rule.UpdatedBy = c.GetString("user_id")
+
+	if err := h.service.Update(c.Request.Context(), &rule); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rule})
+}
+
+// Delete handles deleting a rule
+func (h *ConfigRuleHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Request.Context(), c.Param("id"), c.GetString("tenant_id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Config rule deleted successfully"})
+}
+
+// respondError responds with an error
+func (h *ConfigRuleHandler) respondError(c *gin.Context, err error) {
+	appErr, body := errorResponse(err)
+	h.logger.Error("Request failed",
+		zap.String("path", c.Request.URL.Path),
+		zap.String("method", c.Request.Method),
+		zap.String("error", appErr.Message),
+	)
+	c.JSON(appErr.StatusCode, gin.H{"error": body})
+}
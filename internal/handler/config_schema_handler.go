@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// ConfigSchemaHandler handles HTTP requests for the JSON Schemas of app component configs and
+// service package limits
+type ConfigSchemaHandler struct {
+	service *service.ConfigSchemaService
+	logger  *logger.Logger
+}
+
+// NewConfigSchemaHandler creates a new config schema handler
+func NewConfigSchemaHandler(service *service.ConfigSchemaService, log *logger.Logger) *ConfigSchemaHandler {
+	return &ConfigSchemaHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+// Create handles creating a new schema
+func (h *ConfigSchemaHandler) Create(c *gin.Context) {
+	var schema domain.ConfigSchema
+	if err := c.ShouldBindJSON(&schema); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+	schema.CreatedBy = c.GetString("user_id")
+	schema.UpdatedBy = schema.CreatedBy
+
+	if err := h.service.Create(c.Request.Context(), &schema); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": schema})
+}
+
+// GetByID handles getting a schema by ID
+func (h *ConfigSchemaHandler) GetByID(c *gin.Context) {
+	schema, err := h.service.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": schema})
+}
+
+// List handles listing schemas, optionally of one ?target=
+func (h *ConfigSchemaHandler) List(c *gin.Context) {
+	var req domain.PaginationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		req.Page = 1
+		req.PerPage = 30
+	}
+	req.SetDefaults()
+
+	schemas, total, err := h.service.List(c.Request.Context(), c.Query("target"), req.Page, req.PerPage)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	totalPages := int(total) / req.PerPage
+	if int(total)%req.PerPage > 0 {
+		totalPages++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": schemas,
+		"pagination": domain.PaginationResponse{
+			Page:       req.Page,
+			PerPage:    req.PerPage,
+			TotalPages: totalPages,
+			TotalItems: total,
+		},
+	})
+}
+
+// Update handles writing a new version of a schema
+func (h *ConfigSchemaHandler) Update(c *gin.Context) {
+	var schema domain.ConfigSchema
+	if err := c.ShouldBindJSON(&schema); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		h.respondError(c, errors.BadRequest("Invalid ID format"))
+		return
+	}
+	schema.ID = objectID
+	schema.UpdatedBy = c.GetString("user_id")
+
+	if err := h.service.Update(c.Request.Context(), &schema); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": schema})
+}
+
+// Delete handles deleting a schema
+func (h *ConfigSchemaHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Config schema deleted successfully"})
+}
+
+// respondError responds with an error
+func (h *ConfigSchemaHandler) respondError(c *gin.Context, err error) {
+	appErr, body := errorResponse(err)
+	h.logger.Error("Request failed",
+		zap.String("path", c.Request.URL.Path),
+		zap.String("method", c.Request.Method),
+		zap.String("error", appErr.Message),
+	)
+	c.JSON(appErr.StatusCode, gin.H{"error": body})
+}
@@ -54,6 +54,7 @@ func (h *CountryHandler) GetByCode(c *gin.Context) {
 		h.respondError(c, err)
 		return
 	}
+	domain.Localization{Locales: requestLocales(c, "")}.Apply(country)
 
 	c.JSON(http.StatusOK, gin.H{"data": country})
 }
@@ -65,8 +66,15 @@ func (h *CountryHandler) List(c *gin.Context) {
 		req.Page = 1
 		req.PerPage = 30
 	}
+	req.SetDefaults()
 
-	countries, total, err := h.service.List(c.Request.Context(), req.Page, req.PerPage)
+	l10n, err := requestLocalization(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	countries, total, err := h.service.List(c.Request.Context(), l10n, req.Page, req.PerPage)
 	if err != nil {
 		h.respondError(c, err)
 		return
@@ -130,11 +138,11 @@ func (h *CountryHandler) Delete(c *gin.Context) {
 
 // respondError responds with an error
 func (h *CountryHandler) respondError(c *gin.Context, err error) {
-	appErr := errors.FromError(err)
+	appErr, body := errorResponse(err)
 	h.logger.Error("Request failed",
 		zap.String("path", c.Request.URL.Path),
 		zap.String("method", c.Request.Method),
 		zap.String("error", appErr.Message),
 	)
-	c.JSON(appErr.StatusCode, gin.H{"error": appErr})
+	c.JSON(appErr.StatusCode, gin.H{"error": body})
 }
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// CurrencyHandler handles HTTP requests for currencies
+type CurrencyHandler struct {
+	service *service.CurrencyService
+	logger  *logger.Logger
+}
+
+// NewCurrencyHandler creates a new currency handler
+func NewCurrencyHandler(service *service.CurrencyService, log *logger.Logger) *CurrencyHandler {
+	return &CurrencyHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+// Create handles creating a new currency
+func (h *CurrencyHandler) Create(c *gin.Context) {
+	var currency domain.Currency
+	if err := c.ShouldBindJSON(&currency); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	if err := h.service.Create(c.Request.Context(), &currency); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": currency})
+}
+
+// GetByCode handles getting a currency by code
+func (h *CurrencyHandler) GetByCode(c *gin.Context) {
+	code := c.Param("code")
+	if code == "" {
+		h.respondError(c, errors.BadRequest("Code is required"))
+		return
+	}
+
+	currency, err := h.service.GetByCode(c.Request.Context(), code)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	domain.Localization{Locales: requestLocales(c, "")}.Apply(currency)
+
+	c.JSON(http.StatusOK, gin.H{"data": currency})
+}
+
+// List handles listing currencies
+func (h *CurrencyHandler) List(c *gin.Context) {
+	var req domain.PaginationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		req.Page = 1
+		req.PerPage = 30
+	}
+	req.SetDefaults()
+
+	l10n, err := requestLocalization(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	currencies, total, err := h.service.List(c.Request.Context(), l10n, req.Page, req.PerPage)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	totalPages := int(total) / req.PerPage
+	if int(total)%req.PerPage > 0 {
+		totalPages++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": currencies,
+		"pagination": domain.PaginationResponse{
+			Page:       req.Page,
+			PerPage:    req.PerPage,
+			TotalPages: totalPages,
+			TotalItems: total,
+		},
+	})
+}
+
+// Update handles updating a currency
+func (h *CurrencyHandler) Update(c *gin.Context) {
+	code := c.Param("code")
+	if code == "" {
+		h.respondError(c, errors.BadRequest("Code is required"))
+		return
+	}
+
+	var currency domain.Currency
+	if err := c.ShouldBindJSON(&currency); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	currency.Code = code
+
+	if err := h.service.Update(c.Request.Context(), &currency); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": currency})
+}
+
+// Delete handles deleting a currency
+func (h *CurrencyHandler) Delete(c *gin.Context) {
+	code := c.Param("code")
+	if code == "" {
+		h.respondError(c, errors.BadRequest("Code is required"))
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), code); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Currency deleted successfully"})
+}
+
+// Format handles formatting an amount of a currency for a locale, taken from ?locale= or else
+// the first Accept-Language tag
+func (h *CurrencyHandler) Format(c *gin.Context) {
+	var req domain.MoneyFormatRequest
+	if err := c.ShouldBindQuery(&req); err != nil || req.Amount == nil {
+		h.respondError(c, errors.BadRequest("amount must be a number"))
+		return
+	}
+
+	formatted, err := h.service.Format(c.Request.Context(), c.Param("code"), *req.Amount, requestLocale(c, req.Locale))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": formatted})
+}
+
+// Parse handles reading an amount of a currency written for a locale
+func (h *CurrencyHandler) Parse(c *gin.Context) {
+	var req domain.MoneyFormatRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid query parameters"))
+		return
+	}
+
+	parsed, err := h.service.Parse(c.Request.Context(), c.Param("code"), req.Text, requestLocale(c, req.Locale))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": parsed})
+}
+
+// respondError responds with an error
+func (h *CurrencyHandler) respondError(c *gin.Context, err error) {
+	appErr, body := errorResponse(err)
+	h.logger.Error("Request failed",
+		zap.String("path", c.Request.URL.Path),
+		zap.String("method", c.Request.Method),
+		zap.String("error", appErr.Message),
+	)
+	c.JSON(appErr.StatusCode, gin.H{"error": body})
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	stderrors "errors"
+	"net/http"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+)
+
+// validationFailedCode is the error code of requests failing field-level validation
+const validationFailedCode = "VALIDATION_FAILED"
+
+// errorResponse converts an error returned by a service into the error responded with and the
+// body of the "error" of the response. Validation failures are bad requests listing their
+// field-level errors as details.
+func errorResponse(err error) (*errors.AppError, interface{}) {
+	var validation *domain.ValidationError
+	if stderrors.As(err, &validation) {
+		appErr := errors.New(validationFailedCode, validation.Error(), http.StatusBadRequest)
+		return appErr, domain.ErrorResponse{Code: validationFailedCode, Message: appErr.Message, Details: validation.Fields}
+	}
+
+	appErr := errors.FromError(err)
+	return appErr, appErr
+}
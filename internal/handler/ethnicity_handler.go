@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// EthnicityHandler handles HTTP requests for ethnicities
+type EthnicityHandler struct {
+	service *service.EthnicityService
+	logger  *logger.Logger
+}
+
+// NewEthnicityHandler creates a new ethnicity handler
+func NewEthnicityHandler(service *service.EthnicityService, log *logger.Logger) *EthnicityHandler {
+	return &EthnicityHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+// Create handles creating a new ethnicity
+func (h *EthnicityHandler) Create(c *gin.Context) {
+	var ethnicity domain.Ethnicity
+	if err := c.ShouldBindJSON(&ethnicity); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	if err := h.service.Create(c.Request.Context(), &ethnicity); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": ethnicity})
+}
+
+// GetByID handles getting an ethnicity by ID
+func (h *EthnicityHandler) GetByID(c *gin.Context) {
+	ethnicity, err := h.service.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	domain.Localization{Locales: requestLocales(c, "")}.Apply(ethnicity)
+
+	c.JSON(http.StatusOK, gin.H{"data": ethnicity})
+}
+
+// List handles listing the ethnicities of all countries
+func (h *EthnicityHandler) List(c *gin.Context) {
+	h.list(c, "")
+}
+
+// ListByCountry handles listing the ethnicities of a country
+func (h *EthnicityHandler) ListByCountry(c *gin.Context) {
+	h.list(c, c.Param("country_code"))
+}
+
+// list responds with a page of ethnicities localized for the request
+func (h *EthnicityHandler) list(c *gin.Context, countryCode string) {
+	var req domain.PaginationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		req.Page = 1
+		req.PerPage = 30
+	}
+	req.SetDefaults()
+
+	l10n, err := requestLocalization(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ethnicities, total, err := h.service.List(c.Request.Context(), countryCode, l10n, req.Page, req.PerPage)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	totalPages := int(total) / req.PerPage
+	if int(total)%req.PerPage > 0 {
+		totalPages++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": ethnicities,
+		"pagination": domain.PaginationResponse{
+			Page:       req.Page,
+			PerPage:    req.PerPage,
+			TotalPages: totalPages,
+			TotalItems: total,
+		},
+	})
+}
+
+// Update handles updating an ethnicity
+func (h *EthnicityHandler) Update(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		h.respondError(c, errors.BadRequest("Invalid ID format"))
+		return
+	}
+
+	var ethnicity domain.Ethnicity
+	if err := c.ShouldBindJSON(&ethnicity); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	ethnicity.ID = id
+
+	if err := h.service.Update(c.Request.Context(), &ethnicity); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": ethnicity})
+}
+
+// Delete handles deleting an ethnicity
+func (h *EthnicityHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Ethnicity deleted successfully"})
+}
+
+// respondError responds with an error
+func (h *EthnicityHandler) respondError(c *gin.Context, err error) {
+	appErr, body := errorResponse(err)
+	h.logger.Error("Request failed",
+		zap.String("path", c.Request.URL.Path),
+		zap.String("method", c.Request.Method),
+		zap.String("error", appErr.Message),
+	)
+	c.JSON(appErr.StatusCode, gin.H{"error": body})
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// ExchangeRateHandler handles HTTP requests for exchange rates and currency conversion
+type ExchangeRateHandler struct {
+	service *service.ExchangeRateService
+	logger  *logger.Logger
+}
+
+// NewExchangeRateHandler creates a new exchange rate handler
+func NewExchangeRateHandler(service *service.ExchangeRateService, log *logger.Logger) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+// Record handles manually entering the rate of a pair for a day
+func (h *ExchangeRateHandler) Record(c *gin.Context) {
+	var rate domain.ExchangeRate
+	if err := c.ShouldBindJSON(&rate); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	created, err := h.service.Record(c.Request.Context(), &rate)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, gin.H{"data": rate})
+}
+
+// Ingest handles a batch of rates pushed by a provider
+func (h *ExchangeRateHandler) Ingest(c *gin.Context) {
+	var req domain.ExchangeRateIngestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	report, err := h.service.Ingest(c.Request.Context(), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// List handles listing stored rates, optionally of one pair and between two days
+func (h *ExchangeRateHandler) List(c *gin.Context) {
+	var filter domain.ExchangeRateFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid query parameters: dates must be YYYY-MM-DD"))
+		return
+	}
+
+	var req domain.PaginationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		req.Page = 1
+		req.PerPage = 30
+	}
+	req.SetDefaults()
+
+	rates, total, err := h.service.List(c.Request.Context(), filter, req.Page, req.PerPage)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	totalPages := int(total) / req.PerPage
+	if int(total)%req.PerPage > 0 {
+		totalPages++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": rates,
+		"pagination": domain.PaginationResponse{
+			Page:       req.Page,
+			PerPage:    req.PerPage,
+			TotalPages: totalPages,
+			TotalItems: total,
+		},
+	})
+}
+
+// Rate handles looking up the rate of a pair on a day, today by default
+func (h *ExchangeRateHandler) Rate(c *gin.Context) {
+	at := time.Now()
+	if date := c.Query("date"); date != "" {
+		parsed, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			h.respondError(c, errors.BadRequest("date must be YYYY-MM-DD"))
+			return
+		}
+		at = parsed
+	}
+
+	rate, err := h.service.Rate(c.Request.Context(), c.Query("base"), c.Query("quote"), at)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rate})
+}
+
+// Convert handles converting an amount between two currencies
+func (h *ExchangeRateHandler) Convert(c *gin.Context) {
+	var req domain.ConversionRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.respondError(c, errors.BadRequest("amount, from and to are required; date must be YYYY-MM-DD"))
+		return
+	}
+
+	conversion, err := h.service.Convert(c.Request.Context(), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": conversion})
+}
+
+// Delete handles deleting a stored rate
+func (h *ExchangeRateHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Exchange rate deleted successfully"})
+}
+
+// respondError responds with an error
+func (h *ExchangeRateHandler) respondError(c *gin.Context, err error) {
+	appErr, body := errorResponse(err)
+	h.logger.Error("Request failed",
+		zap.String("path", c.Request.URL.Path),
+		zap.String("method", c.Request.Method),
+		zap.String("error", appErr.Message),
+	)
+	c.JSON(appErr.StatusCode, gin.H{"error": body})
+}
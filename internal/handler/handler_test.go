@@ -0,0 +1,585 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"github.com/vhvplatform/go-system-config-service/internal/service"
+)
+
+// newTestRouter wires the handlers against in-memory repositories without a cache.
+// The tenant is taken from the X-Tenant-ID header in place of the gateway middleware.
+func newTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	log, err := logger.New("error")
+	require.NoError(t, err)
+
+	configRuleService := service.NewConfigRuleService(repository.NewMemoryConfigRuleRepository(), nil, log)
+	configRuleHandler := NewConfigRuleHandler(configRuleService, log)
+	configSchemaService := service.NewConfigSchemaService(repository.NewMemoryConfigSchemaRepository(), nil, log)
+	configSchemaHandler := NewConfigSchemaHandler(configSchemaService, log)
+	appComponentHandler := NewAppComponentHandler(service.NewAppComponentService(repository.NewMemoryAppComponentRepository(),
+		repository.NewMemoryConfigVersionRepository(), repository.NewMemoryTransactor(), configRuleService, configSchemaService, nil, log), log)
+	countryRepo := repository.NewMemoryCountryRepository()
+	currencyRepo := repository.NewMemoryCurrencyRepository()
+	ethnicityRepo := repository.NewMemoryEthnicityRepository()
+	countryHandler := NewCountryHandler(
+		service.NewCountryService(countryRepo, repository.NewMemoryProvinceRepository(), currencyRepo, ethnicityRepo, nil, log), log)
+	currencyHandler := NewCurrencyHandler(
+		service.NewCurrencyService(currencyRepo, countryRepo, repository.NewMemoryServicePackageRepository(), nil, log), log)
+	exchangeRateHandler := NewExchangeRateHandler(
+		service.NewExchangeRateService(repository.NewMemoryExchangeRateRepository(), currencyRepo, log), log)
+	ethnicityService := service.NewEthnicityService(ethnicityRepo, countryRepo, nil, log)
+	ethnicityHandler := NewEthnicityHandler(ethnicityService, log)
+	translationHandler := NewTranslationHandler(service.NewTranslationService(
+		service.NewCountryService(countryRepo, repository.NewMemoryProvinceRepository(), currencyRepo, ethnicityRepo, nil, log),
+		service.NewCurrencyService(currencyRepo, countryRepo, repository.NewMemoryServicePackageRepository(), nil, log),
+		ethnicityService,
+		service.NewLocationService(countryRepo, repository.NewMemoryProvinceRepository(), repository.NewMemoryDistrictRepository(),
+			repository.NewMemoryWardRepository(), repository.NewMemoryTransactor(), nil, log),
+		service.NewAdminMenuService(repository.NewMemoryAdminMenuRepository(), repository.NewMemoryTransactor(), nil, log),
+		[]string{"en", "vi"}, log), log)
+	configHandler := NewConfigHandler(service.NewConfigService(
+		repository.NewMemoryConfigRepository(), repository.NewMemoryConfigVersionRepository(), repository.NewMemoryConfigOverrideRepository(), configRuleService, nil, log), log)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if tenantID := c.GetHeader("X-Tenant-ID"); tenantID != "" {
+			c.Set("tenant_id", tenantID)
+		}
+		c.Next()
+	})
+	r.GET("/app-components", appComponentHandler.List)
+	r.GET("/app-components/:id", appComponentHandler.GetByID)
+	r.POST("/app-components", appComponentHandler.Create)
+	r.POST("/app-components/migrate", appComponentHandler.MigrateConfigs)
+	r.PUT("/app-components/:id", appComponentHandler.Update)
+	r.GET("/app-components/:id/history", appComponentHandler.History)
+	r.GET("/app-components/:id/diff", appComponentHandler.Diff)
+	r.POST("/app-components/:id/rollback", appComponentHandler.Rollback)
+	r.DELETE("/app-components/:id", appComponentHandler.Delete)
+	r.GET("/countries", countryHandler.List)
+	r.GET("/countries/:code", countryHandler.GetByCode)
+	r.POST("/countries", countryHandler.Create)
+	r.PUT("/countries/:code", countryHandler.Update)
+	r.DELETE("/countries/:code", countryHandler.Delete)
+	r.POST("/currencies", currencyHandler.Create)
+	r.GET("/currencies/:code/format", currencyHandler.Format)
+	r.GET("/currencies/:code/parse", currencyHandler.Parse)
+	r.GET("/exchange-rates", exchangeRateHandler.List)
+	r.GET("/exchange-rates/rate", exchangeRateHandler.Rate)
+	r.GET("/exchange-rates/convert", exchangeRateHandler.Convert)
+	r.POST("/exchange-rates", exchangeRateHandler.Record)
+	r.POST("/exchange-rates/ingest", exchangeRateHandler.Ingest)
+	r.GET("/ethnicities/by-country/:country_code", ethnicityHandler.ListByCountry)
+	r.POST("/ethnicities", ethnicityHandler.Create)
+	r.GET("/translations/report", translationHandler.Report)
+	r.GET("/translations/export", translationHandler.Export)
+	r.POST("/translations/import", translationHandler.Import)
+	r.GET("/configs", configHandler.List)
+	r.GET("/configs/:key", configHandler.Get)
+	r.POST("/configs", configHandler.Create)
+	r.PUT("/configs/:key", configHandler.Update)
+	r.POST("/configs/:key/activate", configHandler.Activate)
+	r.GET("/configs/:key/history", configHandler.History)
+	r.GET("/configs/:key/diff", configHandler.Diff)
+	r.POST("/configs/:key/rollback", configHandler.Rollback)
+	r.PUT("/configs/:key/override", configHandler.SetOverride)
+	r.DELETE("/configs/:key/override", configHandler.DeleteOverride)
+	r.GET("/resolved-configs", configHandler.Resolve)
+	r.GET("/config-rules", configRuleHandler.List)
+	r.GET("/config-rules/:id", configRuleHandler.GetByID)
+	r.POST("/config-rules", configRuleHandler.Create)
+	r.PUT("/config-rules/:id", configRuleHandler.Update)
+	r.DELETE("/config-rules/:id", configRuleHandler.Delete)
+	r.GET("/config-schemas", configSchemaHandler.List)
+	r.GET("/config-schemas/:id", configSchemaHandler.GetByID)
+	r.POST("/config-schemas", configSchemaHandler.Create)
+	r.PUT("/config-schemas/:id", configSchemaHandler.Update)
+	r.DELETE("/config-schemas/:id", configSchemaHandler.Delete)
+	r.DELETE("/configs/:key", configHandler.Delete)
+	return r
+}
+
+func doRequest(t *testing.T, r *gin.Engine, method, path, tenantID string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+	t.Helper()
+
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if tenantID != "" {
+		req.Header.Set("X-Tenant-ID", tenantID)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return w, resp
+}
+
+func TestAppComponentHandler_EndToEnd(t *testing.T) {
+	r := newTestRouter(t)
+
+	w, resp := doRequest(t, r, http.MethodPost, "/app-components", "tenant-1", map[string]interface{}{
+		"code": "dashboard",
+		"name": "Dashboard",
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+	id := resp["data"].(map[string]interface{})["id"].(string)
+
+	w, _ = doRequest(t, r, http.MethodPost, "/app-components", "tenant-1", map[string]interface{}{"code": "dashboard"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w, _ = doRequest(t, r, http.MethodPost, "/app-components", "", map[string]interface{}{"code": "dashboard"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w, resp = doRequest(t, r, http.MethodGet, "/app-components/"+id, "tenant-1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Dashboard", resp["data"].(map[string]interface{})["name"])
+
+	w, resp = doRequest(t, r, http.MethodGet, "/app-components", "tenant-1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, resp["data"], 1)
+	assert.Equal(t, float64(1), resp["pagination"].(map[string]interface{})["total_items"])
+
+	w, resp = doRequest(t, r, http.MethodPut, "/app-components/"+id, "tenant-1", map[string]interface{}{"name": "Home"})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "dashboard", resp["data"].(map[string]interface{})["code"])
+
+	w, _ = doRequest(t, r, http.MethodDelete, "/app-components/"+id, "tenant-2", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w, _ = doRequest(t, r, http.MethodDelete, "/app-components/"+id, "tenant-1", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w, _ = doRequest(t, r, http.MethodGet, "/app-components/"+id, "tenant-1", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCountryHandler_EndToEnd(t *testing.T) {
+	r := newTestRouter(t)
+
+	w, _ := doRequest(t, r, http.MethodPost, "/countries", "", map[string]interface{}{
+		"code": "VN",
+		"name": map[string]string{"en": "Vietnam"},
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w, resp := doRequest(t, r, http.MethodGet, "/countries/VN", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "active", resp["data"].(map[string]interface{})["status"])
+
+	w, resp = doRequest(t, r, http.MethodGet, "/countries?page=1&per_page=10", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, resp["data"], 1)
+
+	w, _ = doRequest(t, r, http.MethodPut, "/countries/XX", "", map[string]interface{}{
+		"name": map[string]string{"en": "Nowhere"},
+	})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w, _ = doRequest(t, r, http.MethodDelete, "/countries/VN", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestExchangeRateHandler_EndToEnd(t *testing.T) {
+	r := newTestRouter(t)
+
+	for code, digits := range map[string]int{"USD": 2, "VND": 0} {
+		w, _ := doRequest(t, r, http.MethodPost, "/currencies", "", map[string]interface{}{
+			"code": code, "name": map[string]string{"en": code}, "decimal_digits": digits,
+		})
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	w, _ := doRequest(t, r, http.MethodPost, "/exchange-rates", "", map[string]interface{}{
+		"base": "USD", "quote": "VND", "rate": 25000, "date": "2025-01-02T00:00:00Z",
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w, resp := doRequest(t, r, http.MethodPost, "/exchange-rates/ingest", "", map[string]interface{}{
+		"source": "vcb",
+		"rates": []map[string]interface{}{
+			{"base": "USD", "quote": "VND", "rate": 25000.5, "date": "2025-01-02T00:00:00Z"},
+			{"base": "USD", "quote": "VND", "rate": 25400, "date": "2025-01-06T00:00:00Z"},
+		},
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, float64(1), resp["data"].(map[string]interface{})["created"])
+	assert.Equal(t, float64(1), resp["data"].(map[string]interface{})["updated"])
+
+	w, resp = doRequest(t, r, http.MethodGet, "/exchange-rates/rate?base=usd&quote=vnd&date=2025-01-05", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 25000.5, resp["data"].(map[string]interface{})["rate"])
+	assert.Equal(t, "2025-01-02T00:00:00Z", resp["data"].(map[string]interface{})["rate_date"])
+
+	w, resp = doRequest(t, r, http.MethodGet, "/exchange-rates/convert?amount=10.123&from=USD&to=VND&date=2025-01-05", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, float64(253080), resp["data"].(map[string]interface{})["converted"])
+
+	w, _ = doRequest(t, r, http.MethodGet, "/exchange-rates/convert?amount=1&from=USD&to=VND&date=2024-12-31", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w, _ = doRequest(t, r, http.MethodGet, "/exchange-rates/convert?amount=1&from=USD&to=VND&date=05/01/2025", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w, resp = doRequest(t, r, http.MethodGet, "/exchange-rates?base=USD&quote=VND&from=2025-01-03", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, resp["data"], 1)
+}
+
+func TestCurrencyHandler_FormatAndParse(t *testing.T) {
+	r := newTestRouter(t)
+
+	w, _ := doRequest(t, r, http.MethodPost, "/currencies", "", map[string]interface{}{
+		"code": "VND", "name": map[string]string{"vi": "Đồng"}, "symbol": "₫",
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	// Without ?locale= the first Accept-Language tag is used
+	req := httptest.NewRequest(http.MethodGet, "/currencies/VND/format?amount=1000000", nil)
+	req.Header.Set("Accept-Language", "vi-VN,vi;q=0.9,en;q=0.8")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "1.000.000 ₫", resp["data"].(map[string]interface{})["formatted"])
+	assert.Equal(t, "vi-VN", resp["data"].(map[string]interface{})["locale"])
+
+	w, resp = doRequest(t, r, http.MethodGet, "/currencies/VND/parse?locale=vi&text=1.500.000%20%E2%82%AB", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, float64(1500000), resp["data"].(map[string]interface{})["amount"])
+
+	w, _ = doRequest(t, r, http.MethodGet, "/currencies/VND/format?locale=vi", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w, _ = doRequest(t, r, http.MethodGet, "/currencies/VND/parse?locale=vi&text=1,5", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestEthnicityHandler_ListByCountry(t *testing.T) {
+	r := newTestRouter(t)
+
+	w, _ := doRequest(t, r, http.MethodPost, "/countries", "", map[string]interface{}{
+		"code": "VN", "name": map[string]string{"en": "Vietnam"},
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+	w, _ = doRequest(t, r, http.MethodPost, "/ethnicities", "", map[string]interface{}{
+		"code": "02", "country_code": "vn", "name": map[string]string{"en": "Tay", "vi": "Tày"},
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w, _ = doRequest(t, r, http.MethodPost, "/ethnicities", "", map[string]interface{}{
+		"code": "01", "country_code": "LA", "name": map[string]string{"en": "Lao"},
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w, resp := doRequest(t, r, http.MethodGet, "/ethnicities/by-country/VN?locale=vi", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, resp["data"], 1)
+	assert.Equal(t, "Tày", resp["data"].([]interface{})[0].(map[string]interface{})["localized_name"])
+
+	w, _ = doRequest(t, r, http.MethodGet, "/ethnicities/by-country/LA", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCountryHandler_Localization(t *testing.T) {
+	r := newTestRouter(t)
+
+	for code, names := range map[string]map[string]string{
+		"DE": {"en": "Germany", "vi": "Đức"},
+		"DK": {"en": "Denmark", "vi": "Đan Mạch"},
+		"DZ": {"en": "Algeria"},
+	} {
+		w, _ := doRequest(t, r, http.MethodPost, "/countries", "", map[string]interface{}{"code": code, "name": names})
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	names := func(resp map[string]interface{}) []string {
+		out := []string{}
+		for _, item := range resp["data"].([]interface{}) {
+			out = append(out, item.(map[string]interface{})["localized_name"].(string))
+		}
+		return out
+	}
+
+	// Accept-Language is honored by quality; untranslated names fall back to English
+	req := httptest.NewRequest(http.MethodGet, "/countries?sort=name", nil)
+	req.Header.Set("Accept-Language", "fr;q=0.5, vi-VN")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []string{"Algeria", "Đan Mạch", "Đức"}, names(resp))
+
+	// ?lang= takes precedence over the header
+	w, resp = doRequest(t, r, http.MethodGet, "/countries?lang=en&sort=name", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"Algeria", "Denmark", "Germany"}, names(resp))
+
+	w, resp = doRequest(t, r, http.MethodGet, "/countries/de?lang=vi", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Đức", resp["data"].(map[string]interface{})["localized_name"])
+
+	w, _ = doRequest(t, r, http.MethodGet, "/countries?sort=population", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTranslationHandler_ExportImport(t *testing.T) {
+	r := newTestRouter(t)
+
+	w, _ := doRequest(t, r, http.MethodPost, "/countries", "", map[string]interface{}{
+		"code": "TH", "name": map[string]string{"en": "Thailand"},
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w, resp := doRequest(t, r, http.MethodGet, "/translations/report?entity=country,currency", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	entities := resp["data"].(map[string]interface{})["entities"].([]interface{})
+	require.Len(t, entities, 2)
+	vi := entities[0].(map[string]interface{})["locales"].(map[string]interface{})["vi"].(map[string]interface{})
+	assert.Equal(t, float64(0), vi["percent"])
+	assert.Equal(t, []interface{}{"TH"}, vi["missing"])
+
+	w, _ = doRequest(t, r, http.MethodGet, "/translations/export?format=xliff", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/translations/export?format=xliff&target=vi&entity=country", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/xliff+xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="translations-vi.xlf"`, w.Header().Get("Content-Disposition"))
+	assert.Contains(t, w.Body.String(), `<trans-unit id="country/TH/name">`)
+
+	// The format of the upload is inferred from its XML content type
+	translated := strings.Replace(w.Body.String(), "<source>Thailand</source>", "<source>Thailand</source><target>Th\u00e1i Lan</target>", 1)
+	req = httptest.NewRequest(http.MethodPost, "/translations/import", strings.NewReader(translated))
+	req.Header.Set("Content-Type", "application/xliff+xml")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, float64(1), resp["data"].(map[string]interface{})["updated"])
+
+	w, _ = doRequest(t, r, http.MethodGet, "/countries/TH?lang=vi", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "Th\u00e1i Lan", resp["data"].(map[string]interface{})["localized_name"])
+
+	req = httptest.NewRequest(http.MethodGet, "/translations/export?locales=vi", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "entity,key,field,vi\ncountry,TH,name,Th\u00e1i Lan\n", w.Body.String())
+}
+
+func TestConfigHandler_EndToEnd(t *testing.T) {
+	r := newTestRouter(t)
+
+	w, resp := doRequest(t, r, http.MethodPost, "/configs", "tenant-1", map[string]interface{}{
+		"key": "db.timeout", "environment": "production", "value_type": "duration", "value": "5s",
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, float64(1), resp["data"].(map[string]interface{})["active_version"])
+
+	w, _ = doRequest(t, r, http.MethodPost, "/configs", "tenant-1", map[string]interface{}{
+		"key": "db.timeout", "environment": "production", "value": "10s",
+	})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w, resp = doRequest(t, r, http.MethodPut, "/configs/db.timeout", "tenant-1", map[string]interface{}{
+		"environment": "production", "value": "30s",
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "30s", resp["data"].(map[string]interface{})["value"])
+
+	w, _ = doRequest(t, r, http.MethodPut, "/configs/db.timeout", "tenant-1", map[string]interface{}{
+		"environment": "production", "value": 30,
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w, resp = doRequest(t, r, http.MethodGet, "/configs/db.timeout?environment=production&version=1", "tenant-1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "5s", resp["data"].(map[string]interface{})["value"])
+
+	w, resp = doRequest(t, r, http.MethodPost, "/configs/db.timeout/activate?environment=production", "tenant-1", map[string]interface{}{"version": 1})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "5s", resp["data"].(map[string]interface{})["value"])
+	assert.Equal(t, float64(2), resp["data"].(map[string]interface{})["latest_version"])
+
+	w, resp = doRequest(t, r, http.MethodPost, "/configs/db.timeout/rollback?environment=production", "tenant-1", map[string]interface{}{
+		"version": 2, "reason": "restore the longer timeout",
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "30s", resp["data"].(map[string]interface{})["value"])
+	assert.Equal(t, float64(3), resp["data"].(map[string]interface{})["active_version"])
+
+	w, resp = doRequest(t, r, http.MethodGet, "/configs/db.timeout/history?environment=production", "tenant-1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, float64(3), resp["pagination"].(map[string]interface{})["total_items"])
+	latest := resp["data"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "restore the longer timeout", latest["reason"])
+	assert.Equal(t, float64(2), latest["rollback_of"])
+	assert.Equal(t, true, latest["active"])
+
+	w, resp = doRequest(t, r, http.MethodGet, "/configs/db.timeout/diff?environment=production&from=1&to=3", "tenant-1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, resp["data"].(map[string]interface{})["changes"], 1)
+	w, _ = doRequest(t, r, http.MethodGet, "/configs/db.timeout/diff?environment=production&from=1", "tenant-1", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w, _ = doRequest(t, r, http.MethodGet, "/configs/db.timeout?environment=production", "tenant-2", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w, resp = doRequest(t, r, http.MethodGet, "/configs?environment=production&prefix=db.", "tenant-1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, resp["data"], 1)
+
+	w, _ = doRequest(t, r, http.MethodDelete, "/configs/db.timeout?environment=production", "tenant-1", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w, _ = doRequest(t, r, http.MethodGet, "/configs/db.timeout?environment=production", "tenant-1", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestConfigHandler_Resolve(t *testing.T) {
+	r := newTestRouter(t)
+
+	w, _ := doRequest(t, r, http.MethodPost, "/configs", "", map[string]interface{}{"key": "db.timeout", "value": "30s"})
+	require.Equal(t, http.StatusCreated, w.Code)
+	w, _ = doRequest(t, r, http.MethodPost, "/configs", "tenant-1", map[string]interface{}{
+		"key": "db.timeout", "environment": "production", "value": "5s",
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w, resp := doRequest(t, r, http.MethodGet, "/resolved-configs?environment=production&prefix=db.", "tenant-1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	values := resp["data"].(map[string]interface{})["values"].([]interface{})
+	require.Len(t, values, 1)
+	timeout := values[0].(map[string]interface{})
+	assert.Equal(t, "5s", timeout["value"])
+	assert.Equal(t, "environment", timeout["layer"])
+	assert.Equal(t, "default", timeout["shadowed"].([]interface{})[0].(map[string]interface{})["layer"])
+
+	w, _ = doRequest(t, r, http.MethodPut, "/configs/db.timeout/override", "tenant-1", map[string]interface{}{
+		"environment": "production", "value": "1s", "ttl": "10m",
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	w, resp = doRequest(t, r, http.MethodGet, "/resolved-configs?environment=production", "tenant-1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	timeout = resp["data"].(map[string]interface{})["values"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "1s", timeout["value"])
+	assert.Equal(t, "override", timeout["layer"])
+	assert.NotEmpty(t, timeout["expires_at"])
+
+	w, _ = doRequest(t, r, http.MethodDelete, "/configs/db.timeout/override?environment=production", "tenant-1", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w, _ = doRequest(t, r, http.MethodDelete, "/configs/db.timeout/override?environment=production", "tenant-1", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestConfigRuleHandler_ValidationErrors(t *testing.T) {
+	r := newTestRouter(t)
+
+	w, resp := doRequest(t, r, http.MethodPost, "/config-rules", "tenant-1", map[string]interface{}{
+		"key_pattern": "db.pool.size", "type": "int", "required": true, "min": 1, "max": 100,
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+	id := resp["data"].(map[string]interface{})["id"].(string)
+	w, _ = doRequest(t, r, http.MethodPost, "/config-rules", "tenant-1", map[string]interface{}{"key_pattern": "db.pool.size", "type": "int"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w, _ = doRequest(t, r, http.MethodGet, "/config-rules/"+id, "tenant-2", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w, resp = doRequest(t, r, http.MethodPost, "/configs", "tenant-1", map[string]interface{}{
+		"key": "db.pool", "value": map[string]interface{}{"size": 500},
+	})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	body := resp["error"].(map[string]interface{})
+	assert.Equal(t, "VALIDATION_FAILED", body["code"])
+	details := body["details"].([]interface{})
+	require.Len(t, details, 1)
+	assert.Equal(t, map[string]interface{}{
+		"field": "value.size", "pointer": "/value/size", "code": "max", "message": "must be at most 100", "rule": "db.pool.size",
+	}, details[0])
+
+	w, _ = doRequest(t, r, http.MethodDelete, "/config-rules/"+id, "tenant-1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	w, _ = doRequest(t, r, http.MethodPost, "/configs", "tenant-1", map[string]interface{}{
+		"key": "db.pool", "value": map[string]interface{}{"size": 500},
+	})
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestConfigSchemaHandler_ValidationAndMigration(t *testing.T) {
+	r := newTestRouter(t)
+
+	w, _ := doRequest(t, r, http.MethodPost, "/app-components", "tenant-1", map[string]interface{}{
+		"code": "dashboard", "name": "Dashboard", "config": map[string]interface{}{"refresh": 30},
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w, _ = doRequest(t, r, http.MethodPost, "/config-schemas", "", map[string]interface{}{
+		"target": "app_component", "code": "dashboard", "schema": map[string]interface{}{"$ref": "#/$defs/dashboard"},
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w, resp := doRequest(t, r, http.MethodPost, "/config-schemas", "", map[string]interface{}{
+		"target": "app_component",
+		"code":   "dashboard",
+		"schema": map[string]interface{}{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type":    "object",
+			"properties": map[string]interface{}{
+				"theme":   map[string]interface{}{"enum": []string{"light", "dark"}, "default": "light"},
+				"widgets": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+			},
+		},
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+	data := resp["data"].(map[string]interface{})
+	assert.Equal(t, float64(1), data["version"])
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", data["schema"].(map[string]interface{})["$schema"])
+
+	w, resp = doRequest(t, r, http.MethodPost, "/app-components", "tenant-2", map[string]interface{}{
+		"code": "dashboard", "name": "Dashboard", "config": map[string]interface{}{"theme": "blue", "widgets": []interface{}{"sales", 7}},
+	})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	details := resp["error"].(map[string]interface{})["details"].([]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"field": "config.theme", "pointer": "/config/theme", "code": "enum", "message": `must be one of "light", "dark"`, "rule": "app_component:dashboard"},
+		map[string]interface{}{"field": "config.widgets.1", "pointer": "/config/widgets/1", "code": "type", "message": "must be of type string", "rule": "app_component:dashboard"},
+	}, details)
+
+	w, _ = doRequest(t, r, http.MethodPost, "/app-components/migrate?dry_run=true", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w, resp = doRequest(t, r, http.MethodPost, "/app-components/migrate?code=dashboard&dry_run=true", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	report := resp["data"].(map[string]interface{})
+	assert.Equal(t, true, report["dry_run"])
+	assert.Equal(t, float64(1), report["checked"])
+	assert.Equal(t, float64(1), report["migrated"])
+	assert.Equal(t, []interface{}{}, report["nonconforming"])
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"golang.org/x/text/language"
+)
+
+// requestLocalization reads how master data in the response should be localized: the locales of
+// ?lang=, or else of Accept-Language by preference, and ?sort=name to order listings by
+// localized name rather than by code
+func requestLocalization(c *gin.Context) (domain.Localization, error) {
+	l10n := domain.Localization{Locales: requestLocales(c, "")}
+	switch c.Query("sort") {
+	case "", "code":
+	case "name":
+		l10n.SortByName = true
+	default:
+		return l10n, errors.BadRequest("sort must be one of code, name")
+	}
+	return l10n, nil
+}
+
+// requestLocale returns the most preferred locale of a request, or "" when it states none
+func requestLocale(c *gin.Context, explicit string) string {
+	if locales := requestLocales(c, explicit); len(locales) > 0 {
+		return locales[0]
+	}
+	return ""
+}
+
+// requestLocales returns the locales a request prefers, most preferred first. An explicit
+// locale, ?lang= or the older ?locale= is the only preference; otherwise Accept-Language is
+// read in order of quality, ignoring malformed headers and the "*" wildcard.
+func requestLocales(c *gin.Context, explicit string) []string {
+	for _, locale := range []string{explicit, c.Query("lang"), c.Query("locale")} {
+		if locale != "" {
+			return []string{locale}
+		}
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+	if err != nil {
+		return nil
+	}
+	locales := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag != language.Und {
+			locales = append(locales, tag.String())
+		}
+	}
+	return locales
+}
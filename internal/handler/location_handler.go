@@ -0,0 +1,372 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// LocationHandler handles HTTP requests for provinces, districts and wards
+type LocationHandler struct {
+	service *service.LocationService
+	logger  *logger.Logger
+}
+
+// NewLocationHandler creates a new location handler
+func NewLocationHandler(service *service.LocationService, log *logger.Logger) *LocationHandler {
+	return &LocationHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+// ListProvinces handles listing the provinces of a country
+func (h *LocationHandler) ListProvinces(c *gin.Context) {
+	l10n, err := requestLocalization(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	provinces, err := h.service.ListProvinces(c.Request.Context(), c.Param("country_code"), l10n)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": provinces})
+}
+
+// GetProvince handles getting a province by code
+func (h *LocationHandler) GetProvince(c *gin.Context) {
+	province, err := h.service.GetProvince(c.Request.Context(), c.Param("province_code"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	domain.Localization{Locales: requestLocales(c, "")}.Apply(province)
+
+	c.JSON(http.StatusOK, gin.H{"data": province})
+}
+
+// CreateProvince handles creating a new province
+func (h *LocationHandler) CreateProvince(c *gin.Context) {
+	var province domain.Province
+	if err := c.ShouldBindJSON(&province); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	if err := h.service.CreateProvince(c.Request.Context(), &province); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": province})
+}
+
+// UpdateProvince handles updating a province
+func (h *LocationHandler) UpdateProvince(c *gin.Context) {
+	var province domain.Province
+	if err := c.ShouldBindJSON(&province); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	province.Code = c.Param("code")
+
+	if err := h.service.UpdateProvince(c.Request.Context(), &province); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": province})
+}
+
+// DeleteProvince handles deleting a province
+func (h *LocationHandler) DeleteProvince(c *gin.Context) {
+	if err := h.service.DeleteProvince(c.Request.Context(), c.Param("code")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Province deleted successfully"})
+}
+
+// ListDistricts handles listing the districts of a province
+func (h *LocationHandler) ListDistricts(c *gin.Context) {
+	l10n, err := requestLocalization(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	districts, err := h.service.ListDistricts(c.Request.Context(), c.Param("province_code"), l10n)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": districts})
+}
+
+// GetDistrict handles getting a district by code
+func (h *LocationHandler) GetDistrict(c *gin.Context) {
+	district, err := h.service.GetDistrict(c.Request.Context(), c.Param("district_code"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	domain.Localization{Locales: requestLocales(c, "")}.Apply(district)
+
+	c.JSON(http.StatusOK, gin.H{"data": district})
+}
+
+// CreateDistrict handles creating a new district
+func (h *LocationHandler) CreateDistrict(c *gin.Context) {
+	var district domain.District
+	if err := c.ShouldBindJSON(&district); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	if err := h.service.CreateDistrict(c.Request.Context(), &district); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": district})
+}
+
+// UpdateDistrict handles updating a district
+func (h *LocationHandler) UpdateDistrict(c *gin.Context) {
+	var district domain.District
+	if err := c.ShouldBindJSON(&district); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	district.Code = c.Param("code")
+
+	if err := h.service.UpdateDistrict(c.Request.Context(), &district); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": district})
+}
+
+// DeleteDistrict handles deleting a district
+func (h *LocationHandler) DeleteDistrict(c *gin.Context) {
+	if err := h.service.DeleteDistrict(c.Request.Context(), c.Param("code")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "District deleted successfully"})
+}
+
+// ListWards handles listing the wards of a district
+func (h *LocationHandler) ListWards(c *gin.Context) {
+	l10n, err := requestLocalization(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	wards, err := h.service.ListWards(c.Request.Context(), c.Param("district_code"), l10n)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": wards})
+}
+
+// GetWard handles getting a ward by code
+func (h *LocationHandler) GetWard(c *gin.Context) {
+	ward, err := h.service.GetWard(c.Request.Context(), c.Param("ward_code"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	domain.Localization{Locales: requestLocales(c, "")}.Apply(ward)
+
+	c.JSON(http.StatusOK, gin.H{"data": ward})
+}
+
+// CreateWard handles creating a new ward
+func (h *LocationHandler) CreateWard(c *gin.Context) {
+	var ward domain.Ward
+	if err := c.ShouldBindJSON(&ward); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	if err := h.service.CreateWard(c.Request.Context(), &ward); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": ward})
+}
+
+// UpdateWard handles updating a ward
+func (h *LocationHandler) UpdateWard(c *gin.Context) {
+	var ward domain.Ward
+	if err := c.ShouldBindJSON(&ward); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	ward.Code = c.Param("code")
+
+	if err := h.service.UpdateWard(c.Request.Context(), &ward); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": ward})
+}
+
+// DeleteWard handles deleting a ward
+func (h *LocationHandler) DeleteWard(c *gin.Context) {
+	if err := h.service.DeleteWard(c.Request.Context(), c.Param("code")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Ward deleted successfully"})
+}
+
+// Search handles type-ahead search across provinces, districts and wards
+func (h *LocationHandler) Search(c *gin.Context) {
+	var req domain.LocationSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid query parameters"))
+		return
+	}
+
+	hits, err := h.service.Search(c.Request.Context(), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	// Hits keep their ranking; only their names and those of their ancestors are localized
+	l10n := domain.Localization{Locales: requestLocales(c, "")}
+	for _, hit := range hits {
+		l10n.Apply(hit)
+		for i := range hit.Path {
+			l10n.Apply(&hit.Path[i])
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": hits})
+}
+
+// Reorganize handles applying a boundary reorganization decree
+func (h *LocationHandler) Reorganize(c *gin.Context) {
+	var decree domain.ReorganizationDecree
+	if err := c.ShouldBindJSON(&decree); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	report, err := h.service.Reorganize(c.Request.Context(), &decree)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// Resolve handles mapping a possibly retired code to the units in effect at ?date= (default now)
+func (h *LocationHandler) Resolve(c *gin.Context) {
+	at := time.Now()
+	if date := c.Query("date"); date != "" {
+		parsed, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			parsed, err = time.Parse(time.RFC3339, date)
+		}
+		if err != nil {
+			h.respondError(c, errors.BadRequest("date must be YYYY-MM-DD or RFC 3339"))
+			return
+		}
+		at = parsed
+	}
+
+	resolution, err := h.service.Resolve(c.Request.Context(), c.Param("code"), c.Query("level"), at)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": resolution})
+}
+
+// Import handles loading administrative units from a CSV or JSON file, sent either as the raw
+// request body or as the "file" field of a multipart form. Without ?format= the format is taken
+// from the file extension or the content type.
+func (h *LocationHandler) Import(c *gin.Context) {
+	var opts domain.LocationImportOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid query parameters"))
+		return
+	}
+
+	var body io.Reader = c.Request.Body
+	filename := ""
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			h.respondError(c, errors.BadRequest("A file is required"))
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			h.respondError(c, errors.BadRequest("Unreadable file"))
+			return
+		}
+		defer file.Close()
+		body, filename = file, header.Filename
+	}
+
+	if opts.Format == "" {
+		switch {
+		case strings.EqualFold(filepath.Ext(filename), ".json"), strings.Contains(c.ContentType(), "json"):
+			opts.Format = domain.LocationImportJSON
+		default:
+			opts.Format = domain.LocationImportCSV
+		}
+	}
+
+	report, err := h.service.ImportLocations(c.Request.Context(), body, &opts)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// respondError responds with an error
+func (h *LocationHandler) respondError(c *gin.Context, err error) {
+	appErr, body := errorResponse(err)
+	h.logger.Error("Request failed",
+		zap.String("path", c.Request.URL.Path),
+		zap.String("method", c.Request.Method),
+		zap.String("error", appErr.Message),
+	)
+	c.JSON(appErr.StatusCode, gin.H{"error": body})
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// PermissionHandler handles HTTP requests for the permission registry.
+// Requests without a tenant operate on the global registry.
+type PermissionHandler struct {
+	service *service.PermissionService
+	logger  *logger.Logger
+}
+
+// NewPermissionHandler creates a new permission handler
+func NewPermissionHandler(service *service.PermissionService, log *logger.Logger) *PermissionHandler {
+	return &PermissionHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+// Create handles creating a new permission
+func (h *PermissionHandler) Create(c *gin.Context) {
+	var permission domain.Permission
+	if err := c.ShouldBindJSON(&permission); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+	permission.TenantID = c.GetString("tenant_id")
+
+	if err := h.service.Create(c.Request.Context(), &permission); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": permission})
+}
+
+// GetByID handles getting a permission by ID
+func (h *PermissionHandler) GetByID(c *gin.Context) {
+	permission, err := h.service.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": permission})
+}
+
+// List handles listing permissions
+func (h *PermissionHandler) List(c *gin.Context) {
+	var req domain.PaginationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		req.Page = 1
+		req.PerPage = 30
+	}
+	req.SetDefaults()
+
+	permissions, total, err := h.service.List(c.Request.Context(), c.GetString("tenant_id"), req.Page, req.PerPage)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	totalPages := int(total) / req.PerPage
+	if int(total)%req.PerPage > 0 {
+		totalPages++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": permissions,
+		"pagination": domain.PaginationResponse{
+			Page:       req.Page,
+			PerPage:    req.PerPage,
+			TotalPages: totalPages,
+			TotalItems: total,
+		},
+	})
+}
+
+// ListByModule handles listing the permissions registered by a module
+func (h *PermissionHandler) ListByModule(c *gin.Context) {
+	permissions, err := h.service.ListByModule(c.Request.Context(), c.GetString("tenant_id"), c.Param("module_code"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": permissions})
+}
+
+// ListByResource handles listing the permissions on a resource
+func (h *PermissionHandler) ListByResource(c *gin.Context) {
+	permissions, err := h.service.ListByResource(c.Request.Context(), c.GetString("tenant_id"), c.Param("resource"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": permissions})
+}
+
+// Update handles updating a permission
+func (h *PermissionHandler) Update(c *gin.Context) {
+	var permission domain.Permission
+	if err := c.ShouldBindJSON(&permission); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		h.respondError(c, errors.BadRequest("Invalid ID format"))
+		return
+	}
+	permission.ID = objectID
+	permission.TenantID = c.GetString("tenant_id")
+
+	if err := h.service.Update(c.Request.Context(), &permission); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": permission})
+}
+
+// Delete handles deleting a permission
+func (h *PermissionHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Request.Context(), c.Param("id"), c.GetString("tenant_id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Permission deleted successfully"})
+}
+
+// Register handles the batch registration of a module's permission manifest
+func (h *PermissionHandler) Register(c *gin.Context) {
+	var manifest domain.PermissionManifest
+	if err := c.ShouldBindJSON(&manifest); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	report, err := h.service.Register(c.Request.Context(), c.GetString("tenant_id"), &manifest)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// respondError responds with an error
+func (h *PermissionHandler) respondError(c *gin.Context, err error) {
+	appErr, body := errorResponse(err)
+	h.logger.Error("Request failed",
+		zap.String("path", c.Request.URL.Path),
+		zap.String("method", c.Request.Method),
+		zap.String("error", appErr.Message),
+	)
+	c.JSON(appErr.StatusCode, gin.H{"error": body})
+}
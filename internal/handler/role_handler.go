@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// RoleHandler handles HTTP requests for roles.
+// Requests without a tenant operate on the global system roles. The caller's role codes
+// are read from the "roles" context value set by the auth middleware alongside the tenant.
+type RoleHandler struct {
+	service *service.RoleService
+	logger  *logger.Logger
+}
+
+// NewRoleHandler creates a new role handler
+func NewRoleHandler(service *service.RoleService, log *logger.Logger) *RoleHandler {
+	return &RoleHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+// Create handles creating a new role
+func (h *RoleHandler) Create(c *gin.Context) {
+	var role domain.Role
+	if err := c.ShouldBindJSON(&role); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+	role.TenantID = c.GetString("tenant_id")
+
+	if err := h.service.Create(c.Request.Context(), &role, c.GetStringSlice("roles")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": role})
+}
+
+// GetByID handles getting a role by ID
+func (h *RoleHandler) GetByID(c *gin.Context) {
+	role, err := h.service.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": role})
+}
+
+// List handles listing roles
+func (h *RoleHandler) List(c *gin.Context) {
+	var req domain.PaginationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		req.Page = 1
+		req.PerPage = 30
+	}
+	req.SetDefaults()
+
+	roles, total, err := h.service.List(c.Request.Context(), c.GetString("tenant_id"), req.Page, req.PerPage)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	totalPages := int(total) / req.PerPage
+	if int(total)%req.PerPage > 0 {
+		totalPages++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": roles,
+		"pagination": domain.PaginationResponse{
+			Page:       req.Page,
+			PerPage:    req.PerPage,
+			TotalPages: totalPages,
+			TotalItems: total,
+		},
+	})
+}
+
+// Update handles updating a role
+func (h *RoleHandler) Update(c *gin.Context) {
+	var role domain.Role
+	if err := c.ShouldBindJSON(&role); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		h.respondError(c, errors.BadRequest("Invalid ID format"))
+		return
+	}
+	role.ID = objectID
+	role.TenantID = c.GetString("tenant_id")
+
+	if err := h.service.Update(c.Request.Context(), &role, c.GetStringSlice("roles")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": role})
+}
+
+// Delete handles deleting a role
+func (h *RoleHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Request.Context(), c.Param("id"), c.GetString("tenant_id"), c.GetStringSlice("roles")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role deleted successfully"})
+}
+
+// Assignable handles listing the roles the caller may assign
+func (h *RoleHandler) Assignable(c *gin.Context) {
+	roles, err := h.service.Assignable(c.Request.Context(), c.GetString("tenant_id"), c.GetStringSlice("roles"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": roles})
+}
+
+// GetPermissions handles getting the permission grants of a role
+func (h *RoleHandler) GetPermissions(c *gin.Context) {
+	role, err := h.service.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	permissions := role.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+	c.JSON(http.StatusOK, gin.H{"data": permissions})
+}
+
+// Clone handles copying a role and its grants into a tenant under a new code
+func (h *RoleHandler) Clone(c *gin.Context) {
+	var req domain.CloneRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	role, err := h.service.Clone(c.Request.Context(), c.Param("id"), c.GetString("tenant_id"), &req, c.GetStringSlice("roles"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": role})
+}
+
+// SetPermissions handles replacing the permission grants of a role
+func (h *RoleHandler) SetPermissions(c *gin.Context) {
+	var req domain.RolePermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	role, err := h.service.SetPermissions(c.Request.Context(), c.Param("id"), c.GetString("tenant_id"), req.Permissions, c.GetStringSlice("roles"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": role.Permissions})
+}
+
+// EffectivePermissions handles expanding the grants of a role against the permission registry
+func (h *RoleHandler) EffectivePermissions(c *gin.Context) {
+	effective, err := h.service.EffectivePermissions(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": effective})
+}
+
+// Check handles deciding whether a set of roles grants a permission
+func (h *RoleHandler) Check(c *gin.Context) {
+	var req domain.PermissionCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	result, err := h.service.Check(c.Request.Context(), c.GetString("tenant_id"), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// respondError responds with an error
+func (h *RoleHandler) respondError(c *gin.Context, err error) {
+	appErr, body := errorResponse(err)
+	h.logger.Error("Request failed",
+		zap.String("path", c.Request.URL.Path),
+		zap.String("method", c.Request.Method),
+		zap.String("error", appErr.Message),
+	)
+	c.JSON(appErr.StatusCode, gin.H{"error": body})
+}
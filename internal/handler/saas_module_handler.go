@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// SaaSModuleHandler handles HTTP requests for SaaS modules.
+// Requests without a tenant operate on the global module catalog.
+type SaaSModuleHandler struct {
+	service *service.SaaSModuleService
+	logger  *logger.Logger
+}
+
+// NewSaaSModuleHandler creates a new SaaS module handler
+func NewSaaSModuleHandler(service *service.SaaSModuleService, log *logger.Logger) *SaaSModuleHandler {
+	return &SaaSModuleHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+// Create handles creating a new SaaS module
+func (h *SaaSModuleHandler) Create(c *gin.Context) {
+	var module domain.SaaSModule
+	if err := c.ShouldBindJSON(&module); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+	module.TenantID = c.GetString("tenant_id")
+
+	if err := h.service.Create(c.Request.Context(), &module); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": module})
+}
+
+// GetByID handles getting a SaaS module by ID
+func (h *SaaSModuleHandler) GetByID(c *gin.Context) {
+	module, err := h.service.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": module})
+}
+
+// List handles listing SaaS modules
+func (h *SaaSModuleHandler) List(c *gin.Context) {
+	var req domain.PaginationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		req.Page = 1
+		req.PerPage = 30
+	}
+	req.SetDefaults()
+
+	modules, total, err := h.service.List(c.Request.Context(), c.GetString("tenant_id"), req.Page, req.PerPage)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	totalPages := int(total) / req.PerPage
+	if int(total)%req.PerPage > 0 {
+		totalPages++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": modules,
+		"pagination": domain.PaginationResponse{
+			Page:       req.Page,
+			PerPage:    req.PerPage,
+			TotalPages: totalPages,
+			TotalItems: total,
+		},
+	})
+}
+
+// Update handles updating a SaaS module
+func (h *SaaSModuleHandler) Update(c *gin.Context) {
+	var module domain.SaaSModule
+	if err := c.ShouldBindJSON(&module); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		h.respondError(c, errors.BadRequest("Invalid ID format"))
+		return
+	}
+	module.ID = objectID
+	module.TenantID = c.GetString("tenant_id")
+
+	if err := h.service.Update(c.Request.Context(), &module); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": module})
+}
+
+// Delete handles deleting a SaaS module
+func (h *SaaSModuleHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Request.Context(), c.Param("id"), c.GetString("tenant_id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Module deleted successfully"})
+}
+
+// Resolve handles resolving a set of module codes into their install order
+func (h *SaaSModuleHandler) Resolve(c *gin.Context) {
+	var req domain.ResolveModulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	resolution, err := h.service.Resolve(c.Request.Context(), c.GetString("tenant_id"), req.Codes)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": resolution})
+}
+
+// respondError responds with an error
+func (h *SaaSModuleHandler) respondError(c *gin.Context, err error) {
+	appErr, body := errorResponse(err)
+	h.logger.Error("Request failed",
+		zap.String("path", c.Request.URL.Path),
+		zap.String("method", c.Request.Method),
+		zap.String("error", appErr.Message),
+	)
+	c.JSON(appErr.StatusCode, gin.H{"error": body})
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// ServicePackageHandler handles HTTP requests for service packages.
+// Requests without a tenant operate on the global package catalog.
+type ServicePackageHandler struct {
+	service *service.ServicePackageService
+	logger  *logger.Logger
+}
+
+// NewServicePackageHandler creates a new service package handler
+func NewServicePackageHandler(service *service.ServicePackageService, log *logger.Logger) *ServicePackageHandler {
+	return &ServicePackageHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+// Create handles creating a new service package
+func (h *ServicePackageHandler) Create(c *gin.Context) {
+	var pkg domain.ServicePackage
+	if err := c.ShouldBindJSON(&pkg); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+	pkg.TenantID = c.GetString("tenant_id")
+
+	if err := h.service.Create(c.Request.Context(), &pkg); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": pkg})
+}
+
+// GetByID handles getting a service package by ID
+func (h *ServicePackageHandler) GetByID(c *gin.Context) {
+	pkg, err := h.service.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": pkg})
+}
+
+// List handles listing service packages
+func (h *ServicePackageHandler) List(c *gin.Context) {
+	var req domain.PaginationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		req.Page = 1
+		req.PerPage = 30
+	}
+	req.SetDefaults()
+
+	packages, total, err := h.service.List(c.Request.Context(), c.GetString("tenant_id"), req.Page, req.PerPage)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	totalPages := int(total) / req.PerPage
+	if int(total)%req.PerPage > 0 {
+		totalPages++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": packages,
+		"pagination": domain.PaginationResponse{
+			Page:       req.Page,
+			PerPage:    req.PerPage,
+			TotalPages: totalPages,
+			TotalItems: total,
+		},
+	})
+}
+
+// Update handles updating a service package
+func (h *ServicePackageHandler) Update(c *gin.Context) {
+	var pkg domain.ServicePackage
+	if err := c.ShouldBindJSON(&pkg); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid request body"))
+		return
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		h.respondError(c, errors.BadRequest("Invalid ID format"))
+		return
+	}
+	pkg.ID = objectID
+	pkg.TenantID = c.GetString("tenant_id")
+
+	if err := h.service.Update(c.Request.Context(), &pkg); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": pkg})
+}
+
+// Delete handles deleting a service package
+func (h *ServicePackageHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Request.Context(), c.Param("id"), c.GetString("tenant_id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Package deleted successfully"})
+}
+
+// Entitlements handles resolving the effective entitlements of a package code
+func (h *ServicePackageHandler) Entitlements(c *gin.Context) {
+	entitlements, err := h.service.Entitlements(c.Request.Context(), c.GetString("tenant_id"), c.Param("code"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": entitlements})
+}
+
+// MigrateLimits handles migrating the limits of every package to the current version of the
+// package_limits schema
+func (h *ServicePackageHandler) MigrateLimits(c *gin.Context) {
+	var opts domain.SchemaMigrationOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid migration options"))
+		return
+	}
+
+	report, err := h.service.MigrateLimits(c.Request.Context(), &opts)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// respondError responds with an error
+func (h *ServicePackageHandler) respondError(c *gin.Context, err error) {
+	appErr, body := errorResponse(err)
+	h.logger.Error("Request failed",
+		zap.String("path", c.Request.URL.Path),
+		zap.String("method", c.Request.Method),
+		zap.String("error", appErr.Message),
+	)
+	c.JSON(appErr.StatusCode, gin.H{"error": body})
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// TenantHandler handles HTTP requests for tenant provisioning
+type TenantHandler struct {
+	service *service.TenantService
+	logger  *logger.Logger
+}
+
+// NewTenantHandler creates a new tenant handler
+func NewTenantHandler(service *service.TenantService, log *logger.Logger) *TenantHandler {
+	return &TenantHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+// Provision handles provisioning the default configuration of a tenant.
+// Tenant callers may only provision their own tenant.
+func (h *TenantHandler) Provision(c *gin.Context) {
+	tenantID := c.Param("tenant_id")
+	if caller := c.GetString("tenant_id"); caller != "" && caller != tenantID {
+		h.respondError(c, errors.Forbidden("Cannot provision another tenant"))
+		return
+	}
+
+	result, err := h.service.Provision(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// respondError responds with an error
+func (h *TenantHandler) respondError(c *gin.Context, err error) {
+	appErr, body := errorResponse(err)
+	h.logger.Error("Request failed",
+		zap.String("path", c.Request.URL.Path),
+		zap.String("method", c.Request.Method),
+		zap.String("error", appErr.Message),
+	)
+	c.JSON(appErr.StatusCode, gin.H{"error": body})
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// TranslationHandler handles HTTP requests for translation reports, exports and imports.
+// Admin menu titles are those of the request tenant, or the global defaults without one.
+type TranslationHandler struct {
+	service *service.TranslationService
+	logger  *logger.Logger
+}
+
+// NewTranslationHandler creates a new translation handler
+func NewTranslationHandler(service *service.TranslationService, log *logger.Logger) *TranslationHandler {
+	return &TranslationHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+// Report handles the translation completeness report
+func (h *TranslationHandler) Report(c *gin.Context) {
+	var query domain.TranslationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid query parameters"))
+		return
+	}
+
+	report, err := h.service.Report(c.Request.Context(), c.GetString("tenant_id"), &query)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// Export handles downloading translations as CSV or XLIFF
+func (h *TranslationHandler) Export(c *gin.Context) {
+	var query domain.TranslationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid query parameters"))
+		return
+	}
+
+	data, err := h.service.Export(c.Request.Context(), c.GetString("tenant_id"), &query)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	contentType, filename := "text/csv; charset=utf-8", "translations.csv"
+	if query.Format == domain.TranslationFormatXLIFF {
+		contentType, filename = "application/xliff+xml; charset=utf-8", "translations-"+query.Target+".xlf"
+	}
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// Import handles uploading translated CSV or XLIFF files
+func (h *TranslationHandler) Import(c *gin.Context) {
+	var opts domain.TranslationImportOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		h.respondError(c, errors.BadRequest("Invalid query parameters"))
+		return
+	}
+
+	var body io.Reader = c.Request.Body
+	filename := ""
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			h.respondError(c, errors.BadRequest("A file is required"))
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			h.respondError(c, errors.BadRequest("Unreadable file"))
+			return
+		}
+		defer file.Close()
+		body, filename = file, header.Filename
+	}
+
+	if opts.Format == "" {
+		switch ext := strings.ToLower(filepath.Ext(filename)); {
+		case ext == ".xlf", ext == ".xliff", strings.Contains(c.ContentType(), "xml"):
+			opts.Format = domain.TranslationFormatXLIFF
+		default:
+			opts.Format = domain.TranslationFormatCSV
+		}
+	}
+
+	report, err := h.service.Import(c.Request.Context(), c.GetString("tenant_id"), body, &opts)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// respondError responds with an error
+func (h *TranslationHandler) respondError(c *gin.Context, err error) {
+	appErr, body := errorResponse(err)
+	h.logger.Error("Request failed",
+		zap.String("path", c.Request.URL.Path),
+		zap.String("method", c.Request.Method),
+		zap.String("error", appErr.Message),
+	)
+	c.JSON(appErr.StatusCode, gin.H{"error": body})
+}
@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AdminMenuRepository handles admin menu data access
+type AdminMenuRepository interface {
+	Create(ctx context.Context, menu *domain.AdminMenu) error
+	FindByID(ctx context.Context, id string) (*domain.AdminMenu, error)
+	FindByCode(ctx context.Context, tenantID, code string) (*domain.AdminMenu, error)
+	List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.AdminMenu, int64, error)
+	ListAll(ctx context.Context, tenantID string) ([]*domain.AdminMenu, error)
+	ListByModule(ctx context.Context, tenantID, moduleCode string) ([]*domain.AdminMenu, error)
+	FindChildren(ctx context.Context, tenantID, parentID string) ([]*domain.AdminMenu, error)
+	Update(ctx context.Context, menu *domain.AdminMenu) error
+	Reorder(ctx context.Context, tenantID string, positions []domain.MenuPosition) error
+	Delete(ctx context.Context, id string) error
+}
+
+// mongoAdminMenuRepository is the MongoDB implementation of AdminMenuRepository
+type mongoAdminMenuRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAdminMenuRepository creates a new MongoDB backed admin menu repository
+func NewAdminMenuRepository(db *mongo.Database) AdminMenuRepository {
+	collection := db.Collection("admin_menus")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "tenantId", Value: 1},
+				{Key: "code", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "tenantId", Value: 1}, {Key: "parentId", Value: 1}, {Key: "order", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "tenantId", Value: 1}, {Key: "moduleCode", Value: 1}, {Key: "order", Value: 1}},
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &mongoAdminMenuRepository{collection: collection}
+}
+
+// Create creates a new admin menu
+func (r *mongoAdminMenuRepository) Create(ctx context.Context, menu *domain.AdminMenu) error {
+	menu.CreatedAt = time.Now()
+	menu.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, menu)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to create admin menu: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create admin menu: %w", err)
+	}
+
+	menu.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByID finds an admin menu by ID
+func (r *mongoAdminMenuRepository) FindByID(ctx context.Context, id string) (*domain.AdminMenu, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid admin menu ID: %w", err)
+	}
+
+	var menu domain.AdminMenu
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&menu)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find admin menu: %w", err)
+	}
+	return &menu, nil
+}
+
+// FindByCode finds an admin menu by code and tenant
+func (r *mongoAdminMenuRepository) FindByCode(ctx context.Context, tenantID, code string) (*domain.AdminMenu, error) {
+	var menu domain.AdminMenu
+	opts := options.FindOne().SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "code", Value: 1}})
+	err := r.collection.FindOne(ctx, bson.M{
+		"tenantId": tenantID,
+		"code":     code,
+	}, opts).Decode(&menu)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find admin menu: %w", err)
+	}
+	return &menu, nil
+}
+
+// List lists admin menus of a tenant ordered by order and code with pagination
+func (r *mongoAdminMenuRepository) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.AdminMenu, int64, error) {
+	filter := bson.M{"tenantId": tenantID}
+
+	countOpts := options.Count().SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "code", Value: 1}})
+	total, err := r.collection.CountDocuments(ctx, filter, countOpts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count admin menus: %w", err)
+	}
+
+	opts := options.Find().
+		SetSkip(int64((page - 1) * perPage)).
+		SetLimit(int64(perPage)).
+		SetSort(bson.D{{Key: "order", Value: 1}, {Key: "code", Value: 1}})
+
+	menus, err := r.find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	return menus, total, nil
+}
+
+// ListAll lists every admin menu of a tenant ordered by order and code
+func (r *mongoAdminMenuRepository) ListAll(ctx context.Context, tenantID string) ([]*domain.AdminMenu, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "order", Value: 1}, {Key: "code", Value: 1}})
+	return r.find(ctx, bson.M{"tenantId": tenantID}, opts)
+}
+
+// ListByModule lists the admin menus of a module ordered by order and code
+func (r *mongoAdminMenuRepository) ListByModule(ctx context.Context, tenantID, moduleCode string) ([]*domain.AdminMenu, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "order", Value: 1}, {Key: "code", Value: 1}}).
+		SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "moduleCode", Value: 1}, {Key: "order", Value: 1}})
+	return r.find(ctx, bson.M{"tenantId": tenantID, "moduleCode": moduleCode}, opts)
+}
+
+// FindChildren finds the direct children of a menu ordered by order and code
+func (r *mongoAdminMenuRepository) FindChildren(ctx context.Context, tenantID, parentID string) ([]*domain.AdminMenu, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "order", Value: 1}, {Key: "code", Value: 1}}).
+		SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "parentId", Value: 1}, {Key: "order", Value: 1}})
+	return r.find(ctx, bson.M{"tenantId": tenantID, "parentId": parentID}, opts)
+}
+
+// Update updates an admin menu
+func (r *mongoAdminMenuRepository) Update(ctx context.Context, menu *domain.AdminMenu) error {
+	menu.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"moduleCode":  menu.ModuleCode,
+			"parentId":    menu.ParentID,
+			"name":        menu.Name,
+			"title":       menu.Title,
+			"icon":        menu.Icon,
+			"path":        menu.Path,
+			"component":   menu.Component,
+			"order":       menu.Order,
+			"permissions": menu.Permissions,
+			"isVisible":   menu.IsVisible,
+			"status":      menu.Status,
+			"updatedAt":   menu.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": menu.ID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update admin menu: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("admin menu %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// Reorder applies the parent and order of several menu items of a tenant in one bulk write.
+// Nothing is written when one of the items does not exist; run it in a Transactor transaction
+// for the positions to be written atomically.
+func (r *mongoAdminMenuRepository) Reorder(ctx context.Context, tenantID string, positions []domain.MenuPosition) error {
+	if len(positions) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	ids := make([]primitive.ObjectID, 0, len(positions))
+	models := make([]mongo.WriteModel, 0, len(positions))
+	for _, position := range positions {
+		ids = append(ids, position.ID)
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": position.ID, "tenantId": tenantID}).
+			SetUpdate(bson.M{"$set": bson.M{
+				"parentId":  position.ParentID,
+				"order":     position.Order,
+				"updatedAt": now,
+			}}))
+	}
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": bson.M{"$in": ids}, "tenantId": tenantID})
+	if err != nil {
+		return fmt.Errorf("failed to reorder admin menus: %w", err)
+	}
+	if count != int64(len(ids)) {
+		return fmt.Errorf("admin menu %w", ErrNotFound)
+	}
+
+	result, err := r.collection.BulkWrite(ctx, models)
+	if err != nil {
+		return fmt.Errorf("failed to reorder admin menus: %w", err)
+	}
+	if result.MatchedCount != int64(len(models)) {
+		return fmt.Errorf("admin menu %w", ErrNotFound)
+	}
+	return nil
+}
+
+// Delete deletes an admin menu
+func (r *mongoAdminMenuRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid admin menu ID: %w", err)
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return fmt.Errorf("failed to delete admin menu: %w", err)
+	}
+	return nil
+}
+
+func (r *mongoAdminMenuRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*domain.AdminMenu, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list admin menus: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var menus []*domain.AdminMenu
+	if err = cursor.All(ctx, &menus); err != nil {
+		return nil, fmt.Errorf("failed to decode admin menus: %w", err)
+	}
+
+	return menus, nil
+}
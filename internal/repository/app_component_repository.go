@@ -13,12 +13,24 @@ import (
 )
 
 // AppComponentRepository handles app component data access
-type AppComponentRepository struct {
+type AppComponentRepository interface {
+	Create(ctx context.Context, component *domain.AppComponent) error
+	FindByID(ctx context.Context, id string) (*domain.AppComponent, error)
+	FindByCode(ctx context.Context, tenantID, code string) (*domain.AppComponent, error)
+	List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.AppComponent, int64, error)
+	Update(ctx context.Context, component *domain.AppComponent) error
+	Delete(ctx context.Context, id string) error
+	FindByIDs(ctx context.Context, ids []string) ([]*domain.AppComponent, error)
+	ListByCode(ctx context.Context, code string) ([]*domain.AppComponent, error)
+}
+
+// mongoAppComponentRepository is the MongoDB implementation of AppComponentRepository
+type mongoAppComponentRepository struct {
 	collection *mongo.Collection
 }
 
-// NewAppComponentRepository creates a new app component repository
-func NewAppComponentRepository(db *mongo.Database) *AppComponentRepository {
+// NewAppComponentRepository creates a new MongoDB backed app component repository
+func NewAppComponentRepository(db *mongo.Database) AppComponentRepository {
 	collection := db.Collection("app_components")
 
 	// Create indexes
@@ -36,20 +48,29 @@ func NewAppComponentRepository(db *mongo.Database) *AppComponentRepository {
 		{
 			Keys: bson.D{{Key: "tenantId", Value: 1}, {Key: "status", Value: 1}},
 		},
+		{
+			Keys: bson.D{{Key: "tenantId", Value: 1}, {Key: "createdAt", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "code", Value: 1}, {Key: "tenantId", Value: 1}},
+		},
 	}
 
 	_, _ = collection.Indexes().CreateMany(ctx, indexes)
 
-	return &AppComponentRepository{collection: collection}
+	return &mongoAppComponentRepository{collection: collection}
 }
 
 // Create creates a new app component
-func (r *AppComponentRepository) Create(ctx context.Context, component *domain.AppComponent) error {
+func (r *mongoAppComponentRepository) Create(ctx context.Context, component *domain.AppComponent) error {
 	component.CreatedAt = time.Now()
 	component.UpdatedAt = time.Now()
 
 	result, err := r.collection.InsertOne(ctx, component)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to create app component: %w", ErrDuplicateKey)
+		}
 		return fmt.Errorf("failed to create app component: %w", err)
 	}
 
@@ -58,7 +79,7 @@ func (r *AppComponentRepository) Create(ctx context.Context, component *domain.A
 }
 
 // FindByID finds an app component by ID
-func (r *AppComponentRepository) FindByID(ctx context.Context, id string) (*domain.AppComponent, error) {
+func (r *mongoAppComponentRepository) FindByID(ctx context.Context, id string) (*domain.AppComponent, error) {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return nil, fmt.Errorf("invalid app component ID: %w", err)
@@ -77,7 +98,7 @@ func (r *AppComponentRepository) FindByID(ctx context.Context, id string) (*doma
 }
 
 // FindByCode finds an app component by code and tenant
-func (r *AppComponentRepository) FindByCode(ctx context.Context, tenantID, code string) (*domain.AppComponent, error) {
+func (r *mongoAppComponentRepository) FindByCode(ctx context.Context, tenantID, code string) (*domain.AppComponent, error) {
 	var component domain.AppComponent
 	// Use compound index hint for optimal performance
 	opts := options.FindOne().SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "code", Value: 1}})
@@ -95,11 +116,11 @@ func (r *AppComponentRepository) FindByCode(ctx context.Context, tenantID, code
 }
 
 // List lists app components with pagination
-func (r *AppComponentRepository) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.AppComponent, int64, error) {
+func (r *mongoAppComponentRepository) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.AppComponent, int64, error) {
 	filter := bson.M{"tenantId": tenantID}
 
 	// Count total with index hint
-	countOpts := options.Count().SetHint(bson.D{{Key: "tenantId", Value: 1}})
+	countOpts := options.Count().SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "createdAt", Value: -1}})
 	total, err := r.collection.CountDocuments(ctx, filter, countOpts)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count app components: %w", err)
@@ -110,7 +131,7 @@ func (r *AppComponentRepository) List(ctx context.Context, tenantID string, page
 		SetSkip(int64((page - 1) * perPage)).
 		SetLimit(int64(perPage)).
 		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
-		SetHint(bson.D{{Key: "tenantId", Value: 1}}) // Use tenantId index
+		SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "createdAt", Value: -1}}) // Index also covers the sort
 
 	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -127,7 +148,7 @@ func (r *AppComponentRepository) List(ctx context.Context, tenantID string, page
 }
 
 // Update updates an app component
-func (r *AppComponentRepository) Update(ctx context.Context, component *domain.AppComponent) error {
+func (r *mongoAppComponentRepository) Update(ctx context.Context, component *domain.AppComponent) error {
 	component.UpdatedAt = time.Now()
 
 	// Use $set to update only provided fields for better performance
@@ -154,14 +175,14 @@ func (r *AppComponentRepository) Update(ctx context.Context, component *domain.A
 	}
 
 	if result.MatchedCount == 0 {
-		return fmt.Errorf("app component not found")
+		return fmt.Errorf("app component %w", ErrNotFound)
 	}
 
 	return nil
 }
 
 // Delete deletes an app component
-func (r *AppComponentRepository) Delete(ctx context.Context, id string) error {
+func (r *mongoAppComponentRepository) Delete(ctx context.Context, id string) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return fmt.Errorf("invalid app component ID: %w", err)
@@ -177,7 +198,7 @@ func (r *AppComponentRepository) Delete(ctx context.Context, id string) error {
 // FindByIDs finds multiple app components by IDs in a single query (batch operation)
 // Note: Invalid IDs are silently skipped. Only valid ObjectIDs are queried.
 // This allows partial results when some IDs are invalid, which is useful for API resilience.
-func (r *AppComponentRepository) FindByIDs(ctx context.Context, ids []string) ([]*domain.AppComponent, error) {
+func (r *mongoAppComponentRepository) FindByIDs(ctx context.Context, ids []string) ([]*domain.AppComponent, error) {
 	if len(ids) == 0 {
 		return []*domain.AppComponent{}, nil
 	}
@@ -210,3 +231,22 @@ func (r *AppComponentRepository) FindByIDs(ctx context.Context, ids []string) ([
 
 	return components, nil
 }
+
+// ListByCode lists the app components of every tenant with a code, ordered by tenant
+func (r *mongoAppComponentRepository) ListByCode(ctx context.Context, code string) ([]*domain.AppComponent, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "tenantId", Value: 1}}).
+		SetHint(bson.D{{Key: "code", Value: 1}, {Key: "tenantId", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"code": code}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list app components: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	components := []*domain.AppComponent{}
+	if err = cursor.All(ctx, &components); err != nil {
+		return nil, fmt.Errorf("failed to decode app components: %w", err)
+	}
+	return components, nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ConfigOverrideRepository handles runtime config override data access. A tenant has at most one
+// override per key and environment; setting another replaces it.
+type ConfigOverrideRepository interface {
+	Set(ctx context.Context, override *domain.ConfigOverride) error
+	List(ctx context.Context, tenantID, prefix string) ([]*domain.ConfigOverride, error)
+	Delete(ctx context.Context, tenantID, key, environment string) error
+}
+
+// mongoConfigOverrideRepository is the MongoDB implementation of ConfigOverrideRepository
+type mongoConfigOverrideRepository struct {
+	collection *mongo.Collection
+}
+
+// NewConfigOverrideRepository creates a new MongoDB backed config override repository.
+// Expired overrides are removed by a TTL index; readers must still skip those not yet removed.
+func NewConfigOverrideRepository(db *mongo.Database) ConfigOverrideRepository {
+	collection := db.Collection("config_overrides")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "tenantId", Value: 1}, {Key: "configKey", Value: 1}, {Key: "environment", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &mongoConfigOverrideRepository{collection: collection}
+}
+
+// Set creates the override of a key for a tenant and environment, replacing any existing one
+func (r *mongoConfigOverrideRepository) Set(ctx context.Context, override *domain.ConfigOverride) error {
+	override.CreatedAt = time.Now()
+
+	filter := bson.M{"tenantId": override.TenantID, "configKey": override.Key, "environment": override.Environment}
+	replacement := *override
+	replacement.ID = primitive.NilObjectID // keep the _id of a replaced override
+	opts := options.FindOneAndReplace().SetUpsert(true).SetReturnDocument(options.After)
+
+	var stored domain.ConfigOverride
+	if err := r.collection.FindOneAndReplace(ctx, filter, replacement, opts).Decode(&stored); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to set config override: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to set config override: %w", err)
+	}
+
+	override.ID = stored.ID
+	return nil
+}
+
+// List lists the overrides of a tenant in every environment ordered by key and environment,
+// optionally restricted to keys starting with a prefix. Expired overrides may be included.
+func (r *mongoConfigOverrideRepository) List(ctx context.Context, tenantID, prefix string) ([]*domain.ConfigOverride, error) {
+	filter := bson.M{"tenantId": tenantID}
+	if prefix != "" {
+		filter["configKey"] = bson.M{"$regex": "^" + regexp.QuoteMeta(prefix)}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "configKey", Value: 1}, {Key: "environment", Value: 1}}).
+		SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "configKey", Value: 1}, {Key: "environment", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list config overrides: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var overrides []*domain.ConfigOverride
+	if err = cursor.All(ctx, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to decode config overrides: %w", err)
+	}
+	for _, override := range overrides {
+		if override.Value, err = domain.PlainConfigValue(override.Value); err != nil {
+			return nil, fmt.Errorf("failed to decode config override %s: %w", override.Key, err)
+		}
+	}
+
+	return overrides, nil
+}
+
+// Delete deletes the override of a key for a tenant and environment
+func (r *mongoConfigOverrideRepository) Delete(ctx context.Context, tenantID, key, environment string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"tenantId": tenantID, "configKey": key, "environment": environment})
+	if err != nil {
+		return fmt.Errorf("failed to delete config override: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("config override %w", ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ConfigRepository handles config entry data access
+type ConfigRepository interface {
+	Create(ctx context.Context, entry *domain.ConfigEntry) error
+	FindByKey(ctx context.Context, tenantID, key, environment string) (*domain.ConfigEntry, error)
+	List(ctx context.Context, query domain.ConfigQuery, page, perPage int) ([]*domain.ConfigEntry, int64, error)
+	Update(ctx context.Context, entry *domain.ConfigEntry) error
+	Delete(ctx context.Context, id string) error
+}
+
+// mongoConfigRepository is the MongoDB implementation of ConfigRepository
+type mongoConfigRepository struct {
+	collection *mongo.Collection
+}
+
+// NewConfigRepository creates a new MongoDB backed config repository
+func NewConfigRepository(db *mongo.Database) ConfigRepository {
+	collection := db.Collection("configs")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "tenantId", Value: 1}, {Key: "configKey", Value: 1}, {Key: "environment", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "tenantId", Value: 1}, {Key: "environment", Value: 1}, {Key: "configKey", Value: 1}},
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &mongoConfigRepository{collection: collection}
+}
+
+// Create creates a new config entry
+func (r *mongoConfigRepository) Create(ctx context.Context, entry *domain.ConfigEntry) error {
+	entry.CreatedAt = time.Now()
+	entry.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, entry)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to create config: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create config: %w", err)
+	}
+
+	entry.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByKey finds the config entry of a key for a tenant and environment
+func (r *mongoConfigRepository) FindByKey(ctx context.Context, tenantID, key, environment string) (*domain.ConfigEntry, error) {
+	var entry domain.ConfigEntry
+	opts := options.FindOne().SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "configKey", Value: 1}, {Key: "environment", Value: 1}})
+	err := r.collection.FindOne(ctx, bson.M{"tenantId": tenantID, "configKey": key, "environment": environment}, opts).Decode(&entry)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find config: %w", err)
+	}
+	return decodedConfigEntry(&entry)
+}
+
+// List lists the config entries of a tenant ordered by key and environment, optionally restricted
+// to one environment and to keys starting with a prefix
+func (r *mongoConfigRepository) List(ctx context.Context, query domain.ConfigQuery, page, perPage int) ([]*domain.ConfigEntry, int64, error) {
+	filter := bson.M{"tenantId": query.TenantID}
+	hint := bson.D{{Key: "tenantId", Value: 1}, {Key: "configKey", Value: 1}, {Key: "environment", Value: 1}}
+	if query.Environment != "" {
+		filter["environment"] = query.Environment
+		hint = bson.D{{Key: "tenantId", Value: 1}, {Key: "environment", Value: 1}, {Key: "configKey", Value: 1}}
+	}
+	if query.Prefix != "" {
+		filter["configKey"] = bson.M{"$regex": "^" + regexp.QuoteMeta(query.Prefix)}
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter, options.Count().SetHint(hint))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count configs: %w", err)
+	}
+
+	opts := options.Find().
+		SetSkip(int64((page - 1) * perPage)).
+		SetLimit(int64(perPage)).
+		SetSort(bson.D{{Key: "configKey", Value: 1}, {Key: "environment", Value: 1}}).
+		SetHint(hint)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list configs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*domain.ConfigEntry
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode configs: %w", err)
+	}
+	for i, entry := range entries {
+		if entries[i], err = decodedConfigEntry(entry); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return entries, total, nil
+}
+
+// Update updates the description, secrecy, active value and version numbers of a config entry
+func (r *mongoConfigRepository) Update(ctx context.Context, entry *domain.ConfigEntry) error {
+	entry.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"description":   entry.Description,
+			"valueType":     entry.ValueType,
+			"value":         entry.Value,
+			"secret":        entry.Secret,
+			"activeVersion": entry.ActiveVersion,
+			"latestVersion": entry.LatestVersion,
+			"updatedAt":     entry.UpdatedAt,
+			"updatedBy":     entry.UpdatedBy,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": entry.ID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update config: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("config %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// Delete deletes a config entry
+func (r *mongoConfigRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid config ID: %w", err)
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return fmt.Errorf("failed to delete config: %w", err)
+	}
+	return nil
+}
+
+// decodedConfigEntry turns the BSON documents and arrays of a decoded value into maps and slices
+func decodedConfigEntry(entry *domain.ConfigEntry) (*domain.ConfigEntry, error) {
+	value, err := domain.PlainConfigValue(entry.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode config %s: %w", entry.Key, err)
+	}
+	entry.Value = value
+	return entry, nil
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ConfigRuleRepository handles config validation rule data access
+type ConfigRuleRepository interface {
+	Create(ctx context.Context, rule *domain.ConfigValidationRule) error
+	FindByID(ctx context.Context, id string) (*domain.ConfigValidationRule, error)
+	List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.ConfigValidationRule, int64, error)
+	Update(ctx context.Context, rule *domain.ConfigValidationRule) error
+	Delete(ctx context.Context, id string) error
+}
+
+// mongoConfigRuleRepository is the MongoDB implementation of ConfigRuleRepository
+type mongoConfigRuleRepository struct {
+	collection *mongo.Collection
+}
+
+// NewConfigRuleRepository creates a new MongoDB backed config validation rule repository
+func NewConfigRuleRepository(db *mongo.Database) ConfigRuleRepository {
+	collection := db.Collection("config_validation_rules")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "tenantId", Value: 1}, {Key: "keyPattern", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &mongoConfigRuleRepository{collection: collection}
+}
+
+// Create creates a new rule; a tenant has one rule per key pattern
+func (r *mongoConfigRuleRepository) Create(ctx context.Context, rule *domain.ConfigValidationRule) error {
+	rule.CreatedAt = time.Now()
+	rule.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, rule)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to create config rule: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create config rule: %w", err)
+	}
+
+	rule.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByID finds a rule by ID
+func (r *mongoConfigRuleRepository) FindByID(ctx context.Context, id string) (*domain.ConfigValidationRule, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config rule ID: %w", err)
+	}
+
+	var rule domain.ConfigValidationRule
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&rule)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find config rule: %w", err)
+	}
+	return &rule, nil
+}
+
+// List lists the rules of a tenant ordered by key pattern
+func (r *mongoConfigRuleRepository) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.ConfigValidationRule, int64, error) {
+	filter := bson.M{"tenantId": tenantID}
+	hint := bson.D{{Key: "tenantId", Value: 1}, {Key: "keyPattern", Value: 1}}
+
+	total, err := r.collection.CountDocuments(ctx, filter, options.Count().SetHint(hint))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count config rules: %w", err)
+	}
+
+	opts := options.Find().
+		SetSkip(int64((page - 1) * perPage)).
+		SetLimit(int64(perPage)).
+		SetSort(bson.D{{Key: "keyPattern", Value: 1}}).
+		SetHint(hint)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list config rules: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rules []*domain.ConfigValidationRule
+	if err = cursor.All(ctx, &rules); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode config rules: %w", err)
+	}
+
+	return rules, total, nil
+}
+
+// Update updates the constraints of a rule
+func (r *mongoConfigRuleRepository) Update(ctx context.Context, rule *domain.ConfigValidationRule) error {
+	rule.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"keyPattern":    rule.KeyPattern,
+			"description":   rule.Description,
+			"type":          rule.Type,
+			"required":      rule.Required,
+			"min":           rule.Min,
+			"max":           rule.Max,
+			"pattern":       rule.Pattern,
+			"allowedValues": rule.AllowedValues,
+			"updatedAt":     rule.UpdatedAt,
+			"updatedBy":     rule.UpdatedBy,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": rule.ID}, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to update config rule: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to update config rule: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("config rule %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// Delete deletes a rule
+func (r *mongoConfigRuleRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid config rule ID: %w", err)
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return fmt.Errorf("failed to delete config rule: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ConfigSchemaRepository handles config schema data access
+type ConfigSchemaRepository interface {
+	Create(ctx context.Context, schema *domain.ConfigSchema) error
+	FindByID(ctx context.Context, id string) (*domain.ConfigSchema, error)
+	FindBySubject(ctx context.Context, target, code string) (*domain.ConfigSchema, error)
+	List(ctx context.Context, target string, page, perPage int) ([]*domain.ConfigSchema, int64, error)
+	Update(ctx context.Context, schema *domain.ConfigSchema, version int) error
+	Delete(ctx context.Context, id string) error
+}
+
+// mongoConfigSchemaRepository is the MongoDB implementation of ConfigSchemaRepository
+type mongoConfigSchemaRepository struct {
+	collection *mongo.Collection
+}
+
+// NewConfigSchemaRepository creates a new MongoDB backed config schema repository
+func NewConfigSchemaRepository(db *mongo.Database) ConfigSchemaRepository {
+	collection := db.Collection("config_schemas")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "target", Value: 1}, {Key: "code", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &mongoConfigSchemaRepository{collection: collection}
+}
+
+// Create creates a new schema; a target has one schema per code
+func (r *mongoConfigSchemaRepository) Create(ctx context.Context, schema *domain.ConfigSchema) error {
+	schema.CreatedAt = time.Now()
+	schema.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, schema)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to create config schema: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create config schema: %w", err)
+	}
+
+	schema.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByID finds a schema by ID
+func (r *mongoConfigSchemaRepository) FindByID(ctx context.Context, id string) (*domain.ConfigSchema, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config schema ID: %w", err)
+	}
+
+	return r.findOne(ctx, bson.M{"_id": objectID})
+}
+
+// FindBySubject finds the schema of a target and code
+func (r *mongoConfigSchemaRepository) FindBySubject(ctx context.Context, target, code string) (*domain.ConfigSchema, error) {
+	return r.findOne(ctx, bson.M{"target": target, "code": code})
+}
+
+func (r *mongoConfigSchemaRepository) findOne(ctx context.Context, filter bson.M) (*domain.ConfigSchema, error) {
+	var schema domain.ConfigSchema
+	err := r.collection.FindOne(ctx, filter).Decode(&schema)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find config schema: %w", err)
+	}
+	return &schema, nil
+}
+
+// List lists the schemas of a target, or of every target when target is empty, ordered by
+// target and code
+func (r *mongoConfigSchemaRepository) List(ctx context.Context, target string, page, perPage int) ([]*domain.ConfigSchema, int64, error) {
+	filter := bson.M{}
+	if target != "" {
+		filter["target"] = target
+	}
+	hint := bson.D{{Key: "target", Value: 1}, {Key: "code", Value: 1}}
+
+	total, err := r.collection.CountDocuments(ctx, filter, options.Count().SetHint(hint))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count config schemas: %w", err)
+	}
+
+	opts := options.Find().
+		SetSkip(int64((page - 1) * perPage)).
+		SetLimit(int64(perPage)).
+		SetSort(hint).
+		SetHint(hint)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list config schemas: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var schemas []*domain.ConfigSchema
+	if err = cursor.All(ctx, &schemas); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode config schemas: %w", err)
+	}
+
+	return schemas, total, nil
+}
+
+// Update updates the document of a schema when its stored version is still version, the one the
+// update was read at; its target and code are immutable
+func (r *mongoConfigSchemaRepository) Update(ctx context.Context, schema *domain.ConfigSchema, version int) error {
+	schema.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"description": schema.Description,
+			"schema":      schema.Schema,
+			"version":     schema.Version,
+			"updatedAt":   schema.UpdatedAt,
+			"updatedBy":   schema.UpdatedBy,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": schema.ID, "version": version}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update config schema: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		count, err := r.collection.CountDocuments(ctx, bson.M{"_id": schema.ID})
+		if err != nil {
+			return fmt.Errorf("failed to update config schema: %w", err)
+		}
+		if count == 0 {
+			return fmt.Errorf("config schema %w", ErrNotFound)
+		}
+		return fmt.Errorf("config schema %w", ErrVersionConflict)
+	}
+
+	return nil
+}
+
+// Delete deletes a schema
+func (r *mongoConfigSchemaRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid config schema ID: %w", err)
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return fmt.Errorf("failed to delete config schema: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ConfigVersionRepository handles config version data access. Versions are immutable: they are
+// only ever created, and deleted along with their config entry.
+type ConfigVersionRepository interface {
+	Create(ctx context.Context, version *domain.ConfigVersion) error
+	Find(ctx context.Context, configID string, version int) (*domain.ConfigVersion, error)
+	List(ctx context.Context, configID string, page, perPage int) ([]*domain.ConfigVersion, int64, error)
+	DeleteByConfig(ctx context.Context, configID string) error
+}
+
+// mongoConfigVersionRepository is the MongoDB implementation of ConfigVersionRepository
+type mongoConfigVersionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewConfigVersionRepository creates a new MongoDB backed config version repository
+func NewConfigVersionRepository(db *mongo.Database) ConfigVersionRepository {
+	collection := db.Collection("config_versions")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "configId", Value: 1}, {Key: "versionNumber", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "tenantId", Value: 1}, {Key: "createdAt", Value: -1}},
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &mongoConfigVersionRepository{collection: collection}
+}
+
+// Create creates a new config version; a version number already taken is a duplicate key
+func (r *mongoConfigVersionRepository) Create(ctx context.Context, version *domain.ConfigVersion) error {
+	version.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, version)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to create config version: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create config version: %w", err)
+	}
+
+	version.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// Find finds a version of a config entry by number
+func (r *mongoConfigVersionRepository) Find(ctx context.Context, configID string, number int) (*domain.ConfigVersion, error) {
+	objectID, err := primitive.ObjectIDFromHex(configID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config ID: %w", err)
+	}
+
+	var version domain.ConfigVersion
+	opts := options.FindOne().SetHint(bson.D{{Key: "configId", Value: 1}, {Key: "versionNumber", Value: 1}})
+	err = r.collection.FindOne(ctx, bson.M{"configId": objectID, "versionNumber": number}, opts).Decode(&version)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find config version: %w", err)
+	}
+	return decodedConfigVersion(&version)
+}
+
+// List lists the versions of a config entry, newest first
+func (r *mongoConfigVersionRepository) List(ctx context.Context, configID string, page, perPage int) ([]*domain.ConfigVersion, int64, error) {
+	objectID, err := primitive.ObjectIDFromHex(configID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid config ID: %w", err)
+	}
+	filter := bson.M{"configId": objectID}
+	hint := bson.D{{Key: "configId", Value: 1}, {Key: "versionNumber", Value: 1}}
+
+	total, err := r.collection.CountDocuments(ctx, filter, options.Count().SetHint(hint))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count config versions: %w", err)
+	}
+
+	opts := options.Find().
+		SetSkip(int64((page - 1) * perPage)).
+		SetLimit(int64(perPage)).
+		SetSort(bson.D{{Key: "versionNumber", Value: -1}}).
+		SetHint(hint) // Index also covers the sort, walked backwards
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list config versions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var versions []*domain.ConfigVersion
+	if err = cursor.All(ctx, &versions); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode config versions: %w", err)
+	}
+	for i, version := range versions {
+		if versions[i], err = decodedConfigVersion(version); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return versions, total, nil
+}
+
+// DeleteByConfig deletes every version of a config entry
+func (r *mongoConfigVersionRepository) DeleteByConfig(ctx context.Context, configID string) error {
+	objectID, err := primitive.ObjectIDFromHex(configID)
+	if err != nil {
+		return fmt.Errorf("invalid config ID: %w", err)
+	}
+
+	_, err = r.collection.DeleteMany(ctx, bson.M{"configId": objectID})
+	if err != nil {
+		return fmt.Errorf("failed to delete config versions: %w", err)
+	}
+	return nil
+}
+
+// decodedConfigVersion turns the BSON documents and arrays of a decoded value into maps and slices
+func decodedConfigVersion(version *domain.ConfigVersion) (*domain.ConfigVersion, error) {
+	value, err := domain.PlainConfigValue(version.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode config %s version %d: %w", version.Key, version.Version, err)
+	}
+	version.Value = value
+	return version, nil
+}
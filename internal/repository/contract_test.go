@@ -0,0 +1,1867 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The contract suites below describe the behavior every repository backend must honor.
+// They run against the in-memory implementations in memory_test.go and against MongoDB
+// in mongo_integration_test.go (go test -tags=integration).
+
+func testAppComponentRepositoryContract(t *testing.T, newRepo func(t *testing.T) AppComponentRepository) {
+	ctx := context.Background()
+
+	t.Run("Create and find", func(t *testing.T) {
+		repo := newRepo(t)
+		component := &domain.AppComponent{TenantID: "tenant-1", Code: "dashboard", Name: "Dashboard", Status: "active"}
+		require.NoError(t, repo.Create(ctx, component))
+		assert.False(t, component.ID.IsZero())
+		assert.False(t, component.CreatedAt.IsZero())
+
+		byID, err := repo.FindByID(ctx, component.ID.Hex())
+		require.NoError(t, err)
+		require.NotNil(t, byID)
+		assert.Equal(t, "dashboard", byID.Code)
+
+		byCode, err := repo.FindByCode(ctx, "tenant-1", "dashboard")
+		require.NoError(t, err)
+		require.NotNil(t, byCode)
+		assert.Equal(t, component.ID, byCode.ID)
+	})
+
+	t.Run("Missing documents return nil", func(t *testing.T) {
+		repo := newRepo(t)
+		component, err := repo.FindByID(ctx, primitive.NewObjectID().Hex())
+		assert.NoError(t, err)
+		assert.Nil(t, component)
+
+		component, err = repo.FindByCode(ctx, "tenant-1", "missing")
+		assert.NoError(t, err)
+		assert.Nil(t, component)
+
+		_, err = repo.FindByID(ctx, "invalid")
+		assert.Error(t, err)
+	})
+
+	t.Run("Unique tenant and code", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Create(ctx, &domain.AppComponent{TenantID: "tenant-1", Code: "dashboard"}))
+
+		err := repo.Create(ctx, &domain.AppComponent{TenantID: "tenant-1", Code: "dashboard"})
+		assert.True(t, errors.Is(err, ErrDuplicateKey))
+
+		assert.NoError(t, repo.Create(ctx, &domain.AppComponent{TenantID: "tenant-2", Code: "dashboard"}))
+	})
+
+	t.Run("List sorts by createdAt desc and paginates", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, code := range []string{"a", "b", "c"} {
+			require.NoError(t, repo.Create(ctx, &domain.AppComponent{TenantID: "tenant-1", Code: code}))
+			time.Sleep(5 * time.Millisecond) // MongoDB stores millisecond precision
+		}
+		require.NoError(t, repo.Create(ctx, &domain.AppComponent{TenantID: "tenant-2", Code: "other"}))
+
+		components, total, err := repo.List(ctx, "tenant-1", 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, components, 2)
+		assert.Equal(t, "c", components[0].Code)
+		assert.Equal(t, "b", components[1].Code)
+
+		components, total, err = repo.List(ctx, "tenant-1", 2, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, components, 1)
+		assert.Equal(t, "a", components[0].Code)
+	})
+
+	t.Run("ListByCode spans tenants", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Create(ctx, &domain.AppComponent{TenantID: "tenant-2", Code: "dashboard"}))
+		require.NoError(t, repo.Create(ctx, &domain.AppComponent{TenantID: "tenant-1", Code: "dashboard"}))
+		require.NoError(t, repo.Create(ctx, &domain.AppComponent{TenantID: "tenant-1", Code: "reports"}))
+
+		components, err := repo.ListByCode(ctx, "dashboard")
+		require.NoError(t, err)
+		require.Len(t, components, 2)
+		assert.Equal(t, "tenant-1", components[0].TenantID)
+		assert.Equal(t, "tenant-2", components[1].TenantID)
+
+		components, err = repo.ListByCode(ctx, "missing")
+		require.NoError(t, err)
+		assert.Empty(t, components)
+	})
+
+	t.Run("Update only touches mutable fields", func(t *testing.T) {
+		repo := newRepo(t)
+		component := &domain.AppComponent{TenantID: "tenant-1", Code: "dashboard", Name: "Dashboard"}
+		require.NoError(t, repo.Create(ctx, component))
+
+		require.NoError(t, repo.Update(ctx, &domain.AppComponent{
+			ID:       component.ID,
+			TenantID: "tenant-2",
+			Code:     "changed",
+			Name:     "Renamed",
+			Config:   map[string]interface{}{"theme": "dark"},
+		}))
+
+		updated, err := repo.FindByID(ctx, component.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, "Renamed", updated.Name)
+		assert.Equal(t, "dark", updated.Config["theme"])
+		assert.Equal(t, "tenant-1", updated.TenantID)
+		assert.Equal(t, "dashboard", updated.Code)
+
+		err = repo.Update(ctx, &domain.AppComponent{ID: primitive.NewObjectID()})
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		component := &domain.AppComponent{TenantID: "tenant-1", Code: "dashboard"}
+		require.NoError(t, repo.Create(ctx, component))
+
+		require.NoError(t, repo.Delete(ctx, component.ID.Hex()))
+		found, err := repo.FindByID(ctx, component.ID.Hex())
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		assert.NoError(t, repo.Delete(ctx, component.ID.Hex()))
+		assert.Error(t, repo.Delete(ctx, "invalid"))
+	})
+
+	t.Run("FindByIDs skips invalid IDs", func(t *testing.T) {
+		repo := newRepo(t)
+		first := &domain.AppComponent{TenantID: "tenant-1", Code: "a"}
+		second := &domain.AppComponent{TenantID: "tenant-1", Code: "b"}
+		require.NoError(t, repo.Create(ctx, first))
+		require.NoError(t, repo.Create(ctx, second))
+
+		components, err := repo.FindByIDs(ctx, []string{first.ID.Hex(), "invalid", second.ID.Hex(), primitive.NewObjectID().Hex()})
+		require.NoError(t, err)
+		codes := make([]string, 0, len(components))
+		for _, component := range components {
+			codes = append(codes, component.Code)
+		}
+		assert.ElementsMatch(t, []string{"a", "b"}, codes)
+
+		components, err = repo.FindByIDs(ctx, []string{"invalid"})
+		assert.NoError(t, err)
+		assert.Empty(t, components)
+
+		components, err = repo.FindByIDs(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, components)
+	})
+}
+
+func testCountryRepositoryContract(t *testing.T, newRepo func(t *testing.T) CountryRepository) {
+	ctx := context.Background()
+
+	newCountry := func(code, status string) *domain.Country {
+		return &domain.Country{Code: code, Name: map[string]string{"en": code}, Status: status}
+	}
+
+	t.Run("Create and find", func(t *testing.T) {
+		repo := newRepo(t)
+		country := newCountry("VN", "active")
+		require.NoError(t, repo.Create(ctx, country))
+		assert.False(t, country.ID.IsZero())
+
+		found, err := repo.FindByCode(ctx, "VN")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, country.ID, found.ID)
+
+		found, err = repo.FindByCode(ctx, "XX")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("Unique code", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Create(ctx, newCountry("VN", "active")))
+		err := repo.Create(ctx, newCountry("VN", "active"))
+		assert.True(t, errors.Is(err, ErrDuplicateKey))
+	})
+
+	t.Run("List returns active countries by code", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, code := range []string{"VN", "GB", "US"} {
+			require.NoError(t, repo.Create(ctx, newCountry(code, "active")))
+		}
+		require.NoError(t, repo.Create(ctx, newCountry("AA", "inactive")))
+
+		countries, total, err := repo.List(ctx, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, countries, 2)
+		assert.Equal(t, "GB", countries[0].Code)
+		assert.Equal(t, "US", countries[1].Code)
+
+		countries, _, err = repo.List(ctx, 2, 2)
+		require.NoError(t, err)
+		require.Len(t, countries, 1)
+		assert.Equal(t, "VN", countries[0].Code)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepo(t)
+		country := newCountry("VN", "active")
+		require.NoError(t, repo.Create(ctx, country))
+
+		country.PhoneCode = "+84"
+		country.Name = map[string]string{"en": "Vietnam", "vi": "Việt Nam"}
+		require.NoError(t, repo.Update(ctx, country))
+
+		found, err := repo.FindByCode(ctx, "VN")
+		require.NoError(t, err)
+		assert.Equal(t, "+84", found.PhoneCode)
+		assert.Equal(t, "Việt Nam", found.Name["vi"])
+
+		err = repo.Update(ctx, &domain.Country{ID: primitive.NewObjectID()})
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Create(ctx, newCountry("VN", "active")))
+		require.NoError(t, repo.Delete(ctx, "VN"))
+
+		found, err := repo.FindByCode(ctx, "VN")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+		assert.NoError(t, repo.Delete(ctx, "VN"))
+	})
+
+	t.Run("FindByCodes", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, code := range []string{"VN", "GB", "US"} {
+			require.NoError(t, repo.Create(ctx, newCountry(code, "active")))
+		}
+
+		countries, err := repo.FindByCodes(ctx, []string{"VN", "US", "XX"})
+		require.NoError(t, err)
+		codes := make([]string, 0, len(countries))
+		for _, country := range countries {
+			codes = append(codes, country.Code)
+		}
+		assert.ElementsMatch(t, []string{"VN", "US"}, codes)
+
+		countries, err = repo.FindByCodes(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, countries)
+	})
+
+	t.Run("CountByCurrency", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, code := range []string{"DE", "FR", "US"} {
+			country := newCountry(code, "active")
+			country.Currency = "EUR"
+			if code == "US" {
+				country.Currency, country.Status = "USD", "inactive"
+			}
+			require.NoError(t, repo.Create(ctx, country))
+		}
+
+		count, err := repo.CountByCurrency(ctx, "EUR")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+		count, err = repo.CountByCurrency(ctx, "USD")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
+
+func testCurrencyRepositoryContract(t *testing.T, newRepo func(t *testing.T) CurrencyRepository) {
+	ctx := context.Background()
+
+	newCurrency := func(code, status string) *domain.Currency {
+		return &domain.Currency{Code: code, Name: map[string]string{"en": code}, DecimalDigits: 2, Status: status}
+	}
+
+	t.Run("Create and find", func(t *testing.T) {
+		repo := newRepo(t)
+		currency := newCurrency("VND", "active")
+		currency.Countries = []string{"VN"}
+		require.NoError(t, repo.Create(ctx, currency))
+		assert.False(t, currency.ID.IsZero())
+
+		found, err := repo.FindByCode(ctx, "VND")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, currency.ID, found.ID)
+		assert.Equal(t, []string{"VN"}, found.Countries)
+
+		found, err = repo.FindByCode(ctx, "XXX")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		err = repo.Create(ctx, newCurrency("VND", "active"))
+		assert.True(t, errors.Is(err, ErrDuplicateKey))
+	})
+
+	t.Run("List returns active currencies by code", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, code := range []string{"VND", "EUR", "USD"} {
+			require.NoError(t, repo.Create(ctx, newCurrency(code, "active")))
+		}
+		require.NoError(t, repo.Create(ctx, newCurrency("DEM", "inactive")))
+
+		currencies, total, err := repo.List(ctx, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, currencies, 2)
+		assert.Equal(t, "EUR", currencies[0].Code)
+		assert.Equal(t, "USD", currencies[1].Code)
+	})
+
+	t.Run("Update and delete", func(t *testing.T) {
+		repo := newRepo(t)
+		currency := newCurrency("EUR", "active")
+		require.NoError(t, repo.Create(ctx, currency))
+
+		currency.Symbol = "€"
+		currency.Countries = []string{"DE", "FR"}
+		require.NoError(t, repo.Update(ctx, currency))
+
+		found, err := repo.FindByCode(ctx, "EUR")
+		require.NoError(t, err)
+		assert.Equal(t, "€", found.Symbol)
+		assert.Equal(t, []string{"DE", "FR"}, found.Countries)
+
+		err = repo.Update(ctx, &domain.Currency{ID: primitive.NewObjectID()})
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		require.NoError(t, repo.Delete(ctx, "EUR"))
+		found, err = repo.FindByCode(ctx, "EUR")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+}
+
+func testExchangeRateRepositoryContract(t *testing.T, newRepo func(t *testing.T) ExchangeRateRepository) {
+	ctx := context.Background()
+
+	day := func(d int) time.Time { return time.Date(2025, time.January, d, 0, 0, 0, 0, time.UTC) }
+	newRate := func(base, quote string, d int, rate float64) *domain.ExchangeRate {
+		return &domain.ExchangeRate{Base: base, Quote: quote, Date: day(d), Rate: rate, Source: "manual"}
+	}
+
+	t.Run("Upsert one rate per pair and day", func(t *testing.T) {
+		repo := newRepo(t)
+		rate := newRate("USD", "VND", 2, 25000)
+		created, err := repo.Upsert(ctx, rate)
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.False(t, rate.ID.IsZero())
+
+		replacement := newRate("USD", "VND", 2, 25100)
+		replacement.Source = "vcb"
+		created, err = repo.Upsert(ctx, replacement)
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, rate.ID, replacement.ID)
+
+		found, err := repo.FindByID(ctx, rate.ID.Hex())
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, 25100.0, found.Rate)
+		assert.Equal(t, "vcb", found.Source)
+		assert.True(t, found.Date.Equal(day(2)))
+
+		found, err = repo.FindByID(ctx, primitive.NewObjectID().Hex())
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("FindLatest falls back to earlier days", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, rate := range []*domain.ExchangeRate{
+			newRate("USD", "VND", 2, 25000), newRate("USD", "VND", 5, 25200), newRate("EUR", "VND", 4, 27000),
+		} {
+			_, err := repo.Upsert(ctx, rate)
+			require.NoError(t, err)
+		}
+
+		found, err := repo.FindLatest(ctx, "USD", "VND", day(4))
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, 25000.0, found.Rate)
+
+		found, err = repo.FindLatest(ctx, "USD", "VND", day(5))
+		require.NoError(t, err)
+		assert.Equal(t, 25200.0, found.Rate)
+
+		found, err = repo.FindLatest(ctx, "USD", "VND", day(1))
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("List and delete", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, rate := range []*domain.ExchangeRate{
+			newRate("USD", "VND", 2, 25000), newRate("USD", "VND", 5, 25200), newRate("EUR", "VND", 5, 27000),
+		} {
+			_, err := repo.Upsert(ctx, rate)
+			require.NoError(t, err)
+		}
+
+		rates, total, err := repo.List(ctx, domain.ExchangeRateFilter{}, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, rates, 2)
+		assert.Equal(t, "EUR", rates[0].Base)
+		assert.Equal(t, "USD", rates[1].Base)
+
+		from := day(3)
+		rates, total, err = repo.List(ctx, domain.ExchangeRateFilter{Base: "USD", Quote: "VND", From: &from}, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, rates, 1)
+		assert.Equal(t, 25200.0, rates[0].Rate)
+
+		require.NoError(t, repo.Delete(ctx, rates[0].ID.Hex()))
+		found, err := repo.FindLatest(ctx, "USD", "VND", day(9))
+		require.NoError(t, err)
+		assert.Equal(t, 25000.0, found.Rate)
+	})
+}
+
+func testEthnicityRepositoryContract(t *testing.T, newRepo func(t *testing.T) EthnicityRepository) {
+	ctx := context.Background()
+
+	newEthnicity := func(country, code, status string) *domain.Ethnicity {
+		return &domain.Ethnicity{CountryCode: country, Code: code, Name: map[string]string{"en": code}, Status: status}
+	}
+
+	t.Run("Create and find", func(t *testing.T) {
+		repo := newRepo(t)
+		ethnicity := newEthnicity("VN", "01", "active")
+		require.NoError(t, repo.Create(ctx, ethnicity))
+		assert.False(t, ethnicity.ID.IsZero())
+
+		found, err := repo.FindByID(ctx, ethnicity.ID.Hex())
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, "01", found.Code)
+
+		found, err = repo.FindByCode(ctx, "VN", "01")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, ethnicity.ID, found.ID)
+
+		found, err = repo.FindByCode(ctx, "LA", "01")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+		found, err = repo.FindByID(ctx, primitive.NewObjectID().Hex())
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		// Codes are unique within a country only
+		err = repo.Create(ctx, newEthnicity("VN", "01", "active"))
+		assert.True(t, errors.Is(err, ErrDuplicateKey))
+		assert.NoError(t, repo.Create(ctx, newEthnicity("LA", "01", "active")))
+	})
+
+	t.Run("List by country", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, code := range []string{"03", "01", "02"} {
+			require.NoError(t, repo.Create(ctx, newEthnicity("VN", code, "active")))
+		}
+		require.NoError(t, repo.Create(ctx, newEthnicity("VN", "04", "inactive")))
+		require.NoError(t, repo.Create(ctx, newEthnicity("LA", "01", "active")))
+
+		ethnicities, total, err := repo.List(ctx, "VN", 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, ethnicities, 2)
+		assert.Equal(t, "01", ethnicities[0].Code)
+		assert.Equal(t, "02", ethnicities[1].Code)
+
+		ethnicities, total, err = repo.List(ctx, "", 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), total)
+		assert.Equal(t, "LA", ethnicities[0].CountryCode)
+
+		count, err := repo.CountByCountry(ctx, "VN")
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+	})
+
+	t.Run("Update and delete", func(t *testing.T) {
+		repo := newRepo(t)
+		ethnicity := newEthnicity("VN", "01", "active")
+		require.NoError(t, repo.Create(ctx, ethnicity))
+
+		ethnicity.Name = map[string]string{"en": "Kinh", "vi": "Kinh"}
+		ethnicity.Status = "inactive"
+		require.NoError(t, repo.Update(ctx, ethnicity))
+
+		found, err := repo.FindByID(ctx, ethnicity.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, "Kinh", found.Name["vi"])
+		assert.Equal(t, "inactive", found.Status)
+
+		err = repo.Update(ctx, &domain.Ethnicity{ID: primitive.NewObjectID()})
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		require.NoError(t, repo.Delete(ctx, ethnicity.ID.Hex()))
+		found, err = repo.FindByID(ctx, ethnicity.ID.Hex())
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+}
+
+func testConfigRepositoryContract(t *testing.T, newRepo func(t *testing.T) ConfigRepository) {
+	ctx := context.Background()
+
+	newEntry := func(tenantID, key, environment string, value interface{}) *domain.ConfigEntry {
+		return &domain.ConfigEntry{TenantID: tenantID, Key: key, Environment: environment, ValueType: domain.ConfigTypeJSON, Value: value, ActiveVersion: 1, LatestVersion: 1}
+	}
+
+	t.Run("Create and find", func(t *testing.T) {
+		repo := newRepo(t)
+		entry := newEntry("tenant-1", "db.pool", "production", map[string]interface{}{"size": 10.0, "hosts": []interface{}{"a", "b"}})
+		require.NoError(t, repo.Create(ctx, entry))
+		assert.False(t, entry.ID.IsZero())
+
+		found, err := repo.FindByKey(ctx, "tenant-1", "db.pool", "production")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, entry.ID, found.ID)
+		assert.Equal(t, map[string]interface{}{"size": 10.0, "hosts": []interface{}{"a", "b"}}, found.Value)
+
+		found, err = repo.FindByKey(ctx, "tenant-1", "db.pool", "")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		// Keys are unique per tenant and environment
+		err = repo.Create(ctx, newEntry("tenant-1", "db.pool", "production", "x"))
+		assert.True(t, errors.Is(err, ErrDuplicateKey))
+		assert.NoError(t, repo.Create(ctx, newEntry("tenant-1", "db.pool", "", "x")))
+		assert.NoError(t, repo.Create(ctx, newEntry("", "db.pool", "production", "x")))
+	})
+
+	t.Run("List", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, key := range []string{"db.timeout", "api.rate_limit", "db.pool", "dbx.size"} {
+			require.NoError(t, repo.Create(ctx, newEntry("tenant-1", key, "production", "x")))
+		}
+		require.NoError(t, repo.Create(ctx, newEntry("tenant-1", "db.pool", "staging", "x")))
+		require.NoError(t, repo.Create(ctx, newEntry("tenant-2", "db.pool", "production", "x")))
+
+		entries, total, err := repo.List(ctx, domain.ConfigQuery{TenantID: "tenant-1"}, 1, 3)
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), total)
+		require.Len(t, entries, 3)
+		assert.Equal(t, "api.rate_limit", entries[0].Key)
+		assert.Equal(t, "production", entries[1].Environment)
+		assert.Equal(t, "staging", entries[2].Environment)
+
+		entries, total, err = repo.List(ctx, domain.ConfigQuery{TenantID: "tenant-1", Environment: "production", Prefix: "db."}, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, "db.pool", entries[0].Key)
+		assert.Equal(t, "db.timeout", entries[1].Key)
+	})
+
+	t.Run("Update and delete", func(t *testing.T) {
+		repo := newRepo(t)
+		entry := newEntry("tenant-1", "db.timeout", "production", "5s")
+		require.NoError(t, repo.Create(ctx, entry))
+
+		entry.ValueType = domain.ConfigTypeDuration
+		entry.Value = "30s"
+		entry.ActiveVersion, entry.LatestVersion = 2, 3
+		entry.Secret = true
+		require.NoError(t, repo.Update(ctx, entry))
+
+		found, err := repo.FindByKey(ctx, "tenant-1", "db.timeout", "production")
+		require.NoError(t, err)
+		assert.Equal(t, "30s", found.Value)
+		assert.True(t, found.Secret)
+		assert.Equal(t, 2, found.ActiveVersion)
+		assert.Equal(t, 3, found.LatestVersion)
+
+		err = repo.Update(ctx, &domain.ConfigEntry{ID: primitive.NewObjectID()})
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		require.NoError(t, repo.Delete(ctx, entry.ID.Hex()))
+		found, err = repo.FindByKey(ctx, "tenant-1", "db.timeout", "production")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+}
+
+func testConfigVersionRepositoryContract(t *testing.T, newRepo func(t *testing.T) ConfigVersionRepository) {
+	ctx := context.Background()
+
+	newVersion := func(configID primitive.ObjectID, number int, value interface{}) *domain.ConfigVersion {
+		return &domain.ConfigVersion{ConfigID: configID, TenantID: "tenant-1", Key: "db.pool", Version: number, ValueType: domain.ConfigTypeJSON, Value: value}
+	}
+
+	t.Run("Create, find and list", func(t *testing.T) {
+		repo := newRepo(t)
+		configID, other := primitive.NewObjectID(), primitive.NewObjectID()
+		for number := 1; number <= 3; number++ {
+			require.NoError(t, repo.Create(ctx, newVersion(configID, number, map[string]interface{}{"size": float64(number)})))
+		}
+		require.NoError(t, repo.Create(ctx, newVersion(other, 1, "x")))
+
+		// Version numbers are unique per config
+		err := repo.Create(ctx, newVersion(configID, 2, "x"))
+		assert.True(t, errors.Is(err, ErrDuplicateKey))
+
+		found, err := repo.Find(ctx, configID.Hex(), 2)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, map[string]interface{}{"size": 2.0}, found.Value)
+		assert.False(t, found.CreatedAt.IsZero())
+
+		found, err = repo.Find(ctx, configID.Hex(), 9)
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		versions, total, err := repo.List(ctx, configID.Hex(), 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, versions, 2)
+		assert.Equal(t, 3, versions[0].Version)
+		assert.Equal(t, 2, versions[1].Version)
+	})
+
+	t.Run("Delete by config", func(t *testing.T) {
+		repo := newRepo(t)
+		configID, other := primitive.NewObjectID(), primitive.NewObjectID()
+		require.NoError(t, repo.Create(ctx, newVersion(configID, 1, "x")))
+		require.NoError(t, repo.Create(ctx, newVersion(other, 1, "x")))
+
+		require.NoError(t, repo.DeleteByConfig(ctx, configID.Hex()))
+		_, total, err := repo.List(ctx, configID.Hex(), 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), total)
+		_, total, err = repo.List(ctx, other.Hex(), 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+	})
+}
+
+func testConfigOverrideRepositoryContract(t *testing.T, newRepo func(t *testing.T) ConfigOverrideRepository) {
+	ctx := context.Background()
+
+	newOverride := func(tenantID, key, environment string, value interface{}) *domain.ConfigOverride {
+		return &domain.ConfigOverride{TenantID: tenantID, Key: key, Environment: environment, ValueType: domain.ConfigTypeJSON, Value: value}
+	}
+
+	t.Run("Set replaces and lists", func(t *testing.T) {
+		repo := newRepo(t)
+		first := newOverride("tenant-1", "db.timeout", "production", "5s")
+		require.NoError(t, repo.Set(ctx, first))
+		assert.False(t, first.ID.IsZero())
+		require.NoError(t, repo.Set(ctx, newOverride("tenant-1", "db.pool", "", map[string]interface{}{"size": 2.0})))
+		require.NoError(t, repo.Set(ctx, newOverride("tenant-1", "api.limit", "production", "x")))
+		require.NoError(t, repo.Set(ctx, newOverride("tenant-2", "db.timeout", "production", "9s")))
+
+		expiresAt := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+		replaced := newOverride("tenant-1", "db.timeout", "production", "1s")
+		replaced.ExpiresAt = &expiresAt
+		require.NoError(t, repo.Set(ctx, replaced))
+		assert.Equal(t, first.ID, replaced.ID)
+
+		overrides, err := repo.List(ctx, "tenant-1", "db.")
+		require.NoError(t, err)
+		require.Len(t, overrides, 2)
+		assert.Equal(t, "db.pool", overrides[0].Key)
+		assert.Equal(t, map[string]interface{}{"size": 2.0}, overrides[0].Value)
+		assert.Equal(t, "1s", overrides[1].Value)
+		require.NotNil(t, overrides[1].ExpiresAt)
+		assert.True(t, expiresAt.Equal(*overrides[1].ExpiresAt))
+
+		overrides, err = repo.List(ctx, "tenant-1", "")
+		require.NoError(t, err)
+		assert.Len(t, overrides, 3)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Set(ctx, newOverride("tenant-1", "db.timeout", "production", "5s")))
+
+		err := repo.Delete(ctx, "tenant-1", "db.timeout", "")
+		assert.True(t, errors.Is(err, ErrNotFound))
+		require.NoError(t, repo.Delete(ctx, "tenant-1", "db.timeout", "production"))
+
+		overrides, err := repo.List(ctx, "tenant-1", "")
+		require.NoError(t, err)
+		assert.Empty(t, overrides)
+	})
+}
+
+func testConfigRuleRepositoryContract(t *testing.T, newRepo func(t *testing.T) ConfigRuleRepository) {
+	ctx := context.Background()
+
+	newRule := func(tenantID, keyPattern string) *domain.ConfigValidationRule {
+		return &domain.ConfigValidationRule{TenantID: tenantID, KeyPattern: keyPattern, Type: domain.ConfigRuleTypeInt, Min: 1.0, Max: 10.0}
+	}
+
+	t.Run("Create, find and list", func(t *testing.T) {
+		repo := newRepo(t)
+		rule := newRule("", "db.pool.size")
+		require.NoError(t, repo.Create(ctx, rule))
+		assert.False(t, rule.ID.IsZero())
+		require.NoError(t, repo.Create(ctx, newRule("", "api.*")))
+		require.NoError(t, repo.Create(ctx, newRule("tenant-1", "db.pool.size")))
+
+		err := repo.Create(ctx, newRule("", "db.pool.size"))
+		assert.True(t, errors.Is(err, ErrDuplicateKey))
+
+		found, err := repo.FindByID(ctx, rule.ID.Hex())
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, 10.0, found.Max)
+
+		found, err = repo.FindByID(ctx, primitive.NewObjectID().Hex())
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		rules, total, err := repo.List(ctx, "", 1, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		require.Len(t, rules, 2)
+		assert.Equal(t, "api.*", rules[0].KeyPattern)
+	})
+
+	t.Run("Update and delete", func(t *testing.T) {
+		repo := newRepo(t)
+		rule := newRule("", "db.pool.size")
+		require.NoError(t, repo.Create(ctx, rule))
+		require.NoError(t, repo.Create(ctx, newRule("", "db.timeout")))
+
+		rule.Type = domain.ConfigRuleTypeString
+		rule.AllowedValues = []string{"small", "large"}
+		rule.Min, rule.Max = nil, nil
+		require.NoError(t, repo.Update(ctx, rule))
+
+		found, err := repo.FindByID(ctx, rule.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, domain.ConfigRuleTypeString, found.Type)
+		assert.Equal(t, []string{"small", "large"}, found.AllowedValues)
+		assert.Nil(t, found.Max)
+
+		rule.KeyPattern = "db.timeout"
+		err = repo.Update(ctx, rule)
+		assert.True(t, errors.Is(err, ErrDuplicateKey))
+		err = repo.Update(ctx, &domain.ConfigValidationRule{ID: primitive.NewObjectID(), KeyPattern: "x"})
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		require.NoError(t, repo.Delete(ctx, rule.ID.Hex()))
+		found, err = repo.FindByID(ctx, rule.ID.Hex())
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+}
+
+func testConfigSchemaRepositoryContract(t *testing.T, newRepo func(t *testing.T) ConfigSchemaRepository) {
+	ctx := context.Background()
+
+	newSchema := func(target, code string) *domain.ConfigSchema {
+		return &domain.ConfigSchema{Target: target, Code: code, Version: 1, Schema: domain.JSONSchema{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type":    "object",
+		}}
+	}
+
+	t.Run("Create, find and list", func(t *testing.T) {
+		repo := newRepo(t)
+		schema := newSchema(domain.SchemaTargetAppComponent, "reports")
+		require.NoError(t, repo.Create(ctx, schema))
+		assert.False(t, schema.ID.IsZero())
+		require.NoError(t, repo.Create(ctx, newSchema(domain.SchemaTargetAppComponent, "dashboard")))
+		require.NoError(t, repo.Create(ctx, newSchema(domain.SchemaTargetPackageLimits, "")))
+
+		err := repo.Create(ctx, newSchema(domain.SchemaTargetAppComponent, "reports"))
+		assert.True(t, errors.Is(err, ErrDuplicateKey))
+
+		found, err := repo.FindByID(ctx, schema.ID.Hex())
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", found.Schema["$schema"])
+
+		found, err = repo.FindBySubject(ctx, domain.SchemaTargetPackageLimits, "")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		found, err = repo.FindBySubject(ctx, domain.SchemaTargetAppComponent, "missing")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		schemas, total, err := repo.List(ctx, domain.SchemaTargetAppComponent, 1, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		require.Len(t, schemas, 2)
+		assert.Equal(t, "dashboard", schemas[0].Code)
+
+		_, total, err = repo.List(ctx, "", 1, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+	})
+
+	t.Run("Update and delete", func(t *testing.T) {
+		repo := newRepo(t)
+		schema := newSchema(domain.SchemaTargetAppComponent, "dashboard")
+		require.NoError(t, repo.Create(ctx, schema))
+
+		require.NoError(t, repo.Update(ctx, &domain.ConfigSchema{
+			ID: schema.ID, Target: domain.SchemaTargetPackageLimits, Code: "changed", Version: 2,
+			Schema: domain.JSONSchema{"type": "object", "required": []interface{}{"theme"}},
+		}, 1))
+
+		found, err := repo.FindByID(ctx, schema.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, 2, found.Version)
+		assert.Equal(t, []interface{}{"theme"}, found.Schema["required"])
+		assert.Equal(t, domain.SchemaTargetAppComponent, found.Target)
+		assert.Equal(t, "dashboard", found.Code)
+
+		// An update read at an earlier version is refused and leaves the schema as it is
+		err = repo.Update(ctx, &domain.ConfigSchema{ID: schema.ID, Version: 2, Schema: domain.JSONSchema{"type": "array"}}, 1)
+		assert.True(t, errors.Is(err, ErrVersionConflict))
+		found, err = repo.FindByID(ctx, schema.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, 2, found.Version)
+		assert.Equal(t, "object", found.Schema["type"])
+
+		err = repo.Update(ctx, &domain.ConfigSchema{ID: primitive.NewObjectID()}, 1)
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		require.NoError(t, repo.Delete(ctx, schema.ID.Hex()))
+		found, err = repo.FindByID(ctx, schema.ID.Hex())
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+}
+
+func testSaaSModuleRepositoryContract(t *testing.T, newRepo func(t *testing.T) SaaSModuleRepository) {
+	ctx := context.Background()
+
+	newModule := func(tenantID, code string, dependencies ...string) *domain.SaaSModule {
+		return &domain.SaaSModule{TenantID: tenantID, Code: code, Name: code, Dependencies: dependencies, Status: "active"}
+	}
+
+	t.Run("Create and find", func(t *testing.T) {
+		repo := newRepo(t)
+		module := newModule("", "core")
+		require.NoError(t, repo.Create(ctx, module))
+		assert.False(t, module.ID.IsZero())
+
+		byID, err := repo.FindByID(ctx, module.ID.Hex())
+		require.NoError(t, err)
+		require.NotNil(t, byID)
+		assert.Equal(t, "core", byID.Code)
+
+		byCode, err := repo.FindByCode(ctx, "", "core")
+		require.NoError(t, err)
+		require.NotNil(t, byCode)
+
+		missing, err := repo.FindByCode(ctx, "tenant-1", "core")
+		assert.NoError(t, err)
+		assert.Nil(t, missing)
+	})
+
+	t.Run("Unique tenant and code", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Create(ctx, newModule("", "core")))
+		err := repo.Create(ctx, newModule("", "core"))
+		assert.True(t, errors.Is(err, ErrDuplicateKey))
+		assert.NoError(t, repo.Create(ctx, newModule("tenant-1", "core")))
+	})
+
+	t.Run("List, ListAll and FindDependents", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Create(ctx, newModule("", "core")))
+		require.NoError(t, repo.Create(ctx, newModule("", "crm", "core")))
+		require.NoError(t, repo.Create(ctx, newModule("", "billing", "core", "crm")))
+		require.NoError(t, repo.Create(ctx, newModule("tenant-1", "crm", "core")))
+
+		modules, total, err := repo.List(ctx, "", 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, modules, 2)
+		assert.Equal(t, "billing", modules[0].Code)
+		assert.Equal(t, "core", modules[1].Code)
+
+		all, err := repo.ListAll(ctx, "")
+		require.NoError(t, err)
+		assert.Len(t, all, 3)
+
+		dependents, err := repo.FindDependents(ctx, "", "core")
+		require.NoError(t, err)
+		require.Len(t, dependents, 2)
+		assert.Equal(t, "billing", dependents[0].Code)
+		assert.Equal(t, "crm", dependents[1].Code)
+	})
+
+	t.Run("Update and delete", func(t *testing.T) {
+		repo := newRepo(t)
+		module := newModule("", "crm")
+		require.NoError(t, repo.Create(ctx, module))
+
+		module.Status = "deprecated"
+		module.Dependencies = []string{"core"}
+		require.NoError(t, repo.Update(ctx, module))
+
+		found, err := repo.FindByID(ctx, module.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, "deprecated", found.Status)
+		assert.Equal(t, []string{"core"}, found.Dependencies)
+
+		err = repo.Update(ctx, &domain.SaaSModule{ID: primitive.NewObjectID()})
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		require.NoError(t, repo.Delete(ctx, module.ID.Hex()))
+		found, err = repo.FindByID(ctx, module.ID.Hex())
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+}
+
+func testServicePackageRepositoryContract(t *testing.T, newRepo func(t *testing.T) ServicePackageRepository) {
+	ctx := context.Background()
+
+	t.Run("Create, find and list", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, code := range []string{"pro", "basic", "enterprise"} {
+			require.NoError(t, repo.Create(ctx, &domain.ServicePackage{Code: code, Name: code, Modules: []string{"core"}}))
+		}
+		require.NoError(t, repo.Create(ctx, &domain.ServicePackage{TenantID: "tenant-1", Code: "pro", Name: "pro"}))
+
+		err := repo.Create(ctx, &domain.ServicePackage{Code: "pro", Name: "pro"})
+		assert.True(t, errors.Is(err, ErrDuplicateKey))
+
+		pkg, err := repo.FindByCode(ctx, "", "pro")
+		require.NoError(t, err)
+		require.NotNil(t, pkg)
+		assert.Equal(t, []string{"core"}, pkg.Modules)
+
+		byID, err := repo.FindByID(ctx, pkg.ID.Hex())
+		require.NoError(t, err)
+		require.NotNil(t, byID)
+		assert.Equal(t, "pro", byID.Code)
+
+		packages, total, err := repo.List(ctx, "", 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, packages, 2)
+		assert.Equal(t, "basic", packages[0].Code)
+		assert.Equal(t, "enterprise", packages[1].Code)
+	})
+
+	t.Run("Update and delete", func(t *testing.T) {
+		repo := newRepo(t)
+		pkg := &domain.ServicePackage{Code: "pro", Name: "Pro"}
+		require.NoError(t, repo.Create(ctx, pkg))
+
+		pkg.Limits = map[string]interface{}{"storage": "10GB"}
+		pkg.Features = []string{"sso"}
+		require.NoError(t, repo.Update(ctx, pkg))
+
+		found, err := repo.FindByID(ctx, pkg.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, "10GB", found.Limits["storage"])
+		assert.Equal(t, []string{"sso"}, found.Features)
+
+		err = repo.Update(ctx, &domain.ServicePackage{ID: primitive.NewObjectID()})
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		require.NoError(t, repo.Delete(ctx, pkg.ID.Hex()))
+		found, err = repo.FindByID(ctx, pkg.ID.Hex())
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("CountByCurrency", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Create(ctx, &domain.ServicePackage{Code: "pro", Name: "pro", Currency: "USD"}))
+		require.NoError(t, repo.Create(ctx, &domain.ServicePackage{TenantID: "tenant-1", Code: "pro", Name: "pro", Currency: "USD"}))
+		require.NoError(t, repo.Create(ctx, &domain.ServicePackage{Code: "basic", Name: "basic", Currency: "VND"}))
+
+		count, err := repo.CountByCurrency(ctx, "USD")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+		count, err = repo.CountByCurrency(ctx, "EUR")
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("FindByModule", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Create(ctx, &domain.ServicePackage{Code: "pro", Name: "pro", Modules: []string{"core", "crm"}}))
+		require.NoError(t, repo.Create(ctx, &domain.ServicePackage{Code: "basic", Name: "basic", Modules: []string{"crm"}}))
+		require.NoError(t, repo.Create(ctx, &domain.ServicePackage{Code: "free", Name: "free", Modules: []string{"core"}}))
+		require.NoError(t, repo.Create(ctx, &domain.ServicePackage{TenantID: "tenant-1", Code: "pro", Name: "pro", Modules: []string{"crm"}}))
+
+		packages, err := repo.FindByModule(ctx, "", "crm")
+		require.NoError(t, err)
+		require.Len(t, packages, 2)
+		assert.Equal(t, "basic", packages[0].Code)
+		assert.Equal(t, "pro", packages[1].Code)
+
+		packages, err = repo.FindByModule(ctx, "", "billing")
+		require.NoError(t, err)
+		assert.Empty(t, packages)
+	})
+
+	t.Run("FindAll spans tenants", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Create(ctx, &domain.ServicePackage{TenantID: "tenant-1", Code: "basic", Name: "basic"}))
+		require.NoError(t, repo.Create(ctx, &domain.ServicePackage{Code: "pro", Name: "pro"}))
+		require.NoError(t, repo.Create(ctx, &domain.ServicePackage{Code: "basic", Name: "basic"}))
+
+		packages, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, packages, 3)
+		assert.Equal(t, []string{"/basic", "/pro", "tenant-1/basic"}, []string{
+			packages[0].TenantID + "/" + packages[0].Code,
+			packages[1].TenantID + "/" + packages[1].Code,
+			packages[2].TenantID + "/" + packages[2].Code,
+		})
+	})
+}
+
+func testAdminMenuRepositoryContract(t *testing.T, newRepo func(t *testing.T) AdminMenuRepository) {
+	ctx := context.Background()
+
+	t.Run("Create and query", func(t *testing.T) {
+		repo := newRepo(t)
+		root := &domain.AdminMenu{Code: "settings", Name: "Settings", ModuleCode: "core", Order: 2}
+		require.NoError(t, repo.Create(ctx, root))
+		require.NoError(t, repo.Create(ctx, &domain.AdminMenu{Code: "dashboard", Name: "Dashboard", ModuleCode: "core", Order: 1}))
+		require.NoError(t, repo.Create(ctx, &domain.AdminMenu{Code: "users", Name: "Users", ModuleCode: "users", ParentID: root.ID.Hex(), Order: 2}))
+		require.NoError(t, repo.Create(ctx, &domain.AdminMenu{Code: "roles", Name: "Roles", ModuleCode: "users", ParentID: root.ID.Hex(), Order: 1}))
+		require.NoError(t, repo.Create(ctx, &domain.AdminMenu{TenantID: "tenant-1", Code: "dashboard", Name: "Dashboard"}))
+
+		err := repo.Create(ctx, &domain.AdminMenu{Code: "settings", Name: "Settings"})
+		assert.True(t, errors.Is(err, ErrDuplicateKey))
+
+		byCode, err := repo.FindByCode(ctx, "", "settings")
+		require.NoError(t, err)
+		require.NotNil(t, byCode)
+		assert.Equal(t, root.ID, byCode.ID)
+
+		all, err := repo.ListAll(ctx, "")
+		require.NoError(t, err)
+		assert.Len(t, all, 4)
+
+		menus, total, err := repo.List(ctx, "", 1, 3)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), total)
+		assert.Len(t, menus, 3)
+
+		children, err := repo.FindChildren(ctx, "", root.ID.Hex())
+		require.NoError(t, err)
+		require.Len(t, children, 2)
+		assert.Equal(t, "roles", children[0].Code)
+		assert.Equal(t, "users", children[1].Code)
+
+		byModule, err := repo.ListByModule(ctx, "", "core")
+		require.NoError(t, err)
+		require.Len(t, byModule, 2)
+		assert.Equal(t, "dashboard", byModule[0].Code)
+	})
+
+	t.Run("Update and delete", func(t *testing.T) {
+		repo := newRepo(t)
+		menu := &domain.AdminMenu{Code: "users", Name: "Users"}
+		require.NoError(t, repo.Create(ctx, menu))
+
+		menu.Title = map[string]string{"vi": "Người dùng"}
+		menu.Order = 5
+		require.NoError(t, repo.Update(ctx, menu))
+
+		found, err := repo.FindByID(ctx, menu.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, 5, found.Order)
+		assert.Equal(t, "Người dùng", found.Title["vi"])
+
+		err = repo.Update(ctx, &domain.AdminMenu{ID: primitive.NewObjectID()})
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		require.NoError(t, repo.Delete(ctx, menu.ID.Hex()))
+		found, err = repo.FindByID(ctx, menu.ID.Hex())
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("Reorder", func(t *testing.T) {
+		repo := newRepo(t)
+		settings := &domain.AdminMenu{Code: "settings", Name: "Settings", Order: 1}
+		users := &domain.AdminMenu{Code: "users", Name: "Users", Order: 2}
+		require.NoError(t, repo.Create(ctx, settings))
+		require.NoError(t, repo.Create(ctx, users))
+
+		err := repo.Reorder(ctx, "", []domain.MenuPosition{
+			{ID: users.ID, ParentID: settings.ID.Hex(), Order: 1},
+			{ID: primitive.NewObjectID(), Order: 2},
+		})
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		found, err := repo.FindByID(ctx, users.ID.Hex())
+		require.NoError(t, err)
+		assert.Empty(t, found.ParentID)
+		assert.Equal(t, 2, found.Order)
+
+		err = repo.Reorder(ctx, "tenant-1", []domain.MenuPosition{{ID: users.ID, Order: 1}})
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		require.NoError(t, repo.Reorder(ctx, "", []domain.MenuPosition{
+			{ID: users.ID, ParentID: settings.ID.Hex(), Order: 1},
+		}))
+		children, err := repo.FindChildren(ctx, "", settings.ID.Hex())
+		require.NoError(t, err)
+		require.Len(t, children, 1)
+		assert.Equal(t, "users", children[0].Code)
+	})
+}
+
+func testPermissionRepositoryContract(t *testing.T, newRepo func(t *testing.T) PermissionRepository) {
+	ctx := context.Background()
+
+	permission := func(module, resource, action string) *domain.Permission {
+		return &domain.Permission{
+			ModuleCode: module,
+			Code:       resource + "." + action,
+			Name:       resource + " " + action,
+			Resource:   resource,
+			Action:     action,
+			Status:     "active",
+		}
+	}
+
+	t.Run("Create and query", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Create(ctx, permission("users", "users", "read")))
+		require.NoError(t, repo.Create(ctx, permission("users", "users", "create")))
+		require.NoError(t, repo.Create(ctx, permission("users", "roles", "read")))
+		require.NoError(t, repo.Create(ctx, permission("crm", "customers", "read")))
+
+		err := repo.Create(ctx, permission("crm", "users", "read"))
+		assert.True(t, errors.Is(err, ErrDuplicateKey))
+
+		found, err := repo.FindByCode(ctx, "", "users.read")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, "users", found.ModuleCode)
+
+		all, err := repo.ListAll(ctx, "")
+		require.NoError(t, err)
+		require.Len(t, all, 4)
+		assert.Equal(t, "customers.read", all[0].Code)
+
+		page, total, err := repo.List(ctx, "", 2, 3)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), total)
+		require.Len(t, page, 1)
+		assert.Equal(t, "users.read", page[0].Code)
+
+		byModule, err := repo.ListByModule(ctx, "", "users")
+		require.NoError(t, err)
+		assert.Len(t, byModule, 3)
+
+		byResource, err := repo.ListByResource(ctx, "", "users")
+		require.NoError(t, err)
+		require.Len(t, byResource, 2)
+		assert.Equal(t, "users.create", byResource[0].Code)
+	})
+
+	t.Run("Upsert", func(t *testing.T) {
+		repo := newRepo(t)
+		existing := permission("users", "users", "read")
+		require.NoError(t, repo.Create(ctx, existing))
+
+		changed := permission("users", "users", "read")
+		changed.Name = "Read users"
+		added := permission("users", "users", "export")
+		require.NoError(t, repo.Upsert(ctx, []*domain.Permission{changed, added}))
+		assert.False(t, added.ID.IsZero())
+
+		found, err := repo.FindByCode(ctx, "", "users.read")
+		require.NoError(t, err)
+		assert.Equal(t, existing.ID, found.ID)
+		assert.Equal(t, "Read users", found.Name)
+
+		found, err = repo.FindByID(ctx, added.ID.Hex())
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, "users.export", found.Code)
+	})
+
+	t.Run("Update and delete", func(t *testing.T) {
+		repo := newRepo(t)
+		perm := permission("users", "users", "read")
+		require.NoError(t, repo.Create(ctx, perm))
+
+		perm.Status = "inactive"
+		require.NoError(t, repo.Update(ctx, perm))
+		found, err := repo.FindByID(ctx, perm.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, "inactive", found.Status)
+
+		err = repo.Update(ctx, &domain.Permission{ID: primitive.NewObjectID()})
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		require.NoError(t, repo.Delete(ctx, perm.ID.Hex()))
+		found, err = repo.FindByID(ctx, perm.ID.Hex())
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+}
+
+func testRoleRepositoryContract(t *testing.T, newRepo func(t *testing.T) RoleRepository) {
+	ctx := context.Background()
+
+	t.Run("Create and query", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.Create(ctx, &domain.Role{Code: "user", Name: "User", Level: 4}))
+		require.NoError(t, repo.Create(ctx, &domain.Role{Code: "admin", Name: "Administrator", Level: 2, Permissions: []string{"admin.*"}}))
+		require.NoError(t, repo.Create(ctx, &domain.Role{Code: "manager", Name: "Manager", Level: 3}))
+		require.NoError(t, repo.Create(ctx, &domain.Role{TenantID: "tenant-1", Code: "admin", Name: "Administrator", Level: 2}))
+
+		err := repo.Create(ctx, &domain.Role{Code: "admin", Name: "Administrator"})
+		assert.True(t, errors.Is(err, ErrDuplicateKey))
+
+		found, err := repo.FindByCode(ctx, "", "admin")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, []string{"admin.*"}, found.Permissions)
+
+		found, err = repo.FindByCode(ctx, "tenant-1", "user")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		roles, total, err := repo.List(ctx, "", 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, roles, 2)
+		assert.Equal(t, "admin", roles[0].Code)
+		assert.Equal(t, "manager", roles[1].Code)
+
+		all, err := repo.ListAll(ctx, "tenant-1")
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.Equal(t, "tenant-1", all[0].TenantID)
+	})
+
+	t.Run("Update and delete", func(t *testing.T) {
+		repo := newRepo(t)
+		role := &domain.Role{Code: "manager", Name: "Manager", Level: 3}
+		require.NoError(t, repo.Create(ctx, role))
+
+		role.Name = "Team Manager"
+		role.ParentCode = "user"
+		role.Permissions = []string{"users.read"}
+		require.NoError(t, repo.Update(ctx, role))
+
+		children, err := repo.FindByParent(ctx, "", "user")
+		require.NoError(t, err)
+		require.Len(t, children, 1)
+		assert.Equal(t, "manager", children[0].Code)
+
+		require.NoError(t, repo.UpdatePermissions(ctx, role.ID.Hex(), []string{"users.read", "users.update"}))
+		found, err := repo.FindByID(ctx, role.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, "Team Manager", found.Name)
+		assert.Equal(t, []string{"users.read", "users.update"}, found.Permissions)
+
+		err = repo.Update(ctx, &domain.Role{ID: primitive.NewObjectID()})
+		assert.True(t, errors.Is(err, ErrNotFound))
+		err = repo.UpdatePermissions(ctx, primitive.NewObjectID().Hex(), nil)
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		require.NoError(t, repo.Delete(ctx, role.ID.Hex()))
+		found, err = repo.FindByID(ctx, role.ID.Hex())
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+}
+
+func testProvinceRepositoryContract(t *testing.T, newRepo func(t *testing.T) ProvinceRepository) {
+	ctx := context.Background()
+
+	newProvince := func(code, countryCode, status string) *domain.Province {
+		return &domain.Province{Code: code, Name: map[string]string{"en": code}, CountryCode: countryCode, Status: status}
+	}
+
+	t.Run("Create and find", func(t *testing.T) {
+		repo := newRepo(t)
+		province := newProvince("01", "VN", "active")
+		require.NoError(t, repo.Create(ctx, province))
+		assert.False(t, province.ID.IsZero())
+
+		found, err := repo.FindByCode(ctx, "01")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, province.ID, found.ID)
+		assert.Equal(t, "VN", found.CountryCode)
+
+		found, err = repo.FindByCode(ctx, "99")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		err = repo.Create(ctx, newProvince("01", "US", "active"))
+		assert.True(t, errors.Is(err, ErrDuplicateKey))
+	})
+
+	t.Run("List and count by parent", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, code := range []string{"79", "01", "48"} {
+			require.NoError(t, repo.Create(ctx, newProvince(code, "VN", "active")))
+		}
+		require.NoError(t, repo.Create(ctx, newProvince("02", "VN", "inactive")))
+		require.NoError(t, repo.Create(ctx, newProvince("CA", "US", "active")))
+
+		provinces, err := repo.ListByCountry(ctx, "VN")
+		require.NoError(t, err)
+		require.Len(t, provinces, 3)
+		assert.Equal(t, "01", provinces[0].Code)
+		assert.Equal(t, "48", provinces[1].Code)
+		assert.Equal(t, "79", provinces[2].Code)
+
+		count, err := repo.CountByCountry(ctx, "VN")
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+
+		provinces, err = repo.ListByCountry(ctx, "XX")
+		require.NoError(t, err)
+		assert.Empty(t, provinces)
+
+		provinces, err = repo.ListCurrent(ctx)
+		require.NoError(t, err)
+		require.Len(t, provinces, 4)
+		assert.Equal(t, "01", provinces[0].Code)
+		assert.Equal(t, "CA", provinces[3].Code)
+	})
+
+	t.Run("Update and delete", func(t *testing.T) {
+		repo := newRepo(t)
+		province := newProvince("01", "VN", "active")
+		require.NoError(t, repo.Create(ctx, province))
+
+		province.Name = map[string]string{"en": "Ha Noi", "vi": "Hà Nội"}
+		province.Type = "city"
+		require.NoError(t, repo.Update(ctx, province))
+
+		found, err := repo.FindByCode(ctx, "01")
+		require.NoError(t, err)
+		assert.Equal(t, "Hà Nội", found.Name["vi"])
+		assert.Equal(t, "city", found.Type)
+
+		err = repo.Update(ctx, &domain.Province{ID: primitive.NewObjectID()})
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		require.NoError(t, repo.Delete(ctx, "01"))
+		found, err = repo.FindByCode(ctx, "01")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("Search folded names", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, item := range []struct{ code, parent, vi string }{
+			{"a1", "VN", "Hà Nội"},
+			{"a2", "VN", "Hà Nam"},
+			{"a3", "US", "Thanh Hà"},
+		} {
+			require.NoError(t, repo.Create(ctx, &domain.Province{
+				Code: item.code, Name: map[string]string{"vi": item.vi}, CountryCode: item.parent, Status: "active",
+			}))
+		}
+
+		found, err := repo.Search(ctx, domain.LocationQuery{Text: "ha", Prefix: true})
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, "a1", found[0].Code)
+		assert.Equal(t, []string{"ha noi"}, found[0].SearchText)
+
+		found, err = repo.Search(ctx, domain.LocationQuery{Text: "ha", CountryCode: "US"})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, "a3", found[0].Code)
+
+		found, err = repo.Search(ctx, domain.LocationQuery{Text: "ha", Limit: 1})
+		require.NoError(t, err)
+		assert.Len(t, found, 1)
+	})
+
+	t.Run("Retire and validity", func(t *testing.T) {
+		repo := newRepo(t)
+		future := time.Now().Add(24 * time.Hour)
+		require.NoError(t, repo.Create(ctx, &domain.Province{Code: "old", Name: map[string]string{"vi": "Cũ"}, CountryCode: "VN", Status: "active"}))
+		require.NoError(t, repo.Create(ctx, &domain.Province{
+			Code: "new", Name: map[string]string{"vi": "Mới"}, CountryCode: "VN", Status: "active",
+			LocationValidity: domain.LocationValidity{EffectiveFrom: &future},
+		}))
+
+		listed, err := repo.ListByCountry(ctx, "VN")
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, "old", listed[0].Code)
+
+		retiredAt := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+		require.NoError(t, repo.Retire(ctx, "old", retiredAt, []string{"new"}))
+		assert.True(t, errors.Is(repo.Retire(ctx, "missing", retiredAt, nil), ErrNotFound))
+
+		found, err := repo.FindByCode(ctx, "old")
+		require.NoError(t, err)
+		require.NotNil(t, found.EffectiveTo)
+		assert.True(t, retiredAt.Equal(*found.EffectiveTo))
+		assert.Equal(t, []string{"new"}, found.SuccessorCodes)
+
+		next, err := repo.NextValidityChange(ctx, "VN", time.Now())
+		require.NoError(t, err)
+		require.NotNil(t, next, "the unit coming into effect tomorrow changes the listing")
+		assert.WithinDuration(t, future, *next, time.Millisecond)
+		next, err = repo.NextValidityChange(ctx, "US", time.Now())
+		require.NoError(t, err)
+		assert.Nil(t, next)
+
+		listed, err = repo.ListByCountry(ctx, "VN")
+		require.NoError(t, err)
+		assert.Empty(t, listed)
+		listed, err = repo.ListCurrent(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, listed)
+		searched, err := repo.Search(ctx, domain.LocationQuery{Text: "m"})
+		require.NoError(t, err)
+		assert.Empty(t, searched)
+
+		count, err := repo.CountByCountry(ctx, "VN")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("FindByCodes", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, code := range []string{"a1", "a2", "a3"} {
+			require.NoError(t, repo.Create(ctx, &domain.Province{Code: code, Name: map[string]string{"en": code}, CountryCode: "VN", Status: "active"}))
+		}
+
+		found, err := repo.FindByCodes(ctx, []string{"a1", "a3", "a1", "zz"})
+		require.NoError(t, err)
+		codes := make([]string, 0, len(found))
+		for _, item := range found {
+			codes = append(codes, item.Code)
+		}
+		assert.ElementsMatch(t, []string{"a1", "a3"}, codes)
+
+		found, err = repo.FindByCodes(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+	})
+}
+
+func testDistrictRepositoryContract(t *testing.T, newRepo func(t *testing.T) DistrictRepository) {
+	ctx := context.Background()
+
+	newDistrict := func(code, provinceCode, status string) *domain.District {
+		return &domain.District{Code: code, Name: map[string]string{"en": code}, ProvinceCode: provinceCode, Status: status}
+	}
+
+	t.Run("Create and find", func(t *testing.T) {
+		repo := newRepo(t)
+		district := newDistrict("001", "01", "active")
+		require.NoError(t, repo.Create(ctx, district))
+		assert.False(t, district.ID.IsZero())
+
+		found, err := repo.FindByCode(ctx, "001")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, district.ID, found.ID)
+		assert.Equal(t, "01", found.ProvinceCode)
+
+		found, err = repo.FindByCode(ctx, "999")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		err = repo.Create(ctx, newDistrict("001", "79", "active"))
+		assert.True(t, errors.Is(err, ErrDuplicateKey))
+	})
+
+	t.Run("List and count by parent", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, code := range []string{"019", "001", "005"} {
+			require.NoError(t, repo.Create(ctx, newDistrict(code, "01", "active")))
+		}
+		require.NoError(t, repo.Create(ctx, newDistrict("002", "01", "inactive")))
+		require.NoError(t, repo.Create(ctx, newDistrict("760", "79", "active")))
+
+		districts, err := repo.ListByProvince(ctx, "01")
+		require.NoError(t, err)
+		require.Len(t, districts, 3)
+		assert.Equal(t, "001", districts[0].Code)
+		assert.Equal(t, "005", districts[1].Code)
+		assert.Equal(t, "019", districts[2].Code)
+
+		count, err := repo.CountByProvince(ctx, "01")
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+
+		districts, err = repo.ListByProvince(ctx, "99")
+		require.NoError(t, err)
+		assert.Empty(t, districts)
+
+		districts, err = repo.ListCurrent(ctx)
+		require.NoError(t, err)
+		require.Len(t, districts, 4)
+		assert.Equal(t, "001", districts[0].Code)
+		assert.Equal(t, "760", districts[3].Code)
+	})
+
+	t.Run("Update and delete", func(t *testing.T) {
+		repo := newRepo(t)
+		district := newDistrict("001", "01", "active")
+		require.NoError(t, repo.Create(ctx, district))
+
+		district.Name = map[string]string{"en": "Ba Dinh", "vi": "Ba Đình"}
+		district.Type = "urban_district"
+		require.NoError(t, repo.Update(ctx, district))
+
+		found, err := repo.FindByCode(ctx, "001")
+		require.NoError(t, err)
+		assert.Equal(t, "Ba Đình", found.Name["vi"])
+		assert.Equal(t, "urban_district", found.Type)
+
+		err = repo.Update(ctx, &domain.District{ID: primitive.NewObjectID()})
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		district.ProvinceCode, district.CountryCode = "79", "US"
+		require.NoError(t, repo.Update(ctx, district))
+		found, err = repo.FindByCode(ctx, "001")
+		require.NoError(t, err)
+		assert.Equal(t, "79", found.ProvinceCode)
+		assert.Equal(t, "US", found.CountryCode)
+
+		require.NoError(t, repo.Create(ctx, newDistrict("002", "79", "active")))
+		require.NoError(t, repo.Create(ctx, newDistrict("003", "01", "active")))
+		require.NoError(t, repo.SetCountryByProvince(ctx, "79", "LA"))
+		for code, country := range map[string]string{"001": "LA", "002": "LA", "003": ""} {
+			found, err = repo.FindByCode(ctx, code)
+			require.NoError(t, err)
+			assert.Equal(t, country, found.CountryCode, code)
+		}
+
+		require.NoError(t, repo.Delete(ctx, "001"))
+		found, err = repo.FindByCode(ctx, "001")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("Search folded names", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, item := range []struct{ code, parent, country, vi string }{
+			{"a1", "01", "VN", "Hà Nội"},
+			{"a2", "01", "VN", "Hà Nam"},
+			{"a3", "79", "VN", "Thanh Hà"},
+			{"a4", "CA", "US", "Lake Hale"},
+		} {
+			require.NoError(t, repo.Create(ctx, &domain.District{
+				Code: item.code, Name: map[string]string{"vi": item.vi}, ProvinceCode: item.parent, CountryCode: item.country, Status: "active",
+			}))
+		}
+
+		found, err := repo.Search(ctx, domain.LocationQuery{Text: "ha", Prefix: true})
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, "a1", found[0].Code)
+		assert.Equal(t, []string{"ha noi"}, found[0].SearchText)
+
+		found, err = repo.Search(ctx, domain.LocationQuery{Text: "ha", ProvinceCode: "79"})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, "a3", found[0].Code)
+
+		found, err = repo.Search(ctx, domain.LocationQuery{Text: "ha", CountryCode: "VN"})
+		require.NoError(t, err)
+		assert.Len(t, found, 3)
+
+		found, err = repo.Search(ctx, domain.LocationQuery{Text: "ha", Limit: 1})
+		require.NoError(t, err)
+		assert.Len(t, found, 1)
+	})
+
+	t.Run("Retire and validity", func(t *testing.T) {
+		repo := newRepo(t)
+		future := time.Now().Add(24 * time.Hour)
+		require.NoError(t, repo.Create(ctx, &domain.District{Code: "old", Name: map[string]string{"vi": "Cũ"}, ProvinceCode: "01", Status: "active"}))
+		require.NoError(t, repo.Create(ctx, &domain.District{
+			Code: "new", Name: map[string]string{"vi": "Mới"}, ProvinceCode: "01", Status: "active",
+			LocationValidity: domain.LocationValidity{EffectiveFrom: &future},
+		}))
+
+		listed, err := repo.ListByProvince(ctx, "01")
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, "old", listed[0].Code)
+
+		retiredAt := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+		require.NoError(t, repo.Retire(ctx, "old", retiredAt, []string{"new"}))
+		assert.True(t, errors.Is(repo.Retire(ctx, "missing", retiredAt, nil), ErrNotFound))
+
+		found, err := repo.FindByCode(ctx, "old")
+		require.NoError(t, err)
+		require.NotNil(t, found.EffectiveTo)
+		assert.True(t, retiredAt.Equal(*found.EffectiveTo))
+		assert.Equal(t, []string{"new"}, found.SuccessorCodes)
+
+		next, err := repo.NextValidityChange(ctx, "01", time.Now())
+		require.NoError(t, err)
+		require.NotNil(t, next, "the unit coming into effect tomorrow changes the listing")
+		assert.WithinDuration(t, future, *next, time.Millisecond)
+		next, err = repo.NextValidityChange(ctx, "02", time.Now())
+		require.NoError(t, err)
+		assert.Nil(t, next)
+
+		listed, err = repo.ListByProvince(ctx, "01")
+		require.NoError(t, err)
+		assert.Empty(t, listed)
+		listed, err = repo.ListCurrent(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, listed)
+		searched, err := repo.Search(ctx, domain.LocationQuery{Text: "m"})
+		require.NoError(t, err)
+		assert.Empty(t, searched)
+
+		count, err := repo.CountByProvince(ctx, "01")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("FindByCodes", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, code := range []string{"a1", "a2", "a3"} {
+			require.NoError(t, repo.Create(ctx, &domain.District{Code: code, Name: map[string]string{"en": code}, ProvinceCode: "01", Status: "active"}))
+		}
+
+		found, err := repo.FindByCodes(ctx, []string{"a1", "a3", "a1", "zz"})
+		require.NoError(t, err)
+		codes := make([]string, 0, len(found))
+		for _, item := range found {
+			codes = append(codes, item.Code)
+		}
+		assert.ElementsMatch(t, []string{"a1", "a3"}, codes)
+
+		found, err = repo.FindByCodes(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+	})
+}
+
+func testWardRepositoryContract(t *testing.T, newRepo func(t *testing.T) WardRepository) {
+	ctx := context.Background()
+
+	newWard := func(code, districtCode, status string) *domain.Ward {
+		return &domain.Ward{Code: code, Name: map[string]string{"en": code}, DistrictCode: districtCode, Status: status}
+	}
+
+	t.Run("Create and find", func(t *testing.T) {
+		repo := newRepo(t)
+		ward := newWard("00001", "001", "active")
+		require.NoError(t, repo.Create(ctx, ward))
+		assert.False(t, ward.ID.IsZero())
+
+		found, err := repo.FindByCode(ctx, "00001")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, ward.ID, found.ID)
+		assert.Equal(t, "001", found.DistrictCode)
+
+		found, err = repo.FindByCode(ctx, "99999")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		err = repo.Create(ctx, newWard("00001", "760", "active"))
+		assert.True(t, errors.Is(err, ErrDuplicateKey))
+	})
+
+	t.Run("List and count by parent", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, code := range []string{"00019", "00001", "00005"} {
+			require.NoError(t, repo.Create(ctx, newWard(code, "001", "active")))
+		}
+		require.NoError(t, repo.Create(ctx, newWard("00002", "001", "inactive")))
+		require.NoError(t, repo.Create(ctx, newWard("26734", "760", "active")))
+
+		wards, err := repo.ListByDistrict(ctx, "001")
+		require.NoError(t, err)
+		require.Len(t, wards, 3)
+		assert.Equal(t, "00001", wards[0].Code)
+		assert.Equal(t, "00005", wards[1].Code)
+		assert.Equal(t, "00019", wards[2].Code)
+
+		count, err := repo.CountByDistrict(ctx, "001")
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+
+		wards, err = repo.ListByDistrict(ctx, "999")
+		require.NoError(t, err)
+		assert.Empty(t, wards)
+
+		wards, err = repo.ListCurrent(ctx)
+		require.NoError(t, err)
+		require.Len(t, wards, 4)
+		assert.Equal(t, "00001", wards[0].Code)
+		assert.Equal(t, "26734", wards[3].Code)
+	})
+
+	t.Run("Update and delete", func(t *testing.T) {
+		repo := newRepo(t)
+		ward := newWard("00001", "001", "active")
+		require.NoError(t, repo.Create(ctx, ward))
+
+		ward.Name = map[string]string{"en": "Phuc Xa", "vi": "Phúc Xá"}
+		ward.Type = "ward"
+		require.NoError(t, repo.Update(ctx, ward))
+
+		found, err := repo.FindByCode(ctx, "00001")
+		require.NoError(t, err)
+		assert.Equal(t, "Phúc Xá", found.Name["vi"])
+		assert.Equal(t, "ward", found.Type)
+
+		err = repo.Update(ctx, &domain.Ward{ID: primitive.NewObjectID()})
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		ward.DistrictCode, ward.ProvinceCode, ward.CountryCode = "760", "79", "VN"
+		require.NoError(t, repo.Update(ctx, ward))
+		found, err = repo.FindByCode(ctx, "00001")
+		require.NoError(t, err)
+		assert.Equal(t, "79", found.ProvinceCode)
+		assert.Equal(t, "VN", found.CountryCode)
+
+		other := newWard("00002", "001", "active")
+		other.ProvinceCode, other.CountryCode = "01", "VN"
+		require.NoError(t, repo.Create(ctx, other))
+		require.NoError(t, repo.SetAncestorsByDistrict(ctx, "001", "02", "VN"))
+		require.NoError(t, repo.SetCountryByProvince(ctx, "79", "LA"))
+		for code, want := range map[string][2]string{"00001": {"79", "LA"}, "00002": {"02", "VN"}} {
+			found, err = repo.FindByCode(ctx, code)
+			require.NoError(t, err)
+			assert.Equal(t, want[0], found.ProvinceCode, code)
+			assert.Equal(t, want[1], found.CountryCode, code)
+		}
+
+		require.NoError(t, repo.Delete(ctx, "00001"))
+		found, err = repo.FindByCode(ctx, "00001")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("Search folded names", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, item := range []struct{ code, parent, province, vi string }{
+			{"a1", "001", "01", "Hà Nội"},
+			{"a2", "001", "01", "Hà Nam"},
+			{"a3", "760", "79", "Thanh Hà"},
+			{"a4", "761", "79", "Thạnh Hà"},
+		} {
+			require.NoError(t, repo.Create(ctx, &domain.Ward{
+				Code: item.code, Name: map[string]string{"vi": item.vi}, DistrictCode: item.parent, ProvinceCode: item.province, CountryCode: "VN",
+				Status: "active",
+			}))
+		}
+
+		found, err := repo.Search(ctx, domain.LocationQuery{Text: "ha", Prefix: true})
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, "a1", found[0].Code)
+		assert.Equal(t, []string{"ha noi"}, found[0].SearchText)
+
+		found, err = repo.Search(ctx, domain.LocationQuery{Text: "ha", DistrictCode: "760"})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, "a3", found[0].Code)
+
+		found, err = repo.Search(ctx, domain.LocationQuery{Text: "ha", ProvinceCode: "79"})
+		require.NoError(t, err)
+		assert.Len(t, found, 2)
+
+		found, err = repo.Search(ctx, domain.LocationQuery{Text: "ha", CountryCode: "US"})
+		require.NoError(t, err)
+		assert.Empty(t, found)
+
+		found, err = repo.Search(ctx, domain.LocationQuery{Text: "ha", Limit: 1})
+		require.NoError(t, err)
+		assert.Len(t, found, 1)
+	})
+
+	t.Run("Retire and validity", func(t *testing.T) {
+		repo := newRepo(t)
+		future := time.Now().Add(24 * time.Hour)
+		require.NoError(t, repo.Create(ctx, &domain.Ward{Code: "old", Name: map[string]string{"vi": "Cũ"}, DistrictCode: "001", Status: "active"}))
+		require.NoError(t, repo.Create(ctx, &domain.Ward{
+			Code: "new", Name: map[string]string{"vi": "Mới"}, DistrictCode: "001", Status: "active",
+			LocationValidity: domain.LocationValidity{EffectiveFrom: &future},
+		}))
+
+		listed, err := repo.ListByDistrict(ctx, "001")
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, "old", listed[0].Code)
+
+		retiredAt := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+		require.NoError(t, repo.Retire(ctx, "old", retiredAt, []string{"new"}))
+		assert.True(t, errors.Is(repo.Retire(ctx, "missing", retiredAt, nil), ErrNotFound))
+
+		found, err := repo.FindByCode(ctx, "old")
+		require.NoError(t, err)
+		require.NotNil(t, found.EffectiveTo)
+		assert.True(t, retiredAt.Equal(*found.EffectiveTo))
+		assert.Equal(t, []string{"new"}, found.SuccessorCodes)
+
+		next, err := repo.NextValidityChange(ctx, "001", time.Now())
+		require.NoError(t, err)
+		require.NotNil(t, next, "the unit coming into effect tomorrow changes the listing")
+		assert.WithinDuration(t, future, *next, time.Millisecond)
+		next, err = repo.NextValidityChange(ctx, "002", time.Now())
+		require.NoError(t, err)
+		assert.Nil(t, next)
+
+		listed, err = repo.ListByDistrict(ctx, "001")
+		require.NoError(t, err)
+		assert.Empty(t, listed)
+		listed, err = repo.ListCurrent(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, listed)
+		searched, err := repo.Search(ctx, domain.LocationQuery{Text: "m"})
+		require.NoError(t, err)
+		assert.Empty(t, searched)
+
+		count, err := repo.CountByDistrict(ctx, "001")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("FindByCodes", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, code := range []string{"a1", "a2", "a3"} {
+			require.NoError(t, repo.Create(ctx, &domain.Ward{Code: code, Name: map[string]string{"en": code}, DistrictCode: "001", Status: "active"}))
+		}
+
+		found, err := repo.FindByCodes(ctx, []string{"a1", "a3", "a1", "zz"})
+		require.NoError(t, err)
+		codes := make([]string, 0, len(found))
+		for _, item := range found {
+			codes = append(codes, item.Code)
+		}
+		assert.ElementsMatch(t, []string{"a1", "a3"}, codes)
+
+		found, err = repo.FindByCodes(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+	})
+}
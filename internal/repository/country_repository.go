@@ -13,12 +13,23 @@ import (
 )
 
 // CountryRepository handles country data access
-type CountryRepository struct {
+type CountryRepository interface {
+	Create(ctx context.Context, country *domain.Country) error
+	FindByCode(ctx context.Context, code string) (*domain.Country, error)
+	List(ctx context.Context, page, perPage int) ([]*domain.Country, int64, error)
+	Update(ctx context.Context, country *domain.Country) error
+	Delete(ctx context.Context, code string) error
+	FindByCodes(ctx context.Context, codes []string) ([]*domain.Country, error)
+	CountByCurrency(ctx context.Context, currency string) (int64, error)
+}
+
+// mongoCountryRepository is the MongoDB implementation of CountryRepository
+type mongoCountryRepository struct {
 	collection *mongo.Collection
 }
 
-// NewCountryRepository creates a new country repository
-func NewCountryRepository(db *mongo.Database) *CountryRepository {
+// NewCountryRepository creates a new MongoDB backed country repository
+func NewCountryRepository(db *mongo.Database) CountryRepository {
 	collection := db.Collection("countries")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -32,20 +43,26 @@ func NewCountryRepository(db *mongo.Database) *CountryRepository {
 		{
 			Keys: bson.D{{Key: "status", Value: 1}},
 		},
+		{
+			Keys: bson.D{{Key: "currency", Value: 1}},
+		},
 	}
 
 	_, _ = collection.Indexes().CreateMany(ctx, indexes)
 
-	return &CountryRepository{collection: collection}
+	return &mongoCountryRepository{collection: collection}
 }
 
 // Create creates a new country
-func (r *CountryRepository) Create(ctx context.Context, country *domain.Country) error {
+func (r *mongoCountryRepository) Create(ctx context.Context, country *domain.Country) error {
 	country.CreatedAt = time.Now()
 	country.UpdatedAt = time.Now()
 
 	result, err := r.collection.InsertOne(ctx, country)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to create country: %w", ErrDuplicateKey)
+		}
 		return fmt.Errorf("failed to create country: %w", err)
 	}
 
@@ -54,7 +71,7 @@ func (r *CountryRepository) Create(ctx context.Context, country *domain.Country)
 }
 
 // FindByCode finds a country by code
-func (r *CountryRepository) FindByCode(ctx context.Context, code string) (*domain.Country, error) {
+func (r *mongoCountryRepository) FindByCode(ctx context.Context, code string) (*domain.Country, error) {
 	var country domain.Country
 	opts := options.FindOne().SetHint(bson.D{{Key: "code", Value: 1}}) // Use code index for optimal performance
 	err := r.collection.FindOne(ctx, bson.M{"code": code}, opts).Decode(&country)
@@ -68,7 +85,7 @@ func (r *CountryRepository) FindByCode(ctx context.Context, code string) (*domai
 }
 
 // List lists all countries
-func (r *CountryRepository) List(ctx context.Context, page, perPage int) ([]*domain.Country, int64, error) {
+func (r *mongoCountryRepository) List(ctx context.Context, page, perPage int) ([]*domain.Country, int64, error) {
 	filter := bson.M{"status": "active"}
 
 	// Use CountDocuments with a hint to use the status index for better performance
@@ -100,7 +117,7 @@ func (r *CountryRepository) List(ctx context.Context, page, perPage int) ([]*dom
 }
 
 // Update updates a country
-func (r *CountryRepository) Update(ctx context.Context, country *domain.Country) error {
+func (r *mongoCountryRepository) Update(ctx context.Context, country *domain.Country) error {
 	country.UpdatedAt = time.Now()
 
 	// Use $set to update only provided fields for better performance
@@ -128,14 +145,14 @@ func (r *CountryRepository) Update(ctx context.Context, country *domain.Country)
 	}
 
 	if result.MatchedCount == 0 {
-		return fmt.Errorf("country not found")
+		return fmt.Errorf("country %w", ErrNotFound)
 	}
 
 	return nil
 }
 
 // Delete deletes a country
-func (r *CountryRepository) Delete(ctx context.Context, code string) error {
+func (r *mongoCountryRepository) Delete(ctx context.Context, code string) error {
 	_, err := r.collection.DeleteOne(ctx, bson.M{"code": code})
 	if err != nil {
 		return fmt.Errorf("failed to delete country: %w", err)
@@ -144,7 +161,7 @@ func (r *CountryRepository) Delete(ctx context.Context, code string) error {
 }
 
 // FindByCodes finds multiple countries by codes in a single query (batch operation)
-func (r *CountryRepository) FindByCodes(ctx context.Context, codes []string) ([]*domain.Country, error) {
+func (r *mongoCountryRepository) FindByCodes(ctx context.Context, codes []string) ([]*domain.Country, error) {
 	if len(codes) == 0 {
 		return []*domain.Country{}, nil
 	}
@@ -165,3 +182,13 @@ func (r *CountryRepository) FindByCodes(ctx context.Context, codes []string) ([]
 
 	return countries, nil
 }
+
+// CountByCurrency counts the countries using a currency regardless of status
+func (r *mongoCountryRepository) CountByCurrency(ctx context.Context, currency string) (int64, error) {
+	opts := options.Count().SetHint(bson.D{{Key: "currency", Value: 1}})
+	count, err := r.collection.CountDocuments(ctx, bson.M{"currency": currency}, opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count countries: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CurrencyRepository handles currency data access
+type CurrencyRepository interface {
+	Create(ctx context.Context, currency *domain.Currency) error
+	FindByCode(ctx context.Context, code string) (*domain.Currency, error)
+	List(ctx context.Context, page, perPage int) ([]*domain.Currency, int64, error)
+	Update(ctx context.Context, currency *domain.Currency) error
+	Delete(ctx context.Context, code string) error
+}
+
+// mongoCurrencyRepository is the MongoDB implementation of CurrencyRepository
+type mongoCurrencyRepository struct {
+	collection *mongo.Collection
+}
+
+// NewCurrencyRepository creates a new MongoDB backed currency repository
+func NewCurrencyRepository(db *mongo.Database) CurrencyRepository {
+	collection := db.Collection("currencies")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "code", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}},
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &mongoCurrencyRepository{collection: collection}
+}
+
+// Create creates a new currency
+func (r *mongoCurrencyRepository) Create(ctx context.Context, currency *domain.Currency) error {
+	currency.CreatedAt = time.Now()
+	currency.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, currency)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to create currency: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create currency: %w", err)
+	}
+
+	currency.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByCode finds a currency by code
+func (r *mongoCurrencyRepository) FindByCode(ctx context.Context, code string) (*domain.Currency, error) {
+	var currency domain.Currency
+	opts := options.FindOne().SetHint(bson.D{{Key: "code", Value: 1}})
+	err := r.collection.FindOne(ctx, bson.M{"code": code}, opts).Decode(&currency)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find currency: %w", err)
+	}
+	return &currency, nil
+}
+
+// List lists active currencies ordered by code
+func (r *mongoCurrencyRepository) List(ctx context.Context, page, perPage int) ([]*domain.Currency, int64, error) {
+	filter := bson.M{"status": "active"}
+
+	countOpts := options.Count().SetHint(bson.D{{Key: "status", Value: 1}})
+	total, err := r.collection.CountDocuments(ctx, filter, countOpts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count currencies: %w", err)
+	}
+
+	opts := options.Find().
+		SetSkip(int64((page - 1) * perPage)).
+		SetLimit(int64(perPage)).
+		SetSort(bson.D{{Key: "code", Value: 1}}).
+		SetHint(bson.D{{Key: "status", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list currencies: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var currencies []*domain.Currency
+	if err = cursor.All(ctx, &currencies); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode currencies: %w", err)
+	}
+
+	return currencies, total, nil
+}
+
+// Update updates a currency
+func (r *mongoCurrencyRepository) Update(ctx context.Context, currency *domain.Currency) error {
+	currency.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":          currency.Name,
+			"symbol":        currency.Symbol,
+			"decimalDigits": currency.DecimalDigits,
+			"countries":     currency.Countries,
+			"status":        currency.Status,
+			"updatedAt":     currency.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": currency.ID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update currency: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("currency %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// Delete deletes a currency
+func (r *mongoCurrencyRepository) Delete(ctx context.Context, code string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"code": code})
+	if err != nil {
+		return fmt.Errorf("failed to delete currency: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DistrictRepository handles district data access
+type DistrictRepository interface {
+	Create(ctx context.Context, district *domain.District) error
+	FindByCode(ctx context.Context, code string) (*domain.District, error)
+	FindByCodes(ctx context.Context, codes []string) ([]*domain.District, error)
+	ListByProvince(ctx context.Context, provinceCode string) ([]*domain.District, error)
+	ListCurrent(ctx context.Context) ([]*domain.District, error)
+	NextValidityChange(ctx context.Context, provinceCode string, after time.Time) (*time.Time, error)
+	CountByProvince(ctx context.Context, provinceCode string) (int64, error)
+	SetCountryByProvince(ctx context.Context, provinceCode, countryCode string) error
+	Search(ctx context.Context, query domain.LocationQuery) ([]*domain.District, error)
+	Update(ctx context.Context, district *domain.District) error
+	Retire(ctx context.Context, code string, at time.Time, successorCodes []string) error
+	Delete(ctx context.Context, code string) error
+}
+
+// mongoDistrictRepository is the MongoDB implementation of DistrictRepository
+type mongoDistrictRepository struct {
+	collection *mongo.Collection
+}
+
+// NewDistrictRepository creates a new MongoDB backed district repository
+func NewDistrictRepository(db *mongo.Database) DistrictRepository {
+	collection := db.Collection("districts")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "code", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "provinceCode", Value: 1}, {Key: "code", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "countryCode", Value: 1}, {Key: "code", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "searchText", Value: 1}},
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &mongoDistrictRepository{collection: collection}
+}
+
+// Create creates a new district
+func (r *mongoDistrictRepository) Create(ctx context.Context, district *domain.District) error {
+	district.CreatedAt = time.Now()
+	district.UpdatedAt = time.Now()
+	district.SearchText = domain.SearchTerms(district.Name)
+
+	result, err := r.collection.InsertOne(ctx, district)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to create district: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create district: %w", err)
+	}
+
+	district.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByCode finds a district by code
+func (r *mongoDistrictRepository) FindByCode(ctx context.Context, code string) (*domain.District, error) {
+	var district domain.District
+	opts := options.FindOne().SetHint(bson.D{{Key: "code", Value: 1}})
+	err := r.collection.FindOne(ctx, bson.M{"code": code}, opts).Decode(&district)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find district: %w", err)
+	}
+	return &district, nil
+}
+
+// FindByCodes finds multiple districts by codes in a single query
+func (r *mongoDistrictRepository) FindByCodes(ctx context.Context, codes []string) ([]*domain.District, error) {
+	if len(codes) == 0 {
+		return []*domain.District{}, nil
+	}
+
+	filter := bson.M{"code": bson.M{"$in": codes}}
+	opts := options.Find().SetHint(bson.D{{Key: "code", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find districts: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	districts := []*domain.District{}
+	if err = cursor.All(ctx, &districts); err != nil {
+		return nil, fmt.Errorf("failed to decode districts: %w", err)
+	}
+	return districts, nil
+}
+
+// ListByProvince lists the active districts of a province currently in effect, ordered by code
+func (r *mongoDistrictRepository) ListByProvince(ctx context.Context, provinceCode string) ([]*domain.District, error) {
+	filter := currentLocationFilter(time.Now())
+	filter["provinceCode"] = provinceCode
+	filter["status"] = "active"
+	opts := options.Find().
+		SetSort(bson.D{{Key: "code", Value: 1}}).
+		SetHint(bson.D{{Key: "provinceCode", Value: 1}, {Key: "code", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list districts: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	districts := []*domain.District{}
+	if err = cursor.All(ctx, &districts); err != nil {
+		return nil, fmt.Errorf("failed to decode districts: %w", err)
+	}
+	return districts, nil
+}
+
+// ListCurrent lists all active districts currently in effect, ordered by code
+func (r *mongoDistrictRepository) ListCurrent(ctx context.Context) ([]*domain.District, error) {
+	filter := currentLocationFilter(time.Now())
+	filter["status"] = "active"
+	opts := options.Find().SetSort(bson.D{{Key: "code", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list districts: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	districts := []*domain.District{}
+	if err = cursor.All(ctx, &districts); err != nil {
+		return nil, fmt.Errorf("failed to decode districts: %w", err)
+	}
+	return districts, nil
+}
+
+// NextValidityChange returns the earliest time after the given one at which an active district of a
+// province comes into or goes out of effect, or nil when none does
+func (r *mongoDistrictRepository) NextValidityChange(ctx context.Context, provinceCode string, after time.Time) (*time.Time, error) {
+	return nextValidityChange(ctx, r.collection, bson.M{"provinceCode": provinceCode, "status": "active"}, after)
+}
+
+// CountByProvince counts the districts of a province regardless of status
+func (r *mongoDistrictRepository) CountByProvince(ctx context.Context, provinceCode string) (int64, error) {
+	opts := options.Count().SetHint(bson.D{{Key: "provinceCode", Value: 1}, {Key: "code", Value: 1}})
+	count, err := r.collection.CountDocuments(ctx, bson.M{"provinceCode": provinceCode}, opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count districts: %w", err)
+	}
+	return count, nil
+}
+
+// SetCountryByProvince records the country of a province on all of its districts
+func (r *mongoDistrictRepository) SetCountryByProvince(ctx context.Context, provinceCode, countryCode string) error {
+	update := bson.M{"$set": bson.M{"countryCode": countryCode, "updatedAt": time.Now()}}
+	if _, err := r.collection.UpdateMany(ctx, bson.M{"provinceCode": provinceCode}, update); err != nil {
+		return fmt.Errorf("failed to update districts: %w", err)
+	}
+	return nil
+}
+
+// Search finds active districts in effect whose folded names match the query, ordered by code
+func (r *mongoDistrictRepository) Search(ctx context.Context, query domain.LocationQuery) ([]*domain.District, error) {
+	pattern := regexp.QuoteMeta(query.Text)
+	if query.Prefix {
+		pattern = "^" + pattern
+	}
+
+	filter := currentLocationFilter(time.Now())
+	filter["searchText"] = bson.M{"$regex": pattern}
+	filter["status"] = "active"
+	if query.CountryCode != "" {
+		filter["countryCode"] = query.CountryCode
+	}
+	if query.ProvinceCode != "" {
+		filter["provinceCode"] = query.ProvinceCode
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "code", Value: 1}})
+	if query.Limit > 0 {
+		opts.SetLimit(int64(query.Limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search districts: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	districts := []*domain.District{}
+	if err = cursor.All(ctx, &districts); err != nil {
+		return nil, fmt.Errorf("failed to decode districts: %w", err)
+	}
+	return districts, nil
+}
+
+// Update updates a district
+func (r *mongoDistrictRepository) Update(ctx context.Context, district *domain.District) error {
+	district.UpdatedAt = time.Now()
+	district.SearchText = domain.SearchTerms(district.Name)
+
+	update := bson.M{
+		"$set": bson.M{
+			"searchText":   district.SearchText,
+			"name":         district.Name,
+			"provinceCode": district.ProvinceCode,
+			"countryCode":  district.CountryCode,
+			"type":         district.Type,
+			"status":       district.Status,
+			"updatedAt":    district.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": district.ID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update district: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("district %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// Retire ends the validity of a district at the given time and records its successors
+func (r *mongoDistrictRepository) Retire(ctx context.Context, code string, at time.Time, successorCodes []string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"effectiveTo":    at,
+			"successorCodes": successorCodes,
+			"updatedAt":      time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"code": code}, update)
+	if err != nil {
+		return fmt.Errorf("failed to retire district: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("district %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// Delete deletes a district
+func (r *mongoDistrictRepository) Delete(ctx context.Context, code string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"code": code})
+	if err != nil {
+		return fmt.Errorf("failed to delete district: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package repository
+
+import "errors"
+
+var (
+	// ErrDuplicateKey is returned when a write violates a unique index
+	ErrDuplicateKey = errors.New("duplicate key")
+
+	// ErrNotFound is returned when a write targets a document that does not exist
+	ErrNotFound = errors.New("not found")
+
+	// ErrVersionConflict is returned when a write conditional on the version of a document finds
+	// that it was changed since it was read
+	ErrVersionConflict = errors.New("version conflict")
+)
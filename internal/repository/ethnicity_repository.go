@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EthnicityRepository handles ethnicity data access
+type EthnicityRepository interface {
+	Create(ctx context.Context, ethnicity *domain.Ethnicity) error
+	FindByID(ctx context.Context, id string) (*domain.Ethnicity, error)
+	FindByCode(ctx context.Context, countryCode, code string) (*domain.Ethnicity, error)
+	List(ctx context.Context, countryCode string, page, perPage int) ([]*domain.Ethnicity, int64, error)
+	CountByCountry(ctx context.Context, countryCode string) (int64, error)
+	Update(ctx context.Context, ethnicity *domain.Ethnicity) error
+	Delete(ctx context.Context, id string) error
+}
+
+// mongoEthnicityRepository is the MongoDB implementation of EthnicityRepository
+type mongoEthnicityRepository struct {
+	collection *mongo.Collection
+}
+
+// NewEthnicityRepository creates a new MongoDB backed ethnicity repository
+func NewEthnicityRepository(db *mongo.Database) EthnicityRepository {
+	collection := db.Collection("ethnicities")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "countryCode", Value: 1}, {Key: "code", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "countryCode", Value: 1}, {Key: "code", Value: 1}},
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &mongoEthnicityRepository{collection: collection}
+}
+
+// Create creates a new ethnicity
+func (r *mongoEthnicityRepository) Create(ctx context.Context, ethnicity *domain.Ethnicity) error {
+	ethnicity.CreatedAt = time.Now()
+	ethnicity.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, ethnicity)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to create ethnicity: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create ethnicity: %w", err)
+	}
+
+	ethnicity.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByID finds an ethnicity by ID
+func (r *mongoEthnicityRepository) FindByID(ctx context.Context, id string) (*domain.Ethnicity, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ethnicity ID: %w", err)
+	}
+
+	var ethnicity domain.Ethnicity
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&ethnicity)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find ethnicity: %w", err)
+	}
+	return &ethnicity, nil
+}
+
+// FindByCode finds an ethnicity by its code within a country
+func (r *mongoEthnicityRepository) FindByCode(ctx context.Context, countryCode, code string) (*domain.Ethnicity, error) {
+	var ethnicity domain.Ethnicity
+	opts := options.FindOne().SetHint(bson.D{{Key: "countryCode", Value: 1}, {Key: "code", Value: 1}})
+	err := r.collection.FindOne(ctx, bson.M{"countryCode": countryCode, "code": code}, opts).Decode(&ethnicity)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find ethnicity: %w", err)
+	}
+	return &ethnicity, nil
+}
+
+// List lists active ethnicities ordered by country and code, restricted to one country unless
+// countryCode is empty
+func (r *mongoEthnicityRepository) List(ctx context.Context, countryCode string, page, perPage int) ([]*domain.Ethnicity, int64, error) {
+	filter := bson.M{"status": "active"}
+	if countryCode != "" {
+		filter["countryCode"] = countryCode
+	}
+	hint := bson.D{{Key: "status", Value: 1}, {Key: "countryCode", Value: 1}, {Key: "code", Value: 1}}
+
+	total, err := r.collection.CountDocuments(ctx, filter, options.Count().SetHint(hint))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count ethnicities: %w", err)
+	}
+
+	opts := options.Find().
+		SetSkip(int64((page - 1) * perPage)).
+		SetLimit(int64(perPage)).
+		SetSort(bson.D{{Key: "countryCode", Value: 1}, {Key: "code", Value: 1}}).
+		SetHint(hint) // Index also covers the sort
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list ethnicities: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var ethnicities []*domain.Ethnicity
+	if err = cursor.All(ctx, &ethnicities); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode ethnicities: %w", err)
+	}
+
+	return ethnicities, total, nil
+}
+
+// CountByCountry counts the ethnicities of a country regardless of status
+func (r *mongoEthnicityRepository) CountByCountry(ctx context.Context, countryCode string) (int64, error) {
+	opts := options.Count().SetHint(bson.D{{Key: "countryCode", Value: 1}, {Key: "code", Value: 1}})
+	count, err := r.collection.CountDocuments(ctx, bson.M{"countryCode": countryCode}, opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count ethnicities: %w", err)
+	}
+	return count, nil
+}
+
+// Update updates the name and status of an ethnicity
+func (r *mongoEthnicityRepository) Update(ctx context.Context, ethnicity *domain.Ethnicity) error {
+	ethnicity.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":      ethnicity.Name,
+			"status":    ethnicity.Status,
+			"updatedAt": ethnicity.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": ethnicity.ID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update ethnicity: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("ethnicity %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// Delete deletes an ethnicity
+func (r *mongoEthnicityRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid ethnicity ID: %w", err)
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return fmt.Errorf("failed to delete ethnicity: %w", err)
+	}
+	return nil
+}
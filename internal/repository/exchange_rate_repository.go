@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ExchangeRateRepository handles exchange rate data access.
+// A currency pair has at most one rate per day.
+type ExchangeRateRepository interface {
+	Upsert(ctx context.Context, rate *domain.ExchangeRate) (bool, error)
+	FindByID(ctx context.Context, id string) (*domain.ExchangeRate, error)
+	FindLatest(ctx context.Context, base, quote string, at time.Time) (*domain.ExchangeRate, error)
+	List(ctx context.Context, filter domain.ExchangeRateFilter, page, perPage int) ([]*domain.ExchangeRate, int64, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// mongoExchangeRateRepository is the MongoDB implementation of ExchangeRateRepository
+type mongoExchangeRateRepository struct {
+	collection *mongo.Collection
+}
+
+// NewExchangeRateRepository creates a new MongoDB backed exchange rate repository
+func NewExchangeRateRepository(db *mongo.Database) ExchangeRateRepository {
+	collection := db.Collection("exchange_rates")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "base", Value: 1},
+				{Key: "quote", Value: 1},
+				{Key: "date", Value: -1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "date", Value: -1}},
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &mongoExchangeRateRepository{collection: collection}
+}
+
+// Upsert stores the rate of its pair and day, replacing the rate and source of an existing one.
+// It reports whether a new rate was created.
+func (r *mongoExchangeRateRepository) Upsert(ctx context.Context, rate *domain.ExchangeRate) (bool, error) {
+	now := time.Now()
+	filter := bson.M{"base": rate.Base, "quote": rate.Quote, "date": rate.Date}
+	update := bson.M{
+		"$set": bson.M{
+			"rate":      rate.Rate,
+			"source":    rate.Source,
+			"updatedAt": now,
+		},
+		"$setOnInsert": bson.M{"createdAt": now},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, fmt.Errorf("failed to upsert exchange rate: %w", err)
+	}
+
+	if result.UpsertedID != nil {
+		rate.ID = result.UpsertedID.(primitive.ObjectID)
+		rate.CreatedAt = now
+		rate.UpdatedAt = now
+		return true, nil
+	}
+
+	var stored domain.ExchangeRate
+	if err := r.collection.FindOne(ctx, filter).Decode(&stored); err != nil {
+		return false, fmt.Errorf("failed to find exchange rate: %w", err)
+	}
+	rate.ID = stored.ID
+	rate.CreatedAt = stored.CreatedAt
+	rate.UpdatedAt = stored.UpdatedAt
+	return false, nil
+}
+
+// FindByID finds an exchange rate by ID
+func (r *mongoExchangeRateRepository) FindByID(ctx context.Context, id string) (*domain.ExchangeRate, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exchange rate ID: %w", err)
+	}
+
+	var rate domain.ExchangeRate
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&rate)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find exchange rate: %w", err)
+	}
+	return &rate, nil
+}
+
+// FindLatest finds the most recent rate of a pair dated on or before at
+func (r *mongoExchangeRateRepository) FindLatest(ctx context.Context, base, quote string, at time.Time) (*domain.ExchangeRate, error) {
+	filter := bson.M{"base": base, "quote": quote, "date": bson.M{"$lte": at}}
+	opts := options.FindOne().
+		SetSort(bson.D{{Key: "date", Value: -1}}).
+		SetHint(bson.D{{Key: "base", Value: 1}, {Key: "quote", Value: 1}, {Key: "date", Value: -1}})
+
+	var rate domain.ExchangeRate
+	err := r.collection.FindOne(ctx, filter, opts).Decode(&rate)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find exchange rate: %w", err)
+	}
+	return &rate, nil
+}
+
+// List lists rates matching the filter, newest first and then by pair
+func (r *mongoExchangeRateRepository) List(ctx context.Context, filter domain.ExchangeRateFilter, page, perPage int) ([]*domain.ExchangeRate, int64, error) {
+	query := bson.M{}
+	if filter.Base != "" {
+		query["base"] = filter.Base
+	}
+	if filter.Quote != "" {
+		query["quote"] = filter.Quote
+	}
+	if filter.From != nil || filter.To != nil {
+		dates := bson.M{}
+		if filter.From != nil {
+			dates["$gte"] = *filter.From
+		}
+		if filter.To != nil {
+			dates["$lte"] = *filter.To
+		}
+		query["date"] = dates
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count exchange rates: %w", err)
+	}
+
+	opts := options.Find().
+		SetSkip(int64((page - 1) * perPage)).
+		SetLimit(int64(perPage)).
+		SetSort(bson.D{{Key: "date", Value: -1}, {Key: "base", Value: 1}, {Key: "quote", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list exchange rates: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rates []*domain.ExchangeRate
+	if err = cursor.All(ctx, &rates); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode exchange rates: %w", err)
+	}
+
+	return rates, total, nil
+}
+
+// Delete deletes an exchange rate
+func (r *mongoExchangeRateRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid exchange rate ID: %w", err)
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return fmt.Errorf("failed to delete exchange rate: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// currentLocationFilter matches administrative units in effect at t. Units without
+// effectiveFrom or effectiveTo are unbounded on that side.
+func currentLocationFilter(t time.Time) bson.M {
+	return bson.M{"$and": bson.A{
+		bson.M{"$or": bson.A{bson.M{"effectiveFrom": nil}, bson.M{"effectiveFrom": bson.M{"$lte": t}}}},
+		bson.M{"$or": bson.A{bson.M{"effectiveTo": nil}, bson.M{"effectiveTo": bson.M{"$gt": t}}}},
+	}}
+}
+
+// nextValidityChange returns the earliest effectiveFrom or effectiveTo after t among the units
+// matching filter, or nil when none of them changes after t
+func nextValidityChange(ctx context.Context, collection *mongo.Collection, filter bson.M, t time.Time) (*time.Time, error) {
+	var next *time.Time
+	for _, field := range []string{"effectiveFrom", "effectiveTo"} {
+		query := bson.M{field: bson.M{"$gt": t}}
+		for key, value := range filter {
+			query[key] = value
+		}
+		opts := options.FindOne().SetSort(bson.D{{Key: field, Value: 1}}).SetProjection(bson.M{field: 1})
+
+		var validity domain.LocationValidity
+		err := collection.FindOne(ctx, query, opts).Decode(&validity)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find validity changes: %w", err)
+		}
+
+		boundary := validity.NextChangeAfter(t)
+		if boundary != nil && (next == nil || boundary.Before(*next)) {
+			next = boundary
+		}
+	}
+	return next, nil
+}
+
+// earliestValidityChange returns the earliest boundary after t of the given validity periods, or nil
+func earliestValidityChange(validities []domain.LocationValidity, t time.Time) *time.Time {
+	var next *time.Time
+	for _, validity := range validities {
+		boundary := validity.NextChangeAfter(t)
+		if boundary != nil && (next == nil || boundary.Before(*next)) {
+			next = boundary
+		}
+	}
+	return next
+}
+
+// cloneLocationValidity copies the period and successors so stored units are not shared with callers
+func cloneLocationValidity(v domain.LocationValidity) domain.LocationValidity {
+	clone := domain.LocationValidity{SuccessorCodes: copySlice(v.SuccessorCodes)}
+	if v.EffectiveFrom != nil {
+		from := *v.EffectiveFrom
+		clone.EffectiveFrom = &from
+	}
+	if v.EffectiveTo != nil {
+		to := *v.EffectiveTo
+		clone.EffectiveTo = &to
+	}
+	return clone
+}
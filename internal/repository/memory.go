@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+)
+
+// pageBounds returns the slice bounds of a page over total items, mirroring skip/limit semantics
+func pageBounds(total, page, perPage int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		return 0, total
+	}
+
+	start := (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// copyMap returns a shallow copy of a map so stored documents are not shared with callers
+func copyMap[K comparable, V any](m map[K]V) map[K]V {
+	if m == nil {
+		return nil
+	}
+	out := make(map[K]V, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// copySlice returns a copy of a slice so stored documents are not shared with callers
+func copySlice[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	return append([]T(nil), s...)
+}
+
+// matchesSearchText mirrors the anchored or unanchored $regex the MongoDB repositories run over searchText
+func matchesSearchText(terms []string, query domain.LocationQuery) bool {
+	for _, term := range terms {
+		if query.Prefix && strings.HasPrefix(term, query.Text) {
+			return true
+		}
+		if !query.Prefix && strings.Contains(term, query.Text) {
+			return true
+		}
+	}
+	return false
+}
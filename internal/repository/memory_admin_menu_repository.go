@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryAdminMenuRepository is an in-memory implementation of AdminMenuRepository.
+// It enforces the same unique (tenantId, code) index as the MongoDB implementation.
+type memoryAdminMenuRepository struct {
+	mu    sync.RWMutex
+	menus map[primitive.ObjectID]*domain.AdminMenu
+}
+
+// NewMemoryAdminMenuRepository creates a new in-memory admin menu repository
+func NewMemoryAdminMenuRepository() AdminMenuRepository {
+	return &memoryAdminMenuRepository{
+		menus: make(map[primitive.ObjectID]*domain.AdminMenu),
+	}
+}
+
+// Create creates a new admin menu
+func (r *memoryAdminMenuRepository) Create(ctx context.Context, menu *domain.AdminMenu) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if menu.ID.IsZero() {
+		menu.ID = primitive.NewObjectID()
+	}
+	if _, ok := r.menus[menu.ID]; ok {
+		return fmt.Errorf("failed to create admin menu: %w", ErrDuplicateKey)
+	}
+	for _, existing := range r.menus {
+		if existing.TenantID == menu.TenantID && existing.Code == menu.Code {
+			return fmt.Errorf("failed to create admin menu: %w", ErrDuplicateKey)
+		}
+	}
+
+	menu.CreatedAt = time.Now()
+	menu.UpdatedAt = time.Now()
+	r.menus[menu.ID] = cloneAdminMenu(menu)
+	return nil
+}
+
+// FindByID finds an admin menu by ID
+func (r *memoryAdminMenuRepository) FindByID(ctx context.Context, id string) (*domain.AdminMenu, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid admin menu ID: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	menu, ok := r.menus[objectID]
+	if !ok {
+		return nil, nil
+	}
+	return cloneAdminMenu(menu), nil
+}
+
+// FindByCode finds an admin menu by code and tenant
+func (r *memoryAdminMenuRepository) FindByCode(ctx context.Context, tenantID, code string) (*domain.AdminMenu, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, menu := range r.menus {
+		if menu.TenantID == tenantID && menu.Code == code {
+			return cloneAdminMenu(menu), nil
+		}
+	}
+	return nil, nil
+}
+
+// List lists admin menus of a tenant ordered by order and code with pagination
+func (r *memoryAdminMenuRepository) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.AdminMenu, int64, error) {
+	matched := r.filter(func(menu *domain.AdminMenu) bool { return menu.TenantID == tenantID })
+
+	start, end := pageBounds(len(matched), page, perPage)
+	return matched[start:end], int64(len(matched)), nil
+}
+
+// ListAll lists every admin menu of a tenant ordered by order and code
+func (r *memoryAdminMenuRepository) ListAll(ctx context.Context, tenantID string) ([]*domain.AdminMenu, error) {
+	return r.filter(func(menu *domain.AdminMenu) bool { return menu.TenantID == tenantID }), nil
+}
+
+// ListByModule lists the admin menus of a module ordered by order and code
+func (r *memoryAdminMenuRepository) ListByModule(ctx context.Context, tenantID, moduleCode string) ([]*domain.AdminMenu, error) {
+	return r.filter(func(menu *domain.AdminMenu) bool {
+		return menu.TenantID == tenantID && menu.ModuleCode == moduleCode
+	}), nil
+}
+
+// FindChildren finds the direct children of a menu ordered by order and code
+func (r *memoryAdminMenuRepository) FindChildren(ctx context.Context, tenantID, parentID string) ([]*domain.AdminMenu, error) {
+	return r.filter(func(menu *domain.AdminMenu) bool {
+		return menu.TenantID == tenantID && menu.ParentID == parentID
+	}), nil
+}
+
+// Update updates the mutable fields of an admin menu
+func (r *memoryAdminMenuRepository) Update(ctx context.Context, menu *domain.AdminMenu) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.menus[menu.ID]
+	if !ok {
+		return fmt.Errorf("admin menu %w", ErrNotFound)
+	}
+
+	menu.UpdatedAt = time.Now()
+	stored.ModuleCode = menu.ModuleCode
+	stored.ParentID = menu.ParentID
+	stored.Name = menu.Name
+	stored.Title = copyMap(menu.Title)
+	stored.Icon = menu.Icon
+	stored.Path = menu.Path
+	stored.Component = menu.Component
+	stored.Order = menu.Order
+	stored.Permissions = copySlice(menu.Permissions)
+	stored.IsVisible = menu.IsVisible
+	stored.Status = menu.Status
+	stored.UpdatedAt = menu.UpdatedAt
+	return nil
+}
+
+// Reorder applies the parent and order of several menu items of a tenant.
+// Every item is checked before any is written so a missing item leaves the store unchanged.
+func (r *memoryAdminMenuRepository) Reorder(ctx context.Context, tenantID string, positions []domain.MenuPosition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, position := range positions {
+		if stored, ok := r.menus[position.ID]; !ok || stored.TenantID != tenantID {
+			return fmt.Errorf("admin menu %w", ErrNotFound)
+		}
+	}
+
+	now := time.Now()
+	for _, position := range positions {
+		stored := r.menus[position.ID]
+		stored.ParentID = position.ParentID
+		stored.Order = position.Order
+		stored.UpdatedAt = now
+	}
+	return nil
+}
+
+// snapshot captures the stored menus for a memoryTransactor rollback
+func (r *memoryAdminMenuRepository) snapshot() func() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	saved := make(map[primitive.ObjectID]*domain.AdminMenu, len(r.menus))
+	for id, menu := range r.menus {
+		saved[id] = cloneAdminMenu(menu)
+	}
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.menus = saved
+	}
+}
+
+// Delete deletes an admin menu
+func (r *memoryAdminMenuRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid admin menu ID: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.menus, objectID)
+	return nil
+}
+
+// filter returns copies of the matching menus ordered by order and code
+func (r *memoryAdminMenuRepository) filter(match func(menu *domain.AdminMenu) bool) []*domain.AdminMenu {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	menus := make([]*domain.AdminMenu, 0)
+	for _, menu := range r.menus {
+		if match(menu) {
+			menus = append(menus, cloneAdminMenu(menu))
+		}
+	}
+	sort.Slice(menus, func(i, j int) bool {
+		if menus[i].Order != menus[j].Order {
+			return menus[i].Order < menus[j].Order
+		}
+		return menus[i].Code < menus[j].Code
+	})
+	return menus
+}
+
+func cloneAdminMenu(menu *domain.AdminMenu) *domain.AdminMenu {
+	clone := *menu
+	clone.Title = copyMap(menu.Title)
+	clone.Permissions = copySlice(menu.Permissions)
+	return &clone
+}
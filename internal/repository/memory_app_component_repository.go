@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryAppComponentRepository is an in-memory implementation of AppComponentRepository.
+// It enforces the same unique (tenantId, code) index as the MongoDB implementation.
+type memoryAppComponentRepository struct {
+	mu         sync.RWMutex
+	components map[primitive.ObjectID]*domain.AppComponent
+}
+
+// NewMemoryAppComponentRepository creates a new in-memory app component repository
+func NewMemoryAppComponentRepository() AppComponentRepository {
+	return &memoryAppComponentRepository{
+		components: make(map[primitive.ObjectID]*domain.AppComponent),
+	}
+}
+
+// Create creates a new app component
+func (r *memoryAppComponentRepository) Create(ctx context.Context, component *domain.AppComponent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if component.ID.IsZero() {
+		component.ID = primitive.NewObjectID()
+	}
+	if _, ok := r.components[component.ID]; ok {
+		return fmt.Errorf("failed to create app component: %w", ErrDuplicateKey)
+	}
+	for _, existing := range r.components {
+		if existing.TenantID == component.TenantID && existing.Code == component.Code {
+			return fmt.Errorf("failed to create app component: %w", ErrDuplicateKey)
+		}
+	}
+
+	component.CreatedAt = time.Now()
+	component.UpdatedAt = time.Now()
+	r.components[component.ID] = cloneAppComponent(component)
+	return nil
+}
+
+// FindByID finds an app component by ID
+func (r *memoryAppComponentRepository) FindByID(ctx context.Context, id string) (*domain.AppComponent, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app component ID: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	component, ok := r.components[objectID]
+	if !ok {
+		return nil, nil
+	}
+	return cloneAppComponent(component), nil
+}
+
+// FindByCode finds an app component by code and tenant
+func (r *memoryAppComponentRepository) FindByCode(ctx context.Context, tenantID, code string) (*domain.AppComponent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, component := range r.components {
+		if component.TenantID == tenantID && component.Code == code {
+			return cloneAppComponent(component), nil
+		}
+	}
+	return nil, nil
+}
+
+// List lists app components with pagination, newest first
+func (r *memoryAppComponentRepository) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.AppComponent, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := make([]*domain.AppComponent, 0)
+	for _, component := range r.components {
+		if component.TenantID == tenantID {
+			matched = append(matched, component)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].ID.Hex() > matched[j].ID.Hex()
+		}
+		return matched[i].CreatedAt.After(matched[j].CreatedAt)
+	})
+
+	start, end := pageBounds(len(matched), page, perPage)
+	components := make([]*domain.AppComponent, 0, end-start)
+	for _, component := range matched[start:end] {
+		components = append(components, cloneAppComponent(component))
+	}
+	return components, int64(len(matched)), nil
+}
+
+// Update updates the mutable fields of an app component
+func (r *memoryAppComponentRepository) Update(ctx context.Context, component *domain.AppComponent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.components[component.ID]
+	if !ok {
+		return fmt.Errorf("app component %w", ErrNotFound)
+	}
+
+	component.UpdatedAt = time.Now()
+	stored.Name = component.Name
+	stored.Description = component.Description
+	stored.Icon = component.Icon
+	stored.Version = component.Version
+	stored.Status = component.Status
+	stored.Config = copyMap(component.Config)
+	stored.UpdatedAt = component.UpdatedAt
+	stored.UpdatedBy = component.UpdatedBy
+	return nil
+}
+
+// Delete deletes an app component
+func (r *memoryAppComponentRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid app component ID: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.components, objectID)
+	return nil
+}
+
+// snapshot captures the stored components for a memoryTransactor rollback
+func (r *memoryAppComponentRepository) snapshot() func() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	saved := make(map[primitive.ObjectID]*domain.AppComponent, len(r.components))
+	for id, component := range r.components {
+		saved[id] = cloneAppComponent(component)
+	}
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.components = saved
+	}
+}
+
+// FindByIDs finds multiple app components by IDs, silently skipping invalid IDs
+func (r *memoryAppComponentRepository) FindByIDs(ctx context.Context, ids []string) ([]*domain.AppComponent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	components := []*domain.AppComponent{}
+	seen := make(map[primitive.ObjectID]bool, len(ids))
+	for _, id := range ids {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil || seen[objectID] {
+			continue
+		}
+		seen[objectID] = true
+		if component, ok := r.components[objectID]; ok {
+			components = append(components, cloneAppComponent(component))
+		}
+	}
+	return components, nil
+}
+
+// ListByCode lists the app components of every tenant with a code, ordered by tenant
+func (r *memoryAppComponentRepository) ListByCode(ctx context.Context, code string) ([]*domain.AppComponent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	components := []*domain.AppComponent{}
+	for _, component := range r.components {
+		if component.Code == code {
+			components = append(components, cloneAppComponent(component))
+		}
+	}
+	sort.Slice(components, func(i, j int) bool { return components[i].TenantID < components[j].TenantID })
+	return components, nil
+}
+
+func cloneAppComponent(component *domain.AppComponent) *domain.AppComponent {
+	clone := *component
+	clone.Config = copyMap(component.Config)
+	return &clone
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryConfigOverrideRepository is an in-memory implementation of ConfigOverrideRepository.
+// Like the MongoDB implementation it keeps one override per tenant, key and environment; expired
+// overrides are kept until they are replaced or deleted.
+type memoryConfigOverrideRepository struct {
+	mu        sync.RWMutex
+	overrides map[string]*domain.ConfigOverride // tenant, key and environment -> override
+}
+
+// NewMemoryConfigOverrideRepository creates a new in-memory config override repository
+func NewMemoryConfigOverrideRepository() ConfigOverrideRepository {
+	return &memoryConfigOverrideRepository{
+		overrides: make(map[string]*domain.ConfigOverride),
+	}
+}
+
+// Set creates the override of a key for a tenant and environment, replacing any existing one
+func (r *memoryConfigOverrideRepository) Set(ctx context.Context, override *domain.ConfigOverride) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := overrideKey(override.TenantID, override.Key, override.Environment)
+	if existing, ok := r.overrides[key]; ok {
+		override.ID = existing.ID
+	} else {
+		override.ID = primitive.NewObjectID()
+	}
+	override.CreatedAt = time.Now()
+	r.overrides[key] = cloneConfigOverride(override)
+	return nil
+}
+
+// List lists the overrides of a tenant in every environment ordered by key and environment,
+// optionally restricted to keys starting with a prefix. Expired overrides may be included.
+func (r *memoryConfigOverrideRepository) List(ctx context.Context, tenantID, prefix string) ([]*domain.ConfigOverride, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	overrides := make([]*domain.ConfigOverride, 0)
+	for _, override := range r.overrides {
+		if override.TenantID == tenantID && strings.HasPrefix(override.Key, prefix) {
+			overrides = append(overrides, cloneConfigOverride(override))
+		}
+	}
+	sort.Slice(overrides, func(i, j int) bool {
+		if overrides[i].Key != overrides[j].Key {
+			return overrides[i].Key < overrides[j].Key
+		}
+		return overrides[i].Environment < overrides[j].Environment
+	})
+	return overrides, nil
+}
+
+// Delete deletes the override of a key for a tenant and environment
+func (r *memoryConfigOverrideRepository) Delete(ctx context.Context, tenantID, key, environment string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k := overrideKey(tenantID, key, environment)
+	if _, ok := r.overrides[k]; !ok {
+		return fmt.Errorf("config override %w", ErrNotFound)
+	}
+	delete(r.overrides, k)
+	return nil
+}
+
+func overrideKey(tenantID, key, environment string) string {
+	return tenantID + "\x00" + key + "\x00" + environment
+}
+
+func cloneConfigOverride(override *domain.ConfigOverride) *domain.ConfigOverride {
+	clone := *override
+	clone.Value = copyConfigValue(override.Value)
+	if override.ExpiresAt != nil {
+		expiresAt := *override.ExpiresAt
+		clone.ExpiresAt = &expiresAt
+	}
+	return &clone
+}
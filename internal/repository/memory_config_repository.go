@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryConfigRepository is an in-memory implementation of ConfigRepository.
+// It enforces the same unique (tenantId, configKey, environment) index as the MongoDB implementation.
+type memoryConfigRepository struct {
+	mu      sync.RWMutex
+	entries map[primitive.ObjectID]*domain.ConfigEntry
+}
+
+// NewMemoryConfigRepository creates a new in-memory config repository
+func NewMemoryConfigRepository() ConfigRepository {
+	return &memoryConfigRepository{
+		entries: make(map[primitive.ObjectID]*domain.ConfigEntry),
+	}
+}
+
+// Create creates a new config entry
+func (r *memoryConfigRepository) Create(ctx context.Context, entry *domain.ConfigEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.findByKey(entry.TenantID, entry.Key, entry.Environment) != nil {
+		return fmt.Errorf("failed to create config: %w", ErrDuplicateKey)
+	}
+
+	if entry.ID.IsZero() {
+		entry.ID = primitive.NewObjectID()
+	}
+	entry.CreatedAt = time.Now()
+	entry.UpdatedAt = time.Now()
+	r.entries[entry.ID] = cloneConfigEntry(entry)
+	return nil
+}
+
+// FindByKey finds the config entry of a key for a tenant and environment
+func (r *memoryConfigRepository) FindByKey(ctx context.Context, tenantID, key, environment string) (*domain.ConfigEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if entry := r.findByKey(tenantID, key, environment); entry != nil {
+		return cloneConfigEntry(entry), nil
+	}
+	return nil, nil
+}
+
+// List lists the config entries of a tenant ordered by key and environment, optionally restricted
+// to one environment and to keys starting with a prefix
+func (r *memoryConfigRepository) List(ctx context.Context, query domain.ConfigQuery, page, perPage int) ([]*domain.ConfigEntry, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := make([]*domain.ConfigEntry, 0)
+	for _, entry := range r.entries {
+		if entry.TenantID != query.TenantID ||
+			(query.Environment != "" && entry.Environment != query.Environment) ||
+			!strings.HasPrefix(entry.Key, query.Prefix) {
+			continue
+		}
+		matched = append(matched, entry)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].Key != matched[j].Key {
+			return matched[i].Key < matched[j].Key
+		}
+		return matched[i].Environment < matched[j].Environment
+	})
+
+	start, end := pageBounds(len(matched), page, perPage)
+	entries := make([]*domain.ConfigEntry, 0, end-start)
+	for _, entry := range matched[start:end] {
+		entries = append(entries, cloneConfigEntry(entry))
+	}
+	return entries, int64(len(matched)), nil
+}
+
+// Update updates the description, secrecy, active value and version numbers of a config entry
+func (r *memoryConfigRepository) Update(ctx context.Context, entry *domain.ConfigEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.entries[entry.ID]
+	if !ok {
+		return fmt.Errorf("config %w", ErrNotFound)
+	}
+
+	entry.UpdatedAt = time.Now()
+	stored.Description = entry.Description
+	stored.ValueType = entry.ValueType
+	stored.Value = copyConfigValue(entry.Value)
+	stored.Secret = entry.Secret
+	stored.ActiveVersion = entry.ActiveVersion
+	stored.LatestVersion = entry.LatestVersion
+	stored.UpdatedAt = entry.UpdatedAt
+	stored.UpdatedBy = entry.UpdatedBy
+	return nil
+}
+
+// Delete deletes a config entry
+func (r *memoryConfigRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid config ID: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.entries, objectID)
+	return nil
+}
+
+func (r *memoryConfigRepository) findByKey(tenantID, key, environment string) *domain.ConfigEntry {
+	for _, entry := range r.entries {
+		if entry.TenantID == tenantID && entry.Key == key && entry.Environment == environment {
+			return entry
+		}
+	}
+	return nil
+}
+
+func cloneConfigEntry(entry *domain.ConfigEntry) *domain.ConfigEntry {
+	clone := *entry
+	clone.Value = copyConfigValue(entry.Value)
+	return &clone
+}
+
+// copyConfigValue deep-copies a config value so stored documents are not shared with callers
+func copyConfigValue(value interface{}) interface{} {
+	plain, err := domain.PlainConfigValue(value)
+	if err != nil {
+		return value
+	}
+	return plain
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryConfigRuleRepository is an in-memory implementation of ConfigRuleRepository.
+// It enforces the same unique (tenantId, keyPattern) index as the MongoDB implementation.
+type memoryConfigRuleRepository struct {
+	mu    sync.RWMutex
+	rules map[primitive.ObjectID]*domain.ConfigValidationRule
+}
+
+// NewMemoryConfigRuleRepository creates a new in-memory config validation rule repository
+func NewMemoryConfigRuleRepository() ConfigRuleRepository {
+	return &memoryConfigRuleRepository{
+		rules: make(map[primitive.ObjectID]*domain.ConfigValidationRule),
+	}
+}
+
+// Create creates a new rule; a tenant has one rule per key pattern
+func (r *memoryConfigRuleRepository) Create(ctx context.Context, rule *domain.ConfigValidationRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.taken(rule) {
+		return fmt.Errorf("failed to create config rule: %w", ErrDuplicateKey)
+	}
+
+	if rule.ID.IsZero() {
+		rule.ID = primitive.NewObjectID()
+	}
+	rule.CreatedAt = time.Now()
+	rule.UpdatedAt = time.Now()
+	r.rules[rule.ID] = cloneConfigRule(rule)
+	return nil
+}
+
+// FindByID finds a rule by ID
+func (r *memoryConfigRuleRepository) FindByID(ctx context.Context, id string) (*domain.ConfigValidationRule, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config rule ID: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rule, ok := r.rules[objectID]
+	if !ok {
+		return nil, nil
+	}
+	return cloneConfigRule(rule), nil
+}
+
+// List lists the rules of a tenant ordered by key pattern
+func (r *memoryConfigRuleRepository) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.ConfigValidationRule, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := make([]*domain.ConfigValidationRule, 0)
+	for _, rule := range r.rules {
+		if rule.TenantID == tenantID {
+			matched = append(matched, rule)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].KeyPattern < matched[j].KeyPattern })
+
+	start, end := pageBounds(len(matched), page, perPage)
+	rules := make([]*domain.ConfigValidationRule, 0, end-start)
+	for _, rule := range matched[start:end] {
+		rules = append(rules, cloneConfigRule(rule))
+	}
+	return rules, int64(len(matched)), nil
+}
+
+// Update updates the constraints of a rule
+func (r *memoryConfigRuleRepository) Update(ctx context.Context, rule *domain.ConfigValidationRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.rules[rule.ID]
+	if !ok {
+		return fmt.Errorf("config rule %w", ErrNotFound)
+	}
+	if r.taken(&domain.ConfigValidationRule{ID: rule.ID, TenantID: stored.TenantID, KeyPattern: rule.KeyPattern}) {
+		return fmt.Errorf("failed to update config rule: %w", ErrDuplicateKey)
+	}
+
+	rule.UpdatedAt = time.Now()
+	updated := cloneConfigRule(rule)
+	updated.TenantID = stored.TenantID
+	updated.CreatedAt = stored.CreatedAt
+	updated.CreatedBy = stored.CreatedBy
+	r.rules[rule.ID] = updated
+	return nil
+}
+
+// Delete deletes a rule
+func (r *memoryConfigRuleRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid config rule ID: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.rules, objectID)
+	return nil
+}
+
+// taken reports whether another rule of the tenant has the key pattern of rule
+func (r *memoryConfigRuleRepository) taken(rule *domain.ConfigValidationRule) bool {
+	for _, existing := range r.rules {
+		if existing.ID != rule.ID && existing.TenantID == rule.TenantID && existing.KeyPattern == rule.KeyPattern {
+			return true
+		}
+	}
+	return false
+}
+
+func cloneConfigRule(rule *domain.ConfigValidationRule) *domain.ConfigValidationRule {
+	clone := *rule
+	clone.Min = copyConfigValue(rule.Min)
+	clone.Max = copyConfigValue(rule.Max)
+	if rule.AllowedValues != nil {
+		clone.AllowedValues = append([]string(nil), rule.AllowedValues...)
+	}
+	return &clone
+}
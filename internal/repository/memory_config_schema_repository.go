@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryConfigSchemaRepository is an in-memory implementation of ConfigSchemaRepository.
+// It enforces the same unique (target, code) index as the MongoDB implementation.
+type memoryConfigSchemaRepository struct {
+	mu      sync.RWMutex
+	schemas map[primitive.ObjectID]*domain.ConfigSchema
+}
+
+// NewMemoryConfigSchemaRepository creates a new in-memory config schema repository
+func NewMemoryConfigSchemaRepository() ConfigSchemaRepository {
+	return &memoryConfigSchemaRepository{
+		schemas: make(map[primitive.ObjectID]*domain.ConfigSchema),
+	}
+}
+
+// Create creates a new schema; a target has one schema per code
+func (r *memoryConfigSchemaRepository) Create(ctx context.Context, schema *domain.ConfigSchema) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.find(schema.Target, schema.Code) != nil {
+		return fmt.Errorf("failed to create config schema: %w", ErrDuplicateKey)
+	}
+
+	if schema.ID.IsZero() {
+		schema.ID = primitive.NewObjectID()
+	}
+	schema.CreatedAt = time.Now()
+	schema.UpdatedAt = time.Now()
+	r.schemas[schema.ID] = cloneConfigSchema(schema)
+	return nil
+}
+
+// FindByID finds a schema by ID
+func (r *memoryConfigSchemaRepository) FindByID(ctx context.Context, id string) (*domain.ConfigSchema, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config schema ID: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schema, ok := r.schemas[objectID]
+	if !ok {
+		return nil, nil
+	}
+	return cloneConfigSchema(schema), nil
+}
+
+// FindBySubject finds the schema of a target and code
+func (r *memoryConfigSchemaRepository) FindBySubject(ctx context.Context, target, code string) (*domain.ConfigSchema, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if schema := r.find(target, code); schema != nil {
+		return cloneConfigSchema(schema), nil
+	}
+	return nil, nil
+}
+
+// List lists the schemas of a target, or of every target when target is empty, ordered by
+// target and code
+func (r *memoryConfigSchemaRepository) List(ctx context.Context, target string, page, perPage int) ([]*domain.ConfigSchema, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := make([]*domain.ConfigSchema, 0)
+	for _, schema := range r.schemas {
+		if target == "" || schema.Target == target {
+			matched = append(matched, schema)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].Target != matched[j].Target {
+			return matched[i].Target < matched[j].Target
+		}
+		return matched[i].Code < matched[j].Code
+	})
+
+	start, end := pageBounds(len(matched), page, perPage)
+	schemas := make([]*domain.ConfigSchema, 0, end-start)
+	for _, schema := range matched[start:end] {
+		schemas = append(schemas, cloneConfigSchema(schema))
+	}
+	return schemas, int64(len(matched)), nil
+}
+
+// Update updates the document of a schema when its stored version is still version, the one the
+// update was read at; its target and code are immutable
+func (r *memoryConfigSchemaRepository) Update(ctx context.Context, schema *domain.ConfigSchema, version int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.schemas[schema.ID]
+	if !ok {
+		return fmt.Errorf("config schema %w", ErrNotFound)
+	}
+	if stored.Version != version {
+		return fmt.Errorf("config schema %w", ErrVersionConflict)
+	}
+
+	schema.UpdatedAt = time.Now()
+	updated := cloneConfigSchema(schema)
+	updated.Target = stored.Target
+	updated.Code = stored.Code
+	updated.CreatedAt = stored.CreatedAt
+	updated.CreatedBy = stored.CreatedBy
+	r.schemas[schema.ID] = updated
+	return nil
+}
+
+// Delete deletes a schema
+func (r *memoryConfigSchemaRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid config schema ID: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.schemas, objectID)
+	return nil
+}
+
+func (r *memoryConfigSchemaRepository) find(target, code string) *domain.ConfigSchema {
+	for _, schema := range r.schemas {
+		if schema.Target == target && schema.Code == code {
+			return schema
+		}
+	}
+	return nil
+}
+
+func cloneConfigSchema(schema *domain.ConfigSchema) *domain.ConfigSchema {
+	clone := *schema
+	if schema.Schema != nil {
+		clone.Schema = copyConfigValue(map[string]interface{}(schema.Schema)).(map[string]interface{})
+	}
+	return &clone
+}
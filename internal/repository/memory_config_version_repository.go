@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryConfigVersionRepository is an in-memory implementation of ConfigVersionRepository.
+// It enforces the same unique (configId, versionNumber) index as the MongoDB implementation.
+type memoryConfigVersionRepository struct {
+	mu       sync.RWMutex
+	versions map[primitive.ObjectID][]*domain.ConfigVersion // config ID -> versions in creation order
+}
+
+// NewMemoryConfigVersionRepository creates a new in-memory config version repository
+func NewMemoryConfigVersionRepository() ConfigVersionRepository {
+	return &memoryConfigVersionRepository{
+		versions: make(map[primitive.ObjectID][]*domain.ConfigVersion),
+	}
+}
+
+// Create creates a new config version; a version number already taken is a duplicate key
+func (r *memoryConfigVersionRepository) Create(ctx context.Context, version *domain.ConfigVersion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.versions[version.ConfigID] {
+		if existing.Version == version.Version {
+			return fmt.Errorf("failed to create config version: %w", ErrDuplicateKey)
+		}
+	}
+
+	if version.ID.IsZero() {
+		version.ID = primitive.NewObjectID()
+	}
+	version.CreatedAt = time.Now()
+	r.versions[version.ConfigID] = append(r.versions[version.ConfigID], cloneConfigVersion(version))
+	return nil
+}
+
+// Find finds a version of a config entry by number
+func (r *memoryConfigVersionRepository) Find(ctx context.Context, configID string, number int) (*domain.ConfigVersion, error) {
+	objectID, err := primitive.ObjectIDFromHex(configID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config ID: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, version := range r.versions[objectID] {
+		if version.Version == number {
+			return cloneConfigVersion(version), nil
+		}
+	}
+	return nil, nil
+}
+
+// List lists the versions of a config entry, newest first
+func (r *memoryConfigVersionRepository) List(ctx context.Context, configID string, page, perPage int) ([]*domain.ConfigVersion, int64, error) {
+	objectID, err := primitive.ObjectIDFromHex(configID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid config ID: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := append([]*domain.ConfigVersion(nil), r.versions[objectID]...)
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Version > matched[j].Version })
+
+	start, end := pageBounds(len(matched), page, perPage)
+	versions := make([]*domain.ConfigVersion, 0, end-start)
+	for _, version := range matched[start:end] {
+		versions = append(versions, cloneConfigVersion(version))
+	}
+	return versions, int64(len(matched)), nil
+}
+
+// DeleteByConfig deletes every version of a config entry
+func (r *memoryConfigVersionRepository) DeleteByConfig(ctx context.Context, configID string) error {
+	objectID, err := primitive.ObjectIDFromHex(configID)
+	if err != nil {
+		return fmt.Errorf("invalid config ID: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.versions, objectID)
+	return nil
+}
+
+// snapshot captures the stored versions for a memoryTransactor rollback
+func (r *memoryConfigVersionRepository) snapshot() func() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	saved := make(map[primitive.ObjectID][]*domain.ConfigVersion, len(r.versions))
+	for configID, versions := range r.versions {
+		for _, version := range versions {
+			saved[configID] = append(saved[configID], cloneConfigVersion(version))
+		}
+	}
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.versions = saved
+	}
+}
+
+func cloneConfigVersion(version *domain.ConfigVersion) *domain.ConfigVersion {
+	clone := *version
+	clone.Value = copyConfigValue(version.Value)
+	return &clone
+}
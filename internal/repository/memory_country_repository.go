@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryCountryRepository is an in-memory implementation of CountryRepository.
+// It enforces the same unique code index as the MongoDB implementation.
+type memoryCountryRepository struct {
+	mu        sync.RWMutex
+	countries map[string]*domain.Country // keyed by code
+}
+
+// NewMemoryCountryRepository creates a new in-memory country repository
+func NewMemoryCountryRepository() CountryRepository {
+	return &memoryCountryRepository{
+		countries: make(map[string]*domain.Country),
+	}
+}
+
+// Create creates a new country
+func (r *memoryCountryRepository) Create(ctx context.Context, country *domain.Country) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.countries[country.Code]; ok {
+		return fmt.Errorf("failed to create country: %w", ErrDuplicateKey)
+	}
+
+	if country.ID.IsZero() {
+		country.ID = primitive.NewObjectID()
+	}
+	country.CreatedAt = time.Now()
+	country.UpdatedAt = time.Now()
+	r.countries[country.Code] = cloneCountry(country)
+	return nil
+}
+
+// FindByCode finds a country by code
+func (r *memoryCountryRepository) FindByCode(ctx context.Context, code string) (*domain.Country, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	country, ok := r.countries[code]
+	if !ok {
+		return nil, nil
+	}
+	return cloneCountry(country), nil
+}
+
+// List lists active countries ordered by code
+func (r *memoryCountryRepository) List(ctx context.Context, page, perPage int) ([]*domain.Country, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := make([]*domain.Country, 0)
+	for _, country := range r.countries {
+		if country.Status == "active" {
+			matched = append(matched, country)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Code < matched[j].Code })
+
+	start, end := pageBounds(len(matched), page, perPage)
+	countries := make([]*domain.Country, 0, end-start)
+	for _, country := range matched[start:end] {
+		countries = append(countries, cloneCountry(country))
+	}
+	return countries, int64(len(matched)), nil
+}
+
+// Update updates the mutable fields of a country
+func (r *memoryCountryRepository) Update(ctx context.Context, country *domain.Country) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var stored *domain.Country
+	for _, candidate := range r.countries {
+		if candidate.ID == country.ID {
+			stored = candidate
+			break
+		}
+	}
+	if stored == nil {
+		return fmt.Errorf("country %w", ErrNotFound)
+	}
+
+	country.UpdatedAt = time.Now()
+	stored.Code3 = country.Code3
+	stored.Name = copyMap(country.Name)
+	stored.NativeName = country.NativeName
+	stored.PhoneCode = country.PhoneCode
+	stored.Currency = country.Currency
+	stored.Flag = country.Flag
+	stored.Region = country.Region
+	stored.Status = country.Status
+	stored.UpdatedAt = country.UpdatedAt
+	return nil
+}
+
+// Delete deletes a country
+func (r *memoryCountryRepository) Delete(ctx context.Context, code string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.countries, code)
+	return nil
+}
+
+// FindByCodes finds multiple countries by codes
+func (r *memoryCountryRepository) FindByCodes(ctx context.Context, codes []string) ([]*domain.Country, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	countries := []*domain.Country{}
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		if country, ok := r.countries[code]; ok {
+			countries = append(countries, cloneCountry(country))
+		}
+	}
+	return countries, nil
+}
+
+// CountByCurrency counts the countries using a currency regardless of status
+func (r *memoryCountryRepository) CountByCurrency(ctx context.Context, currency string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, country := range r.countries {
+		if country.Currency == currency {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func cloneCountry(country *domain.Country) *domain.Country {
+	clone := *country
+	clone.Name = copyMap(country.Name)
+	return &clone
+}
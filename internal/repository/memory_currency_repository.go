@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryCurrencyRepository is an in-memory implementation of CurrencyRepository.
+// It enforces the same unique code index as the MongoDB implementation.
+type memoryCurrencyRepository struct {
+	mu         sync.RWMutex
+	currencies map[string]*domain.Currency // keyed by code
+}
+
+// NewMemoryCurrencyRepository creates a new in-memory currency repository
+func NewMemoryCurrencyRepository() CurrencyRepository {
+	return &memoryCurrencyRepository{
+		currencies: make(map[string]*domain.Currency),
+	}
+}
+
+// Create creates a new currency
+func (r *memoryCurrencyRepository) Create(ctx context.Context, currency *domain.Currency) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.currencies[currency.Code]; ok {
+		return fmt.Errorf("failed to create currency: %w", ErrDuplicateKey)
+	}
+
+	if currency.ID.IsZero() {
+		currency.ID = primitive.NewObjectID()
+	}
+	currency.CreatedAt = time.Now()
+	currency.UpdatedAt = time.Now()
+	r.currencies[currency.Code] = cloneCurrency(currency)
+	return nil
+}
+
+// FindByCode finds a currency by code
+func (r *memoryCurrencyRepository) FindByCode(ctx context.Context, code string) (*domain.Currency, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	currency, ok := r.currencies[code]
+	if !ok {
+		return nil, nil
+	}
+	return cloneCurrency(currency), nil
+}
+
+// List lists active currencies ordered by code
+func (r *memoryCurrencyRepository) List(ctx context.Context, page, perPage int) ([]*domain.Currency, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := make([]*domain.Currency, 0)
+	for _, currency := range r.currencies {
+		if currency.Status == "active" {
+			matched = append(matched, currency)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Code < matched[j].Code })
+
+	start, end := pageBounds(len(matched), page, perPage)
+	currencies := make([]*domain.Currency, 0, end-start)
+	for _, currency := range matched[start:end] {
+		currencies = append(currencies, cloneCurrency(currency))
+	}
+	return currencies, int64(len(matched)), nil
+}
+
+// Update updates the mutable fields of a currency
+func (r *memoryCurrencyRepository) Update(ctx context.Context, currency *domain.Currency) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var stored *domain.Currency
+	for _, candidate := range r.currencies {
+		if candidate.ID == currency.ID {
+			stored = candidate
+			break
+		}
+	}
+	if stored == nil {
+		return fmt.Errorf("currency %w", ErrNotFound)
+	}
+
+	currency.UpdatedAt = time.Now()
+	stored.Name = copyMap(currency.Name)
+	stored.Symbol = currency.Symbol
+	stored.DecimalDigits = currency.DecimalDigits
+	stored.Countries = copySlice(currency.Countries)
+	stored.Status = currency.Status
+	stored.UpdatedAt = currency.UpdatedAt
+	return nil
+}
+
+// Delete deletes a currency
+func (r *memoryCurrencyRepository) Delete(ctx context.Context, code string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.currencies, code)
+	return nil
+}
+
+func cloneCurrency(currency *domain.Currency) *domain.Currency {
+	clone := *currency
+	clone.Name = copyMap(currency.Name)
+	clone.Countries = copySlice(currency.Countries)
+	return &clone
+}
@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryDistrictRepository is an in-memory implementation of DistrictRepository.
+// It enforces the same unique code index as the MongoDB implementation.
+type memoryDistrictRepository struct {
+	mu        sync.RWMutex
+	districts map[string]*domain.District // keyed by code
+}
+
+// NewMemoryDistrictRepository creates a new in-memory district repository
+func NewMemoryDistrictRepository() DistrictRepository {
+	return &memoryDistrictRepository{
+		districts: make(map[string]*domain.District),
+	}
+}
+
+// Create creates a new district
+func (r *memoryDistrictRepository) Create(ctx context.Context, district *domain.District) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.districts[district.Code]; ok {
+		return fmt.Errorf("failed to create district: %w", ErrDuplicateKey)
+	}
+
+	if district.ID.IsZero() {
+		district.ID = primitive.NewObjectID()
+	}
+	district.CreatedAt = time.Now()
+	district.UpdatedAt = time.Now()
+	district.SearchText = domain.SearchTerms(district.Name)
+	r.districts[district.Code] = cloneDistrict(district)
+	return nil
+}
+
+// FindByCode finds a district by code
+func (r *memoryDistrictRepository) FindByCode(ctx context.Context, code string) (*domain.District, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	district, ok := r.districts[code]
+	if !ok {
+		return nil, nil
+	}
+	return cloneDistrict(district), nil
+}
+
+// FindByCodes finds multiple districts by codes
+func (r *memoryDistrictRepository) FindByCodes(ctx context.Context, codes []string) ([]*domain.District, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	districts := []*domain.District{}
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		if district, ok := r.districts[code]; ok {
+			districts = append(districts, cloneDistrict(district))
+		}
+	}
+	return districts, nil
+}
+
+// ListByProvince lists the active districts of a province currently in effect, ordered by code
+func (r *memoryDistrictRepository) ListByProvince(ctx context.Context, provinceCode string) ([]*domain.District, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	districts := []*domain.District{}
+	for _, district := range r.districts {
+		if district.ProvinceCode == provinceCode && district.Status == "active" && district.IsCurrentAt(now) {
+			districts = append(districts, cloneDistrict(district))
+		}
+	}
+	sort.Slice(districts, func(i, j int) bool { return districts[i].Code < districts[j].Code })
+	return districts, nil
+}
+
+// ListCurrent lists all active districts currently in effect, ordered by code
+func (r *memoryDistrictRepository) ListCurrent(ctx context.Context) ([]*domain.District, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	districts := []*domain.District{}
+	for _, district := range r.districts {
+		if district.Status == "active" && district.IsCurrentAt(now) {
+			districts = append(districts, cloneDistrict(district))
+		}
+	}
+	sort.Slice(districts, func(i, j int) bool { return districts[i].Code < districts[j].Code })
+	return districts, nil
+}
+
+// NextValidityChange returns the earliest time after the given one at which an active district of a
+// province comes into or goes out of effect, or nil when none does
+func (r *memoryDistrictRepository) NextValidityChange(ctx context.Context, provinceCode string, after time.Time) (*time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var validities []domain.LocationValidity
+	for _, district := range r.districts {
+		if district.ProvinceCode == provinceCode && district.Status == "active" {
+			validities = append(validities, district.LocationValidity)
+		}
+	}
+	return earliestValidityChange(validities, after), nil
+}
+
+// CountByProvince counts the districts of a province regardless of status
+func (r *memoryDistrictRepository) CountByProvince(ctx context.Context, provinceCode string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, district := range r.districts {
+		if district.ProvinceCode == provinceCode {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// SetCountryByProvince records the country of a province on all of its districts
+func (r *memoryDistrictRepository) SetCountryByProvince(ctx context.Context, provinceCode, countryCode string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, district := range r.districts {
+		if district.ProvinceCode == provinceCode {
+			district.CountryCode = countryCode
+			district.UpdatedAt = now
+		}
+	}
+	return nil
+}
+
+// Search finds active districts in effect whose folded names match the query, ordered by code
+func (r *memoryDistrictRepository) Search(ctx context.Context, query domain.LocationQuery) ([]*domain.District, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	districts := []*domain.District{}
+	for _, district := range r.districts {
+		if district.Status != "active" || !district.IsCurrentAt(now) ||
+			(query.CountryCode != "" && district.CountryCode != query.CountryCode) ||
+			(query.ProvinceCode != "" && district.ProvinceCode != query.ProvinceCode) {
+			continue
+		}
+		if matchesSearchText(district.SearchText, query) {
+			districts = append(districts, cloneDistrict(district))
+		}
+	}
+	sort.Slice(districts, func(i, j int) bool { return districts[i].Code < districts[j].Code })
+	if query.Limit > 0 && len(districts) > query.Limit {
+		districts = districts[:query.Limit]
+	}
+	return districts, nil
+}
+
+// Update updates the mutable fields of a district
+func (r *memoryDistrictRepository) Update(ctx context.Context, district *domain.District) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var stored *domain.District
+	for _, candidate := range r.districts {
+		if candidate.ID == district.ID {
+			stored = candidate
+			break
+		}
+	}
+	if stored == nil {
+		return fmt.Errorf("district %w", ErrNotFound)
+	}
+
+	district.UpdatedAt = time.Now()
+	district.SearchText = domain.SearchTerms(district.Name)
+	stored.Name = copyMap(district.Name)
+	stored.SearchText = copySlice(district.SearchText)
+	stored.ProvinceCode = district.ProvinceCode
+	stored.CountryCode = district.CountryCode
+	stored.Type = district.Type
+	stored.Status = district.Status
+	stored.UpdatedAt = district.UpdatedAt
+	return nil
+}
+
+// Retire ends the validity of a district at the given time and records its successors
+func (r *memoryDistrictRepository) Retire(ctx context.Context, code string, at time.Time, successorCodes []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.districts[code]
+	if !ok {
+		return fmt.Errorf("district %w", ErrNotFound)
+	}
+
+	stored.EffectiveTo = &at
+	stored.SuccessorCodes = copySlice(successorCodes)
+	stored.UpdatedAt = time.Now()
+	return nil
+}
+
+// Delete deletes a district
+func (r *memoryDistrictRepository) Delete(ctx context.Context, code string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.districts, code)
+	return nil
+}
+
+// snapshot captures the stored districts for a memoryTransactor rollback
+func (r *memoryDistrictRepository) snapshot() func() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	saved := make(map[string]*domain.District, len(r.districts))
+	for code, district := range r.districts {
+		saved[code] = cloneDistrict(district)
+	}
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.districts = saved
+	}
+}
+
+func cloneDistrict(district *domain.District) *domain.District {
+	clone := *district
+	clone.Name = copyMap(district.Name)
+	clone.SearchText = copySlice(district.SearchText)
+	clone.LocationValidity = cloneLocationValidity(district.LocationValidity)
+	return &clone
+}
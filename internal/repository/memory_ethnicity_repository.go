@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryEthnicityRepository is an in-memory implementation of EthnicityRepository.
+// It enforces the same unique (countryCode, code) index as the MongoDB implementation.
+type memoryEthnicityRepository struct {
+	mu          sync.RWMutex
+	ethnicities map[primitive.ObjectID]*domain.Ethnicity
+}
+
+// NewMemoryEthnicityRepository creates a new in-memory ethnicity repository
+func NewMemoryEthnicityRepository() EthnicityRepository {
+	return &memoryEthnicityRepository{
+		ethnicities: make(map[primitive.ObjectID]*domain.Ethnicity),
+	}
+}
+
+// Create creates a new ethnicity
+func (r *memoryEthnicityRepository) Create(ctx context.Context, ethnicity *domain.Ethnicity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.findByCode(ethnicity.CountryCode, ethnicity.Code) != nil {
+		return fmt.Errorf("failed to create ethnicity: %w", ErrDuplicateKey)
+	}
+
+	if ethnicity.ID.IsZero() {
+		ethnicity.ID = primitive.NewObjectID()
+	}
+	ethnicity.CreatedAt = time.Now()
+	ethnicity.UpdatedAt = time.Now()
+	r.ethnicities[ethnicity.ID] = cloneEthnicity(ethnicity)
+	return nil
+}
+
+// FindByID finds an ethnicity by ID
+func (r *memoryEthnicityRepository) FindByID(ctx context.Context, id string) (*domain.Ethnicity, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ethnicity ID: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ethnicity, ok := r.ethnicities[objectID]
+	if !ok {
+		return nil, nil
+	}
+	return cloneEthnicity(ethnicity), nil
+}
+
+// FindByCode finds an ethnicity by its code within a country
+func (r *memoryEthnicityRepository) FindByCode(ctx context.Context, countryCode, code string) (*domain.Ethnicity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if ethnicity := r.findByCode(countryCode, code); ethnicity != nil {
+		return cloneEthnicity(ethnicity), nil
+	}
+	return nil, nil
+}
+
+// List lists active ethnicities ordered by country and code, restricted to one country unless
+// countryCode is empty
+func (r *memoryEthnicityRepository) List(ctx context.Context, countryCode string, page, perPage int) ([]*domain.Ethnicity, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := make([]*domain.Ethnicity, 0)
+	for _, ethnicity := range r.ethnicities {
+		if ethnicity.Status == "active" && (countryCode == "" || ethnicity.CountryCode == countryCode) {
+			matched = append(matched, ethnicity)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].CountryCode != matched[j].CountryCode {
+			return matched[i].CountryCode < matched[j].CountryCode
+		}
+		return matched[i].Code < matched[j].Code
+	})
+
+	start, end := pageBounds(len(matched), page, perPage)
+	ethnicities := make([]*domain.Ethnicity, 0, end-start)
+	for _, ethnicity := range matched[start:end] {
+		ethnicities = append(ethnicities, cloneEthnicity(ethnicity))
+	}
+	return ethnicities, int64(len(matched)), nil
+}
+
+// CountByCountry counts the ethnicities of a country regardless of status
+func (r *memoryEthnicityRepository) CountByCountry(ctx context.Context, countryCode string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, ethnicity := range r.ethnicities {
+		if ethnicity.CountryCode == countryCode {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Update updates the name and status of an ethnicity
+func (r *memoryEthnicityRepository) Update(ctx context.Context, ethnicity *domain.Ethnicity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.ethnicities[ethnicity.ID]
+	if !ok {
+		return fmt.Errorf("ethnicity %w", ErrNotFound)
+	}
+
+	ethnicity.UpdatedAt = time.Now()
+	stored.Name = copyMap(ethnicity.Name)
+	stored.Status = ethnicity.Status
+	stored.UpdatedAt = ethnicity.UpdatedAt
+	return nil
+}
+
+// Delete deletes an ethnicity
+func (r *memoryEthnicityRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid ethnicity ID: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.ethnicities, objectID)
+	return nil
+}
+
+// findByCode returns the stored ethnicity with a code in a country; callers must hold the lock
+func (r *memoryEthnicityRepository) findByCode(countryCode, code string) *domain.Ethnicity {
+	for _, ethnicity := range r.ethnicities {
+		if ethnicity.CountryCode == countryCode && ethnicity.Code == code {
+			return ethnicity
+		}
+	}
+	return nil
+}
+
+func cloneEthnicity(ethnicity *domain.Ethnicity) *domain.Ethnicity {
+	clone := *ethnicity
+	clone.Name = copyMap(ethnicity.Name)
+	return &clone
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryExchangeRateRepository is an in-memory implementation of ExchangeRateRepository.
+// It enforces the same unique (base, quote, date) index as the MongoDB implementation.
+type memoryExchangeRateRepository struct {
+	mu    sync.RWMutex
+	rates map[primitive.ObjectID]*domain.ExchangeRate
+}
+
+// NewMemoryExchangeRateRepository creates a new in-memory exchange rate repository
+func NewMemoryExchangeRateRepository() ExchangeRateRepository {
+	return &memoryExchangeRateRepository{
+		rates: make(map[primitive.ObjectID]*domain.ExchangeRate),
+	}
+}
+
+// Upsert stores the rate of its pair and day, replacing the rate and source of an existing one.
+// It reports whether a new rate was created.
+func (r *memoryExchangeRateRepository) Upsert(ctx context.Context, rate *domain.ExchangeRate) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, stored := range r.rates {
+		if stored.Base == rate.Base && stored.Quote == rate.Quote && stored.Date.Equal(rate.Date) {
+			stored.Rate = rate.Rate
+			stored.Source = rate.Source
+			stored.UpdatedAt = now
+			rate.ID = stored.ID
+			rate.CreatedAt = stored.CreatedAt
+			rate.UpdatedAt = now
+			return false, nil
+		}
+	}
+
+	rate.ID = primitive.NewObjectID()
+	rate.CreatedAt = now
+	rate.UpdatedAt = now
+	clone := *rate
+	r.rates[rate.ID] = &clone
+	return true, nil
+}
+
+// FindByID finds an exchange rate by ID
+func (r *memoryExchangeRateRepository) FindByID(ctx context.Context, id string) (*domain.ExchangeRate, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exchange rate ID: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rate, ok := r.rates[objectID]
+	if !ok {
+		return nil, nil
+	}
+	clone := *rate
+	return &clone, nil
+}
+
+// FindLatest finds the most recent rate of a pair dated on or before at
+func (r *memoryExchangeRateRepository) FindLatest(ctx context.Context, base, quote string, at time.Time) (*domain.ExchangeRate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest *domain.ExchangeRate
+	for _, rate := range r.rates {
+		if rate.Base != base || rate.Quote != quote || rate.Date.After(at) {
+			continue
+		}
+		if latest == nil || rate.Date.After(latest.Date) {
+			latest = rate
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	clone := *latest
+	return &clone, nil
+}
+
+// List lists rates matching the filter, newest first and then by pair
+func (r *memoryExchangeRateRepository) List(ctx context.Context, filter domain.ExchangeRateFilter, page, perPage int) ([]*domain.ExchangeRate, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := make([]*domain.ExchangeRate, 0)
+	for _, rate := range r.rates {
+		switch {
+		case filter.Base != "" && rate.Base != filter.Base,
+			filter.Quote != "" && rate.Quote != filter.Quote,
+			filter.From != nil && rate.Date.Before(*filter.From),
+			filter.To != nil && rate.Date.After(*filter.To):
+			continue
+		}
+		matched = append(matched, rate)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].Date.Equal(matched[j].Date) {
+			return matched[i].Date.After(matched[j].Date)
+		}
+		if matched[i].Base != matched[j].Base {
+			return matched[i].Base < matched[j].Base
+		}
+		return matched[i].Quote < matched[j].Quote
+	})
+
+	start, end := pageBounds(len(matched), page, perPage)
+	rates := make([]*domain.ExchangeRate, 0, end-start)
+	for _, rate := range matched[start:end] {
+		clone := *rate
+		rates = append(rates, &clone)
+	}
+	return rates, int64(len(matched)), nil
+}
+
+// Delete deletes an exchange rate
+func (r *memoryExchangeRateRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid exchange rate ID: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.rates, objectID)
+	return nil
+}
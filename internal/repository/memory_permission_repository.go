@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryPermissionRepository is an in-memory implementation of PermissionRepository.
+// It enforces the same unique (tenantId, code) index as the MongoDB implementation.
+type memoryPermissionRepository struct {
+	mu          sync.RWMutex
+	permissions map[primitive.ObjectID]*domain.Permission
+}
+
+// NewMemoryPermissionRepository creates a new in-memory permission repository
+func NewMemoryPermissionRepository() PermissionRepository {
+	return &memoryPermissionRepository{
+		permissions: make(map[primitive.ObjectID]*domain.Permission),
+	}
+}
+
+// Create creates a new permission
+func (r *memoryPermissionRepository) Create(ctx context.Context, permission *domain.Permission) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if permission.ID.IsZero() {
+		permission.ID = primitive.NewObjectID()
+	}
+	if _, ok := r.permissions[permission.ID]; ok {
+		return fmt.Errorf("failed to create permission: %w", ErrDuplicateKey)
+	}
+	if r.findByCode(permission.TenantID, permission.Code) != nil {
+		return fmt.Errorf("failed to create permission: %w", ErrDuplicateKey)
+	}
+
+	permission.CreatedAt = time.Now()
+	permission.UpdatedAt = time.Now()
+	clone := *permission
+	r.permissions[permission.ID] = &clone
+	return nil
+}
+
+// FindByID finds a permission by ID
+func (r *memoryPermissionRepository) FindByID(ctx context.Context, id string) (*domain.Permission, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid permission ID: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	permission, ok := r.permissions[objectID]
+	if !ok {
+		return nil, nil
+	}
+	clone := *permission
+	return &clone, nil
+}
+
+// FindByCode finds a permission by code and tenant
+func (r *memoryPermissionRepository) FindByCode(ctx context.Context, tenantID, code string) (*domain.Permission, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	permission := r.findByCode(tenantID, code)
+	if permission == nil {
+		return nil, nil
+	}
+	clone := *permission
+	return &clone, nil
+}
+
+// List lists permissions of a tenant ordered by code with pagination
+func (r *memoryPermissionRepository) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.Permission, int64, error) {
+	matched := r.filter(func(permission *domain.Permission) bool { return permission.TenantID == tenantID })
+
+	start, end := pageBounds(len(matched), page, perPage)
+	return matched[start:end], int64(len(matched)), nil
+}
+
+// ListAll lists every permission of a tenant ordered by code
+func (r *memoryPermissionRepository) ListAll(ctx context.Context, tenantID string) ([]*domain.Permission, error) {
+	return r.filter(func(permission *domain.Permission) bool { return permission.TenantID == tenantID }), nil
+}
+
+// ListByModule lists the permissions registered by a module ordered by code
+func (r *memoryPermissionRepository) ListByModule(ctx context.Context, tenantID, moduleCode string) ([]*domain.Permission, error) {
+	return r.filter(func(permission *domain.Permission) bool {
+		return permission.TenantID == tenantID && permission.ModuleCode == moduleCode
+	}), nil
+}
+
+// ListByResource lists the permissions on a resource ordered by code
+func (r *memoryPermissionRepository) ListByResource(ctx context.Context, tenantID, resource string) ([]*domain.Permission, error) {
+	return r.filter(func(permission *domain.Permission) bool {
+		return permission.TenantID == tenantID && permission.Resource == resource
+	}), nil
+}
+
+// Upsert inserts or replaces permissions by tenant and code.
+// Creation timestamps of existing permissions are kept and the IDs of inserted ones are set.
+func (r *memoryPermissionRepository) Upsert(ctx context.Context, permissions []*domain.Permission) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, permission := range permissions {
+		permission.UpdatedAt = now
+		if stored := r.findByCode(permission.TenantID, permission.Code); stored != nil {
+			permission.ID = stored.ID
+			permission.CreatedAt = stored.CreatedAt
+		} else {
+			permission.ID = primitive.NewObjectID()
+			permission.CreatedAt = now
+		}
+		clone := *permission
+		r.permissions[permission.ID] = &clone
+	}
+	return nil
+}
+
+// Update updates the mutable fields of a permission
+func (r *memoryPermissionRepository) Update(ctx context.Context, permission *domain.Permission) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.permissions[permission.ID]
+	if !ok {
+		return fmt.Errorf("permission %w", ErrNotFound)
+	}
+
+	permission.UpdatedAt = time.Now()
+	stored.Name = permission.Name
+	stored.Description = permission.Description
+	stored.Category = permission.Category
+	stored.Status = permission.Status
+	stored.UpdatedAt = permission.UpdatedAt
+	return nil
+}
+
+// Delete deletes a permission
+func (r *memoryPermissionRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid permission ID: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.permissions, objectID)
+	return nil
+}
+
+// findByCode returns the stored permission with the given code; the caller must hold the lock
+func (r *memoryPermissionRepository) findByCode(tenantID, code string) *domain.Permission {
+	for _, permission := range r.permissions {
+		if permission.TenantID == tenantID && permission.Code == code {
+			return permission
+		}
+	}
+	return nil
+}
+
+// filter returns copies of the matching permissions ordered by code
+func (r *memoryPermissionRepository) filter(match func(permission *domain.Permission) bool) []*domain.Permission {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	permissions := make([]*domain.Permission, 0)
+	for _, permission := range r.permissions {
+		if match(permission) {
+			clone := *permission
+			permissions = append(permissions, &clone)
+		}
+	}
+	sort.Slice(permissions, func(i, j int) bool { return permissions[i].Code < permissions[j].Code })
+	return permissions
+}
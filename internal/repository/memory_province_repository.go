@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryProvinceRepository is an in-memory implementation of ProvinceRepository.
+// It enforces the same unique code index as the MongoDB implementation.
+type memoryProvinceRepository struct {
+	mu        sync.RWMutex
+	provinces map[string]*domain.Province // keyed by code
+}
+
+// NewMemoryProvinceRepository creates a new in-memory province repository
+func NewMemoryProvinceRepository() ProvinceRepository {
+	return &memoryProvinceRepository{
+		provinces: make(map[string]*domain.Province),
+	}
+}
+
+// Create creates a new province
+func (r *memoryProvinceRepository) Create(ctx context.Context, province *domain.Province) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.provinces[province.Code]; ok {
+		return fmt.Errorf("failed to create province: %w", ErrDuplicateKey)
+	}
+
+	if province.ID.IsZero() {
+		province.ID = primitive.NewObjectID()
+	}
+	province.CreatedAt = time.Now()
+	province.UpdatedAt = time.Now()
+	province.SearchText = domain.SearchTerms(province.Name)
+	r.provinces[province.Code] = cloneProvince(province)
+	return nil
+}
+
+// FindByCode finds a province by code
+func (r *memoryProvinceRepository) FindByCode(ctx context.Context, code string) (*domain.Province, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	province, ok := r.provinces[code]
+	if !ok {
+		return nil, nil
+	}
+	return cloneProvince(province), nil
+}
+
+// FindByCodes finds multiple provinces by codes
+func (r *memoryProvinceRepository) FindByCodes(ctx context.Context, codes []string) ([]*domain.Province, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	provinces := []*domain.Province{}
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		if province, ok := r.provinces[code]; ok {
+			provinces = append(provinces, cloneProvince(province))
+		}
+	}
+	return provinces, nil
+}
+
+// ListByCountry lists the active provinces of a country currently in effect, ordered by code
+func (r *memoryProvinceRepository) ListByCountry(ctx context.Context, countryCode string) ([]*domain.Province, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	provinces := []*domain.Province{}
+	for _, province := range r.provinces {
+		if province.CountryCode == countryCode && province.Status == "active" && province.IsCurrentAt(now) {
+			provinces = append(provinces, cloneProvince(province))
+		}
+	}
+	sort.Slice(provinces, func(i, j int) bool { return provinces[i].Code < provinces[j].Code })
+	return provinces, nil
+}
+
+// ListCurrent lists all active provinces currently in effect, ordered by code
+func (r *memoryProvinceRepository) ListCurrent(ctx context.Context) ([]*domain.Province, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	provinces := []*domain.Province{}
+	for _, province := range r.provinces {
+		if province.Status == "active" && province.IsCurrentAt(now) {
+			provinces = append(provinces, cloneProvince(province))
+		}
+	}
+	sort.Slice(provinces, func(i, j int) bool { return provinces[i].Code < provinces[j].Code })
+	return provinces, nil
+}
+
+// NextValidityChange returns the earliest time after the given one at which an active province of a
+// country comes into or goes out of effect, or nil when none does
+func (r *memoryProvinceRepository) NextValidityChange(ctx context.Context, countryCode string, after time.Time) (*time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var validities []domain.LocationValidity
+	for _, province := range r.provinces {
+		if province.CountryCode == countryCode && province.Status == "active" {
+			validities = append(validities, province.LocationValidity)
+		}
+	}
+	return earliestValidityChange(validities, after), nil
+}
+
+// CountByCountry counts the provinces of a country regardless of status
+func (r *memoryProvinceRepository) CountByCountry(ctx context.Context, countryCode string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, province := range r.provinces {
+		if province.CountryCode == countryCode {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Search finds active provinces in effect whose folded names match the query, ordered by code
+func (r *memoryProvinceRepository) Search(ctx context.Context, query domain.LocationQuery) ([]*domain.Province, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	provinces := []*domain.Province{}
+	for _, province := range r.provinces {
+		if province.Status != "active" || !province.IsCurrentAt(now) || (query.CountryCode != "" && province.CountryCode != query.CountryCode) {
+			continue
+		}
+		if matchesSearchText(province.SearchText, query) {
+			provinces = append(provinces, cloneProvince(province))
+		}
+	}
+	sort.Slice(provinces, func(i, j int) bool { return provinces[i].Code < provinces[j].Code })
+	if query.Limit > 0 && len(provinces) > query.Limit {
+		provinces = provinces[:query.Limit]
+	}
+	return provinces, nil
+}
+
+// Update updates the mutable fields of a province
+func (r *memoryProvinceRepository) Update(ctx context.Context, province *domain.Province) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var stored *domain.Province
+	for _, candidate := range r.provinces {
+		if candidate.ID == province.ID {
+			stored = candidate
+			break
+		}
+	}
+	if stored == nil {
+		return fmt.Errorf("province %w", ErrNotFound)
+	}
+
+	province.UpdatedAt = time.Now()
+	province.SearchText = domain.SearchTerms(province.Name)
+	stored.Name = copyMap(province.Name)
+	stored.SearchText = copySlice(province.SearchText)
+	stored.CountryCode = province.CountryCode
+	stored.Type = province.Type
+	stored.Status = province.Status
+	stored.UpdatedAt = province.UpdatedAt
+	return nil
+}
+
+// Retire ends the validity of a province at the given time and records its successors
+func (r *memoryProvinceRepository) Retire(ctx context.Context, code string, at time.Time, successorCodes []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.provinces[code]
+	if !ok {
+		return fmt.Errorf("province %w", ErrNotFound)
+	}
+
+	stored.EffectiveTo = &at
+	stored.SuccessorCodes = copySlice(successorCodes)
+	stored.UpdatedAt = time.Now()
+	return nil
+}
+
+// Delete deletes a province
+func (r *memoryProvinceRepository) Delete(ctx context.Context, code string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.provinces, code)
+	return nil
+}
+
+// snapshot captures the stored provinces for a memoryTransactor rollback
+func (r *memoryProvinceRepository) snapshot() func() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	saved := make(map[string]*domain.Province, len(r.provinces))
+	for code, province := range r.provinces {
+		saved[code] = cloneProvince(province)
+	}
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.provinces = saved
+	}
+}
+
+func cloneProvince(province *domain.Province) *domain.Province {
+	clone := *province
+	clone.Name = copyMap(province.Name)
+	clone.SearchText = copySlice(province.SearchText)
+	clone.LocationValidity = cloneLocationValidity(province.LocationValidity)
+	return &clone
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryRoleRepository is an in-memory implementation of RoleRepository.
+// It enforces the same unique (tenantId, code) index as the MongoDB implementation.
+type memoryRoleRepository struct {
+	mu    sync.RWMutex
+	roles map[primitive.ObjectID]*domain.Role
+}
+
+// NewMemoryRoleRepository creates a new in-memory role repository
+func NewMemoryRoleRepository() RoleRepository {
+	return &memoryRoleRepository{
+		roles: make(map[primitive.ObjectID]*domain.Role),
+	}
+}
+
+// Create creates a new role
+func (r *memoryRoleRepository) Create(ctx context.Context, role *domain.Role) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if role.ID.IsZero() {
+		role.ID = primitive.NewObjectID()
+	}
+	if _, ok := r.roles[role.ID]; ok {
+		return fmt.Errorf("failed to create role: %w", ErrDuplicateKey)
+	}
+	for _, existing := range r.roles {
+		if existing.TenantID == role.TenantID && existing.Code == role.Code {
+			return fmt.Errorf("failed to create role: %w", ErrDuplicateKey)
+		}
+	}
+
+	role.CreatedAt = time.Now()
+	role.UpdatedAt = time.Now()
+	r.roles[role.ID] = cloneRole(role)
+	return nil
+}
+
+// FindByID finds a role by ID
+func (r *memoryRoleRepository) FindByID(ctx context.Context, id string) (*domain.Role, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid role ID: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	role, ok := r.roles[objectID]
+	if !ok {
+		return nil, nil
+	}
+	return cloneRole(role), nil
+}
+
+// FindByCode finds a role by code and tenant
+func (r *memoryRoleRepository) FindByCode(ctx context.Context, tenantID, code string) (*domain.Role, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, role := range r.roles {
+		if role.TenantID == tenantID && role.Code == code {
+			return cloneRole(role), nil
+		}
+	}
+	return nil, nil
+}
+
+// List lists roles of a tenant ordered by level and code with pagination
+func (r *memoryRoleRepository) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.Role, int64, error) {
+	matched, _ := r.ListAll(ctx, tenantID)
+
+	start, end := pageBounds(len(matched), page, perPage)
+	return matched[start:end], int64(len(matched)), nil
+}
+
+// ListAll lists every role of a tenant ordered by level and code
+func (r *memoryRoleRepository) ListAll(ctx context.Context, tenantID string) ([]*domain.Role, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	roles := make([]*domain.Role, 0)
+	for _, role := range r.roles {
+		if role.TenantID == tenantID {
+			roles = append(roles, cloneRole(role))
+		}
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		if roles[i].Level != roles[j].Level {
+			return roles[i].Level < roles[j].Level
+		}
+		return roles[i].Code < roles[j].Code
+	})
+	return roles, nil
+}
+
+// FindByParent finds the roles of a tenant that inherit directly from the given role code
+func (r *memoryRoleRepository) FindByParent(ctx context.Context, tenantID, parentCode string) ([]*domain.Role, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	roles := make([]*domain.Role, 0)
+	for _, role := range r.roles {
+		if role.TenantID == tenantID && role.ParentCode == parentCode {
+			roles = append(roles, cloneRole(role))
+		}
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Code < roles[j].Code })
+	return roles, nil
+}
+
+// Update updates the mutable fields of a role including its permission grants
+func (r *memoryRoleRepository) Update(ctx context.Context, role *domain.Role) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.roles[role.ID]
+	if !ok {
+		return fmt.Errorf("role %w", ErrNotFound)
+	}
+
+	role.UpdatedAt = time.Now()
+	stored.Name = role.Name
+	stored.Description = role.Description
+	stored.Level = role.Level
+	stored.ParentCode = role.ParentCode
+	stored.Permissions = copySlice(role.Permissions)
+	stored.Status = role.Status
+	stored.UpdatedAt = role.UpdatedAt
+	return nil
+}
+
+// UpdatePermissions replaces the permission grants of a role
+func (r *memoryRoleRepository) UpdatePermissions(ctx context.Context, id string, permissions []string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid role ID: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.roles[objectID]
+	if !ok {
+		return fmt.Errorf("role %w", ErrNotFound)
+	}
+	stored.Permissions = copySlice(permissions)
+	stored.UpdatedAt = time.Now()
+	return nil
+}
+
+// Delete deletes a role
+func (r *memoryRoleRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid role ID: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.roles, objectID)
+	return nil
+}
+
+func cloneRole(role *domain.Role) *domain.Role {
+	clone := *role
+	clone.Permissions = copySlice(role.Permissions)
+	return &clone
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memorySaaSModuleRepository is an in-memory implementation of SaaSModuleRepository.
+// It enforces the same unique (tenantId, code) index as the MongoDB implementation.
+type memorySaaSModuleRepository struct {
+	mu      sync.RWMutex
+	modules map[primitive.ObjectID]*domain.SaaSModule
+}
+
+// NewMemorySaaSModuleRepository creates a new in-memory SaaS module repository
+func NewMemorySaaSModuleRepository() SaaSModuleRepository {
+	return &memorySaaSModuleRepository{
+		modules: make(map[primitive.ObjectID]*domain.SaaSModule),
+	}
+}
+
+// Create creates a new SaaS module
+func (r *memorySaaSModuleRepository) Create(ctx context.Context, module *domain.SaaSModule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if module.ID.IsZero() {
+		module.ID = primitive.NewObjectID()
+	}
+	if _, ok := r.modules[module.ID]; ok {
+		return fmt.Errorf("failed to create SaaS module: %w", ErrDuplicateKey)
+	}
+	for _, existing := range r.modules {
+		if existing.TenantID == module.TenantID && existing.Code == module.Code {
+			return fmt.Errorf("failed to create SaaS module: %w", ErrDuplicateKey)
+		}
+	}
+
+	module.CreatedAt = time.Now()
+	module.UpdatedAt = time.Now()
+	r.modules[module.ID] = cloneSaaSModule(module)
+	return nil
+}
+
+// FindByID finds a SaaS module by ID
+func (r *memorySaaSModuleRepository) FindByID(ctx context.Context, id string) (*domain.SaaSModule, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SaaS module ID: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	module, ok := r.modules[objectID]
+	if !ok {
+		return nil, nil
+	}
+	return cloneSaaSModule(module), nil
+}
+
+// FindByCode finds a SaaS module by code and tenant
+func (r *memorySaaSModuleRepository) FindByCode(ctx context.Context, tenantID, code string) (*domain.SaaSModule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, module := range r.modules {
+		if module.TenantID == tenantID && module.Code == code {
+			return cloneSaaSModule(module), nil
+		}
+	}
+	return nil, nil
+}
+
+// List lists SaaS modules of a tenant ordered by code with pagination
+func (r *memorySaaSModuleRepository) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.SaaSModule, int64, error) {
+	matched := r.filter(func(module *domain.SaaSModule) bool { return module.TenantID == tenantID })
+
+	start, end := pageBounds(len(matched), page, perPage)
+	return matched[start:end], int64(len(matched)), nil
+}
+
+// ListAll lists every SaaS module of a tenant ordered by code
+func (r *memorySaaSModuleRepository) ListAll(ctx context.Context, tenantID string) ([]*domain.SaaSModule, error) {
+	return r.filter(func(module *domain.SaaSModule) bool { return module.TenantID == tenantID }), nil
+}
+
+// FindDependents finds the modules of a tenant that directly depend on the given module code
+func (r *memorySaaSModuleRepository) FindDependents(ctx context.Context, tenantID, code string) ([]*domain.SaaSModule, error) {
+	return r.filter(func(module *domain.SaaSModule) bool {
+		if module.TenantID != tenantID {
+			return false
+		}
+		for _, dependency := range module.Dependencies {
+			if dependency == code {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+// Update updates the mutable fields of a SaaS module
+func (r *memorySaaSModuleRepository) Update(ctx context.Context, module *domain.SaaSModule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.modules[module.ID]
+	if !ok {
+		return fmt.Errorf("SaaS module %w", ErrNotFound)
+	}
+
+	module.UpdatedAt = time.Now()
+	stored.Name = module.Name
+	stored.Description = module.Description
+	stored.Icon = module.Icon
+	stored.Category = module.Category
+	stored.IsCore = module.IsCore
+	stored.Dependencies = copySlice(module.Dependencies)
+	stored.Price = module.Price
+	stored.Status = module.Status
+	stored.Features = copySlice(module.Features)
+	stored.UpdatedAt = module.UpdatedAt
+	return nil
+}
+
+// Delete deletes a SaaS module
+func (r *memorySaaSModuleRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid SaaS module ID: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.modules, objectID)
+	return nil
+}
+
+// filter returns copies of the matching modules ordered by code
+func (r *memorySaaSModuleRepository) filter(match func(module *domain.SaaSModule) bool) []*domain.SaaSModule {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	modules := make([]*domain.SaaSModule, 0)
+	for _, module := range r.modules {
+		if match(module) {
+			modules = append(modules, cloneSaaSModule(module))
+		}
+	}
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Code < modules[j].Code })
+	return modules
+}
+
+func cloneSaaSModule(module *domain.SaaSModule) *domain.SaaSModule {
+	clone := *module
+	clone.Dependencies = copySlice(module.Dependencies)
+	clone.Features = copySlice(module.Features)
+	return &clone
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryServicePackageRepository is an in-memory implementation of ServicePackageRepository.
+// It enforces the same unique (tenantId, code) index as the MongoDB implementation.
+type memoryServicePackageRepository struct {
+	mu       sync.RWMutex
+	packages map[primitive.ObjectID]*domain.ServicePackage
+}
+
+// NewMemoryServicePackageRepository creates a new in-memory service package repository
+func NewMemoryServicePackageRepository() ServicePackageRepository {
+	return &memoryServicePackageRepository{
+		packages: make(map[primitive.ObjectID]*domain.ServicePackage),
+	}
+}
+
+// Create creates a new service package
+func (r *memoryServicePackageRepository) Create(ctx context.Context, pkg *domain.ServicePackage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if pkg.ID.IsZero() {
+		pkg.ID = primitive.NewObjectID()
+	}
+	if _, ok := r.packages[pkg.ID]; ok {
+		return fmt.Errorf("failed to create service package: %w", ErrDuplicateKey)
+	}
+	for _, existing := range r.packages {
+		if existing.TenantID == pkg.TenantID && existing.Code == pkg.Code {
+			return fmt.Errorf("failed to create service package: %w", ErrDuplicateKey)
+		}
+	}
+
+	pkg.CreatedAt = time.Now()
+	pkg.UpdatedAt = time.Now()
+	r.packages[pkg.ID] = cloneServicePackage(pkg)
+	return nil
+}
+
+// FindByID finds a service package by ID
+func (r *memoryServicePackageRepository) FindByID(ctx context.Context, id string) (*domain.ServicePackage, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service package ID: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pkg, ok := r.packages[objectID]
+	if !ok {
+		return nil, nil
+	}
+	return cloneServicePackage(pkg), nil
+}
+
+// FindByCode finds a service package by code and tenant
+func (r *memoryServicePackageRepository) FindByCode(ctx context.Context, tenantID, code string) (*domain.ServicePackage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, pkg := range r.packages {
+		if pkg.TenantID == tenantID && pkg.Code == code {
+			return cloneServicePackage(pkg), nil
+		}
+	}
+	return nil, nil
+}
+
+// List lists service packages of a tenant ordered by code with pagination
+func (r *memoryServicePackageRepository) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.ServicePackage, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := make([]*domain.ServicePackage, 0)
+	for _, pkg := range r.packages {
+		if pkg.TenantID == tenantID {
+			matched = append(matched, pkg)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Code < matched[j].Code })
+
+	start, end := pageBounds(len(matched), page, perPage)
+	packages := make([]*domain.ServicePackage, 0, end-start)
+	for _, pkg := range matched[start:end] {
+		packages = append(packages, cloneServicePackage(pkg))
+	}
+	return packages, int64(len(matched)), nil
+}
+
+// Update updates the mutable fields of a service package
+func (r *memoryServicePackageRepository) Update(ctx context.Context, pkg *domain.ServicePackage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.packages[pkg.ID]
+	if !ok {
+		return fmt.Errorf("service package %w", ErrNotFound)
+	}
+
+	pkg.UpdatedAt = time.Now()
+	stored.Name = pkg.Name
+	stored.Description = pkg.Description
+	stored.Tier = pkg.Tier
+	stored.Price = pkg.Price
+	stored.Currency = pkg.Currency
+	stored.BillingCycle = pkg.BillingCycle
+	stored.Modules = copySlice(pkg.Modules)
+	stored.Limits = copyMap(pkg.Limits)
+	stored.Features = copySlice(pkg.Features)
+	stored.IsPopular = pkg.IsPopular
+	stored.Status = pkg.Status
+	stored.UpdatedAt = pkg.UpdatedAt
+	return nil
+}
+
+// Delete deletes a service package
+func (r *memoryServicePackageRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid service package ID: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.packages, objectID)
+	return nil
+}
+
+// CountByCurrency counts the service packages of every tenant priced in a currency
+func (r *memoryServicePackageRepository) CountByCurrency(ctx context.Context, currency string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, pkg := range r.packages {
+		if pkg.Currency == currency {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// FindByModule finds the service packages of a tenant that include the given module code, ordered by code
+func (r *memoryServicePackageRepository) FindByModule(ctx context.Context, tenantID, moduleCode string) ([]*domain.ServicePackage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var packages []*domain.ServicePackage
+	for _, pkg := range r.packages {
+		if pkg.TenantID != tenantID {
+			continue
+		}
+		for _, code := range pkg.Modules {
+			if code == moduleCode {
+				packages = append(packages, cloneServicePackage(pkg))
+				break
+			}
+		}
+	}
+	sort.Slice(packages, func(i, j int) bool { return packages[i].Code < packages[j].Code })
+	return packages, nil
+}
+
+// FindAll finds the service packages of every tenant, ordered by tenant and code
+func (r *memoryServicePackageRepository) FindAll(ctx context.Context) ([]*domain.ServicePackage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	packages := make([]*domain.ServicePackage, 0, len(r.packages))
+	for _, pkg := range r.packages {
+		packages = append(packages, cloneServicePackage(pkg))
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].TenantID != packages[j].TenantID {
+			return packages[i].TenantID < packages[j].TenantID
+		}
+		return packages[i].Code < packages[j].Code
+	})
+	return packages, nil
+}
+
+func cloneServicePackage(pkg *domain.ServicePackage) *domain.ServicePackage {
+	clone := *pkg
+	clone.Modules = copySlice(pkg.Modules)
+	clone.Limits = copyMap(pkg.Limits)
+	clone.Features = copySlice(pkg.Features)
+	return &clone
+}
@@ -0,0 +1,111 @@
+package repository
+
+import "testing"
+
+func TestMemoryAppComponentRepository(t *testing.T) {
+	testAppComponentRepositoryContract(t, func(t *testing.T) AppComponentRepository {
+		return NewMemoryAppComponentRepository()
+	})
+}
+
+func TestMemoryCountryRepository(t *testing.T) {
+	testCountryRepositoryContract(t, func(t *testing.T) CountryRepository {
+		return NewMemoryCountryRepository()
+	})
+}
+
+func TestMemoryCurrencyRepository(t *testing.T) {
+	testCurrencyRepositoryContract(t, func(t *testing.T) CurrencyRepository {
+		return NewMemoryCurrencyRepository()
+	})
+}
+
+func TestMemoryExchangeRateRepository(t *testing.T) {
+	testExchangeRateRepositoryContract(t, func(t *testing.T) ExchangeRateRepository {
+		return NewMemoryExchangeRateRepository()
+	})
+}
+
+func TestMemoryEthnicityRepository(t *testing.T) {
+	testEthnicityRepositoryContract(t, func(t *testing.T) EthnicityRepository {
+		return NewMemoryEthnicityRepository()
+	})
+}
+
+func TestMemoryConfigRepository(t *testing.T) {
+	testConfigRepositoryContract(t, func(t *testing.T) ConfigRepository {
+		return NewMemoryConfigRepository()
+	})
+}
+
+func TestMemoryConfigVersionRepository(t *testing.T) {
+	testConfigVersionRepositoryContract(t, func(t *testing.T) ConfigVersionRepository {
+		return NewMemoryConfigVersionRepository()
+	})
+}
+
+func TestMemoryConfigOverrideRepository(t *testing.T) {
+	testConfigOverrideRepositoryContract(t, func(t *testing.T) ConfigOverrideRepository {
+		return NewMemoryConfigOverrideRepository()
+	})
+}
+
+func TestMemoryConfigRuleRepository(t *testing.T) {
+	testConfigRuleRepositoryContract(t, func(t *testing.T) ConfigRuleRepository {
+		return NewMemoryConfigRuleRepository()
+	})
+}
+
+func TestMemoryConfigSchemaRepository(t *testing.T) {
+	testConfigSchemaRepositoryContract(t, func(t *testing.T) ConfigSchemaRepository {
+		return NewMemoryConfigSchemaRepository()
+	})
+}
+
+func TestMemorySaaSModuleRepository(t *testing.T) {
+	testSaaSModuleRepositoryContract(t, func(t *testing.T) SaaSModuleRepository {
+		return NewMemorySaaSModuleRepository()
+	})
+}
+
+func TestMemoryServicePackageRepository(t *testing.T) {
+	testServicePackageRepositoryContract(t, func(t *testing.T) ServicePackageRepository {
+		return NewMemoryServicePackageRepository()
+	})
+}
+
+func TestMemoryAdminMenuRepository(t *testing.T) {
+	testAdminMenuRepositoryContract(t, func(t *testing.T) AdminMenuRepository {
+		return NewMemoryAdminMenuRepository()
+	})
+}
+
+func TestMemoryPermissionRepository(t *testing.T) {
+	testPermissionRepositoryContract(t, func(t *testing.T) PermissionRepository {
+		return NewMemoryPermissionRepository()
+	})
+}
+
+func TestMemoryRoleRepository(t *testing.T) {
+	testRoleRepositoryContract(t, func(t *testing.T) RoleRepository {
+		return NewMemoryRoleRepository()
+	})
+}
+
+func TestMemoryProvinceRepository(t *testing.T) {
+	testProvinceRepositoryContract(t, func(t *testing.T) ProvinceRepository {
+		return NewMemoryProvinceRepository()
+	})
+}
+
+func TestMemoryDistrictRepository(t *testing.T) {
+	testDistrictRepositoryContract(t, func(t *testing.T) DistrictRepository {
+		return NewMemoryDistrictRepository()
+	})
+}
+
+func TestMemoryWardRepository(t *testing.T) {
+	testWardRepositoryContract(t, func(t *testing.T) WardRepository {
+		return NewMemoryWardRepository()
+	})
+}
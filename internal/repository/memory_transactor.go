@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"sync"
+)
+
+// snapshotter is implemented by in-memory repositories that can take part in a memoryTransactor
+// transaction. snapshot captures the stored documents and returns a function restoring them.
+type snapshotter interface {
+	snapshot() (restore func())
+}
+
+// memoryTransactor is an in-memory implementation of Transactor. It runs transactions one at a
+// time and rolls back the repositories it was created with when a transaction fails.
+type memoryTransactor struct {
+	mu           sync.Mutex
+	repositories []snapshotter
+}
+
+// NewMemoryTransactor creates a new in-memory transactor rolling back the given in-memory
+// repositories; writes to other repositories are kept when a transaction fails
+func NewMemoryTransactor(repositories ...interface{}) Transactor {
+	t := &memoryTransactor{}
+	for _, repository := range repositories {
+		if s, ok := repository.(snapshotter); ok {
+			t.repositories = append(t.repositories, s)
+		}
+	}
+	return t
+}
+
+// WithTransaction runs fn, restoring the repositories to their state before fn when it fails
+func (t *memoryTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	restores := make([]func(), 0, len(t.repositories))
+	for _, repository := range t.repositories {
+		restores = append(restores, repository.snapshot())
+	}
+
+	if err := fn(ctx); err != nil {
+		for _, restore := range restores {
+			restore()
+		}
+		return err
+	}
+	return nil
+}
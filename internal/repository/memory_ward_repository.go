@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryWardRepository is an in-memory implementation of WardRepository.
+// It enforces the same unique code index as the MongoDB implementation.
+type memoryWardRepository struct {
+	mu    sync.RWMutex
+	wards map[string]*domain.Ward // keyed by code
+}
+
+// NewMemoryWardRepository creates a new in-memory ward repository
+func NewMemoryWardRepository() WardRepository {
+	return &memoryWardRepository{
+		wards: make(map[string]*domain.Ward),
+	}
+}
+
+// Create creates a new ward
+func (r *memoryWardRepository) Create(ctx context.Context, ward *domain.Ward) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.wards[ward.Code]; ok {
+		return fmt.Errorf("failed to create ward: %w", ErrDuplicateKey)
+	}
+
+	if ward.ID.IsZero() {
+		ward.ID = primitive.NewObjectID()
+	}
+	ward.CreatedAt = time.Now()
+	ward.UpdatedAt = time.Now()
+	ward.SearchText = domain.SearchTerms(ward.Name)
+	r.wards[ward.Code] = cloneWard(ward)
+	return nil
+}
+
+// FindByCode finds a ward by code
+func (r *memoryWardRepository) FindByCode(ctx context.Context, code string) (*domain.Ward, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ward, ok := r.wards[code]
+	if !ok {
+		return nil, nil
+	}
+	return cloneWard(ward), nil
+}
+
+// FindByCodes finds multiple wards by codes
+func (r *memoryWardRepository) FindByCodes(ctx context.Context, codes []string) ([]*domain.Ward, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wards := []*domain.Ward{}
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		if ward, ok := r.wards[code]; ok {
+			wards = append(wards, cloneWard(ward))
+		}
+	}
+	return wards, nil
+}
+
+// ListByDistrict lists the active wards of a district currently in effect, ordered by code
+func (r *memoryWardRepository) ListByDistrict(ctx context.Context, districtCode string) ([]*domain.Ward, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	wards := []*domain.Ward{}
+	for _, ward := range r.wards {
+		if ward.DistrictCode == districtCode && ward.Status == "active" && ward.IsCurrentAt(now) {
+			wards = append(wards, cloneWard(ward))
+		}
+	}
+	sort.Slice(wards, func(i, j int) bool { return wards[i].Code < wards[j].Code })
+	return wards, nil
+}
+
+// ListCurrent lists all active wards currently in effect, ordered by code
+func (r *memoryWardRepository) ListCurrent(ctx context.Context) ([]*domain.Ward, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	wards := []*domain.Ward{}
+	for _, ward := range r.wards {
+		if ward.Status == "active" && ward.IsCurrentAt(now) {
+			wards = append(wards, cloneWard(ward))
+		}
+	}
+	sort.Slice(wards, func(i, j int) bool { return wards[i].Code < wards[j].Code })
+	return wards, nil
+}
+
+// NextValidityChange returns the earliest time after the given one at which an active ward of a
+// district comes into or goes out of effect, or nil when none does
+func (r *memoryWardRepository) NextValidityChange(ctx context.Context, districtCode string, after time.Time) (*time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var validities []domain.LocationValidity
+	for _, ward := range r.wards {
+		if ward.DistrictCode == districtCode && ward.Status == "active" {
+			validities = append(validities, ward.LocationValidity)
+		}
+	}
+	return earliestValidityChange(validities, after), nil
+}
+
+// CountByDistrict counts the wards of a district regardless of status
+func (r *memoryWardRepository) CountByDistrict(ctx context.Context, districtCode string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, ward := range r.wards {
+		if ward.DistrictCode == districtCode {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// SetCountryByProvince records the country of a province on all of its wards
+func (r *memoryWardRepository) SetCountryByProvince(ctx context.Context, provinceCode, countryCode string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, ward := range r.wards {
+		if ward.ProvinceCode == provinceCode {
+			ward.CountryCode = countryCode
+			ward.UpdatedAt = now
+		}
+	}
+	return nil
+}
+
+// SetAncestorsByDistrict records the province and country of a district on all of its wards
+func (r *memoryWardRepository) SetAncestorsByDistrict(ctx context.Context, districtCode, provinceCode, countryCode string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, ward := range r.wards {
+		if ward.DistrictCode == districtCode {
+			ward.ProvinceCode = provinceCode
+			ward.CountryCode = countryCode
+			ward.UpdatedAt = now
+		}
+	}
+	return nil
+}
+
+// Search finds active wards in effect whose folded names match the query, ordered by code
+func (r *memoryWardRepository) Search(ctx context.Context, query domain.LocationQuery) ([]*domain.Ward, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	wards := []*domain.Ward{}
+	for _, ward := range r.wards {
+		if ward.Status != "active" || !ward.IsCurrentAt(now) ||
+			(query.CountryCode != "" && ward.CountryCode != query.CountryCode) ||
+			(query.ProvinceCode != "" && ward.ProvinceCode != query.ProvinceCode) ||
+			(query.DistrictCode != "" && ward.DistrictCode != query.DistrictCode) {
+			continue
+		}
+		if matchesSearchText(ward.SearchText, query) {
+			wards = append(wards, cloneWard(ward))
+		}
+	}
+	sort.Slice(wards, func(i, j int) bool { return wards[i].Code < wards[j].Code })
+	if query.Limit > 0 && len(wards) > query.Limit {
+		wards = wards[:query.Limit]
+	}
+	return wards, nil
+}
+
+// Update updates the mutable fields of a ward
+func (r *memoryWardRepository) Update(ctx context.Context, ward *domain.Ward) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var stored *domain.Ward
+	for _, candidate := range r.wards {
+		if candidate.ID == ward.ID {
+			stored = candidate
+			break
+		}
+	}
+	if stored == nil {
+		return fmt.Errorf("ward %w", ErrNotFound)
+	}
+
+	ward.UpdatedAt = time.Now()
+	ward.SearchText = domain.SearchTerms(ward.Name)
+	stored.Name = copyMap(ward.Name)
+	stored.SearchText = copySlice(ward.SearchText)
+	stored.DistrictCode = ward.DistrictCode
+	stored.ProvinceCode = ward.ProvinceCode
+	stored.CountryCode = ward.CountryCode
+	stored.Type = ward.Type
+	stored.Status = ward.Status
+	stored.UpdatedAt = ward.UpdatedAt
+	return nil
+}
+
+// Retire ends the validity of a ward at the given time and records its successors
+func (r *memoryWardRepository) Retire(ctx context.Context, code string, at time.Time, successorCodes []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.wards[code]
+	if !ok {
+		return fmt.Errorf("ward %w", ErrNotFound)
+	}
+
+	stored.EffectiveTo = &at
+	stored.SuccessorCodes = copySlice(successorCodes)
+	stored.UpdatedAt = time.Now()
+	return nil
+}
+
+// Delete deletes a ward
+func (r *memoryWardRepository) Delete(ctx context.Context, code string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.wards, code)
+	return nil
+}
+
+// snapshot captures the stored wards for a memoryTransactor rollback
+func (r *memoryWardRepository) snapshot() func() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	saved := make(map[string]*domain.Ward, len(r.wards))
+	for code, ward := range r.wards {
+		saved[code] = cloneWard(ward)
+	}
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.wards = saved
+	}
+}
+
+func cloneWard(ward *domain.Ward) *domain.Ward {
+	clone := *ward
+	clone.Name = copyMap(ward.Name)
+	clone.SearchText = copySlice(ward.SearchText)
+	clone.LocationValidity = cloneLocationValidity(ward.LocationValidity)
+	return &clone
+}
@@ -0,0 +1,180 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// newTestDatabase connects to MONGODB_URI and returns a throwaway database dropped after the test
+func newTestDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+
+	uri := os.Getenv("MONGODB_URI")
+	if uri == "" {
+		uri = "mongodb://localhost:27017"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	require.NoError(t, err)
+	require.NoError(t, client.Ping(ctx, nil))
+
+	db := client.Database("system_config_test_" + primitive.NewObjectID().Hex())
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+		_ = client.Disconnect(ctx)
+	})
+	return db
+}
+
+func TestMongoAppComponentRepository(t *testing.T) {
+	testAppComponentRepositoryContract(t, func(t *testing.T) AppComponentRepository {
+		return NewAppComponentRepository(newTestDatabase(t))
+	})
+}
+
+func TestMongoCountryRepository(t *testing.T) {
+	testCountryRepositoryContract(t, func(t *testing.T) CountryRepository {
+		return NewCountryRepository(newTestDatabase(t))
+	})
+}
+
+func TestMongoCurrencyRepository(t *testing.T) {
+	testCurrencyRepositoryContract(t, func(t *testing.T) CurrencyRepository {
+		return NewCurrencyRepository(newTestDatabase(t))
+	})
+}
+
+func TestMongoExchangeRateRepository(t *testing.T) {
+	testExchangeRateRepositoryContract(t, func(t *testing.T) ExchangeRateRepository {
+		return NewExchangeRateRepository(newTestDatabase(t))
+	})
+}
+
+func TestMongoEthnicityRepository(t *testing.T) {
+	testEthnicityRepositoryContract(t, func(t *testing.T) EthnicityRepository {
+		return NewEthnicityRepository(newTestDatabase(t))
+	})
+}
+
+func TestMongoConfigRepository(t *testing.T) {
+	testConfigRepositoryContract(t, func(t *testing.T) ConfigRepository {
+		return NewConfigRepository(newTestDatabase(t))
+	})
+}
+
+func TestMongoConfigVersionRepository(t *testing.T) {
+	testConfigVersionRepositoryContract(t, func(t *testing.T) ConfigVersionRepository {
+		return NewConfigVersionRepository(newTestDatabase(t))
+	})
+}
+
+func TestMongoConfigOverrideRepository(t *testing.T) {
+	testConfigOverrideRepositoryContract(t, func(t *testing.T) ConfigOverrideRepository {
+		return NewConfigOverrideRepository(newTestDatabase(t))
+	})
+}
+
+func TestMongoConfigRuleRepository(t *testing.T) {
+	testConfigRuleRepositoryContract(t, func(t *testing.T) ConfigRuleRepository {
+		return NewConfigRuleRepository(newTestDatabase(t))
+	})
+}
+
+func TestMongoConfigSchemaRepository(t *testing.T) {
+	testConfigSchemaRepositoryContract(t, func(t *testing.T) ConfigSchemaRepository {
+		return NewConfigSchemaRepository(newTestDatabase(t))
+	})
+}
+
+func TestMongoSaaSModuleRepository(t *testing.T) {
+	testSaaSModuleRepositoryContract(t, func(t *testing.T) SaaSModuleRepository {
+		return NewSaaSModuleRepository(newTestDatabase(t))
+	})
+}
+
+func TestMongoServicePackageRepository(t *testing.T) {
+	testServicePackageRepositoryContract(t, func(t *testing.T) ServicePackageRepository {
+		return NewServicePackageRepository(newTestDatabase(t))
+	})
+}
+
+func TestMongoAdminMenuRepository(t *testing.T) {
+	testAdminMenuRepositoryContract(t, func(t *testing.T) AdminMenuRepository {
+		return NewAdminMenuRepository(newTestDatabase(t))
+	})
+}
+
+func TestMongoPermissionRepository(t *testing.T) {
+	testPermissionRepositoryContract(t, func(t *testing.T) PermissionRepository {
+		return NewPermissionRepository(newTestDatabase(t))
+	})
+}
+
+func TestMongoRoleRepository(t *testing.T) {
+	testRoleRepositoryContract(t, func(t *testing.T) RoleRepository {
+		return NewRoleRepository(newTestDatabase(t))
+	})
+}
+
+func TestMongoProvinceRepository(t *testing.T) {
+	testProvinceRepositoryContract(t, func(t *testing.T) ProvinceRepository {
+		return NewProvinceRepository(newTestDatabase(t))
+	})
+}
+
+func TestMongoDistrictRepository(t *testing.T) {
+	testDistrictRepositoryContract(t, func(t *testing.T) DistrictRepository {
+		return NewDistrictRepository(newTestDatabase(t))
+	})
+}
+
+func TestMongoWardRepository(t *testing.T) {
+	testWardRepositoryContract(t, func(t *testing.T) WardRepository {
+		return NewWardRepository(newTestDatabase(t))
+	})
+}
+
+func TestMongoTransactor(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+	provinces := NewProvinceRepository(db)
+	districts := NewDistrictRepository(db)
+	tx := NewTransactor(db)
+
+	failure := errors.New("decree failed")
+	err := tx.WithTransaction(ctx, func(ctx context.Context) error {
+		require.NoError(t, provinces.Create(ctx, &domain.Province{Code: "01", CountryCode: "VN"}))
+		require.NoError(t, districts.Create(ctx, &domain.District{Code: "001", ProvinceCode: "01"}))
+		return failure
+	})
+	require.ErrorIs(t, err, failure)
+	province, err := provinces.FindByCode(ctx, "01")
+	require.NoError(t, err)
+	require.Nil(t, province, "aborted transactions write nothing")
+
+	require.NoError(t, tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := provinces.Create(ctx, &domain.Province{Code: "01", CountryCode: "VN"}); err != nil {
+			return err
+		}
+		return districts.Create(ctx, &domain.District{Code: "001", ProvinceCode: "01"})
+	}))
+	district, err := districts.FindByCode(ctx, "001")
+	require.NoError(t, err)
+	require.NotNil(t, district)
+}
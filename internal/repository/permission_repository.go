@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PermissionRepository handles permission data access
+type PermissionRepository interface {
+	Create(ctx context.Context, permission *domain.Permission) error
+	FindByID(ctx context.Context, id string) (*domain.Permission, error)
+	FindByCode(ctx context.Context, tenantID, code string) (*domain.Permission, error)
+	List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.Permission, int64, error)
+	ListAll(ctx context.Context, tenantID string) ([]*domain.Permission, error)
+	ListByModule(ctx context.Context, tenantID, moduleCode string) ([]*domain.Permission, error)
+	ListByResource(ctx context.Context, tenantID, resource string) ([]*domain.Permission, error)
+	Upsert(ctx context.Context, permissions []*domain.Permission) error
+	Update(ctx context.Context, permission *domain.Permission) error
+	Delete(ctx context.Context, id string) error
+}
+
+// mongoPermissionRepository is the MongoDB implementation of PermissionRepository
+type mongoPermissionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewPermissionRepository creates a new MongoDB backed permission repository
+func NewPermissionRepository(db *mongo.Database) PermissionRepository {
+	collection := db.Collection("permissions")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "tenantId", Value: 1},
+				{Key: "code", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "tenantId", Value: 1}, {Key: "moduleCode", Value: 1}, {Key: "code", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "tenantId", Value: 1}, {Key: "resource", Value: 1}, {Key: "code", Value: 1}},
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &mongoPermissionRepository{collection: collection}
+}
+
+// Create creates a new permission
+func (r *mongoPermissionRepository) Create(ctx context.Context, permission *domain.Permission) error {
+	permission.CreatedAt = time.Now()
+	permission.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, permission)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to create permission: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create permission: %w", err)
+	}
+
+	permission.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByID finds a permission by ID
+func (r *mongoPermissionRepository) FindByID(ctx context.Context, id string) (*domain.Permission, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid permission ID: %w", err)
+	}
+
+	var permission domain.Permission
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&permission)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find permission: %w", err)
+	}
+	return &permission, nil
+}
+
+// FindByCode finds a permission by code and tenant
+func (r *mongoPermissionRepository) FindByCode(ctx context.Context, tenantID, code string) (*domain.Permission, error) {
+	var permission domain.Permission
+	opts := options.FindOne().SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "code", Value: 1}})
+	err := r.collection.FindOne(ctx, bson.M{
+		"tenantId": tenantID,
+		"code":     code,
+	}, opts).Decode(&permission)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find permission: %w", err)
+	}
+	return &permission, nil
+}
+
+// List lists permissions of a tenant ordered by code with pagination
+func (r *mongoPermissionRepository) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.Permission, int64, error) {
+	filter := bson.M{"tenantId": tenantID}
+
+	countOpts := options.Count().SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "code", Value: 1}})
+	total, err := r.collection.CountDocuments(ctx, filter, countOpts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count permissions: %w", err)
+	}
+
+	opts := options.Find().
+		SetSkip(int64((page - 1) * perPage)).
+		SetLimit(int64(perPage)).
+		SetSort(bson.D{{Key: "code", Value: 1}}).
+		SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "code", Value: 1}})
+
+	permissions, err := r.find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	return permissions, total, nil
+}
+
+// ListAll lists every permission of a tenant ordered by code
+func (r *mongoPermissionRepository) ListAll(ctx context.Context, tenantID string) ([]*domain.Permission, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "code", Value: 1}}).
+		SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "code", Value: 1}})
+	return r.find(ctx, bson.M{"tenantId": tenantID}, opts)
+}
+
+// ListByModule lists the permissions registered by a module ordered by code
+func (r *mongoPermissionRepository) ListByModule(ctx context.Context, tenantID, moduleCode string) ([]*domain.Permission, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "code", Value: 1}}).
+		SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "moduleCode", Value: 1}, {Key: "code", Value: 1}})
+	return r.find(ctx, bson.M{"tenantId": tenantID, "moduleCode": moduleCode}, opts)
+}
+
+// ListByResource lists the permissions on a resource ordered by code
+func (r *mongoPermissionRepository) ListByResource(ctx context.Context, tenantID, resource string) ([]*domain.Permission, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "code", Value: 1}}).
+		SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "resource", Value: 1}, {Key: "code", Value: 1}})
+	return r.find(ctx, bson.M{"tenantId": tenantID, "resource": resource}, opts)
+}
+
+// Upsert inserts or replaces permissions by tenant and code in a single bulk write.
+// Creation timestamps of existing permissions are kept and the IDs of inserted ones are set.
+func (r *mongoPermissionRepository) Upsert(ctx context.Context, permissions []*domain.Permission) error {
+	if len(permissions) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	models := make([]mongo.WriteModel, 0, len(permissions))
+	for _, permission := range permissions {
+		permission.UpdatedAt = now
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"tenantId": permission.TenantID, "code": permission.Code}).
+			SetUpdate(bson.M{
+				"$set": bson.M{
+					"moduleCode":  permission.ModuleCode,
+					"name":        permission.Name,
+					"description": permission.Description,
+					"resource":    permission.Resource,
+					"action":      permission.Action,
+					"category":    permission.Category,
+					"status":      permission.Status,
+					"updatedAt":   now,
+				},
+				"$setOnInsert": bson.M{"createdAt": now},
+			}).
+			SetUpsert(true))
+	}
+
+	result, err := r.collection.BulkWrite(ctx, models)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to upsert permissions: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to upsert permissions: %w", err)
+	}
+
+	for index, id := range result.UpsertedIDs {
+		if objectID, ok := id.(primitive.ObjectID); ok {
+			permissions[index].ID = objectID
+			permissions[index].CreatedAt = now
+		}
+	}
+	return nil
+}
+
+// Update updates a permission
+func (r *mongoPermissionRepository) Update(ctx context.Context, permission *domain.Permission) error {
+	permission.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":        permission.Name,
+			"description": permission.Description,
+			"category":    permission.Category,
+			"status":      permission.Status,
+			"updatedAt":   permission.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": permission.ID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update permission: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("permission %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// Delete deletes a permission
+func (r *mongoPermissionRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid permission ID: %w", err)
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return fmt.Errorf("failed to delete permission: %w", err)
+	}
+	return nil
+}
+
+func (r *mongoPermissionRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*domain.Permission, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list permissions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var permissions []*domain.Permission
+	if err = cursor.All(ctx, &permissions); err != nil {
+		return nil, fmt.Errorf("failed to decode permissions: %w", err)
+	}
+
+	return permissions, nil
+}
@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ProvinceRepository handles province data access
+type ProvinceRepository interface {
+	Create(ctx context.Context, province *domain.Province) error
+	FindByCode(ctx context.Context, code string) (*domain.Province, error)
+	FindByCodes(ctx context.Context, codes []string) ([]*domain.Province, error)
+	ListByCountry(ctx context.Context, countryCode string) ([]*domain.Province, error)
+	ListCurrent(ctx context.Context) ([]*domain.Province, error)
+	NextValidityChange(ctx context.Context, countryCode string, after time.Time) (*time.Time, error)
+	CountByCountry(ctx context.Context, countryCode string) (int64, error)
+	Search(ctx context.Context, query domain.LocationQuery) ([]*domain.Province, error)
+	Update(ctx context.Context, province *domain.Province) error
+	Retire(ctx context.Context, code string, at time.Time, successorCodes []string) error
+	Delete(ctx context.Context, code string) error
+}
+
+// mongoProvinceRepository is the MongoDB implementation of ProvinceRepository
+type mongoProvinceRepository struct {
+	collection *mongo.Collection
+}
+
+// NewProvinceRepository creates a new MongoDB backed province repository
+func NewProvinceRepository(db *mongo.Database) ProvinceRepository {
+	collection := db.Collection("provinces")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "code", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "countryCode", Value: 1}, {Key: "code", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "searchText", Value: 1}},
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &mongoProvinceRepository{collection: collection}
+}
+
+// Create creates a new province
+func (r *mongoProvinceRepository) Create(ctx context.Context, province *domain.Province) error {
+	province.CreatedAt = time.Now()
+	province.UpdatedAt = time.Now()
+	province.SearchText = domain.SearchTerms(province.Name)
+
+	result, err := r.collection.InsertOne(ctx, province)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to create province: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create province: %w", err)
+	}
+
+	province.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByCode finds a province by code
+func (r *mongoProvinceRepository) FindByCode(ctx context.Context, code string) (*domain.Province, error) {
+	var province domain.Province
+	opts := options.FindOne().SetHint(bson.D{{Key: "code", Value: 1}})
+	err := r.collection.FindOne(ctx, bson.M{"code": code}, opts).Decode(&province)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find province: %w", err)
+	}
+	return &province, nil
+}
+
+// FindByCodes finds multiple provinces by codes in a single query
+func (r *mongoProvinceRepository) FindByCodes(ctx context.Context, codes []string) ([]*domain.Province, error) {
+	if len(codes) == 0 {
+		return []*domain.Province{}, nil
+	}
+
+	filter := bson.M{"code": bson.M{"$in": codes}}
+	opts := options.Find().SetHint(bson.D{{Key: "code", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find provinces: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	provinces := []*domain.Province{}
+	if err = cursor.All(ctx, &provinces); err != nil {
+		return nil, fmt.Errorf("failed to decode provinces: %w", err)
+	}
+	return provinces, nil
+}
+
+// ListByCountry lists the active provinces of a country currently in effect, ordered by code
+func (r *mongoProvinceRepository) ListByCountry(ctx context.Context, countryCode string) ([]*domain.Province, error) {
+	filter := currentLocationFilter(time.Now())
+	filter["countryCode"] = countryCode
+	filter["status"] = "active"
+	opts := options.Find().
+		SetSort(bson.D{{Key: "code", Value: 1}}).
+		SetHint(bson.D{{Key: "countryCode", Value: 1}, {Key: "code", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list provinces: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	provinces := []*domain.Province{}
+	if err = cursor.All(ctx, &provinces); err != nil {
+		return nil, fmt.Errorf("failed to decode provinces: %w", err)
+	}
+	return provinces, nil
+}
+
+// ListCurrent lists all active provinces currently in effect, ordered by code
+func (r *mongoProvinceRepository) ListCurrent(ctx context.Context) ([]*domain.Province, error) {
+	filter := currentLocationFilter(time.Now())
+	filter["status"] = "active"
+	opts := options.Find().SetSort(bson.D{{Key: "code", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list provinces: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	provinces := []*domain.Province{}
+	if err = cursor.All(ctx, &provinces); err != nil {
+		return nil, fmt.Errorf("failed to decode provinces: %w", err)
+	}
+	return provinces, nil
+}
+
+// NextValidityChange returns the earliest time after the given one at which an active province of a
+// country comes into or goes out of effect, or nil when none does
+func (r *mongoProvinceRepository) NextValidityChange(ctx context.Context, countryCode string, after time.Time) (*time.Time, error) {
+	return nextValidityChange(ctx, r.collection, bson.M{"countryCode": countryCode, "status": "active"}, after)
+}
+
+// CountByCountry counts the provinces of a country regardless of status
+func (r *mongoProvinceRepository) CountByCountry(ctx context.Context, countryCode string) (int64, error) {
+	opts := options.Count().SetHint(bson.D{{Key: "countryCode", Value: 1}, {Key: "code", Value: 1}})
+	count, err := r.collection.CountDocuments(ctx, bson.M{"countryCode": countryCode}, opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count provinces: %w", err)
+	}
+	return count, nil
+}
+
+// Search finds active provinces in effect whose folded names match the query, ordered by code
+func (r *mongoProvinceRepository) Search(ctx context.Context, query domain.LocationQuery) ([]*domain.Province, error) {
+	pattern := regexp.QuoteMeta(query.Text)
+	if query.Prefix {
+		pattern = "^" + pattern
+	}
+
+	filter := currentLocationFilter(time.Now())
+	filter["searchText"] = bson.M{"$regex": pattern}
+	filter["status"] = "active"
+	if query.CountryCode != "" {
+		filter["countryCode"] = query.CountryCode
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "code", Value: 1}})
+	if query.Limit > 0 {
+		opts.SetLimit(int64(query.Limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search provinces: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	provinces := []*domain.Province{}
+	if err = cursor.All(ctx, &provinces); err != nil {
+		return nil, fmt.Errorf("failed to decode provinces: %w", err)
+	}
+	return provinces, nil
+}
+
+// Update updates a province
+func (r *mongoProvinceRepository) Update(ctx context.Context, province *domain.Province) error {
+	province.UpdatedAt = time.Now()
+	province.SearchText = domain.SearchTerms(province.Name)
+
+	update := bson.M{
+		"$set": bson.M{
+			"searchText":  province.SearchText,
+			"name":        province.Name,
+			"countryCode": province.CountryCode,
+			"type":        province.Type,
+			"status":      province.Status,
+			"updatedAt":   province.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": province.ID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update province: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("province %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// Retire ends the validity of a province at the given time and records its successors
+func (r *mongoProvinceRepository) Retire(ctx context.Context, code string, at time.Time, successorCodes []string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"effectiveTo":    at,
+			"successorCodes": successorCodes,
+			"updatedAt":      time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"code": code}, update)
+	if err != nil {
+		return fmt.Errorf("failed to retire province: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("province %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// Delete deletes a province
+func (r *mongoProvinceRepository) Delete(ctx context.Context, code string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"code": code})
+	if err != nil {
+		return fmt.Errorf("failed to delete province: %w", err)
+	}
+	return nil
+}
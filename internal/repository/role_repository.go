@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RoleRepository handles role data access
+type RoleRepository interface {
+	Create(ctx context.Context, role *domain.Role) error
+	FindByID(ctx context.Context, id string) (*domain.Role, error)
+	FindByCode(ctx context.Context, tenantID, code string) (*domain.Role, error)
+	List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.Role, int64, error)
+	ListAll(ctx context.Context, tenantID string) ([]*domain.Role, error)
+	FindByParent(ctx context.Context, tenantID, parentCode string) ([]*domain.Role, error)
+	Update(ctx context.Context, role *domain.Role) error
+	UpdatePermissions(ctx context.Context, id string, permissions []string) error
+	Delete(ctx context.Context, id string) error
+}
+
+// mongoRoleRepository is the MongoDB implementation of RoleRepository
+type mongoRoleRepository struct {
+	collection *mongo.Collection
+}
+
+// NewRoleRepository creates a new MongoDB backed role repository
+func NewRoleRepository(db *mongo.Database) RoleRepository {
+	collection := db.Collection("roles")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "tenantId", Value: 1},
+				{Key: "code", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "tenantId", Value: 1}, {Key: "level", Value: 1}, {Key: "code", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "tenantId", Value: 1}, {Key: "parentCode", Value: 1}},
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &mongoRoleRepository{collection: collection}
+}
+
+// Create creates a new role
+func (r *mongoRoleRepository) Create(ctx context.Context, role *domain.Role) error {
+	role.CreatedAt = time.Now()
+	role.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, role)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to create role: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create role: %w", err)
+	}
+
+	role.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByID finds a role by ID
+func (r *mongoRoleRepository) FindByID(ctx context.Context, id string) (*domain.Role, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid role ID: %w", err)
+	}
+
+	var role domain.Role
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&role)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find role: %w", err)
+	}
+	return &role, nil
+}
+
+// FindByCode finds a role by code and tenant
+func (r *mongoRoleRepository) FindByCode(ctx context.Context, tenantID, code string) (*domain.Role, error) {
+	var role domain.Role
+	opts := options.FindOne().SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "code", Value: 1}})
+	err := r.collection.FindOne(ctx, bson.M{
+		"tenantId": tenantID,
+		"code":     code,
+	}, opts).Decode(&role)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find role: %w", err)
+	}
+	return &role, nil
+}
+
+// List lists roles of a tenant ordered by level and code with pagination
+func (r *mongoRoleRepository) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.Role, int64, error) {
+	filter := bson.M{"tenantId": tenantID}
+
+	countOpts := options.Count().SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "code", Value: 1}})
+	total, err := r.collection.CountDocuments(ctx, filter, countOpts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count roles: %w", err)
+	}
+
+	opts := options.Find().
+		SetSkip(int64((page - 1) * perPage)).
+		SetLimit(int64(perPage)).
+		SetSort(bson.D{{Key: "level", Value: 1}, {Key: "code", Value: 1}}).
+		SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "level", Value: 1}, {Key: "code", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list roles: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var roles []*domain.Role
+	if err = cursor.All(ctx, &roles); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode roles: %w", err)
+	}
+
+	return roles, total, nil
+}
+
+// ListAll lists every role of a tenant ordered by level and code
+func (r *mongoRoleRepository) ListAll(ctx context.Context, tenantID string) ([]*domain.Role, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "level", Value: 1}, {Key: "code", Value: 1}}).
+		SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "level", Value: 1}, {Key: "code", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"tenantId": tenantID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var roles []*domain.Role
+	if err = cursor.All(ctx, &roles); err != nil {
+		return nil, fmt.Errorf("failed to decode roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+// FindByParent finds the roles of a tenant that inherit directly from the given role code
+func (r *mongoRoleRepository) FindByParent(ctx context.Context, tenantID, parentCode string) ([]*domain.Role, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "code", Value: 1}}).
+		SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "parentCode", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"tenantId": tenantID, "parentCode": parentCode}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find child roles: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var roles []*domain.Role
+	if err = cursor.All(ctx, &roles); err != nil {
+		return nil, fmt.Errorf("failed to decode roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+// Update updates a role including its permission grants
+func (r *mongoRoleRepository) Update(ctx context.Context, role *domain.Role) error {
+	role.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":        role.Name,
+			"description": role.Description,
+			"level":       role.Level,
+			"parentCode":  role.ParentCode,
+			"permissions": role.Permissions,
+			"status":      role.Status,
+			"updatedAt":   role.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": role.ID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("role %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// UpdatePermissions replaces the permission grants of a role
+func (r *mongoRoleRepository) UpdatePermissions(ctx context.Context, id string, permissions []string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid role ID: %w", err)
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"permissions": permissions,
+			"updatedAt":   time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update role permissions: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("role %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// Delete deletes a role
+func (r *mongoRoleRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid role ID: %w", err)
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SaaSModuleRepository handles SaaS module data access
+type SaaSModuleRepository interface {
+	Create(ctx context.Context, module *domain.SaaSModule) error
+	FindByID(ctx context.Context, id string) (*domain.SaaSModule, error)
+	FindByCode(ctx context.Context, tenantID, code string) (*domain.SaaSModule, error)
+	List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.SaaSModule, int64, error)
+	ListAll(ctx context.Context, tenantID string) ([]*domain.SaaSModule, error)
+	FindDependents(ctx context.Context, tenantID, code string) ([]*domain.SaaSModule, error)
+	Update(ctx context.Context, module *domain.SaaSModule) error
+	Delete(ctx context.Context, id string) error
+}
+
+// mongoSaaSModuleRepository is the MongoDB implementation of SaaSModuleRepository
+type mongoSaaSModuleRepository struct {
+	collection *mongo.Collection
+}
+
+// NewSaaSModuleRepository creates a new MongoDB backed SaaS module repository
+func NewSaaSModuleRepository(db *mongo.Database) SaaSModuleRepository {
+	collection := db.Collection("saas_modules")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "tenantId", Value: 1},
+				{Key: "code", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "tenantId", Value: 1}, {Key: "dependencies", Value: 1}},
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &mongoSaaSModuleRepository{collection: collection}
+}
+
+// Create creates a new SaaS module
+func (r *mongoSaaSModuleRepository) Create(ctx context.Context, module *domain.SaaSModule) error {
+	module.CreatedAt = time.Now()
+	module.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, module)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to create SaaS module: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create SaaS module: %w", err)
+	}
+
+	module.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByID finds a SaaS module by ID
+func (r *mongoSaaSModuleRepository) FindByID(ctx context.Context, id string) (*domain.SaaSModule, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SaaS module ID: %w", err)
+	}
+
+	var module domain.SaaSModule
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&module)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find SaaS module: %w", err)
+	}
+	return &module, nil
+}
+
+// FindByCode finds a SaaS module by code and tenant
+func (r *mongoSaaSModuleRepository) FindByCode(ctx context.Context, tenantID, code string) (*domain.SaaSModule, error) {
+	var module domain.SaaSModule
+	opts := options.FindOne().SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "code", Value: 1}})
+	err := r.collection.FindOne(ctx, bson.M{
+		"tenantId": tenantID,
+		"code":     code,
+	}, opts).Decode(&module)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find SaaS module: %w", err)
+	}
+	return &module, nil
+}
+
+// List lists SaaS modules of a tenant ordered by code with pagination
+func (r *mongoSaaSModuleRepository) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.SaaSModule, int64, error) {
+	filter := bson.M{"tenantId": tenantID}
+
+	countOpts := options.Count().SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "code", Value: 1}})
+	total, err := r.collection.CountDocuments(ctx, filter, countOpts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count SaaS modules: %w", err)
+	}
+
+	opts := options.Find().
+		SetSkip(int64((page - 1) * perPage)).
+		SetLimit(int64(perPage)).
+		SetSort(bson.D{{Key: "code", Value: 1}}).
+		SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "code", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list SaaS modules: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var modules []*domain.SaaSModule
+	if err = cursor.All(ctx, &modules); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode SaaS modules: %w", err)
+	}
+
+	return modules, total, nil
+}
+
+// ListAll lists every SaaS module of a tenant ordered by code.
+// The catalog is small, so dependency resolution loads it as a whole.
+func (r *mongoSaaSModuleRepository) ListAll(ctx context.Context, tenantID string) ([]*domain.SaaSModule, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "code", Value: 1}}).
+		SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "code", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"tenantId": tenantID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SaaS modules: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var modules []*domain.SaaSModule
+	if err = cursor.All(ctx, &modules); err != nil {
+		return nil, fmt.Errorf("failed to decode SaaS modules: %w", err)
+	}
+
+	return modules, nil
+}
+
+// FindDependents finds the modules of a tenant that directly depend on the given module code
+func (r *mongoSaaSModuleRepository) FindDependents(ctx context.Context, tenantID, code string) ([]*domain.SaaSModule, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "code", Value: 1}}).
+		SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "dependencies", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"tenantId": tenantID, "dependencies": code}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find dependent SaaS modules: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var modules []*domain.SaaSModule
+	if err = cursor.All(ctx, &modules); err != nil {
+		return nil, fmt.Errorf("failed to decode SaaS modules: %w", err)
+	}
+
+	return modules, nil
+}
+
+// Update updates a SaaS module
+func (r *mongoSaaSModuleRepository) Update(ctx context.Context, module *domain.SaaSModule) error {
+	module.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":         module.Name,
+			"description":  module.Description,
+			"icon":         module.Icon,
+			"category":     module.Category,
+			"isCore":       module.IsCore,
+			"dependencies": module.Dependencies,
+			"price":        module.Price,
+			"status":       module.Status,
+			"features":     module.Features,
+			"updatedAt":    module.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": module.ID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update SaaS module: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("SaaS module %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// Delete deletes a SaaS module
+func (r *mongoSaaSModuleRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid SaaS module ID: %w", err)
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return fmt.Errorf("failed to delete SaaS module: %w", err)
+	}
+	return nil
+}
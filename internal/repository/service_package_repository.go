@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ServicePackageRepository handles service package data access
+type ServicePackageRepository interface {
+	Create(ctx context.Context, pkg *domain.ServicePackage) error
+	FindByID(ctx context.Context, id string) (*domain.ServicePackage, error)
+	FindByCode(ctx context.Context, tenantID, code string) (*domain.ServicePackage, error)
+	List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.ServicePackage, int64, error)
+	Update(ctx context.Context, pkg *domain.ServicePackage) error
+	Delete(ctx context.Context, id string) error
+	CountByCurrency(ctx context.Context, currency string) (int64, error)
+	FindByModule(ctx context.Context, tenantID, moduleCode string) ([]*domain.ServicePackage, error)
+	FindAll(ctx context.Context) ([]*domain.ServicePackage, error)
+}
+
+// mongoServicePackageRepository is the MongoDB implementation of ServicePackageRepository
+type mongoServicePackageRepository struct {
+	collection *mongo.Collection
+}
+
+// NewServicePackageRepository creates a new MongoDB backed service package repository
+func NewServicePackageRepository(db *mongo.Database) ServicePackageRepository {
+	collection := db.Collection("service_packages")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "tenantId", Value: 1},
+				{Key: "code", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "tenantId", Value: 1}, {Key: "status", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "currency", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "tenantId", Value: 1}, {Key: "modules", Value: 1}},
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &mongoServicePackageRepository{collection: collection}
+}
+
+// Create creates a new service package
+func (r *mongoServicePackageRepository) Create(ctx context.Context, pkg *domain.ServicePackage) error {
+	pkg.CreatedAt = time.Now()
+	pkg.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, pkg)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to create service package: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create service package: %w", err)
+	}
+
+	pkg.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByID finds a service package by ID
+func (r *mongoServicePackageRepository) FindByID(ctx context.Context, id string) (*domain.ServicePackage, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service package ID: %w", err)
+	}
+
+	var pkg domain.ServicePackage
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&pkg)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find service package: %w", err)
+	}
+	return &pkg, nil
+}
+
+// FindByCode finds a service package by code and tenant
+func (r *mongoServicePackageRepository) FindByCode(ctx context.Context, tenantID, code string) (*domain.ServicePackage, error) {
+	var pkg domain.ServicePackage
+	opts := options.FindOne().SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "code", Value: 1}})
+	err := r.collection.FindOne(ctx, bson.M{
+		"tenantId": tenantID,
+		"code":     code,
+	}, opts).Decode(&pkg)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find service package: %w", err)
+	}
+	return &pkg, nil
+}
+
+// List lists service packages of a tenant ordered by code with pagination
+func (r *mongoServicePackageRepository) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.ServicePackage, int64, error) {
+	filter := bson.M{"tenantId": tenantID}
+
+	countOpts := options.Count().SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "code", Value: 1}})
+	total, err := r.collection.CountDocuments(ctx, filter, countOpts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count service packages: %w", err)
+	}
+
+	opts := options.Find().
+		SetSkip(int64((page - 1) * perPage)).
+		SetLimit(int64(perPage)).
+		SetSort(bson.D{{Key: "code", Value: 1}}).
+		SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "code", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list service packages: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var packages []*domain.ServicePackage
+	if err = cursor.All(ctx, &packages); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode service packages: %w", err)
+	}
+
+	return packages, total, nil
+}
+
+// Update updates a service package
+func (r *mongoServicePackageRepository) Update(ctx context.Context, pkg *domain.ServicePackage) error {
+	pkg.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":         pkg.Name,
+			"description":  pkg.Description,
+			"tier":         pkg.Tier,
+			"price":        pkg.Price,
+			"currency":     pkg.Currency,
+			"billingCycle": pkg.BillingCycle,
+			"modules":      pkg.Modules,
+			"limits":       pkg.Limits,
+			"features":     pkg.Features,
+			"isPopular":    pkg.IsPopular,
+			"status":       pkg.Status,
+			"updatedAt":    pkg.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": pkg.ID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update service package: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("service package %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// Delete deletes a service package
+func (r *mongoServicePackageRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid service package ID: %w", err)
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return fmt.Errorf("failed to delete service package: %w", err)
+	}
+	return nil
+}
+
+// CountByCurrency counts the service packages of every tenant priced in a currency
+func (r *mongoServicePackageRepository) CountByCurrency(ctx context.Context, currency string) (int64, error) {
+	opts := options.Count().SetHint(bson.D{{Key: "currency", Value: 1}})
+	count, err := r.collection.CountDocuments(ctx, bson.M{"currency": currency}, opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count service packages: %w", err)
+	}
+	return count, nil
+}
+
+// FindByModule finds the service packages of a tenant that include the given module code, ordered by code
+func (r *mongoServicePackageRepository) FindByModule(ctx context.Context, tenantID, moduleCode string) ([]*domain.ServicePackage, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "code", Value: 1}}).
+		SetHint(bson.D{{Key: "tenantId", Value: 1}, {Key: "modules", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"tenantId": tenantID, "modules": moduleCode}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find service packages: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var packages []*domain.ServicePackage
+	if err = cursor.All(ctx, &packages); err != nil {
+		return nil, fmt.Errorf("failed to decode service packages: %w", err)
+	}
+	return packages, nil
+}
+
+// FindAll finds the service packages of every tenant, ordered by tenant and code
+func (r *mongoServicePackageRepository) FindAll(ctx context.Context) ([]*domain.ServicePackage, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "tenantId", Value: 1}, {Key: "code", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find service packages: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	packages := []*domain.ServicePackage{}
+	if err = cursor.All(ctx, &packages); err != nil {
+		return nil, fmt.Errorf("failed to decode service packages: %w", err)
+	}
+	return packages, nil
+}
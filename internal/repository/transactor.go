@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Transactor runs writes spanning several repositories in one transaction. Repository calls made
+// with the context passed to fn join the transaction.
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// mongoTransactor is the MongoDB implementation of Transactor
+type mongoTransactor struct {
+	client *mongo.Client
+}
+
+// NewTransactor creates a new MongoDB backed transactor; MongoDB transactions require a replica set
+func NewTransactor(db *mongo.Database) Transactor {
+	return &mongoTransactor{client: db.Client()}
+}
+
+// WithTransaction runs fn in a transaction committed when fn succeeds and aborted when it fails.
+// fn may be retried on transient transaction errors, so it must not keep state between runs.
+func (t *mongoTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := t.client.StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
@@ -0,0 +1,295 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WardRepository handles ward data access
+type WardRepository interface {
+	Create(ctx context.Context, ward *domain.Ward) error
+	FindByCode(ctx context.Context, code string) (*domain.Ward, error)
+	FindByCodes(ctx context.Context, codes []string) ([]*domain.Ward, error)
+	ListByDistrict(ctx context.Context, districtCode string) ([]*domain.Ward, error)
+	ListCurrent(ctx context.Context) ([]*domain.Ward, error)
+	NextValidityChange(ctx context.Context, districtCode string, after time.Time) (*time.Time, error)
+	CountByDistrict(ctx context.Context, districtCode string) (int64, error)
+	SetCountryByProvince(ctx context.Context, provinceCode, countryCode string) error
+	SetAncestorsByDistrict(ctx context.Context, districtCode, provinceCode, countryCode string) error
+	Search(ctx context.Context, query domain.LocationQuery) ([]*domain.Ward, error)
+	Update(ctx context.Context, ward *domain.Ward) error
+	Retire(ctx context.Context, code string, at time.Time, successorCodes []string) error
+	Delete(ctx context.Context, code string) error
+}
+
+// mongoWardRepository is the MongoDB implementation of WardRepository
+type mongoWardRepository struct {
+	collection *mongo.Collection
+}
+
+// NewWardRepository creates a new MongoDB backed ward repository
+func NewWardRepository(db *mongo.Database) WardRepository {
+	collection := db.Collection("wards")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "code", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "districtCode", Value: 1}, {Key: "code", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "provinceCode", Value: 1}, {Key: "code", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "countryCode", Value: 1}, {Key: "code", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "searchText", Value: 1}},
+		},
+	}
+
+	_, _ = collection.Indexes().CreateMany(ctx, indexes)
+
+	return &mongoWardRepository{collection: collection}
+}
+
+// Create creates a new ward
+func (r *mongoWardRepository) Create(ctx context.Context, ward *domain.Ward) error {
+	ward.CreatedAt = time.Now()
+	ward.UpdatedAt = time.Now()
+	ward.SearchText = domain.SearchTerms(ward.Name)
+
+	result, err := r.collection.InsertOne(ctx, ward)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to create ward: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create ward: %w", err)
+	}
+
+	ward.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByCode finds a ward by code
+func (r *mongoWardRepository) FindByCode(ctx context.Context, code string) (*domain.Ward, error) {
+	var ward domain.Ward
+	opts := options.FindOne().SetHint(bson.D{{Key: "code", Value: 1}})
+	err := r.collection.FindOne(ctx, bson.M{"code": code}, opts).Decode(&ward)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find ward: %w", err)
+	}
+	return &ward, nil
+}
+
+// FindByCodes finds multiple wards by codes in a single query
+func (r *mongoWardRepository) FindByCodes(ctx context.Context, codes []string) ([]*domain.Ward, error) {
+	if len(codes) == 0 {
+		return []*domain.Ward{}, nil
+	}
+
+	filter := bson.M{"code": bson.M{"$in": codes}}
+	opts := options.Find().SetHint(bson.D{{Key: "code", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find wards: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	wards := []*domain.Ward{}
+	if err = cursor.All(ctx, &wards); err != nil {
+		return nil, fmt.Errorf("failed to decode wards: %w", err)
+	}
+	return wards, nil
+}
+
+// ListByDistrict lists the active wards of a district currently in effect, ordered by code
+func (r *mongoWardRepository) ListByDistrict(ctx context.Context, districtCode string) ([]*domain.Ward, error) {
+	filter := currentLocationFilter(time.Now())
+	filter["districtCode"] = districtCode
+	filter["status"] = "active"
+	opts := options.Find().
+		SetSort(bson.D{{Key: "code", Value: 1}}).
+		SetHint(bson.D{{Key: "districtCode", Value: 1}, {Key: "code", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wards: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	wards := []*domain.Ward{}
+	if err = cursor.All(ctx, &wards); err != nil {
+		return nil, fmt.Errorf("failed to decode wards: %w", err)
+	}
+	return wards, nil
+}
+
+// ListCurrent lists all active wards currently in effect, ordered by code
+func (r *mongoWardRepository) ListCurrent(ctx context.Context) ([]*domain.Ward, error) {
+	filter := currentLocationFilter(time.Now())
+	filter["status"] = "active"
+	opts := options.Find().SetSort(bson.D{{Key: "code", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wards: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	wards := []*domain.Ward{}
+	if err = cursor.All(ctx, &wards); err != nil {
+		return nil, fmt.Errorf("failed to decode wards: %w", err)
+	}
+	return wards, nil
+}
+
+// NextValidityChange returns the earliest time after the given one at which an active ward of a
+// district comes into or goes out of effect, or nil when none does
+func (r *mongoWardRepository) NextValidityChange(ctx context.Context, districtCode string, after time.Time) (*time.Time, error) {
+	return nextValidityChange(ctx, r.collection, bson.M{"districtCode": districtCode, "status": "active"}, after)
+}
+
+// CountByDistrict counts the wards of a district regardless of status
+func (r *mongoWardRepository) CountByDistrict(ctx context.Context, districtCode string) (int64, error) {
+	opts := options.Count().SetHint(bson.D{{Key: "districtCode", Value: 1}, {Key: "code", Value: 1}})
+	count, err := r.collection.CountDocuments(ctx, bson.M{"districtCode": districtCode}, opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count wards: %w", err)
+	}
+	return count, nil
+}
+
+// SetCountryByProvince records the country of a province on all of its wards
+func (r *mongoWardRepository) SetCountryByProvince(ctx context.Context, provinceCode, countryCode string) error {
+	update := bson.M{"$set": bson.M{"countryCode": countryCode, "updatedAt": time.Now()}}
+	if _, err := r.collection.UpdateMany(ctx, bson.M{"provinceCode": provinceCode}, update); err != nil {
+		return fmt.Errorf("failed to update wards: %w", err)
+	}
+	return nil
+}
+
+// SetAncestorsByDistrict records the province and country of a district on all of its wards
+func (r *mongoWardRepository) SetAncestorsByDistrict(ctx context.Context, districtCode, provinceCode, countryCode string) error {
+	update := bson.M{"$set": bson.M{"provinceCode": provinceCode, "countryCode": countryCode, "updatedAt": time.Now()}}
+	if _, err := r.collection.UpdateMany(ctx, bson.M{"districtCode": districtCode}, update); err != nil {
+		return fmt.Errorf("failed to update wards: %w", err)
+	}
+	return nil
+}
+
+// Search finds active wards in effect whose folded names match the query, ordered by code
+func (r *mongoWardRepository) Search(ctx context.Context, query domain.LocationQuery) ([]*domain.Ward, error) {
+	pattern := regexp.QuoteMeta(query.Text)
+	if query.Prefix {
+		pattern = "^" + pattern
+	}
+
+	filter := currentLocationFilter(time.Now())
+	filter["searchText"] = bson.M{"$regex": pattern}
+	filter["status"] = "active"
+	if query.CountryCode != "" {
+		filter["countryCode"] = query.CountryCode
+	}
+	if query.ProvinceCode != "" {
+		filter["provinceCode"] = query.ProvinceCode
+	}
+	if query.DistrictCode != "" {
+		filter["districtCode"] = query.DistrictCode
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "code", Value: 1}})
+	if query.Limit > 0 {
+		opts.SetLimit(int64(query.Limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search wards: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	wards := []*domain.Ward{}
+	if err = cursor.All(ctx, &wards); err != nil {
+		return nil, fmt.Errorf("failed to decode wards: %w", err)
+	}
+	return wards, nil
+}
+
+// Update updates a ward
+func (r *mongoWardRepository) Update(ctx context.Context, ward *domain.Ward) error {
+	ward.UpdatedAt = time.Now()
+	ward.SearchText = domain.SearchTerms(ward.Name)
+
+	update := bson.M{
+		"$set": bson.M{
+			"searchText":   ward.SearchText,
+			"name":         ward.Name,
+			"districtCode": ward.DistrictCode,
+			"provinceCode": ward.ProvinceCode,
+			"countryCode":  ward.CountryCode,
+			"type":         ward.Type,
+			"status":       ward.Status,
+			"updatedAt":    ward.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": ward.ID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update ward: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("ward %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// Retire ends the validity of a ward at the given time and records its successors
+func (r *mongoWardRepository) Retire(ctx context.Context, code string, at time.Time, successorCodes []string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"effectiveTo":    at,
+			"successorCodes": successorCodes,
+			"updatedAt":      time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"code": code}, update)
+	if err != nil {
+		return fmt.Errorf("failed to retire ward: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("ward %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// Delete deletes a ward
+func (r *mongoWardRepository) Delete(ctx context.Context, code string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"code": code})
+	if err != nil {
+		return fmt.Errorf("failed to delete ward: %w", err)
+	}
+	return nil
+}
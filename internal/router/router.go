@@ -12,6 +12,20 @@ import (
 func SetupRouter(
 	appComponentHandler *handler.AppComponentHandler,
 	countryHandler *handler.CountryHandler,
+	moduleHandler *handler.SaaSModuleHandler,
+	packageHandler *handler.ServicePackageHandler,
+	menuHandler *handler.AdminMenuHandler,
+	permissionHandler *handler.PermissionHandler,
+	roleHandler *handler.RoleHandler,
+	tenantHandler *handler.TenantHandler,
+	locationHandler *handler.LocationHandler,
+	currencyHandler *handler.CurrencyHandler,
+	exchangeRateHandler *handler.ExchangeRateHandler,
+	ethnicityHandler *handler.EthnicityHandler,
+	translationHandler *handler.TranslationHandler,
+	configHandler *handler.ConfigHandler,
+	configRuleHandler *handler.ConfigRuleHandler,
+	configSchemaHandler *handler.ConfigSchemaHandler,
 	log *logger.Logger,
 ) *gin.Engine {
 	router := gin.New()
@@ -34,13 +48,55 @@ func SetupRouter(
 	// API v1 routes
 	v1 := router.Group("/api/v1/system-config")
 	{
+		// Configs
+		configs := v1.Group("/configs")
+		{
+			configs.GET("", configHandler.List)
+			configs.GET("/:key", configHandler.Get)
+			configs.POST("", configHandler.Create)
+			configs.PUT("/:key", configHandler.Update)
+			configs.POST("/:key/activate", configHandler.Activate)
+			configs.GET("/:key/history", configHandler.History)
+			configs.GET("/:key/diff", configHandler.Diff)
+			configs.POST("/:key/rollback", configHandler.Rollback)
+			configs.PUT("/:key/override", configHandler.SetOverride)
+			configs.DELETE("/:key/override", configHandler.DeleteOverride)
+			configs.DELETE("/:key", configHandler.Delete)
+		}
+		// Effective configs merged across layers; not under /configs so that no key is shadowed
+		v1.GET("/resolved-configs", configHandler.Resolve)
+
+		// Config validation rules
+		configRules := v1.Group("/config-rules")
+		{
+			configRules.GET("", configRuleHandler.List)
+			configRules.GET("/:id", configRuleHandler.GetByID)
+			configRules.POST("", configRuleHandler.Create)
+			configRules.PUT("/:id", configRuleHandler.Update)
+			configRules.DELETE("/:id", configRuleHandler.Delete)
+		}
+
+		// JSON Schemas of app component configs and package limits
+		configSchemas := v1.Group("/config-schemas")
+		{
+			configSchemas.GET("", configSchemaHandler.List)
+			configSchemas.GET("/:id", configSchemaHandler.GetByID)
+			configSchemas.POST("", configSchemaHandler.Create)
+			configSchemas.PUT("/:id", configSchemaHandler.Update)
+			configSchemas.DELETE("/:id", configSchemaHandler.Delete)
+		}
+
 		// App Components
 		appComponents := v1.Group("/app-components")
 		{
 			appComponents.GET("", appComponentHandler.List)
 			appComponents.GET("/:id", appComponentHandler.GetByID)
 			appComponents.POST("", appComponentHandler.Create)
+			appComponents.POST("/migrate", appComponentHandler.MigrateConfigs)
 			appComponents.PUT("/:id", appComponentHandler.Update)
+			appComponents.GET("/:id/history", appComponentHandler.History)
+			appComponents.GET("/:id/diff", appComponentHandler.Diff)
+			appComponents.POST("/:id/rollback", appComponentHandler.Rollback)
 			appComponents.DELETE("/:id", appComponentHandler.Delete)
 		}
 
@@ -54,117 +110,143 @@ func SetupRouter(
 			countries.DELETE("/:code", countryHandler.Delete)
 		}
 
-		// Placeholder routes for other entities
-		// These would be implemented similarly to the above
-
 		// SaaS Modules
 		modules := v1.Group("/modules")
 		{
-			modules.GET("", placeholderHandler)
-			modules.GET("/:id", placeholderHandler)
-			modules.POST("", placeholderHandler)
-			modules.PUT("/:id", placeholderHandler)
-			modules.DELETE("/:id", placeholderHandler)
+			modules.GET("", moduleHandler.List)
+			modules.GET("/:id", moduleHandler.GetByID)
+			modules.POST("", moduleHandler.Create)
+			modules.POST("/resolve", moduleHandler.Resolve)
+			modules.PUT("/:id", moduleHandler.Update)
+			modules.DELETE("/:id", moduleHandler.Delete)
 		}
 
 		// Service Packages
 		packages := v1.Group("/packages")
 		{
-			packages.GET("", placeholderHandler)
-			packages.GET("/:id", placeholderHandler)
-			packages.POST("", placeholderHandler)
-			packages.PUT("/:id", placeholderHandler)
-			packages.DELETE("/:id", placeholderHandler)
+			packages.GET("", packageHandler.List)
+			packages.GET("/:id", packageHandler.GetByID)
+			packages.GET("/entitlements/:code", packageHandler.Entitlements)
+			packages.POST("", packageHandler.Create)
+			packages.POST("/migrate", packageHandler.MigrateLimits)
+			packages.PUT("/:id", packageHandler.Update)
+			packages.DELETE("/:id", packageHandler.Delete)
 		}
 
 		// Admin Menus
 		menus := v1.Group("/menus")
 		{
-			menus.GET("", placeholderHandler)
-			menus.GET("/tree", placeholderHandler)
-			menus.GET("/by-module/:module_code", placeholderHandler)
-			menus.GET("/:id", placeholderHandler)
-			menus.POST("", placeholderHandler)
-			menus.PUT("/:id", placeholderHandler)
-			menus.DELETE("/:id", placeholderHandler)
+			menus.GET("", menuHandler.List)
+			menus.GET("/tree", menuHandler.Tree)
+			menus.GET("/by-module/:module_code", menuHandler.ListByModule)
+			menus.GET("/:id", menuHandler.GetByID)
+			menus.POST("", menuHandler.Create)
+			menus.PUT("/:id", menuHandler.Update)
+			menus.POST("/:id/move", menuHandler.Move)
+			menus.DELETE("/:id", menuHandler.Delete)
 		}
 
 		// Permissions
 		permissions := v1.Group("/permissions")
 		{
-			permissions.GET("", placeholderHandler)
-			permissions.GET("/:id", placeholderHandler)
-			permissions.GET("/by-module/:module_code", placeholderHandler)
-			permissions.GET("/by-resource/:resource", placeholderHandler)
-			permissions.POST("", placeholderHandler)
-			permissions.PUT("/:id", placeholderHandler)
-			permissions.DELETE("/:id", placeholderHandler)
-			permissions.POST("/batch", placeholderHandler)
+			permissions.GET("", permissionHandler.List)
+			permissions.GET("/:id", permissionHandler.GetByID)
+			permissions.GET("/by-module/:module_code", permissionHandler.ListByModule)
+			permissions.GET("/by-resource/:resource", permissionHandler.ListByResource)
+			permissions.POST("", permissionHandler.Create)
+			permissions.PUT("/:id", permissionHandler.Update)
+			permissions.DELETE("/:id", permissionHandler.Delete)
+			permissions.POST("/batch", permissionHandler.Register)
 		}
 
 		// Roles
 		roles := v1.Group("/roles")
 		{
-			roles.GET("", placeholderHandler)
-			roles.GET("/:id", placeholderHandler)
-			roles.POST("", placeholderHandler)
-			roles.PUT("/:id", placeholderHandler)
-			roles.DELETE("/:id", placeholderHandler)
-			roles.GET("/:id/permissions", placeholderHandler)
-			roles.PUT("/:id/permissions", placeholderHandler)
-			roles.POST("/:id/clone", placeholderHandler)
+			roles.GET("", roleHandler.List)
+			roles.GET("/assignable", roleHandler.Assignable)
+			roles.GET("/:id", roleHandler.GetByID)
+			roles.POST("", roleHandler.Create)
+			roles.POST("/check", roleHandler.Check)
+			roles.PUT("/:id", roleHandler.Update)
+			roles.DELETE("/:id", roleHandler.Delete)
+			roles.GET("/:id/permissions", roleHandler.GetPermissions)
+			roles.PUT("/:id/permissions", roleHandler.SetPermissions)
+			roles.GET("/:id/effective-permissions", roleHandler.EffectivePermissions)
+			roles.POST("/:id/clone", roleHandler.Clone)
+		}
+
+		// Tenants
+		tenants := v1.Group("/tenants")
+		{
+			tenants.POST("/:tenant_id/provision", tenantHandler.Provision)
 		}
 
 		// Ethnicities
 		ethnicities := v1.Group("/ethnicities")
 		{
-			ethnicities.GET("", placeholderHandler)
-			ethnicities.GET("/:id", placeholderHandler)
-			ethnicities.GET("/by-country/:country_code", placeholderHandler)
-			ethnicities.POST("", placeholderHandler)
-			ethnicities.PUT("/:id", placeholderHandler)
-			ethnicities.DELETE("/:id", placeholderHandler)
+			ethnicities.GET("", ethnicityHandler.List)
+			ethnicities.GET("/:id", ethnicityHandler.GetByID)
+			ethnicities.GET("/by-country/:country_code", ethnicityHandler.ListByCountry)
+			ethnicities.POST("", ethnicityHandler.Create)
+			ethnicities.PUT("/:id", ethnicityHandler.Update)
+			ethnicities.DELETE("/:id", ethnicityHandler.Delete)
 		}
 
 		// Locations (Hierarchical)
 		locations := v1.Group("/locations")
 		{
-			locations.GET("/countries/:country_code/provinces", placeholderHandler)
-			locations.GET("/provinces/:province_code", placeholderHandler)
-			locations.GET("/provinces/:province_code/districts", placeholderHandler)
-			locations.GET("/districts/:district_code", placeholderHandler)
-			locations.GET("/districts/:district_code/wards", placeholderHandler)
-			locations.GET("/wards/:ward_code", placeholderHandler)
-			locations.GET("/search", placeholderHandler)
-			locations.POST("/provinces", placeholderHandler)
-			locations.POST("/districts", placeholderHandler)
-			locations.POST("/wards", placeholderHandler)
-			locations.PUT("/provinces/:code", placeholderHandler)
-			locations.PUT("/districts/:code", placeholderHandler)
-			locations.PUT("/wards/:code", placeholderHandler)
-			locations.DELETE("/provinces/:code", placeholderHandler)
-			locations.DELETE("/districts/:code", placeholderHandler)
-			locations.DELETE("/wards/:code", placeholderHandler)
+			locations.GET("/countries/:country_code/provinces", locationHandler.ListProvinces)
+			locations.GET("/provinces/:province_code", locationHandler.GetProvince)
+			locations.GET("/provinces/:province_code/districts", locationHandler.ListDistricts)
+			locations.GET("/districts/:district_code", locationHandler.GetDistrict)
+			locations.GET("/districts/:district_code/wards", locationHandler.ListWards)
+			locations.GET("/wards/:ward_code", locationHandler.GetWard)
+			locations.GET("/search", locationHandler.Search)
+			locations.GET("/resolve/:code", locationHandler.Resolve)
+			locations.POST("/provinces", locationHandler.CreateProvince)
+			locations.POST("/districts", locationHandler.CreateDistrict)
+			locations.POST("/wards", locationHandler.CreateWard)
+			locations.POST("/reorganizations", locationHandler.Reorganize)
+			locations.POST("/import", locationHandler.Import)
+			locations.PUT("/provinces/:code", locationHandler.UpdateProvince)
+			locations.PUT("/districts/:code", locationHandler.UpdateDistrict)
+			locations.PUT("/wards/:code", locationHandler.UpdateWard)
+			locations.DELETE("/provinces/:code", locationHandler.DeleteProvince)
+			locations.DELETE("/districts/:code", locationHandler.DeleteDistrict)
+			locations.DELETE("/wards/:code", locationHandler.DeleteWard)
 		}
 
 		// Currencies
 		currencies := v1.Group("/currencies")
 		{
-			currencies.GET("", placeholderHandler)
-			currencies.GET("/:code", placeholderHandler)
-			currencies.POST("", placeholderHandler)
-			currencies.PUT("/:code", placeholderHandler)
-			currencies.DELETE("/:code", placeholderHandler)
+			currencies.GET("", currencyHandler.List)
+			currencies.GET("/:code", currencyHandler.GetByCode)
+			currencies.GET("/:code/format", currencyHandler.Format)
+			currencies.GET("/:code/parse", currencyHandler.Parse)
+			currencies.POST("", currencyHandler.Create)
+			currencies.PUT("/:code", currencyHandler.Update)
+			currencies.DELETE("/:code", currencyHandler.Delete)
+		}
+
+		// Exchange Rates
+		exchangeRates := v1.Group("/exchange-rates")
+		{
+			exchangeRates.GET("", exchangeRateHandler.List)
+			exchangeRates.GET("/rate", exchangeRateHandler.Rate)
+			exchangeRates.GET("/convert", exchangeRateHandler.Convert)
+			exchangeRates.POST("", exchangeRateHandler.Record)
+			exchangeRates.POST("/ingest", exchangeRateHandler.Ingest)
+			exchangeRates.DELETE("/:id", exchangeRateHandler.Delete)
+		}
+
+		// Translations
+		translations := v1.Group("/translations")
+		{
+			translations.GET("/report", translationHandler.Report)
+			translations.GET("/export", translationHandler.Export)
+			translations.POST("/import", translationHandler.Import)
 		}
 	}
 
 	return router
 }
-
-// placeholderHandler is a temporary handler for routes that are not yet implemented
-func placeholderHandler(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"message": "This endpoint is not yet implemented",
-		"path":    c.Request.URL.Path,
-	})
-}
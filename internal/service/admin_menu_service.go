@@ -0,0 +1,371 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// AdminMenuService handles admin menu business logic
+type AdminMenuService struct {
+	repo   repository.AdminMenuRepository
+	tx     repository.Transactor
+	cache  cacheStore
+	logger *logger.Logger
+}
+
+// NewAdminMenuService creates a new admin menu service
+func NewAdminMenuService(repo repository.AdminMenuRepository, tx repository.Transactor, cache Cache, log *logger.Logger) *AdminMenuService {
+	return &AdminMenuService{
+		repo:   repo,
+		tx:     tx,
+		cache:  cacheStore{cache: cache, logger: log},
+		logger: log,
+	}
+}
+
+// Create creates a new admin menu item under an existing parent of the same tenant
+func (s *AdminMenuService) Create(ctx context.Context, menu *domain.AdminMenu) error {
+	if menu.Status == "" {
+		menu.Status = "active"
+	}
+	if err := menu.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	existing, err := s.repo.FindByCode(ctx, menu.TenantID, menu.Code)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.Conflict(fmt.Sprintf("Menu with code '%s' already exists", menu.Code))
+	}
+
+	if err := s.validateParent(ctx, menu); err != nil {
+		return err
+	}
+
+	if err := s.repo.Create(ctx, menu); err != nil {
+		if stderrors.Is(err, repository.ErrDuplicateKey) {
+			return errors.Conflict(fmt.Sprintf("Menu with code '%s' already exists", menu.Code))
+		}
+		return err
+	}
+
+	s.cache.invalidate(ctx, menuListKey(menu.TenantID))
+	s.logger.Info("Admin menu created",
+		zap.String("tenant_id", menu.TenantID),
+		zap.String("code", menu.Code),
+	)
+	return nil
+}
+
+// GetByID gets an admin menu item by ID
+func (s *AdminMenuService) GetByID(ctx context.Context, id string) (*domain.AdminMenu, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, errors.BadRequest("Invalid ID format")
+	}
+
+	menu, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if menu == nil {
+		return nil, errors.NotFound("Menu not found")
+	}
+	return menu, nil
+}
+
+// List lists admin menu items of a tenant with pagination
+func (s *AdminMenuService) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.AdminMenu, int64, error) {
+	return s.repo.List(ctx, tenantID, page, perPage)
+}
+
+// ListByModule lists the admin menu items contributed by a module
+func (s *AdminMenuService) ListByModule(ctx context.Context, tenantID, moduleCode string) ([]*domain.AdminMenu, error) {
+	return s.repo.ListByModule(ctx, tenantID, moduleCode)
+}
+
+// Update updates an admin menu item.
+// The tenant and code are immutable; re-parenting under the item's own subtree is refused.
+func (s *AdminMenuService) Update(ctx context.Context, menu *domain.AdminMenu) error {
+	existing, err := s.repo.FindByID(ctx, menu.ID.Hex())
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.TenantID != menu.TenantID {
+		return errors.NotFound("Menu not found")
+	}
+
+	menu.Code = existing.Code
+	menu.CreatedAt = existing.CreatedAt
+	if menu.Status == "" {
+		menu.Status = existing.Status
+	}
+	if err := menu.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	if menu.ParentID != existing.ParentID {
+		if err := s.validateParent(ctx, menu); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.Update(ctx, menu); err != nil {
+		if stderrors.Is(err, repository.ErrNotFound) {
+			return errors.NotFound("Menu not found")
+		}
+		return err
+	}
+
+	s.cache.invalidate(ctx, menuListKey(menu.TenantID))
+	return nil
+}
+
+// Delete deletes an admin menu item unless it still has children
+func (s *AdminMenuService) Delete(ctx context.Context, id, tenantID string) error {
+	if !primitive.IsValidObjectID(id) {
+		return errors.BadRequest("Invalid ID format")
+	}
+
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.TenantID != tenantID {
+		return errors.NotFound("Menu not found")
+	}
+
+	children, err := s.repo.FindChildren(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if len(children) > 0 {
+		return errors.Conflict(fmt.Sprintf("Cannot delete menu '%s': it has %d child items", existing.Code, len(children)))
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, menuListKey(tenantID))
+	s.logger.Info("Admin menu deleted",
+		zap.String("tenant_id", tenantID),
+		zap.String("code", existing.Code),
+	)
+	return nil
+}
+
+// Move places a menu item, together with its subtree, under a new parent at the given
+// sibling position and renumbers the orders of the old and new siblings. The parent is
+// checked, the positions computed and written in one transaction, so concurrent moves
+// cannot combine into a cycle. It returns the new siblings in their resulting order.
+func (s *AdminMenuService) Move(ctx context.Context, id, tenantID string, req *domain.MoveMenuRequest) ([]*domain.AdminMenu, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, errors.BadRequest("Invalid ID format")
+	}
+	if req.ParentID != "" && !primitive.IsValidObjectID(req.ParentID) {
+		return nil, errors.BadRequest("parent_id must be a valid ID")
+	}
+
+	var menu *domain.AdminMenu
+	var siblings []*domain.AdminMenu
+	var position int
+	err := s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		menu, err = s.repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if menu == nil || menu.TenantID != tenantID {
+			return errors.NotFound("Menu not found")
+		}
+
+		oldParentID := menu.ParentID
+		var ancestors []*domain.AdminMenu
+		if req.ParentID != oldParentID {
+			moved := *menu
+			moved.ParentID = req.ParentID
+			if ancestors, err = s.ancestors(ctx, &moved); err != nil {
+				return err
+			}
+		}
+
+		siblings, err = s.repo.FindChildren(ctx, tenantID, req.ParentID)
+		if err != nil {
+			return err
+		}
+		siblings = withoutMenu(siblings, menu.ID)
+
+		position = len(siblings)
+		if req.Position != nil && *req.Position >= 0 && *req.Position < position {
+			position = *req.Position
+		}
+		menu.ParentID = req.ParentID
+		siblings = append(siblings[:position], append([]*domain.AdminMenu{menu}, siblings[position:]...)...)
+
+		positions := renumberMenus(siblings, menu.ID)
+		if oldParentID != req.ParentID {
+			previous, err := s.repo.FindChildren(ctx, tenantID, oldParentID)
+			if err != nil {
+				return err
+			}
+			positions = append(positions, renumberMenus(withoutMenu(previous, menu.ID), primitive.NilObjectID)...)
+		}
+		positions = appendUnchanged(positions, ancestors)
+
+		if err := s.repo.Reorder(ctx, tenantID, positions); err != nil {
+			if stderrors.Is(err, repository.ErrNotFound) {
+				return errors.Conflict("Menu items changed during the move, please retry")
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.invalidate(ctx, menuListKey(tenantID))
+	s.logger.Info("Admin menu moved",
+		zap.String("tenant_id", tenantID),
+		zap.String("code", menu.Code),
+		zap.String("parent_id", req.ParentID),
+		zap.Int("position", position),
+	)
+	return siblings, nil
+}
+
+// Tree renders the menu tree of a tenant for a locale, keeping only the items
+// the given permissions allow
+func (s *AdminMenuService) Tree(ctx context.Context, tenantID, locale string, permissions []string) (*domain.MenuTree, error) {
+	if locale == "" {
+		locale = domain.DefaultLocale
+	}
+
+	menus, err := s.listAll(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := buildMenuTree(menus, locale, newPermissionSet(permissions))
+	if len(tree.Orphans) > 0 || len(tree.Cycles) > 0 {
+		s.logger.Warn("Admin menu tree has detached items",
+			zap.String("tenant_id", tenantID),
+			zap.Strings("orphans", tree.Orphans),
+			zap.Int("cycles", len(tree.Cycles)),
+		)
+	}
+	return tree, nil
+}
+
+// listAll loads every menu item of a tenant through the cache
+func (s *AdminMenuService) listAll(ctx context.Context, tenantID string) ([]*domain.AdminMenu, error) {
+	key := menuListKey(tenantID)
+
+	var menus []*domain.AdminMenu
+	if s.cache.get(ctx, key, &menus) {
+		return menus, nil
+	}
+
+	menus, err := s.repo.ListAll(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.set(ctx, key, menus, configDataTTL)
+	return menus, nil
+}
+
+// validateParent checks that the parent of a menu item exists in the same tenant
+// and that the item is not placed below itself. Stored parent chains that already loop
+// are refused instead of followed forever.
+func (s *AdminMenuService) validateParent(ctx context.Context, menu *domain.AdminMenu) error {
+	_, err := s.ancestors(ctx, menu)
+	return err
+}
+
+// ancestors validates the parent of a menu item like validateParent and returns its
+// ancestors, nearest first
+func (s *AdminMenuService) ancestors(ctx context.Context, menu *domain.AdminMenu) ([]*domain.AdminMenu, error) {
+	var chain []*domain.AdminMenu
+	parentID := menu.ParentID
+	visited := make(map[string]bool)
+	for depth := 0; parentID != ""; depth++ {
+		if !menu.ID.IsZero() && parentID == menu.ID.Hex() {
+			return nil, errors.BadRequest("menu cannot be moved below itself")
+		}
+		if visited[parentID] {
+			return nil, errors.BadRequest(fmt.Sprintf("parent menu '%s' is part of a cycle", menu.ParentID))
+		}
+		visited[parentID] = true
+
+		parent, err := s.repo.FindByID(ctx, parentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil || parent.TenantID != menu.TenantID {
+			if depth == 0 {
+				return nil, errors.BadRequest(fmt.Sprintf("parent menu '%s' not found", parentID))
+			}
+			return chain, nil
+		}
+		chain = append(chain, parent)
+		parentID = parent.ParentID
+	}
+	return chain, nil
+}
+
+// renumberMenus assigns consecutive orders starting at 1 to siblings in their current
+// sequence and returns the positions that changed; the moved item is always written
+// because its parent changes along with its order
+func renumberMenus(siblings []*domain.AdminMenu, moved primitive.ObjectID) []domain.MenuPosition {
+	var positions []domain.MenuPosition
+	for i, sibling := range siblings {
+		order := i + 1
+		if sibling.Order == order && sibling.ID != moved {
+			continue
+		}
+		sibling.Order = order
+		positions = append(positions, domain.MenuPosition{ID: sibling.ID, ParentID: sibling.ParentID, Order: order})
+	}
+	return positions
+}
+
+// appendUnchanged adds the current positions of menu items not yet written. A move rewrites
+// the ancestors of its new parent this way so that a concurrent move changing one of them
+// conflicts with it, instead of both committing after checking a chain the other changes.
+func appendUnchanged(positions []domain.MenuPosition, menus []*domain.AdminMenu) []domain.MenuPosition {
+	written := make(map[primitive.ObjectID]bool, len(positions))
+	for _, position := range positions {
+		written[position.ID] = true
+	}
+	for _, menu := range menus {
+		if !written[menu.ID] {
+			positions = append(positions, domain.MenuPosition{ID: menu.ID, ParentID: menu.ParentID, Order: menu.Order})
+		}
+	}
+	return positions
+}
+
+// withoutMenu returns the menu items other than the one with the given ID
+func withoutMenu(menus []*domain.AdminMenu, id primitive.ObjectID) []*domain.AdminMenu {
+	kept := make([]*domain.AdminMenu, 0, len(menus))
+	for _, menu := range menus {
+		if menu.ID != id {
+			kept = append(kept, menu)
+		}
+	}
+	return kept
+}
+
+func menuListKey(tenantID string) string {
+	return cacheKey("menus", tenantScope(tenantID), "all")
+}
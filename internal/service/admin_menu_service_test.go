@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newTestAdminMenuService(t *testing.T) (*AdminMenuService, *memoryCache) {
+	cache := newMemoryCache()
+	repo := repository.NewMemoryAdminMenuRepository()
+	return NewAdminMenuService(repo, repository.NewMemoryTransactor(repo), cache, newTestLogger(t)), cache
+}
+
+func createMenu(t *testing.T, svc *AdminMenuService, menu *domain.AdminMenu) *domain.AdminMenu {
+	t.Helper()
+
+	if menu.Name == "" {
+		menu.Name = menu.Code
+	}
+	menu.IsVisible = true
+	require.NoError(t, svc.Create(context.Background(), menu))
+	return menu
+}
+
+func menuCodes(nodes []*domain.MenuNode) []string {
+	codes := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		codes = append(codes, node.Code)
+	}
+	return codes
+}
+
+func TestBuildMenuTree(t *testing.T) {
+	menu := func(code, parent string, order int, permissions ...string) *domain.AdminMenu {
+		return &domain.AdminMenu{
+			ID:          primitive.NewObjectID(),
+			Code:        code,
+			Name:        code,
+			ParentID:    parent,
+			Order:       order,
+			Permissions: permissions,
+			IsVisible:   true,
+			Status:      "active",
+		}
+	}
+
+	dashboard := menu("dashboard", "", 1)
+	dashboard.Title = map[string]string{"en": "Dashboard", "vi": "Tổng quan"}
+	settings := menu("settings", "", 2)
+	users := menu("users", settings.ID.Hex(), 2, "user.read")
+	roles := menu("roles", settings.ID.Hex(), 1, "role.read")
+	reports := menu("reports", "", 3)
+	billing := menu("billing", reports.ID.Hex(), 1, "billing.read")
+	hidden := menu("hidden", "", 4)
+	hidden.IsVisible = false
+	orphan := menu("orphan", primitive.NewObjectID().Hex(), 1)
+	loopA := menu("loop-a", "", 1)
+	loopB := menu("loop-b", loopA.ID.Hex(), 1)
+	loopA.ParentID = loopB.ID.Hex()
+
+	menus := []*domain.AdminMenu{users, settings, dashboard, roles, reports, billing, hidden, orphan, loopA, loopB}
+
+	tree := buildMenuTree(menus, "vi-VN", newPermissionSet([]string{"user.read", "role.read"}))
+	assert.Equal(t, []string{"dashboard", "settings"}, menuCodes(tree.Items))
+	assert.Equal(t, "Tổng quan", tree.Items[0].Title)
+	assert.Equal(t, "settings", tree.Items[1].Title)
+	assert.Equal(t, []string{"roles", "users"}, menuCodes(tree.Items[1].Children))
+	assert.Equal(t, []string{"orphan"}, tree.Orphans)
+	assert.Equal(t, [][]string{{"loop-a", "loop-b"}}, tree.Cycles)
+
+	tree = buildMenuTree(menus, "en", newPermissionSet([]string{"role.read", "billing.read"}))
+	assert.Equal(t, []string{"dashboard", "settings", "reports"}, menuCodes(tree.Items))
+	assert.Equal(t, []string{"roles"}, menuCodes(tree.Items[1].Children))
+
+	tree = buildMenuTree(menus, "en", newPermissionSet(nil))
+	assert.Equal(t, []string{"dashboard"}, menuCodes(tree.Items))
+}
+
+func TestAdminMenuService_CreateValidatesParent(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestAdminMenuService(t)
+
+	settings := createMenu(t, svc, &domain.AdminMenu{Code: "settings"})
+	createMenu(t, svc, &domain.AdminMenu{Code: "users", ParentID: settings.ID.Hex()})
+
+	err := svc.Create(ctx, &domain.AdminMenu{Code: "users", Name: "Users"})
+	assertStatus(t, err, http.StatusConflict)
+
+	err = svc.Create(ctx, &domain.AdminMenu{Code: "roles", Name: "Roles", ParentID: primitive.NewObjectID().Hex()})
+	assertStatus(t, err, http.StatusBadRequest)
+
+	err = svc.Create(ctx, &domain.AdminMenu{TenantID: "tenant-1", Code: "roles", Name: "Roles", ParentID: settings.ID.Hex()})
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+func TestAdminMenuService_UpdateRejectsCycles(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestAdminMenuService(t)
+
+	settings := createMenu(t, svc, &domain.AdminMenu{Code: "settings"})
+	users := createMenu(t, svc, &domain.AdminMenu{Code: "users", ParentID: settings.ID.Hex()})
+	audit := createMenu(t, svc, &domain.AdminMenu{Code: "audit", ParentID: users.ID.Hex()})
+
+	settings.ParentID = audit.ID.Hex()
+	err := svc.Update(ctx, settings)
+	assertStatus(t, err, http.StatusBadRequest)
+
+	audit.ParentID = settings.ID.Hex()
+	require.NoError(t, svc.Update(ctx, audit))
+}
+
+func TestAdminMenuService_RefusesParentsInStoredCycles(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestAdminMenuService(t)
+
+	settings := createMenu(t, svc, &domain.AdminMenu{Code: "settings"})
+	users := createMenu(t, svc, &domain.AdminMenu{Code: "users", ParentID: settings.ID.Hex()})
+	settings.ParentID = users.ID.Hex()
+	require.NoError(t, svc.repo.Update(ctx, settings))
+
+	err := svc.Create(ctx, &domain.AdminMenu{Code: "audit", Name: "audit", ParentID: users.ID.Hex()})
+	assertStatus(t, err, http.StatusBadRequest)
+
+	reports := createMenu(t, svc, &domain.AdminMenu{Code: "reports"})
+	_, err = svc.Move(ctx, reports.ID.Hex(), "", &domain.MoveMenuRequest{ParentID: settings.ID.Hex()})
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+func TestAdminMenuService_DeleteRefusesParents(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestAdminMenuService(t)
+
+	settings := createMenu(t, svc, &domain.AdminMenu{Code: "settings"})
+	users := createMenu(t, svc, &domain.AdminMenu{Code: "users", ParentID: settings.ID.Hex()})
+
+	err := svc.Delete(ctx, settings.ID.Hex(), "")
+	assertStatus(t, err, http.StatusConflict)
+
+	err = svc.Delete(ctx, users.ID.Hex(), "tenant-1")
+	assertStatus(t, err, http.StatusNotFound)
+
+	require.NoError(t, svc.Delete(ctx, users.ID.Hex(), ""))
+	require.NoError(t, svc.Delete(ctx, settings.ID.Hex(), ""))
+}
+
+func TestAdminMenuService_TreeCachesMenus(t *testing.T) {
+	ctx := context.Background()
+	svc, cache := newTestAdminMenuService(t)
+
+	createMenu(t, svc, &domain.AdminMenu{Code: "dashboard", Title: map[string]string{"vi": "Tổng quan"}})
+	settings := createMenu(t, svc, &domain.AdminMenu{Code: "settings"})
+	createMenu(t, svc, &domain.AdminMenu{Code: "users", ParentID: settings.ID.Hex(), Permissions: []string{"user.read"}})
+
+	tree, err := svc.Tree(ctx, "", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultLocale, tree.Locale)
+	assert.Equal(t, []string{"dashboard"}, menuCodes(tree.Items))
+	assert.Equal(t, "Tổng quan", tree.Items[0].Title)
+	assert.True(t, cache.has(menuListKey("")))
+
+	tree, err = svc.Tree(ctx, "", "vi", []string{"user.read"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dashboard", "settings"}, menuCodes(tree.Items))
+
+	createMenu(t, svc, &domain.AdminMenu{Code: "reports", Order: 10})
+	assert.False(t, cache.has(menuListKey("")))
+}
+
+func TestAdminMenuService_Move(t *testing.T) {
+	ctx := context.Background()
+	svc, cache := newTestAdminMenuService(t)
+
+	settings := createMenu(t, svc, &domain.AdminMenu{Code: "settings", Order: 1})
+	reports := createMenu(t, svc, &domain.AdminMenu{Code: "reports", Order: 2})
+	users := createMenu(t, svc, &domain.AdminMenu{Code: "users", ParentID: settings.ID.Hex(), Order: 1})
+	createMenu(t, svc, &domain.AdminMenu{Code: "audit", ParentID: users.ID.Hex(), Order: 1})
+	createMenu(t, svc, &domain.AdminMenu{Code: "roles", ParentID: settings.ID.Hex(), Order: 5})
+	createMenu(t, svc, &domain.AdminMenu{Code: "sales", ParentID: reports.ID.Hex(), Order: 10})
+	createMenu(t, svc, &domain.AdminMenu{Code: "stock", ParentID: reports.ID.Hex(), Order: 20})
+
+	_, err := svc.Tree(ctx, "", "en", nil)
+	require.NoError(t, err)
+
+	position := 1
+	siblings, err := svc.Move(ctx, users.ID.Hex(), "", &domain.MoveMenuRequest{ParentID: reports.ID.Hex(), Position: &position})
+	require.NoError(t, err)
+	require.Len(t, siblings, 3)
+	for i, code := range []string{"sales", "users", "stock"} {
+		assert.Equal(t, code, siblings[i].Code)
+		assert.Equal(t, i+1, siblings[i].Order)
+	}
+	assert.False(t, cache.has(menuListKey("")))
+
+	tree, err := svc.Tree(ctx, "", "en", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"settings", "reports"}, menuCodes(tree.Items))
+	assert.Equal(t, []string{"roles"}, menuCodes(tree.Items[0].Children))
+	assert.Equal(t, 1, tree.Items[0].Children[0].Order)
+	require.Equal(t, []string{"sales", "users", "stock"}, menuCodes(tree.Items[1].Children))
+	assert.Equal(t, []string{"audit"}, menuCodes(tree.Items[1].Children[1].Children))
+
+	siblings, err = svc.Move(ctx, users.ID.Hex(), "", &domain.MoveMenuRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"settings", "reports", "users"}, []string{siblings[0].Code, siblings[1].Code, siblings[2].Code})
+	assert.Equal(t, 3, siblings[2].Order)
+
+	// the new parent is also one of the old siblings renumbered by the move
+	siblings, err = svc.Move(ctx, users.ID.Hex(), "", &domain.MoveMenuRequest{ParentID: settings.ID.Hex()})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"roles", "users"}, []string{siblings[0].Code, siblings[1].Code})
+	tree, err = svc.Tree(ctx, "", "en", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"settings", "reports"}, menuCodes(tree.Items))
+	assert.Equal(t, []string{"roles", "users"}, menuCodes(tree.Items[0].Children))
+
+	_, err = svc.Move(ctx, settings.ID.Hex(), "", &domain.MoveMenuRequest{ParentID: settings.ID.Hex()})
+	assertStatus(t, err, http.StatusBadRequest)
+
+	_, err = svc.Move(ctx, users.ID.Hex(), "", &domain.MoveMenuRequest{ParentID: primitive.NewObjectID().Hex()})
+	assertStatus(t, err, http.StatusBadRequest)
+
+	_, err = svc.Move(ctx, users.ID.Hex(), "tenant-1", &domain.MoveMenuRequest{})
+	assertStatus(t, err, http.StatusNotFound)
+}
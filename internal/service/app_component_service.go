@@ -0,0 +1,496 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// AppComponentService handles app component business logic
+type AppComponentService struct {
+	repo     repository.AppComponentRepository
+	versions repository.ConfigVersionRepository
+	tx       repository.Transactor
+	rules    *ConfigRuleService
+	schemas  *ConfigSchemaService
+	cache    cacheStore
+	logger   *logger.Logger
+}
+
+// NewAppComponentService creates a new app component service.
+// Every change to the Config of a component is recorded as a config version so it can be
+// listed, compared and rolled back; the version is written in one transaction with the component,
+// so the history never lists a config that was not stored. Configs are checked against the config validation rules,
+// their fields being config keys below the component code, and against the config schema of the
+// component code.
+func NewAppComponentService(repo repository.AppComponentRepository, versions repository.ConfigVersionRepository, tx repository.Transactor, rules *ConfigRuleService, schemas *ConfigSchemaService, cache Cache, log *logger.Logger) *AppComponentService {
+	return &AppComponentService{
+		repo:     repo,
+		versions: versions,
+		tx:       tx,
+		rules:    rules,
+		schemas:  schemas,
+		cache:    cacheStore{cache: cache, logger: log},
+		logger:   log,
+	}
+}
+
+// Create creates a new app component
+func (s *AppComponentService) Create(ctx context.Context, component *domain.AppComponent) error {
+	if err := component.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	if err := s.checkConfig(ctx, component, component.Config); err != nil {
+		return err
+	}
+
+	existing, err := s.repo.FindByCode(ctx, component.TenantID, component.Code)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.Conflict(fmt.Sprintf("App component with code '%s' already exists", component.Code))
+	}
+
+	if component.Status == "" {
+		component.Status = "active"
+	}
+
+	err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, component); err != nil {
+			if stderrors.Is(err, repository.ErrDuplicateKey) {
+				return errors.Conflict(fmt.Sprintf("App component with code '%s' already exists", component.Code))
+			}
+			return err
+		}
+		return s.recordConfig(ctx, component, component.Config, component.CreatedBy, component.ChangeReason, 1, 0)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, appComponentListKey(component.TenantID))
+	s.logger.Info("App component created",
+		zap.String("tenant_id", component.TenantID),
+		zap.String("code", component.Code),
+	)
+	return nil
+}
+
+// GetByID gets an app component by ID
+func (s *AppComponentService) GetByID(ctx context.Context, id string) (*domain.AppComponent, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, errors.BadRequest("Invalid ID format")
+	}
+
+	key := appComponentKey(id)
+	var cached domain.AppComponent
+	if s.cache.get(ctx, key, &cached) {
+		return &cached, nil
+	}
+
+	component, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if component == nil {
+		return nil, errors.NotFound("App component not found")
+	}
+
+	s.cache.set(ctx, key, component, configDataTTL)
+	return component, nil
+}
+
+// List lists app components of a tenant with pagination
+func (s *AppComponentService) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.AppComponent, int64, error) {
+	key := appComponentListKey(tenantID)
+	if page == 1 {
+		var cached cachedPage[*domain.AppComponent]
+		if s.cache.get(ctx, key, &cached) && cached.PerPage == perPage {
+			return cached.Items, cached.Total, nil
+		}
+	}
+
+	components, total, err := s.repo.List(ctx, tenantID, page, perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if page == 1 {
+		s.cache.set(ctx, key, cachedPage[*domain.AppComponent]{PerPage: perPage, Items: components, Total: total}, configDataTTL)
+	}
+	return components, total, nil
+}
+
+// Update updates an app component.
+// The tenant and code of a component are immutable and are kept from the stored document.
+func (s *AppComponentService) Update(ctx context.Context, component *domain.AppComponent) error {
+	existing, err := s.repo.FindByID(ctx, component.ID.Hex())
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.NotFound("App component not found")
+	}
+
+	component.TenantID = existing.TenantID
+	component.Code = existing.Code
+	component.CreatedAt = existing.CreatedAt
+	component.CreatedBy = existing.CreatedBy
+	if component.Status == "" {
+		component.Status = existing.Status
+	}
+
+	if err := component.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	if err := s.checkConfig(ctx, component, component.Config); err != nil {
+		return err
+	}
+
+	err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, component); err != nil {
+			if stderrors.Is(err, repository.ErrNotFound) {
+				return errors.NotFound("App component not found")
+			}
+			return err
+		}
+		return s.versionConfig(ctx, existing, component)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, appComponentKey(component.ID.Hex()), appComponentListKey(component.TenantID))
+	return nil
+}
+
+// Delete deletes an app component owned by the given tenant
+func (s *AppComponentService) Delete(ctx context.Context, id, tenantID string) error {
+	if tenantID == "" {
+		return errors.BadRequest("Tenant ID is required")
+	}
+	if !primitive.IsValidObjectID(id) {
+		return errors.BadRequest("Invalid ID format")
+	}
+
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	// Components of other tenants are reported as missing so their existence is not leaked
+	if existing == nil || existing.TenantID != tenantID {
+		return errors.NotFound("App component not found")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, appComponentKey(id), appComponentListKey(tenantID))
+	s.logger.Info("App component deleted",
+		zap.String("tenant_id", tenantID),
+		zap.String("code", existing.Code),
+	)
+	return nil
+}
+
+// History lists the recorded versions of the config of a component owned by the given tenant,
+// newest first; the newest one is the current config
+func (s *AppComponentService) History(ctx context.Context, id, tenantID string, page, perPage int) ([]*domain.ConfigVersion, int64, error) {
+	if _, err := s.owned(ctx, id, tenantID); err != nil {
+		return nil, 0, err
+	}
+
+	versions, total, err := s.versions.List(ctx, id, page, perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, version := range versions {
+		version.Active = int64(version.Version) == total
+	}
+	return versions, total, nil
+}
+
+// Diff compares two recorded versions of the config of a component owned by the given tenant
+func (s *AppComponentService) Diff(ctx context.Context, id, tenantID string, from, to int) (*domain.ConfigDiff, error) {
+	if _, err := s.owned(ctx, id, tenantID); err != nil {
+		return nil, err
+	}
+	return diffVersions(ctx, s.versions, id, from, to)
+}
+
+// Rollback restores the config of an earlier version as a new version of a component owned by
+// the given tenant
+func (s *AppComponentService) Rollback(ctx context.Context, id, tenantID, author string, req *domain.ConfigRollbackRequest) (*domain.AppComponent, error) {
+	component, err := s.owned(ctx, id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Version < 1 {
+		return nil, errors.BadRequest("version must be a positive number")
+	}
+	target, err := s.versions.Find(ctx, id, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, errors.NotFound("Config version not found")
+	}
+	config, ok := target.Value.(map[string]interface{})
+	if !ok {
+		return nil, errors.Internal(fmt.Sprintf("Config version %d of app component '%s' is not an object", target.Version, component.Code))
+	}
+	if err := s.checkConfig(ctx, component, config); err != nil {
+		return nil, err
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		reason = fmt.Sprintf("Rollback to version %d", target.Version)
+	}
+	component.Config = config
+	component.UpdatedBy = author
+	err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, component); err != nil {
+			if stderrors.Is(err, repository.ErrNotFound) {
+				return errors.NotFound("App component not found")
+			}
+			return err
+		}
+		_, total, err := s.versions.List(ctx, id, 1, 1)
+		if err != nil {
+			return err
+		}
+		return s.recordConfig(ctx, component, config, author, reason, int(total)+1, target.Version)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.invalidate(ctx, appComponentKey(id), appComponentListKey(component.TenantID))
+	s.logger.Info("App component config rolled back",
+		zap.String("tenant_id", component.TenantID),
+		zap.String("code", component.Code),
+		zap.Int("to_version", target.Version),
+	)
+	return component, nil
+}
+
+// MigrateConfigs brings the configs of the components of every tenant with a code in line with
+// the current version of their schema: configs lacking properties that have schema defaults gain
+// them as a new config version when they then conform. Configs that do not conform are left as
+// they are and reported.
+func (s *AppComponentService) MigrateConfigs(ctx context.Context, code, author string, opts *domain.SchemaMigrationOptions) (*domain.SchemaMigrationReport, error) {
+	schema, err := s.schemas.Find(ctx, domain.SchemaTargetAppComponent, code)
+	if err != nil {
+		return nil, err
+	}
+	if schema == nil {
+		return nil, errors.NotFound("Config schema not found")
+	}
+	compiled, err := schema.Compile()
+	if err != nil {
+		return nil, errors.Internal(fmt.Sprintf("Config schema '%s' does not compile: %v", schema.Subject(), err))
+	}
+
+	components, err := s.repo.ListByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.SchemaMigrationReport{
+		DryRun:        opts.DryRun,
+		Schema:        schema.Subject(),
+		SchemaVersion: schema.Version,
+		Nonconforming: []domain.SchemaNonconformance{},
+	}
+	for _, component := range components {
+		report.Checked++
+		nonconforming := func(fields []domain.FieldError) {
+			report.Nonconforming = append(report.Nonconforming, domain.SchemaNonconformance{
+				ID:         component.ID.Hex(),
+				TenantID:   component.TenantID,
+				Code:       component.Code,
+				Violations: fields,
+			})
+		}
+
+		// Stored configs holding values that JSON cannot represent cannot conform
+		plain, err := domain.PlainConfigValue(configObject(component.Config))
+		if err != nil {
+			nonconforming([]domain.FieldError{{Field: "config", Pointer: "/config", Code: "type", Message: err.Error(), Rule: schema.Subject()}})
+			continue
+		}
+		config := plain.(map[string]interface{})
+		_, filled := compiled.ApplyDefaults(config)
+
+		fields, err := s.configViolations(ctx, component, config)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) > 0 {
+			nonconforming(fields)
+			continue
+		}
+		if !filled {
+			continue
+		}
+
+		report.Migrated++
+		if opts.DryRun {
+			continue
+		}
+		migrated := *component
+		migrated.Config = config
+		migrated.UpdatedBy = author
+		migrated.ChangeReason = fmt.Sprintf("Migrated to version %d of schema %s", schema.Version, schema.Subject())
+		err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+			if err := s.repo.Update(ctx, &migrated); err != nil {
+				return err
+			}
+			return s.versionConfig(ctx, component, &migrated)
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.cache.invalidate(ctx, appComponentKey(component.ID.Hex()), appComponentListKey(component.TenantID))
+	}
+
+	s.logger.Info("App component configs migrated",
+		zap.String("code", code),
+		zap.Int("schema_version", schema.Version),
+		zap.Int("checked", report.Checked),
+		zap.Int("migrated", report.Migrated),
+		zap.Int("nonconforming", len(report.Nonconforming)),
+		zap.Bool("dry_run", opts.DryRun),
+	)
+	return report, nil
+}
+
+// versionConfig records the config of an update when it differs from the stored one; call it in
+// the transaction writing the update. Components
+// created before configs were versioned first get their stored config recorded as version 1, so
+// the previous state is never lost.
+func (s *AppComponentService) versionConfig(ctx context.Context, existing, component *domain.AppComponent) error {
+	before, err := domain.PlainConfigValue(configObject(existing.Config))
+	if err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	after, err := domain.PlainConfigValue(configObject(component.Config))
+	if err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	if len(domain.DiffConfigValues(before, after)) == 0 {
+		return nil
+	}
+
+	_, total, err := s.versions.List(ctx, existing.ID.Hex(), 1, 1)
+	if err != nil {
+		return err
+	}
+	if total == 0 {
+		if err := s.recordConfig(ctx, existing, existing.Config, existing.UpdatedBy, "Recorded before the first versioned change", 1, 0); err != nil {
+			return err
+		}
+		total = 1
+	}
+	return s.recordConfig(ctx, component, component.Config, component.UpdatedBy, component.ChangeReason, int(total)+1, 0)
+}
+
+// recordConfig stores config as the given version of the config history of a component
+func (s *AppComponentService) recordConfig(ctx context.Context, component *domain.AppComponent, config map[string]interface{}, author, reason string, number, rollbackOf int) error {
+	valueType, value, err := domain.NormalizeConfigValue(domain.ConfigTypeJSON, configObject(config))
+	if err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	version := &domain.ConfigVersion{
+		ConfigID:   component.ID,
+		TenantID:   component.TenantID,
+		Key:        component.Code,
+		Version:    number,
+		ValueType:  valueType,
+		Value:      value,
+		Reason:     strings.TrimSpace(reason),
+		RollbackOf: rollbackOf,
+		CreatedBy:  author,
+	}
+	if err := s.versions.Create(ctx, version); err != nil {
+		if stderrors.Is(err, repository.ErrDuplicateKey) {
+			return errors.Conflict(fmt.Sprintf("App component '%s' was changed concurrently; retry the update", component.Code))
+		}
+		return err
+	}
+	return nil
+}
+
+// checkConfig checks a config of a component against the config validation rules and the
+// schema of the component code, reporting the violations of both
+func (s *AppComponentService) checkConfig(ctx context.Context, component *domain.AppComponent, config map[string]interface{}) error {
+	value, err := domain.PlainConfigValue(configObject(config))
+	if err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	fields, err := s.configViolations(ctx, component, value)
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		return validationFailed(fields)
+	}
+	return nil
+}
+
+// configViolations lists the violations of a plain config of a component
+func (s *AppComponentService) configViolations(ctx context.Context, component *domain.AppComponent, value interface{}) ([]domain.FieldError, error) {
+	fields, err := s.rules.Violations(ctx, component.TenantID, component.Code, "config", value)
+	if err != nil {
+		return nil, err
+	}
+	schemaFields, err := s.schemas.Violations(ctx, domain.SchemaTargetAppComponent, component.Code, "config", value)
+	if err != nil {
+		return nil, err
+	}
+	return append(fields, schemaFields...), nil
+}
+
+// owned loads a component, reporting components of other tenants as missing
+func (s *AppComponentService) owned(ctx context.Context, id, tenantID string) (*domain.AppComponent, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, errors.BadRequest("Invalid ID format")
+	}
+
+	component, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if component == nil || component.TenantID != tenantID {
+		return nil, errors.NotFound("App component not found")
+	}
+	return component, nil
+}
+
+// configObject treats a missing config as an empty object
+func configObject(config map[string]interface{}) map[string]interface{} {
+	if config == nil {
+		return map[string]interface{}{}
+	}
+	return config
+}
+
+func appComponentKey(id string) string {
+	return cacheKey("app_components", "id", id)
+}
+
+func appComponentListKey(tenantID string) string {
+	return cacheKey("app_components", tenantID, "list")
+}
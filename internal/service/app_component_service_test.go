@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newTestAppComponentService(t *testing.T) (*AppComponentService, *memoryCache) {
+	cache := newMemoryCache()
+	repo, versions := repository.NewMemoryAppComponentRepository(), repository.NewMemoryConfigVersionRepository()
+	return NewAppComponentService(repo, versions, repository.NewMemoryTransactor(repo, versions),
+		NewConfigRuleService(repository.NewMemoryConfigRuleRepository(), cache, newTestLogger(t)),
+		NewConfigSchemaService(repository.NewMemoryConfigSchemaRepository(), cache, newTestLogger(t)), cache, newTestLogger(t)), cache
+}
+
+func assertStatus(t *testing.T, err error, status int) {
+	t.Helper()
+
+	require.Error(t, err)
+	assert.Equal(t, status, errors.FromError(err).StatusCode)
+}
+
+func TestAppComponentService_Create(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestAppComponentService(t)
+
+	component := &domain.AppComponent{TenantID: "tenant-1", Code: "dashboard"}
+	require.NoError(t, svc.Create(ctx, component))
+	assert.Equal(t, "active", component.Status)
+
+	err := svc.Create(ctx, &domain.AppComponent{TenantID: "tenant-1", Code: "dashboard"})
+	assertStatus(t, err, http.StatusConflict)
+
+	err = svc.Create(ctx, &domain.AppComponent{TenantID: "tenant-1"})
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+func TestAppComponentService_GetByID(t *testing.T) {
+	ctx := context.Background()
+	svc, cache := newTestAppComponentService(t)
+
+	component := &domain.AppComponent{TenantID: "tenant-1", Code: "dashboard", Name: "Dashboard"}
+	require.NoError(t, svc.Create(ctx, component))
+
+	found, err := svc.GetByID(ctx, component.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, "Dashboard", found.Name)
+	assert.True(t, cache.has(appComponentKey(component.ID.Hex())))
+
+	found, err = svc.GetByID(ctx, component.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, component.ID, found.ID)
+	assert.Equal(t, 1, cache.hits)
+
+	_, err = svc.GetByID(ctx, primitive.NewObjectID().Hex())
+	assertStatus(t, err, http.StatusNotFound)
+
+	_, err = svc.GetByID(ctx, "invalid")
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+func TestAppComponentService_UpdateInvalidatesCache(t *testing.T) {
+	ctx := context.Background()
+	svc, cache := newTestAppComponentService(t)
+
+	component := &domain.AppComponent{TenantID: "tenant-1", Code: "dashboard", Name: "Dashboard"}
+	require.NoError(t, svc.Create(ctx, component))
+	_, err := svc.GetByID(ctx, component.ID.Hex())
+	require.NoError(t, err)
+
+	update := &domain.AppComponent{ID: component.ID, Name: "Home"}
+	require.NoError(t, svc.Update(ctx, update))
+	assert.Equal(t, "tenant-1", update.TenantID)
+	assert.Equal(t, "dashboard", update.Code)
+	assert.False(t, cache.has(appComponentKey(component.ID.Hex())))
+
+	found, err := svc.GetByID(ctx, component.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, "Home", found.Name)
+
+	err = svc.Update(ctx, &domain.AppComponent{ID: primitive.NewObjectID()})
+	assertStatus(t, err, http.StatusNotFound)
+}
+
+func TestAppComponentService_ListCachesFirstPage(t *testing.T) {
+	ctx := context.Background()
+	svc, cache := newTestAppComponentService(t)
+
+	require.NoError(t, svc.Create(ctx, &domain.AppComponent{TenantID: "tenant-1", Code: "a"}))
+
+	components, total, err := svc.List(ctx, "tenant-1", 1, 30)
+	require.NoError(t, err)
+	assert.Len(t, components, 1)
+	assert.Equal(t, int64(1), total)
+	assert.True(t, cache.has(appComponentListKey("tenant-1")))
+
+	require.NoError(t, svc.Create(ctx, &domain.AppComponent{TenantID: "tenant-1", Code: "b"}))
+	assert.False(t, cache.has(appComponentListKey("tenant-1")))
+
+	components, total, err = svc.List(ctx, "tenant-1", 1, 30)
+	require.NoError(t, err)
+	assert.Len(t, components, 2)
+	assert.Equal(t, int64(2), total)
+}
+
+func TestAppComponentService_DeleteIsTenantScoped(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestAppComponentService(t)
+
+	component := &domain.AppComponent{TenantID: "tenant-1", Code: "dashboard"}
+	require.NoError(t, svc.Create(ctx, component))
+
+	err := svc.Delete(ctx, component.ID.Hex(), "tenant-2")
+	assertStatus(t, err, http.StatusNotFound)
+
+	err = svc.Delete(ctx, component.ID.Hex(), "")
+	assertStatus(t, err, http.StatusBadRequest)
+
+	require.NoError(t, svc.Delete(ctx, component.ID.Hex(), "tenant-1"))
+	_, err = svc.GetByID(ctx, component.ID.Hex())
+	assertStatus(t, err, http.StatusNotFound)
+}
+
+func TestAppComponentService_ConfigHistory(t *testing.T) {
+	ctx := context.Background()
+	svc, cache := newTestAppComponentService(t)
+
+	component := &domain.AppComponent{TenantID: "tenant-1", Code: "dashboard", CreatedBy: "alice", Config: map[string]interface{}{"theme": "light", "widgets": []interface{}{"sales"}}}
+	require.NoError(t, svc.Create(ctx, component))
+	id := component.ID.Hex()
+
+	// Updates that leave the config alone do not add versions
+	require.NoError(t, svc.Update(ctx, &domain.AppComponent{ID: component.ID, Name: "Dashboard", Config: component.Config}))
+	require.NoError(t, svc.Update(ctx, &domain.AppComponent{
+		ID: component.ID, UpdatedBy: "bob", ChangeReason: "dark mode",
+		Config: map[string]interface{}{"theme": "dark", "widgets": []interface{}{"sales", "users"}},
+	}))
+
+	versions, total, err := svc.History(ctx, id, "tenant-1", 1, 30)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, "bob", versions[0].CreatedBy)
+	assert.Equal(t, "dark mode", versions[0].Reason)
+	assert.True(t, versions[0].Active)
+	assert.Equal(t, "alice", versions[1].CreatedBy)
+
+	diff, err := svc.Diff(ctx, id, "tenant-1", 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ConfigChange{
+		{Path: "/theme", Op: domain.ConfigChangeChanged, Old: "light", New: "dark"},
+		{Path: "/widgets/1", Op: domain.ConfigChangeAdded, New: "users"},
+	}, diff.Changes)
+
+	_, err = svc.GetByID(ctx, id)
+	require.NoError(t, err)
+	restored, err := svc.Rollback(ctx, id, "tenant-1", "carol", &domain.ConfigRollbackRequest{Version: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "light", restored.Config["theme"])
+	assert.False(t, cache.has(appComponentKey(id)))
+
+	found, err := svc.GetByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "light", found.Config["theme"])
+
+	_, total, err = svc.History(ctx, id, "tenant-1", 1, 30)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+
+	_, _, err = svc.History(ctx, id, "tenant-2", 1, 30)
+	assertStatus(t, err, http.StatusNotFound)
+	_, err = svc.Rollback(ctx, id, "tenant-2", "mallory", &domain.ConfigRollbackRequest{Version: 1})
+	assertStatus(t, err, http.StatusNotFound)
+}
+
+func TestAppComponentService_ConfigHistoryOfUnversionedComponent(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryAppComponentRepository()
+	cache := newMemoryCache()
+	versionRepo := repository.NewMemoryConfigVersionRepository()
+	svc := NewAppComponentService(repo, versionRepo, repository.NewMemoryTransactor(repo, versionRepo),
+		NewConfigRuleService(repository.NewMemoryConfigRuleRepository(), cache, newTestLogger(t)),
+		NewConfigSchemaService(repository.NewMemoryConfigSchemaRepository(), cache, newTestLogger(t)), cache, newTestLogger(t))
+
+	// Components stored before configs were versioned have no history yet
+	component := &domain.AppComponent{TenantID: "tenant-1", Code: "legacy", Status: "active", Config: map[string]interface{}{"limit": 10.0}}
+	require.NoError(t, repo.Create(ctx, component))
+
+	require.NoError(t, svc.Update(ctx, &domain.AppComponent{ID: component.ID, Config: map[string]interface{}{"limit": 20.0}}))
+
+	versions, _, err := svc.History(ctx, component.ID.Hex(), "tenant-1", 1, 30)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, 10.0, versions[1].Value.(map[string]interface{})["limit"], "the previous config is kept")
+	assert.Equal(t, 20.0, versions[0].Value.(map[string]interface{})["limit"])
+}
+
+// failingAppComponentRepository fails to update components while fail is set
+type failingAppComponentRepository struct {
+	repository.AppComponentRepository
+	fail *bool
+}
+
+func (r failingAppComponentRepository) Update(ctx context.Context, component *domain.AppComponent) error {
+	if *r.fail {
+		return stderrors.New("write failed")
+	}
+	return r.AppComponentRepository.Update(ctx, component)
+}
+
+func TestAppComponentService_ConfigVersionsFollowComponentWrites(t *testing.T) {
+	ctx := context.Background()
+	cache := newMemoryCache()
+	repo, versions := repository.NewMemoryAppComponentRepository(), repository.NewMemoryConfigVersionRepository()
+	failUpdate, failVersion := false, true
+	svc := NewAppComponentService(failingAppComponentRepository{AppComponentRepository: repo, fail: &failUpdate},
+		failingVersionRepository{ConfigVersionRepository: versions, fail: &failVersion}, repository.NewMemoryTransactor(repo, versions),
+		NewConfigRuleService(repository.NewMemoryConfigRuleRepository(), cache, newTestLogger(t)),
+		NewConfigSchemaService(repository.NewMemoryConfigSchemaRepository(), cache, newTestLogger(t)), cache, newTestLogger(t))
+	component := func(limit float64) *domain.AppComponent {
+		return &domain.AppComponent{TenantID: "tenant-1", Code: "dashboard", Config: map[string]interface{}{"limit": limit}}
+	}
+
+	// A component whose first version cannot be recorded is not created
+	require.Error(t, svc.Create(ctx, component(10)))
+	found, err := repo.FindByCode(ctx, "tenant-1", "dashboard")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	failVersion = false
+	created := component(10)
+	require.NoError(t, svc.Create(ctx, created))
+	id := created.ID.Hex()
+
+	// A failed component write records no version, and a failed version write keeps the stored config
+	failUpdate = true
+	require.Error(t, svc.Update(ctx, &domain.AppComponent{ID: created.ID, Config: map[string]interface{}{"limit": 20.0}}))
+	_, err = svc.Rollback(ctx, id, "tenant-1", "alice", &domain.ConfigRollbackRequest{Version: 1})
+	require.Error(t, err)
+	failUpdate, failVersion = false, true
+	require.Error(t, svc.Update(ctx, &domain.AppComponent{ID: created.ID, Config: map[string]interface{}{"limit": 30.0}}))
+
+	history, total, err := svc.History(ctx, id, "tenant-1", 1, 30)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.True(t, history[0].Active)
+	found, err = repo.FindByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, found.Config["limit"])
+}
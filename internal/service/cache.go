@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-shared/redis"
+	"go.uber.org/zap"
+)
+
+const (
+	// cacheKeyPrefix namespaces every key written by this service
+	cacheKeyPrefix = "system-config"
+
+	// configDataTTL is used for tenant configuration data (app components, modules)
+	configDataTTL = time.Hour
+
+	// masterDataTTL is used for global master data (countries, currencies, ethnicities)
+	masterDataTTL = 24 * time.Hour
+)
+
+// Cache is the subset of the Redis client used by the services
+type Cache interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+var _ Cache = (*redis.Client)(nil)
+
+// cacheKey builds a cache key following the system-config:{type}:... pattern
+func cacheKey(parts ...string) string {
+	return cacheKeyPrefix + ":" + strings.Join(parts, ":")
+}
+
+// cacheStore wraps a Cache with JSON encoding and best-effort error handling.
+// Cache failures are logged and never surfaced to callers; a nil Cache disables caching.
+type cacheStore struct {
+	cache  Cache
+	logger *logger.Logger
+}
+
+// get loads a cached value into dest and reports whether it was found
+func (c cacheStore) get(ctx context.Context, key string, dest interface{}) bool {
+	if c.cache == nil {
+		return false
+	}
+
+	raw, err := c.cache.Get(ctx, key)
+	if err != nil || raw == "" {
+		return false
+	}
+
+	if err := json.Unmarshal([]byte(raw), dest); err != nil {
+		c.logger.Warn("Failed to decode cached value", zap.String("key", key), zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// set stores a value in the cache
+func (c cacheStore) set(ctx context.Context, key string, value interface{}, ttl time.Duration) {
+	if c.cache == nil {
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		c.logger.Warn("Failed to encode cache value", zap.String("key", key), zap.Error(err))
+		return
+	}
+
+	if err := c.cache.Set(ctx, key, string(data), ttl); err != nil {
+		c.logger.Warn("Failed to write cache", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// invalidate removes the given keys from the cache, or records them when ctx defers invalidation
+func (c cacheStore) invalidate(ctx context.Context, keys ...string) {
+	if c.cache == nil || len(keys) == 0 {
+		return
+	}
+	if deferred, ok := ctx.Value(deferredInvalidationKey{}).(*deferredInvalidation); ok {
+		deferred.mu.Lock()
+		deferred.keys = append(deferred.keys, keys...)
+		deferred.mu.Unlock()
+		return
+	}
+
+	for _, key := range keys {
+		if err := c.cache.Delete(ctx, key); err != nil {
+			c.logger.Warn("Failed to invalidate cache", zap.String("key", key), zap.Error(err))
+		}
+	}
+}
+
+// deferredInvalidationKey is the context key of a deferredInvalidation
+type deferredInvalidationKey struct{}
+
+// deferredInvalidation collects the keys invalidated under a context returned by deferInvalidation
+type deferredInvalidation struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+// deferInvalidation returns a context under which invalidate only records keys. Writes made in a
+// transaction use it so cached entries are dropped by flush once the transaction has committed,
+// not while other requests can still cache the state before the commit.
+func deferInvalidation(ctx context.Context) (context.Context, *deferredInvalidation) {
+	deferred := &deferredInvalidation{}
+	return context.WithValue(ctx, deferredInvalidationKey{}, deferred), deferred
+}
+
+// flush invalidates the keys recorded by a deferredInvalidation
+func (c cacheStore) flush(ctx context.Context, deferred *deferredInvalidation) {
+	deferred.mu.Lock()
+	keys := deferred.keys
+	deferred.keys = nil
+	deferred.mu.Unlock()
+
+	c.invalidate(ctx, keys...)
+}
+
+// cachedPage holds the first page of a list query.
+// Only the first page is cached since it is by far the most requested one.
+type cachedPage[T any] struct {
+	PerPage int   `json:"per_page"`
+	Items   []T   `json:"items"`
+	Total   int64 `json:"total"`
+}
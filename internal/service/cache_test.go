@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-shared/logger"
+)
+
+// memoryCache is an in-memory Cache used by the service tests
+type memoryCache struct {
+	mu     sync.Mutex
+	values map[string]string
+	ttls   map[string]time.Duration
+	hits   int
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{values: make(map[string]string), ttls: make(map[string]time.Duration)}
+}
+
+func (c *memoryCache) Get(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value, ok := c.values[key]
+	if ok {
+		c.hits++
+	}
+	return value, nil
+}
+
+func (c *memoryCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values[key] = value.(string)
+	c.ttls[key] = expiration
+	return nil
+}
+
+func (c *memoryCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.values, key)
+	return nil
+}
+
+// ttl returns the expiration a key was last written with
+func (c *memoryCache) ttl(key string) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ttls[key]
+}
+
+func (c *memoryCache) has(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.values[key]
+	return ok
+}
+
+func newTestLogger(t *testing.T) *logger.Logger {
+	t.Helper()
+
+	log, err := logger.New("error")
+	require.NoError(t, err)
+	return log
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+// SetOverride sets the runtime override of a config key for a tenant, or globally without one.
+// The key does not need a config entry.
+func (s *ConfigService) SetOverride(ctx context.Context, tenantID, author, key string, req *domain.ConfigOverrideRequest) (*domain.ConfigOverride, error) {
+	key = strings.TrimSpace(key)
+	if err := domain.ValidateConfigKey(key); err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+	if err := s.rules.Check(ctx, tenantID, key, "value", req.Value); err != nil {
+		return nil, err
+	}
+
+	override := &domain.ConfigOverride{
+		TenantID:    tenantID,
+		Key:         key,
+		Environment: req.Environment,
+		ValueType:   req.ValueType,
+		Value:       req.Value,
+		Reason:      req.Reason,
+		CreatedBy:   author,
+	}
+	if req.TTL != "" {
+		ttl, _ := time.ParseDuration(req.TTL)
+		expiresAt := time.Now().Add(ttl)
+		override.ExpiresAt = &expiresAt
+	}
+	if err := s.overrides.Set(ctx, override); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Config override set",
+		zap.String("tenant_id", tenantID),
+		zap.String("key", key),
+		zap.String("environment", override.Environment),
+	)
+	return override, nil
+}
+
+// DeleteOverride removes the runtime override of a config key
+func (s *ConfigService) DeleteOverride(ctx context.Context, tenantID, key, environment string) error {
+	key, environment = strings.TrimSpace(key), strings.ToLower(strings.TrimSpace(environment))
+	if err := domain.ValidateConfigKey(key); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	if err := domain.ValidateConfigEnvironment(environment); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	if err := s.overrides.Delete(ctx, tenantID, key, environment); err != nil {
+		if stderrors.Is(err, repository.ErrNotFound) {
+			return errors.NotFound("Config override not found")
+		}
+		return err
+	}
+
+	s.logger.Info("Config override removed",
+		zap.String("tenant_id", tenantID),
+		zap.String("key", key),
+		zap.String("environment", environment),
+	)
+	return nil
+}
+
+// Resolve merges the configs a tenant sees in an environment, from the lowest layer to the
+// highest: global defaults, global values of the environment, tenant values, tenant values of
+// the environment, secrets and runtime overrides. Within a layer tenant values win over global
+// ones and environment values over those for every environment. Every value is annotated with
+// the layer that supplied it and the values it shadows. The values of keys with a secret entry
+// are masked unless reveal is set.
+func (s *ConfigService) Resolve(ctx context.Context, tenantID string, query *domain.ConfigResolveQuery, reveal bool) (*domain.ResolvedConfig, error) {
+	query.Normalize()
+	if err := domain.ValidateConfigEnvironment(query.Environment); err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+
+	tenants := []string{""}
+	if tenantID != "" {
+		tenants = append(tenants, tenantID)
+	}
+
+	candidates := make(map[string][]rankedLayerValue)
+	now := time.Now()
+	for _, tenant := range tenants {
+		entries, _, err := s.repo.List(ctx, domain.ConfigQuery{TenantID: tenant, Prefix: query.Prefix}, 1, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			// Entries with only draft versions have no value yet
+			if !appliesTo(entry.Environment, query.Environment) || entry.ActiveVersion == 0 {
+				continue
+			}
+			layer := configLayer(entry)
+			candidates[entry.Key] = append(candidates[entry.Key], rankedLayerValue{
+				rank: layerRank(layer, entry.TenantID, entry.Environment),
+				value: domain.ConfigLayerValue{
+					Layer:       layer,
+					TenantID:    entry.TenantID,
+					Environment: entry.Environment,
+					ValueType:   entry.ValueType,
+					Value:       entry.Value,
+					Version:     entry.ActiveVersion,
+				},
+			})
+		}
+
+		overrides, err := s.overrides.List(ctx, tenant, query.Prefix)
+		if err != nil {
+			return nil, err
+		}
+		for _, override := range overrides {
+			if !appliesTo(override.Environment, query.Environment) || override.Expired(now) {
+				continue
+			}
+			candidates[override.Key] = append(candidates[override.Key], rankedLayerValue{
+				rank: layerRank(domain.ConfigLayerOverride, override.TenantID, override.Environment),
+				value: domain.ConfigLayerValue{
+					Layer:       domain.ConfigLayerOverride,
+					TenantID:    override.TenantID,
+					Environment: override.Environment,
+					ValueType:   override.ValueType,
+					Value:       override.Value,
+					ExpiresAt:   override.ExpiresAt,
+				},
+			})
+		}
+	}
+
+	resolved := &domain.ResolvedConfig{
+		TenantID:    tenantID,
+		Environment: query.Environment,
+		Prefix:      query.Prefix,
+		Values:      make([]domain.ResolvedConfigValue, 0, len(candidates)),
+	}
+	for key, values := range candidates {
+		sort.SliceStable(values, func(i, j int) bool { return values[i].rank > values[j].rank })
+		value := domain.ResolvedConfigValue{Key: key, ConfigLayerValue: values[0].value}
+		for _, shadowed := range values[1:] {
+			value.Shadowed = append(value.Shadowed, shadowed.value)
+		}
+		if !reveal {
+			value.MaskSecret()
+		}
+		resolved.Values = append(resolved.Values, value)
+	}
+	sort.Slice(resolved.Values, func(i, j int) bool { return resolved.Values[i].Key < resolved.Values[j].Key })
+	return resolved, nil
+}
+
+// rankedLayerValue is a candidate value of a key with its resolution priority
+type rankedLayerValue struct {
+	rank  int
+	value domain.ConfigLayerValue
+}
+
+// configLayer names the layer a config entry belongs to
+func configLayer(entry *domain.ConfigEntry) string {
+	switch {
+	case entry.Secret:
+		return domain.ConfigLayerSecret
+	case entry.TenantID == "" && entry.Environment == "":
+		return domain.ConfigLayerDefault
+	case entry.TenantID == "":
+		return domain.ConfigLayerGlobal
+	case entry.Environment == "":
+		return domain.ConfigLayerTenant
+	default:
+		return domain.ConfigLayerEnvironment
+	}
+}
+
+// layerRank orders candidate values by layer, then tenant over global and environment over
+// every environment
+func layerRank(layer, tenantID, environment string) int {
+	rank := 0
+	for i, name := range domain.ConfigLayers {
+		if name == layer {
+			rank = i * 4
+		}
+	}
+	if tenantID != "" {
+		rank += 2
+	}
+	if environment != "" {
+		rank++
+	}
+	return rank
+}
+
+// appliesTo reports whether a value stored for an environment applies to the resolved one;
+// values for every environment always do
+func appliesTo(stored, environment string) bool {
+	return stored == "" || stored == environment
+}
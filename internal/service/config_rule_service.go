@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// ConfigRuleService handles the validation rules that config writes and app component configs
+// are checked against
+type ConfigRuleService struct {
+	repo   repository.ConfigRuleRepository
+	cache  cacheStore
+	logger *logger.Logger
+}
+
+// NewConfigRuleService creates a new config validation rule service
+func NewConfigRuleService(repo repository.ConfigRuleRepository, cache Cache, log *logger.Logger) *ConfigRuleService {
+	return &ConfigRuleService{
+		repo:   repo,
+		cache:  cacheStore{cache: cache, logger: log},
+		logger: log,
+	}
+}
+
+// Create creates a new rule
+func (s *ConfigRuleService) Create(ctx context.Context, rule *domain.ConfigValidationRule) error {
+	rule.Normalize()
+	if err := rule.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	if err := s.repo.Create(ctx, rule); err != nil {
+		if stderrors.Is(err, repository.ErrDuplicateKey) {
+			return errors.Conflict(fmt.Sprintf("A rule for '%s' already exists", rule.KeyPattern))
+		}
+		return err
+	}
+
+	s.cache.invalidate(ctx, configRulesKey(rule.TenantID))
+	s.logger.Info("Config rule created",
+		zap.String("tenant_id", rule.TenantID),
+		zap.String("key_pattern", rule.KeyPattern),
+	)
+	return nil
+}
+
+// GetByID gets a rule of a tenant by ID
+func (s *ConfigRuleService) GetByID(ctx context.Context, id, tenantID string) (*domain.ConfigValidationRule, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, errors.BadRequest("Invalid ID format")
+	}
+
+	rule, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil || rule.TenantID != tenantID {
+		return nil, errors.NotFound("Config rule not found")
+	}
+	return rule, nil
+}
+
+// List lists the rules of a tenant, or the global ones without a tenant, ordered by key pattern
+func (s *ConfigRuleService) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.ConfigValidationRule, int64, error) {
+	return s.repo.List(ctx, tenantID, page, perPage)
+}
+
+// Update updates a rule of a tenant; the tenant of a rule is immutable
+func (s *ConfigRuleService) Update(ctx context.Context, rule *domain.ConfigValidationRule) error {
+	existing, err := s.GetByID(ctx, rule.ID.Hex(), rule.TenantID)
+	if err != nil {
+		return err
+	}
+
+	rule.CreatedAt = existing.CreatedAt
+	rule.CreatedBy = existing.CreatedBy
+	rule.Normalize()
+	if err := rule.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	if err := s.repo.Update(ctx, rule); err != nil {
+		switch {
+		case stderrors.Is(err, repository.ErrDuplicateKey):
+			return errors.Conflict(fmt.Sprintf("A rule for '%s' already exists", rule.KeyPattern))
+		case stderrors.Is(err, repository.ErrNotFound):
+			return errors.NotFound("Config rule not found")
+		}
+		return err
+	}
+
+	s.cache.invalidate(ctx, configRulesKey(rule.TenantID))
+	return nil
+}
+
+// Delete deletes a rule of a tenant
+func (s *ConfigRuleService) Delete(ctx context.Context, id, tenantID string) error {
+	existing, err := s.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, configRulesKey(tenantID))
+	s.logger.Info("Config rule deleted",
+		zap.String("tenant_id", tenantID),
+		zap.String("key_pattern", existing.KeyPattern),
+	)
+	return nil
+}
+
+// Check checks a value written under a config key of a tenant against the global rules and those
+// of the tenant. Violations are returned as a bad request listing them field by field; field
+// names the value in the request.
+func (s *ConfigRuleService) Check(ctx context.Context, tenantID, key, field string, value interface{}) error {
+	fields, err := s.Violations(ctx, tenantID, key, field, value)
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		return validationFailed(fields)
+	}
+	return nil
+}
+
+// Violations lists the violations of the rules that Check reports
+func (s *ConfigRuleService) Violations(ctx context.Context, tenantID, key, field string, value interface{}) ([]domain.FieldError, error) {
+	rules, err := s.rules(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if tenantID != "" {
+		tenantRules, err := s.rules(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, tenantRules...)
+	}
+	return domain.ValidateConfigFields(rules, key, field, value), nil
+}
+
+// rules loads every rule of a tenant, or the global ones, through the cache
+func (s *ConfigRuleService) rules(ctx context.Context, tenantID string) ([]*domain.ConfigValidationRule, error) {
+	key := configRulesKey(tenantID)
+	var cached []*domain.ConfigValidationRule
+	if s.cache.get(ctx, key, &cached) {
+		return cached, nil
+	}
+
+	rules, _, err := s.repo.List(ctx, tenantID, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.set(ctx, key, rules, configDataTTL)
+	return rules, nil
+}
+
+// validationFailed reports field-level validation errors; handlers respond to them with a bad
+// request listing the fields
+func validationFailed(fields []domain.FieldError) error {
+	return &domain.ValidationError{Fields: fields}
+}
+
+func configRulesKey(tenantID string) string {
+	return cacheKey("config_rules", tenantScope(tenantID))
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+)
+
+// assertFieldErrors checks that err is a validation error listing the given fields and codes
+func assertFieldErrors(t *testing.T, err error, expected map[string]string) {
+	t.Helper()
+
+	require.Error(t, err)
+	var validation *domain.ValidationError
+	require.True(t, stderrors.As(err, &validation), "validation errors carry their fields")
+	actual := make(map[string]string, len(validation.Fields))
+	for _, field := range validation.Fields {
+		actual[field.Field] = field.Code
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestConfigRuleService_CRUD(t *testing.T) {
+	ctx := context.Background()
+	cache := newMemoryCache()
+	svc := NewConfigRuleService(repository.NewMemoryConfigRuleRepository(), cache, newTestLogger(t))
+
+	rule := &domain.ConfigValidationRule{TenantID: "tenant-1", KeyPattern: " api.rate_limit ", Type: "INT", Min: 1.0, CreatedBy: "alice"}
+	require.NoError(t, svc.Create(ctx, rule))
+	assert.Equal(t, "api.rate_limit", rule.KeyPattern)
+	assert.Equal(t, domain.ConfigRuleTypeInt, rule.Type)
+
+	assertStatus(t, svc.Create(ctx, &domain.ConfigValidationRule{TenantID: "tenant-1", KeyPattern: "api.rate_limit", Type: "int"}), http.StatusConflict)
+	assertStatus(t, svc.Create(ctx, &domain.ConfigValidationRule{TenantID: "tenant-1", KeyPattern: "db.pool", Type: "bool", Max: 1.0}), http.StatusBadRequest)
+	require.NoError(t, svc.Create(ctx, &domain.ConfigValidationRule{TenantID: "tenant-2", KeyPattern: "api.rate_limit", Type: "int"}))
+
+	_, err := svc.GetByID(ctx, rule.ID.Hex(), "tenant-2")
+	assertStatus(t, err, http.StatusNotFound)
+	_, err = svc.GetByID(ctx, "invalid", "tenant-1")
+	assertStatus(t, err, http.StatusBadRequest)
+
+	require.NoError(t, svc.Check(ctx, "tenant-1", "api.rate_limit", "value", int64(10)))
+	assert.True(t, cache.has(configRulesKey("tenant-1")))
+
+	require.NoError(t, svc.Update(ctx, &domain.ConfigValidationRule{ID: rule.ID, TenantID: "tenant-1", KeyPattern: "api.rate_limit", Type: "int", Max: 5.0, UpdatedBy: "bob"}))
+	assert.False(t, cache.has(configRulesKey("tenant-1")))
+	updated, err := svc.GetByID(ctx, rule.ID.Hex(), "tenant-1")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", updated.CreatedBy)
+	assert.Equal(t, "bob", updated.UpdatedBy)
+	assertFieldErrors(t, svc.Check(ctx, "tenant-1", "api.rate_limit", "value", int64(10)), map[string]string{"value": domain.ConfigRuleMax})
+	require.NoError(t, svc.Check(ctx, "tenant-3", "api.rate_limit", "value", int64(10)), "rules only apply to their tenant")
+
+	rules, total, err := svc.List(ctx, "tenant-1", 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, rules, 1)
+
+	assertStatus(t, svc.Delete(ctx, rule.ID.Hex(), "tenant-2"), http.StatusNotFound)
+	require.NoError(t, svc.Delete(ctx, rule.ID.Hex(), "tenant-1"))
+	require.NoError(t, svc.Check(ctx, "tenant-1", "api.rate_limit", "value", int64(10)))
+}
+
+func TestConfigRuleService_ChecksConfigWrites(t *testing.T) {
+	ctx := context.Background()
+	cache := newMemoryCache()
+	rules := NewConfigRuleService(repository.NewMemoryConfigRuleRepository(), cache, newTestLogger(t))
+	configs := NewConfigService(repository.NewMemoryConfigRepository(), repository.NewMemoryConfigVersionRepository(), repository.NewMemoryConfigOverrideRepository(), rules, cache, newTestLogger(t))
+	components := NewAppComponentService(repository.NewMemoryAppComponentRepository(), repository.NewMemoryConfigVersionRepository(), repository.NewMemoryTransactor(), rules,
+		NewConfigSchemaService(repository.NewMemoryConfigSchemaRepository(), cache, newTestLogger(t)), cache, newTestLogger(t))
+
+	// Global rules apply to every tenant
+	require.NoError(t, rules.Create(ctx, &domain.ConfigValidationRule{KeyPattern: "db.*.timeout", Type: "duration", Max: "1m"}))
+	require.NoError(t, rules.Create(ctx, &domain.ConfigValidationRule{TenantID: "tenant-1", KeyPattern: "db.pool.size", Type: "int", Required: true, Min: 1.0, Max: 100.0}))
+	require.NoError(t, rules.Create(ctx, &domain.ConfigValidationRule{TenantID: "tenant-1", KeyPattern: "dashboard.theme", Type: "string", AllowedValues: []string{"light", "dark"}}))
+
+	_, err := configs.Create(ctx, "tenant-1", "alice", &domain.ConfigWriteRequest{Key: "db.primary.timeout", Value: "5m"})
+	assertFieldErrors(t, err, map[string]string{"value": domain.ConfigRuleMax})
+	_, err = configs.Create(ctx, "tenant-1", "alice", &domain.ConfigWriteRequest{Key: "db.pool", Value: map[string]interface{}{"size": 0.0, "timeout": "soon"}})
+	assertFieldErrors(t, err, map[string]string{"value.size": domain.ConfigRuleMin, "value.timeout": domain.ConfigRuleType})
+	_, err = configs.Create(ctx, "tenant-1", "alice", &domain.ConfigWriteRequest{Key: "db.pool", Value: map[string]interface{}{"idle": 2.0}})
+	assertFieldErrors(t, err, map[string]string{"value.size": domain.ConfigRuleRequired})
+	_, err = configs.Get(ctx, "tenant-1", "db.pool", "", false)
+	assertStatus(t, err, http.StatusNotFound)
+
+	_, err = configs.Create(ctx, "tenant-1", "alice", &domain.ConfigWriteRequest{Key: "db.pool", Value: map[string]interface{}{"size": 10.0, "timeout": "30s"}})
+	require.NoError(t, err)
+	_, err = configs.Update(ctx, "tenant-1", "bob", &domain.ConfigWriteRequest{Key: "db.pool", Value: map[string]interface{}{"size": 500.0}})
+	assertFieldErrors(t, err, map[string]string{"value.size": domain.ConfigRuleMax})
+	_, err = configs.SetOverride(ctx, "tenant-1", "bob", "db.primary.timeout", &domain.ConfigOverrideRequest{Value: "2h", TTL: "1h"})
+	assertFieldErrors(t, err, map[string]string{"value": domain.ConfigRuleMax})
+	_, err = configs.Create(ctx, "tenant-2", "carol", &domain.ConfigWriteRequest{Key: "db.pool", Value: map[string]interface{}{"size": 500.0}})
+	require.NoError(t, err, "tenant rules do not apply to other tenants")
+
+	// App component configs are checked as keys below the component code
+	component := &domain.AppComponent{TenantID: "tenant-1", Code: "dashboard", Config: map[string]interface{}{"theme": "blue"}}
+	assertFieldErrors(t, components.Create(ctx, component), map[string]string{"config.theme": domain.ConfigRuleAllowed})
+	component.Config["theme"] = "dark"
+	require.NoError(t, components.Create(ctx, component))
+	err = components.Update(ctx, &domain.AppComponent{ID: component.ID, Config: map[string]interface{}{"theme": 1.0}})
+	assertFieldErrors(t, err, map[string]string{"config.theme": domain.ConfigRuleType})
+}
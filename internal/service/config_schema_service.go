@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// ConfigSchemaService handles the JSON Schemas that app component configs and service package
+// limits are validated against
+type ConfigSchemaService struct {
+	repo   repository.ConfigSchemaRepository
+	cache  cacheStore
+	logger *logger.Logger
+}
+
+// NewConfigSchemaService creates a new config schema service
+func NewConfigSchemaService(repo repository.ConfigSchemaRepository, cache Cache, log *logger.Logger) *ConfigSchemaService {
+	return &ConfigSchemaService{
+		repo:   repo,
+		cache:  cacheStore{cache: cache, logger: log},
+		logger: log,
+	}
+}
+
+// Create creates the first version of a schema
+func (s *ConfigSchemaService) Create(ctx context.Context, schema *domain.ConfigSchema) error {
+	schema.Normalize()
+	if err := schema.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	schema.Version = 1
+
+	if err := s.repo.Create(ctx, schema); err != nil {
+		if stderrors.Is(err, repository.ErrDuplicateKey) {
+			return errors.Conflict(fmt.Sprintf("A schema for '%s' already exists", schema.Subject()))
+		}
+		return err
+	}
+
+	s.cache.invalidate(ctx, configSchemaKey(schema.Target, schema.Code))
+	s.logger.Info("Config schema created", zap.String("schema", schema.Subject()))
+	return nil
+}
+
+// GetByID gets a schema by ID
+func (s *ConfigSchemaService) GetByID(ctx context.Context, id string) (*domain.ConfigSchema, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, errors.BadRequest("Invalid ID format")
+	}
+
+	schema, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if schema == nil {
+		return nil, errors.NotFound("Config schema not found")
+	}
+	return schema, nil
+}
+
+// List lists the schemas of a target, or of every target when target is empty
+func (s *ConfigSchemaService) List(ctx context.Context, target string, page, perPage int) ([]*domain.ConfigSchema, int64, error) {
+	return s.repo.List(ctx, target, page, perPage)
+}
+
+// Update writes a new version of a schema; its target and code are immutable. An update racing
+// another one is refused with a conflict rather than writing the same version twice. Stored
+// documents are validated against the new version on their next write, or brought in line by a
+// migration.
+func (s *ConfigSchemaService) Update(ctx context.Context, schema *domain.ConfigSchema) error {
+	schema.Normalize()
+	existing, err := s.GetByID(ctx, schema.ID.Hex())
+	if err != nil {
+		return err
+	}
+
+	schema.Target = existing.Target
+	schema.Code = existing.Code
+	schema.CreatedAt = existing.CreatedAt
+	schema.CreatedBy = existing.CreatedBy
+	schema.Version = existing.Version + 1
+	if err := schema.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	if err := s.repo.Update(ctx, schema, existing.Version); err != nil {
+		if stderrors.Is(err, repository.ErrNotFound) {
+			return errors.NotFound("Config schema not found")
+		}
+		if stderrors.Is(err, repository.ErrVersionConflict) {
+			return errors.Conflict(fmt.Sprintf("Config schema '%s' was changed concurrently; retry the update", schema.Subject()))
+		}
+		return err
+	}
+
+	s.cache.invalidate(ctx, configSchemaKey(schema.Target, schema.Code))
+	s.logger.Info("Config schema updated",
+		zap.String("schema", schema.Subject()),
+		zap.Int("version", schema.Version),
+	)
+	return nil
+}
+
+// Delete deletes a schema, leaving its documents unchecked
+func (s *ConfigSchemaService) Delete(ctx context.Context, id string) error {
+	existing, err := s.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, configSchemaKey(existing.Target, existing.Code))
+	s.logger.Info("Config schema deleted", zap.String("schema", existing.Subject()))
+	return nil
+}
+
+// Find finds the schema of a target and code through the cache; it returns nil when there is
+// none
+func (s *ConfigSchemaService) Find(ctx context.Context, target, code string) (*domain.ConfigSchema, error) {
+	key := configSchemaKey(target, code)
+	var cached *domain.ConfigSchema
+	if s.cache.get(ctx, key, &cached) {
+		return cached, nil
+	}
+
+	schema, err := s.repo.FindBySubject(ctx, target, code)
+	if err != nil {
+		return nil, err
+	}
+
+	// Missing schemas are cached too, as most documents have none
+	s.cache.set(ctx, key, schema, configDataTTL)
+	return schema, nil
+}
+
+// Violations checks a value against the schema of a target and code, if there is one. field
+// names the value in the request and prefixes the fields and pointers of the errors.
+func (s *ConfigSchemaService) Violations(ctx context.Context, target, code, field string, value interface{}) ([]domain.FieldError, error) {
+	schema, err := s.Find(ctx, target, code)
+	if err != nil || schema == nil {
+		return nil, err
+	}
+	compiled, err := schema.Compile()
+	if err != nil {
+		return nil, errors.Internal(fmt.Sprintf("Config schema '%s' does not compile: %v", schema.Subject(), err))
+	}
+
+	plain, err := domain.PlainConfigValue(value)
+	if err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+	return schema.Violations(compiled, field, plain), nil
+}
+
+// Check checks a value against the schema of a target and code, returning the violations as a
+// bad request listing them field by field
+func (s *ConfigSchemaService) Check(ctx context.Context, target, code, field string, value interface{}) error {
+	fields, err := s.Violations(ctx, target, code, field, value)
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		return validationFailed(fields)
+	}
+	return nil
+}
+
+func configSchemaKey(target, code string) string {
+	return cacheKey("config_schemas", target, code)
+}
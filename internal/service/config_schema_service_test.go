@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+)
+
+// validationFields returns the fields of a validation error
+func validationFields(t *testing.T, err error) []domain.FieldError {
+	t.Helper()
+
+	var validation *domain.ValidationError
+	require.True(t, stderrors.As(err, &validation))
+	return validation.Fields
+}
+
+// dashboardSchema is the schema of the dashboard component configs in these tests
+func dashboardSchema() domain.JSONSchema {
+	return domain.JSONSchema{
+		"$schema":  "https://json-schema.org/draft/2020-12/schema",
+		"type":     "object",
+		"required": []interface{}{"theme"},
+		"properties": map[string]interface{}{
+			"theme":   map[string]interface{}{"type": "string", "enum": []interface{}{"light", "dark"}, "default": "light"},
+			"refresh": map[string]interface{}{"type": "integer", "minimum": 5},
+		},
+	}
+}
+
+func TestConfigSchemaService_CRUD(t *testing.T) {
+	ctx := context.Background()
+	cache := newMemoryCache()
+	svc := NewConfigSchemaService(repository.NewMemoryConfigSchemaRepository(), cache, newTestLogger(t))
+
+	missing, err := svc.Find(ctx, domain.SchemaTargetAppComponent, "dashboard")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+	assert.True(t, cache.has(configSchemaKey(domain.SchemaTargetAppComponent, "dashboard")), "missing schemas are cached")
+
+	schema := &domain.ConfigSchema{Target: " App_Component ", Code: "dashboard", Schema: dashboardSchema(), CreatedBy: "alice"}
+	require.NoError(t, svc.Create(ctx, schema))
+	assert.Equal(t, domain.SchemaTargetAppComponent, schema.Target)
+	assert.Equal(t, 1, schema.Version)
+	assert.False(t, cache.has(configSchemaKey(domain.SchemaTargetAppComponent, "dashboard")))
+
+	assertStatus(t, svc.Create(ctx, &domain.ConfigSchema{Target: "app_component", Code: "dashboard", Schema: dashboardSchema()}), http.StatusConflict)
+	assertStatus(t, svc.Create(ctx, &domain.ConfigSchema{Target: "app_component", Schema: dashboardSchema()}), http.StatusBadRequest)
+	assertStatus(t, svc.Create(ctx, &domain.ConfigSchema{Target: "package_limits", Code: "pro", Schema: domain.JSONSchema{}}), http.StatusBadRequest)
+	assertStatus(t, svc.Create(ctx, &domain.ConfigSchema{Target: "package_limits", Schema: domain.JSONSchema{"$ref": "#/$defs/limits"}}), http.StatusBadRequest)
+
+	found, err := svc.Find(ctx, domain.SchemaTargetAppComponent, "dashboard")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, schema.ID, found.ID)
+
+	update := &domain.ConfigSchema{ID: schema.ID, Target: "package_limits", Schema: domain.JSONSchema{"type": "object"}, UpdatedBy: "bob"}
+	require.NoError(t, svc.Update(ctx, update))
+	assert.Equal(t, 2, update.Version)
+	updated, err := svc.GetByID(ctx, schema.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, domain.SchemaTargetAppComponent, updated.Target, "target and code are immutable")
+	assert.Equal(t, "dashboard", updated.Code)
+	assert.Equal(t, "alice", updated.CreatedBy)
+	assert.Equal(t, "bob", updated.UpdatedBy)
+	found, err = svc.Find(ctx, domain.SchemaTargetAppComponent, "dashboard")
+	require.NoError(t, err)
+	assert.Equal(t, 2, found.Version)
+
+	require.NoError(t, svc.Create(ctx, &domain.ConfigSchema{Target: "package_limits", Schema: domain.JSONSchema{"type": "object"}}))
+	schemas, total, err := svc.List(ctx, "", 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, "app_component:dashboard", schemas[0].Subject())
+	assert.Equal(t, "package_limits", schemas[1].Subject())
+
+	require.NoError(t, svc.Delete(ctx, schema.ID.Hex()))
+	_, err = svc.GetByID(ctx, schema.ID.Hex())
+	assertStatus(t, err, http.StatusNotFound)
+	found, err = svc.Find(ctx, domain.SchemaTargetAppComponent, "dashboard")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
+
+// staleSchemaRepository reads schemas as they were when it was created, like an update racing
+// another one
+type staleSchemaRepository struct {
+	repository.ConfigSchemaRepository
+	stale *domain.ConfigSchema
+}
+
+func (r staleSchemaRepository) FindByID(ctx context.Context, id string) (*domain.ConfigSchema, error) {
+	stale := *r.stale
+	return &stale, nil
+}
+
+func TestConfigSchemaService_UpdateConflicts(t *testing.T) {
+	ctx := context.Background()
+	cache := newMemoryCache()
+	repo := repository.NewMemoryConfigSchemaRepository()
+	svc := NewConfigSchemaService(repo, cache, newTestLogger(t))
+
+	schema := &domain.ConfigSchema{Target: "app_component", Code: "dashboard", Schema: dashboardSchema()}
+	require.NoError(t, svc.Create(ctx, schema))
+	stale := *schema
+	require.NoError(t, svc.Update(ctx, &domain.ConfigSchema{ID: schema.ID, Schema: domain.JSONSchema{"type": "object"}, UpdatedBy: "alice"}))
+
+	racing := NewConfigSchemaService(staleSchemaRepository{ConfigSchemaRepository: repo, stale: &stale}, cache, newTestLogger(t))
+	err := racing.Update(ctx, &domain.ConfigSchema{ID: schema.ID, Schema: domain.JSONSchema{"type": "array"}, UpdatedBy: "bob"})
+	assertStatus(t, err, http.StatusConflict)
+
+	found, err := svc.GetByID(ctx, schema.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, 2, found.Version)
+	assert.Equal(t, "object", found.Schema["type"])
+	assert.Equal(t, "alice", found.UpdatedBy)
+}
+
+func TestConfigSchemaService_ChecksComponentConfigs(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestAppComponentService(t)
+	schemas := svc.schemas
+
+	require.NoError(t, schemas.Create(ctx, &domain.ConfigSchema{Target: "app_component", Code: "dashboard", Schema: dashboardSchema()}))
+
+	component := &domain.AppComponent{TenantID: "tenant-1", Code: "dashboard", Name: "Dashboard", Config: map[string]interface{}{"theme": "blue", "refresh": 2.5}}
+	err := svc.Create(ctx, component)
+	assertFieldErrors(t, err, map[string]string{"config.theme": "enum", "config.refresh": "minimum"})
+	validation := validationFields(t, err)
+	assert.Equal(t, "/config/refresh", validation[0].Pointer)
+	assert.Equal(t, "app_component:dashboard", validation[0].Rule)
+
+	component.Config = map[string]interface{}{"theme": "dark", "refresh": 30}
+	require.NoError(t, svc.Create(ctx, component))
+	err = svc.Update(ctx, &domain.AppComponent{ID: component.ID, TenantID: "tenant-1", Code: "dashboard", Name: "Dashboard", Config: map[string]interface{}{"refresh": 10}})
+	assertFieldErrors(t, err, map[string]string{"config.theme": "required"})
+
+	require.NoError(t, svc.Create(ctx, &domain.AppComponent{TenantID: "tenant-1", Code: "reports", Name: "Reports", Config: map[string]interface{}{"theme": 1}}),
+		"components without a schema are not checked")
+}
+
+func TestConfigSchemaService_ChecksPackageLimits(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestServicePackageService(t)
+
+	require.NoError(t, svc.schemas.Create(ctx, &domain.ConfigSchema{Target: "package_limits", Schema: domain.JSONSchema{
+		"type":     "object",
+		"required": []interface{}{"users"},
+		"properties": map[string]interface{}{
+			"users":   map[string]interface{}{"type": "integer", "minimum": 1},
+			"storage": map[string]interface{}{"type": "string", "pattern": "^[0-9]+(MB|GB)$"},
+		},
+	}}))
+
+	pkg := &domain.ServicePackage{Code: "pro", Name: "Professional", Tier: "professional", Limits: map[string]interface{}{"users": 0, "storage": "1TB"}}
+	err := svc.Create(ctx, pkg)
+	assertFieldErrors(t, err, map[string]string{"limits.users": "minimum", "limits.storage": "pattern"})
+	assert.Equal(t, "/limits/storage", validationFields(t, err)[0].Pointer)
+
+	pkg.Limits = map[string]interface{}{"users": 50, "storage": "100GB"}
+	require.NoError(t, svc.Create(ctx, pkg))
+	pkg.Limits = map[string]interface{}{"storage": "100GB"}
+	assertFieldErrors(t, svc.Update(ctx, pkg), map[string]string{"limits.users": "required"})
+}
+
+func TestAppComponentService_MigrateConfigs(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestAppComponentService(t)
+
+	_, err := svc.MigrateConfigs(ctx, "dashboard", "alice", &domain.SchemaMigrationOptions{})
+	assertStatus(t, err, http.StatusNotFound)
+
+	// Components stored before the schema existed
+	outdated := &domain.AppComponent{TenantID: "tenant-2", Code: "dashboard", Name: "Dashboard", Config: map[string]interface{}{"refresh": 30}}
+	require.NoError(t, svc.Create(ctx, outdated))
+	current := &domain.AppComponent{TenantID: "tenant-1", Code: "dashboard", Name: "Dashboard", Config: map[string]interface{}{"theme": "dark"}}
+	require.NoError(t, svc.Create(ctx, current))
+	broken := &domain.AppComponent{TenantID: "tenant-3", Code: "dashboard", Name: "Dashboard", Config: map[string]interface{}{"refresh": 1}}
+	require.NoError(t, svc.Create(ctx, broken))
+	require.NoError(t, svc.Create(ctx, &domain.AppComponent{TenantID: "tenant-1", Code: "reports", Name: "Reports"}))
+	legacy := &domain.AppComponent{TenantID: "tenant-4", Code: "dashboard", Name: "Dashboard",
+		Config: map[string]interface{}{"theme": "dark", "since": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}}
+	require.NoError(t, svc.repo.Create(ctx, legacy))
+	require.NoError(t, svc.schemas.Create(ctx, &domain.ConfigSchema{Target: "app_component", Code: "dashboard", Schema: dashboardSchema()}))
+
+	report, err := svc.MigrateConfigs(ctx, "dashboard", "alice", &domain.SchemaMigrationOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, "app_component:dashboard", report.Schema)
+	assert.Equal(t, 1, report.SchemaVersion)
+	assert.Equal(t, 4, report.Checked)
+	assert.Equal(t, 1, report.Migrated)
+	require.Len(t, report.Nonconforming, 2)
+	assert.Equal(t, broken.ID.Hex(), report.Nonconforming[0].ID)
+	assert.Equal(t, "tenant-3", report.Nonconforming[0].TenantID)
+	assert.Equal(t, []domain.FieldError{{
+		Field: "config.refresh", Pointer: "/config/refresh", Code: "minimum", Message: "must be at least 5", Rule: "app_component:dashboard",
+	}}, report.Nonconforming[0].Violations)
+	assert.Equal(t, legacy.ID.Hex(), report.Nonconforming[1].ID, "configs that cannot be read are reported")
+	assert.Equal(t, []domain.FieldError{{
+		Field: "config", Pointer: "/config", Code: "type", Message: "value of type time.Time is not supported", Rule: "app_component:dashboard",
+	}}, report.Nonconforming[1].Violations)
+	stored, err := svc.GetByID(ctx, outdated.ID.Hex())
+	require.NoError(t, err)
+	assert.NotContains(t, stored.Config, "theme", "dry runs do not write")
+
+	report, err = svc.MigrateConfigs(ctx, "dashboard", "alice", &domain.SchemaMigrationOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Migrated)
+	stored, err = svc.GetByID(ctx, outdated.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, "light", stored.Config["theme"])
+	assert.EqualValues(t, 30, stored.Config["refresh"])
+	versions, total, err := svc.History(ctx, outdated.ID.Hex(), "tenant-2", 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, "alice", versions[0].CreatedBy)
+	assert.Equal(t, "Migrated to version 1 of schema app_component:dashboard", versions[0].Reason)
+	stored, err = svc.GetByID(ctx, broken.ID.Hex())
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, stored.Config["refresh"], "nonconforming configs are left as they are")
+
+	report, err = svc.MigrateConfigs(ctx, "dashboard", "alice", &domain.SchemaMigrationOptions{})
+	require.NoError(t, err)
+	assert.Zero(t, report.Migrated, "migrations are idempotent")
+}
+
+func TestServicePackageService_MigrateLimits(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestServicePackageService(t)
+
+	require.NoError(t, svc.Create(ctx, &domain.ServicePackage{Code: "basic", Name: "Basic", Tier: "basic", Limits: map[string]interface{}{"users": 5}}))
+	require.NoError(t, svc.Create(ctx, &domain.ServicePackage{TenantID: "tenant-1", Code: "custom", Name: "Custom", Tier: "enterprise",
+		Limits: map[string]interface{}{"users": "unlimited"}}))
+	require.NoError(t, svc.schemas.Create(ctx, &domain.ConfigSchema{Target: "package_limits", Schema: domain.JSONSchema{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"users":   map[string]interface{}{"type": "integer"},
+			"storage": map[string]interface{}{"type": "string", "default": "10GB"},
+		},
+	}}))
+
+	report, err := svc.MigrateLimits(ctx, &domain.SchemaMigrationOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "package_limits", report.Schema)
+	assert.Equal(t, 2, report.Checked)
+	assert.Equal(t, 1, report.Migrated)
+	require.Len(t, report.Nonconforming, 1)
+	assert.Equal(t, "custom", report.Nonconforming[0].Code)
+	assert.Equal(t, "tenant-1", report.Nonconforming[0].TenantID)
+	assert.Equal(t, "/limits/users", report.Nonconforming[0].Violations[0].Pointer)
+
+	entitlements, err := svc.Entitlements(ctx, "", "basic")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10*1024*1024*1024), entitlements.Limits.Storage)
+}
@@ -0,0 +1,465 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+// ConfigService handles the versioned key/value config store. Every write stores an immutable
+// version; the entry carries the value of the one active version. Runtime overrides sit on top
+// of the entries when configs are resolved. Every value written is checked against the config
+// validation rules. The values of secret entries are masked in what the service returns unless
+// a read asks to reveal them.
+type ConfigService struct {
+	repo      repository.ConfigRepository
+	versions  repository.ConfigVersionRepository
+	overrides repository.ConfigOverrideRepository
+	rules     *ConfigRuleService
+	cache     cacheStore
+	logger    *logger.Logger
+}
+
+// NewConfigService creates a new config service
+func NewConfigService(repo repository.ConfigRepository, versions repository.ConfigVersionRepository, overrides repository.ConfigOverrideRepository, rules *ConfigRuleService, cache Cache, log *logger.Logger) *ConfigService {
+	return &ConfigService{
+		repo:      repo,
+		versions:  versions,
+		overrides: overrides,
+		rules:     rules,
+		cache:     cacheStore{cache: cache, logger: log},
+		logger:    log,
+	}
+}
+
+// Create creates a config entry with its first version, active unless the write is a draft.
+// When the version cannot be written the entry is removed again, so the create can be retried.
+func (s *ConfigService) Create(ctx context.Context, tenantID, author string, req *domain.ConfigWriteRequest) (*domain.ConfigEntry, error) {
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+	if err := s.rules.Check(ctx, tenantID, req.Key, "value", req.Value); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindByKey(ctx, tenantID, req.Key, req.Environment)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.Conflict(fmt.Sprintf("Config '%s' already exists", configName(req.Key, req.Environment)))
+	}
+
+	entry := &domain.ConfigEntry{
+		TenantID:    tenantID,
+		Key:         req.Key,
+		Environment: req.Environment,
+		CreatedBy:   author,
+		UpdatedBy:   author,
+	}
+	if req.Description != nil {
+		entry.Description = *req.Description
+	}
+	if req.Secret != nil {
+		entry.Secret = *req.Secret
+	}
+	if err := s.repo.Create(ctx, entry); err != nil {
+		if stderrors.Is(err, repository.ErrDuplicateKey) {
+			return nil, errors.Conflict(fmt.Sprintf("Config '%s' already exists", configName(req.Key, req.Environment)))
+		}
+		return nil, err
+	}
+
+	if err := s.write(ctx, entry, author, req, 0); err != nil {
+		s.discard(ctx, entry)
+		return nil, err
+	}
+	s.logger.Info("Config created",
+		zap.String("tenant_id", tenantID),
+		zap.String("key", entry.Key),
+		zap.String("environment", entry.Environment),
+	)
+	entry.MaskSecret()
+	return entry, nil
+}
+
+// Update writes a new version of a config entry, active unless the write is a draft. Without a
+// value type the value must keep the type of the entry.
+func (s *ConfigService) Update(ctx context.Context, tenantID, author string, req *domain.ConfigWriteRequest) (*domain.ConfigEntry, error) {
+	req.Normalize()
+	entry, err := s.find(ctx, tenantID, req.Key, req.Environment)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ValueType == "" {
+		req.ValueType = entry.ValueType
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+	if err := s.rules.Check(ctx, tenantID, entry.Key, "value", req.Value); err != nil {
+		return nil, err
+	}
+	if req.Description != nil {
+		entry.Description = *req.Description
+	}
+	if req.Secret != nil {
+		entry.Secret = *req.Secret
+	}
+
+	if err := s.write(ctx, entry, author, req, 0); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Config updated",
+		zap.String("tenant_id", tenantID),
+		zap.String("key", entry.Key),
+		zap.String("environment", entry.Environment),
+		zap.Int("version", entry.LatestVersion),
+	)
+	entry.MaskSecret()
+	return entry, nil
+}
+
+// Get gets a config entry with the value of its active version, masked for secret entries
+// unless reveal is set
+func (s *ConfigService) Get(ctx context.Context, tenantID, key, environment string, reveal bool) (*domain.ConfigEntry, error) {
+	entry, err := s.get(ctx, tenantID, strings.TrimSpace(key), strings.ToLower(strings.TrimSpace(environment)))
+	if err != nil {
+		return nil, err
+	}
+	if !reveal {
+		entry.MaskSecret()
+	}
+	return entry, nil
+}
+
+// get loads a config entry through the cache
+func (s *ConfigService) get(ctx context.Context, tenantID, key, environment string) (*domain.ConfigEntry, error) {
+	cacheKey := configKey(tenantID, key, environment)
+	var cached domain.ConfigEntry
+	if s.cache.get(ctx, cacheKey, &cached) {
+		if cached.ActiveVersion == 0 {
+			return &cached, nil
+		}
+		// JSON decoding turns integers into floats; restore the typed value
+		if _, value, err := domain.NormalizeConfigValue(cached.ValueType, cached.Value); err == nil {
+			cached.Value = value
+			return &cached, nil
+		}
+	}
+
+	entry, err := s.find(ctx, tenantID, key, environment)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.set(ctx, cacheKey, entry, configDataTTL)
+	return entry, nil
+}
+
+// GetVersion gets one version of a config entry, masked for secret entries unless reveal is set
+func (s *ConfigService) GetVersion(ctx context.Context, tenantID, key, environment string, number int, reveal bool) (*domain.ConfigVersion, error) {
+	entry, err := s.find(ctx, tenantID, strings.TrimSpace(key), strings.ToLower(strings.TrimSpace(environment)))
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.findVersion(ctx, entry, number)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Secret && !reveal {
+		version.Mask()
+	}
+	return version, nil
+}
+
+// List lists the config entries of a tenant ordered by key and environment, masking the values of
+// secret entries unless reveal is set
+func (s *ConfigService) List(ctx context.Context, query domain.ConfigQuery, page, perPage int, reveal bool) ([]*domain.ConfigEntry, int64, error) {
+	query.Environment = strings.ToLower(strings.TrimSpace(query.Environment))
+	query.Prefix = strings.TrimSpace(query.Prefix)
+	entries, total, err := s.repo.List(ctx, query, page, perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !reveal {
+		for _, entry := range entries {
+			entry.MaskSecret()
+		}
+	}
+	return entries, total, nil
+}
+
+// Activate makes a stored version the active one of a config entry
+func (s *ConfigService) Activate(ctx context.Context, tenantID, author, key, environment string, number int) (*domain.ConfigEntry, error) {
+	entry, err := s.find(ctx, tenantID, strings.TrimSpace(key), strings.ToLower(strings.TrimSpace(environment)))
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.findVersion(ctx, entry, number)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.ActiveVersion = version.Version
+	entry.ValueType, entry.Value = version.ValueType, version.Value
+	entry.UpdatedBy = author
+	if err := s.save(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Config version activated",
+		zap.String("tenant_id", tenantID),
+		zap.String("key", entry.Key),
+		zap.String("environment", entry.Environment),
+		zap.Int("version", version.Version),
+	)
+	entry.MaskSecret()
+	return entry, nil
+}
+
+// History lists the versions of a config entry, newest first, marking the active one. The values
+// of a secret entry are masked unless reveal is set.
+func (s *ConfigService) History(ctx context.Context, tenantID, key, environment string, page, perPage int, reveal bool) ([]*domain.ConfigVersion, int64, error) {
+	entry, err := s.find(ctx, tenantID, strings.TrimSpace(key), strings.ToLower(strings.TrimSpace(environment)))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	versions, total, err := s.versions.List(ctx, entry.ID.Hex(), page, perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, version := range versions {
+		version.Active = version.Version == entry.ActiveVersion
+		if entry.Secret && !reveal {
+			version.Mask()
+		}
+	}
+	return versions, total, nil
+}
+
+// Diff compares the values of two versions of a config entry. For a secret entry only the paths
+// that changed are shown unless reveal is set.
+func (s *ConfigService) Diff(ctx context.Context, tenantID, key, environment string, from, to int, reveal bool) (*domain.ConfigDiff, error) {
+	entry, err := s.find(ctx, tenantID, strings.TrimSpace(key), strings.ToLower(strings.TrimSpace(environment)))
+	if err != nil {
+		return nil, err
+	}
+	diff, err := diffVersions(ctx, s.versions, entry.ID.Hex(), from, to)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Secret && !reveal {
+		diff.Mask()
+	}
+	return diff, nil
+}
+
+// Rollback restores the value of an earlier version by writing it as a new active version, so
+// the history keeps every change including the rollback itself
+func (s *ConfigService) Rollback(ctx context.Context, tenantID, author, key, environment string, req *domain.ConfigRollbackRequest) (*domain.ConfigEntry, error) {
+	entry, err := s.find(ctx, tenantID, strings.TrimSpace(key), strings.ToLower(strings.TrimSpace(environment)))
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.findVersion(ctx, entry, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	// Rules may have changed since the version was written
+	if err := s.rules.Check(ctx, tenantID, entry.Key, "value", target.Value); err != nil {
+		return nil, err
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		reason = fmt.Sprintf("Rollback to version %d", target.Version)
+	}
+	write := &domain.ConfigWriteRequest{ValueType: target.ValueType, Value: target.Value, Reason: reason}
+	if err := s.write(ctx, entry, author, write, target.Version); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Config rolled back",
+		zap.String("tenant_id", tenantID),
+		zap.String("key", entry.Key),
+		zap.String("environment", entry.Environment),
+		zap.Int("to_version", target.Version),
+		zap.Int("version", entry.ActiveVersion),
+	)
+	entry.MaskSecret()
+	return entry, nil
+}
+
+// Delete deletes a config entry and all of its versions
+func (s *ConfigService) Delete(ctx context.Context, tenantID, key, environment string) error {
+	entry, err := s.find(ctx, tenantID, strings.TrimSpace(key), strings.ToLower(strings.TrimSpace(environment)))
+	if err != nil {
+		return err
+	}
+
+	if err := s.versions.DeleteByConfig(ctx, entry.ID.Hex()); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, entry.ID.Hex()); err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, configKey(tenantID, entry.Key, entry.Environment))
+	s.logger.Info("Config deleted",
+		zap.String("tenant_id", tenantID),
+		zap.String("key", entry.Key),
+		zap.String("environment", entry.Environment),
+	)
+	return nil
+}
+
+// write stores the value of a validated request as the next version of an entry and activates
+// it unless the request is a draft; rollbackOf is the version a rollback restores, or 0.
+// Concurrent writers racing for the same version number are told to retry by the unique
+// version index.
+func (s *ConfigService) write(ctx context.Context, entry *domain.ConfigEntry, author string, req *domain.ConfigWriteRequest, rollbackOf int) error {
+	version := &domain.ConfigVersion{
+		ConfigID:    entry.ID,
+		TenantID:    entry.TenantID,
+		Key:         entry.Key,
+		Environment: entry.Environment,
+		Version:     entry.LatestVersion + 1,
+		ValueType:   req.ValueType,
+		Value:       req.Value,
+		Reason:      strings.TrimSpace(req.Reason),
+		RollbackOf:  rollbackOf,
+		CreatedBy:   author,
+	}
+	if err := s.versions.Create(ctx, version); err != nil {
+		if stderrors.Is(err, repository.ErrDuplicateKey) {
+			return errors.Conflict(fmt.Sprintf("Config '%s' was changed concurrently; retry the write", configName(entry.Key, entry.Environment)))
+		}
+		return err
+	}
+
+	entry.LatestVersion = version.Version
+	if !req.Draft {
+		entry.ActiveVersion = version.Version
+		entry.ValueType, entry.Value = version.ValueType, version.Value
+	}
+	entry.UpdatedBy = author
+	return s.save(ctx, entry)
+}
+
+// discard removes an entry whose first version could not be written, along with any version
+// stored before the failure
+func (s *ConfigService) discard(ctx context.Context, entry *domain.ConfigEntry) {
+	if err := s.versions.DeleteByConfig(ctx, entry.ID.Hex()); err != nil {
+		s.logger.Error("Failed to discard config versions", zap.String("key", entry.Key), zap.Error(err))
+	}
+	if err := s.repo.Delete(ctx, entry.ID.Hex()); err != nil {
+		s.logger.Error("Failed to discard config", zap.String("key", entry.Key), zap.Error(err))
+	}
+}
+
+// save persists an entry and drops its cached copy
+func (s *ConfigService) save(ctx context.Context, entry *domain.ConfigEntry) error {
+	if err := s.repo.Update(ctx, entry); err != nil {
+		if stderrors.Is(err, repository.ErrNotFound) {
+			return errors.NotFound("Config not found")
+		}
+		return err
+	}
+	s.cache.invalidate(ctx, configKey(entry.TenantID, entry.Key, entry.Environment))
+	return nil
+}
+
+// find loads a config entry, reporting a missing one as not found
+func (s *ConfigService) find(ctx context.Context, tenantID, key, environment string) (*domain.ConfigEntry, error) {
+	if err := domain.ValidateConfigKey(key); err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+	if err := domain.ValidateConfigEnvironment(environment); err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+
+	entry, err := s.repo.FindByKey(ctx, tenantID, key, environment)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, errors.NotFound("Config not found")
+	}
+	return entry, nil
+}
+
+// findVersion loads a version of an entry, reporting a missing one as not found
+func (s *ConfigService) findVersion(ctx context.Context, entry *domain.ConfigEntry, number int) (*domain.ConfigVersion, error) {
+	if number < 1 {
+		return nil, errors.BadRequest("version must be a positive number")
+	}
+
+	version, err := s.versions.Find(ctx, entry.ID.Hex(), number)
+	if err != nil {
+		return nil, err
+	}
+	if version == nil {
+		return nil, errors.NotFound("Config version not found")
+	}
+	return version, nil
+}
+
+// diffVersions compares the values of two versions stored under configID
+func diffVersions(ctx context.Context, versions repository.ConfigVersionRepository, configID string, from, to int) (*domain.ConfigDiff, error) {
+	if from < 1 || to < 1 {
+		return nil, errors.BadRequest("from and to must be positive version numbers")
+	}
+
+	load := func(number int) (*domain.ConfigVersion, error) {
+		version, err := versions.Find(ctx, configID, number)
+		if err != nil {
+			return nil, err
+		}
+		if version == nil {
+			return nil, errors.NotFound(fmt.Sprintf("Version %d not found", number))
+		}
+		return version, nil
+	}
+	older, err := load(from)
+	if err != nil {
+		return nil, err
+	}
+	newer, err := load(to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ConfigDiff{
+		From:     from,
+		To:       to,
+		FromType: older.ValueType,
+		ToType:   newer.ValueType,
+		Changes:  domain.DiffConfigValues(older.Value, newer.Value),
+	}, nil
+}
+
+// configName names a key in an environment for messages
+func configName(key, environment string) string {
+	if environment == "" {
+		return key
+	}
+	return key + "@" + environment
+}
+
+func configKey(tenantID, key, environment string) string {
+	if environment == "" {
+		environment = "*"
+	}
+	return cacheKey("configs", tenantScope(tenantID), environment, key)
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+)
+
+func newTestConfigService(t *testing.T) (*ConfigService, *memoryCache) {
+	cache := newMemoryCache()
+	return NewConfigService(repository.NewMemoryConfigRepository(), repository.NewMemoryConfigVersionRepository(), repository.NewMemoryConfigOverrideRepository(),
+		NewConfigRuleService(repository.NewMemoryConfigRuleRepository(), cache, newTestLogger(t)), cache, newTestLogger(t)), cache
+}
+
+func TestConfigService_Versions(t *testing.T) {
+	ctx := context.Background()
+	svc, cache := newTestConfigService(t)
+
+	entry, err := svc.Create(ctx, "tenant-1", "alice", &domain.ConfigWriteRequest{Key: "api.rate_limit", Environment: "Production", Value: 100.0})
+	require.NoError(t, err)
+	assert.Equal(t, "production", entry.Environment)
+	assert.Equal(t, domain.ConfigTypeInt, entry.ValueType)
+	assert.Equal(t, int64(100), entry.Value)
+	assert.Equal(t, 1, entry.ActiveVersion)
+	assert.Equal(t, "alice", entry.CreatedBy)
+
+	_, err = svc.Create(ctx, "tenant-1", "alice", &domain.ConfigWriteRequest{Key: "api.rate_limit", Environment: "production", Value: 5.0})
+	assertStatus(t, err, http.StatusConflict)
+	_, err = svc.Create(ctx, "tenant-1", "alice", &domain.ConfigWriteRequest{Key: "db timeout", Value: "5s"})
+	assertStatus(t, err, http.StatusBadRequest)
+	_, err = svc.Create(ctx, "tenant-1", "alice", &domain.ConfigWriteRequest{Key: "db.timeout", ValueType: "duration", Value: "soon"})
+	assertStatus(t, err, http.StatusBadRequest)
+
+	found, err := svc.Get(ctx, "tenant-1", "api.rate_limit", "production", false)
+	require.NoError(t, err)
+	assert.True(t, cache.has(configKey("tenant-1", "api.rate_limit", "production")))
+	found, err = svc.Get(ctx, "tenant-1", "api.rate_limit", "production", false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), found.Value, "cached values keep their type")
+
+	// Updates keep the type of the entry and activate the new version
+	_, err = svc.Update(ctx, "tenant-1", "bob", &domain.ConfigWriteRequest{Key: "api.rate_limit", Environment: "production", Value: 2.5})
+	assertStatus(t, err, http.StatusBadRequest)
+	entry, err = svc.Update(ctx, "tenant-1", "bob", &domain.ConfigWriteRequest{Key: "api.rate_limit", Environment: "production", Value: 200.0})
+	require.NoError(t, err)
+	assert.Equal(t, 2, entry.ActiveVersion)
+	assert.Equal(t, "bob", entry.UpdatedBy)
+	assert.False(t, cache.has(configKey("tenant-1", "api.rate_limit", "production")))
+
+	// Drafts are stored without being activated
+	entry, err = svc.Update(ctx, "tenant-1", "bob", &domain.ConfigWriteRequest{Key: "api.rate_limit", Environment: "production", Value: 300.0, Draft: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, entry.ActiveVersion)
+	assert.Equal(t, 3, entry.LatestVersion)
+	assert.Equal(t, int64(200), entry.Value)
+
+	version, err := svc.GetVersion(ctx, "tenant-1", "api.rate_limit", "production", 3, false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), version.Value)
+	assert.Equal(t, "bob", version.CreatedBy)
+
+	entry, err = svc.Activate(ctx, "tenant-1", "carol", "api.rate_limit", "production", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, entry.ActiveVersion)
+	assert.Equal(t, 3, entry.LatestVersion)
+	assert.Equal(t, int64(100), entry.Value)
+
+	_, err = svc.Activate(ctx, "tenant-1", "carol", "api.rate_limit", "production", 9)
+	assertStatus(t, err, http.StatusNotFound)
+	_, err = svc.GetVersion(ctx, "tenant-1", "api.rate_limit", "production", 0, false)
+	assertStatus(t, err, http.StatusBadRequest)
+	_, err = svc.Update(ctx, "tenant-1", "bob", &domain.ConfigWriteRequest{Key: "api.rate_limit", Environment: "staging", Value: 1.0})
+	assertStatus(t, err, http.StatusNotFound)
+}
+
+func TestConfigService_Scopes(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestConfigService(t)
+
+	pool := map[string]interface{}{"size": 10.0, "hosts": []interface{}{"db-1", "db-2"}}
+	for _, write := range []struct {
+		tenantID, environment string
+	}{{"", ""}, {"tenant-1", ""}, {"tenant-1", "production"}, {"tenant-2", "production"}} {
+		_, err := svc.Create(ctx, write.tenantID, "alice", &domain.ConfigWriteRequest{Key: "db.pool", Environment: write.environment, Value: pool})
+		require.NoError(t, err)
+	}
+	_, err := svc.Create(ctx, "tenant-1", "alice", &domain.ConfigWriteRequest{Key: "api.timeout", Environment: "production", ValueType: "duration", Value: "5s"})
+	require.NoError(t, err)
+
+	entry, err := svc.Get(ctx, "", "db.pool", "", false)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ConfigTypeJSON, entry.ValueType)
+	assert.Equal(t, pool, entry.Value)
+
+	entries, total, err := svc.List(ctx, domain.ConfigQuery{TenantID: "tenant-1", Environment: "production"}, 1, 10, false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, "api.timeout", entries[0].Key)
+
+	_, total, err = svc.List(ctx, domain.ConfigQuery{TenantID: "tenant-1", Prefix: "db."}, 1, 10, false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	require.NoError(t, svc.Delete(ctx, "tenant-1", "db.pool", "production"))
+	_, err = svc.Get(ctx, "tenant-1", "db.pool", "production", false)
+	assertStatus(t, err, http.StatusNotFound)
+	_, err = svc.Get(ctx, "tenant-2", "db.pool", "production", false)
+	assert.NoError(t, err)
+	assertStatus(t, svc.Delete(ctx, "tenant-1", "db.pool", "production"), http.StatusNotFound)
+}
+
+func TestConfigService_HistoryDiffRollback(t *testing.T) {
+	ctx := context.Background()
+	svc, cache := newTestConfigService(t)
+
+	_, err := svc.Create(ctx, "tenant-1", "alice", &domain.ConfigWriteRequest{
+		Key: "db.pool", Value: map[string]interface{}{"size": 5.0, "idle": "30s"}, Reason: "initial pool",
+	})
+	require.NoError(t, err)
+	_, err = svc.Update(ctx, "tenant-1", "bob", &domain.ConfigWriteRequest{
+		Key: "db.pool", Value: map[string]interface{}{"size": 20.0}, Reason: "  more load ",
+	})
+	require.NoError(t, err)
+
+	versions, total, err := svc.History(ctx, "tenant-1", "db.pool", "", 1, 30, false)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, 2, versions[0].Version)
+	assert.Equal(t, "bob", versions[0].CreatedBy)
+	assert.Equal(t, "more load", versions[0].Reason)
+	assert.True(t, versions[0].Active)
+	assert.False(t, versions[1].Active)
+
+	diff, err := svc.Diff(ctx, "tenant-1", "db.pool", "", 1, 2, false)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ConfigChange{
+		{Path: "/idle", Op: domain.ConfigChangeRemoved, Old: "30s"},
+		{Path: "/size", Op: domain.ConfigChangeChanged, Old: 5.0, New: 20.0},
+	}, diff.Changes)
+	_, err = svc.Diff(ctx, "tenant-1", "db.pool", "", 1, 7, false)
+	assertStatus(t, err, http.StatusNotFound)
+	_, err = svc.Diff(ctx, "tenant-1", "db.pool", "", 0, 2, false)
+	assertStatus(t, err, http.StatusBadRequest)
+
+	_, err = svc.Get(ctx, "tenant-1", "db.pool", "", false)
+	require.NoError(t, err)
+
+	// A rollback writes a new version equal to the old one rather than moving back
+	entry, err := svc.Rollback(ctx, "tenant-1", "carol", "db.pool", "", &domain.ConfigRollbackRequest{Version: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 3, entry.ActiveVersion)
+	assert.Equal(t, 3, entry.LatestVersion)
+	assert.Equal(t, map[string]interface{}{"size": 5.0, "idle": "30s"}, entry.Value)
+	assert.False(t, cache.has(configKey("tenant-1", "db.pool", "")))
+
+	version, err := svc.GetVersion(ctx, "tenant-1", "db.pool", "", 3, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, version.RollbackOf)
+	assert.Equal(t, "Rollback to version 1", version.Reason)
+	assert.Equal(t, "carol", version.CreatedBy)
+
+	diff, err = svc.Diff(ctx, "tenant-1", "db.pool", "", 1, 3, false)
+	require.NoError(t, err)
+	assert.Empty(t, diff.Changes)
+
+	_, err = svc.Rollback(ctx, "tenant-1", "carol", "db.pool", "", &domain.ConfigRollbackRequest{Version: 9})
+	assertStatus(t, err, http.StatusNotFound)
+	_, _, err = svc.History(ctx, "tenant-2", "db.pool", "", 1, 30, false)
+	assertStatus(t, err, http.StatusNotFound)
+}
+
+func TestConfigService_MasksSecrets(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestConfigService(t)
+	secret := true
+
+	entry, err := svc.Create(ctx, "tenant-1", "alice", &domain.ConfigWriteRequest{Key: "smtp.password", Value: "hunter2", Secret: &secret})
+	require.NoError(t, err)
+	assert.Equal(t, domain.MaskedConfigValue, entry.Value, "writes do not echo secrets")
+	entry, err = svc.Update(ctx, "tenant-1", "bob", &domain.ConfigWriteRequest{Key: "smtp.password", Value: "correct horse"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.MaskedConfigValue, entry.Value)
+	_, err = svc.Create(ctx, "tenant-1", "alice", &domain.ConfigWriteRequest{Key: "smtp.host", Value: "mail.example.com"})
+	require.NoError(t, err)
+
+	entry, err = svc.Get(ctx, "tenant-1", "smtp.password", "", false)
+	require.NoError(t, err)
+	assert.Equal(t, domain.MaskedConfigValue, entry.Value)
+	entry, err = svc.Get(ctx, "tenant-1", "smtp.password", "", true)
+	require.NoError(t, err)
+	assert.Equal(t, "correct horse", entry.Value, "masking leaves the cached entry intact")
+
+	entries, _, err := svc.List(ctx, domain.ConfigQuery{TenantID: "tenant-1", Prefix: "smtp."}, 1, 10, false)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "mail.example.com", entries[0].Value, "plain entries are not masked")
+	assert.Equal(t, domain.MaskedConfigValue, entries[1].Value)
+	entries, _, err = svc.List(ctx, domain.ConfigQuery{TenantID: "tenant-1", Prefix: "smtp."}, 1, 10, true)
+	require.NoError(t, err)
+	assert.Equal(t, "correct horse", entries[1].Value)
+
+	versions, _, err := svc.History(ctx, "tenant-1", "smtp.password", "", 1, 30, false)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, domain.MaskedConfigValue, versions[0].Value)
+	assert.Equal(t, domain.MaskedConfigValue, versions[1].Value)
+	version, err := svc.GetVersion(ctx, "tenant-1", "smtp.password", "", 1, false)
+	require.NoError(t, err)
+	assert.Equal(t, domain.MaskedConfigValue, version.Value)
+	version, err = svc.GetVersion(ctx, "tenant-1", "smtp.password", "", 1, true)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", version.Value)
+
+	diff, err := svc.Diff(ctx, "tenant-1", "smtp.password", "", 1, 2, false)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ConfigChange{{Path: "", Op: domain.ConfigChangeChanged, Old: domain.MaskedConfigValue, New: domain.MaskedConfigValue}}, diff.Changes)
+	diff, err = svc.Diff(ctx, "tenant-1", "smtp.password", "", 1, 2, true)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", diff.Changes[0].Old)
+}
+
+// failingVersionRepository fails to store versions while fail is set
+type failingVersionRepository struct {
+	repository.ConfigVersionRepository
+	fail *bool
+}
+
+func (r failingVersionRepository) Create(ctx context.Context, version *domain.ConfigVersion) error {
+	if *r.fail {
+		return stderrors.New("write failed")
+	}
+	return r.ConfigVersionRepository.Create(ctx, version)
+}
+
+func TestConfigService_CreateRemovesEntryWithoutVersion(t *testing.T) {
+	ctx := context.Background()
+	cache := newMemoryCache()
+	fail := true
+	svc := NewConfigService(repository.NewMemoryConfigRepository(),
+		failingVersionRepository{ConfigVersionRepository: repository.NewMemoryConfigVersionRepository(), fail: &fail},
+		repository.NewMemoryConfigOverrideRepository(), NewConfigRuleService(repository.NewMemoryConfigRuleRepository(), cache, newTestLogger(t)),
+		cache, newTestLogger(t))
+
+	req := func() *domain.ConfigWriteRequest {
+		return &domain.ConfigWriteRequest{Key: "api.rate_limit", Value: 100.0}
+	}
+	_, err := svc.Create(ctx, "tenant-1", "alice", req())
+	require.Error(t, err)
+	_, err = svc.Get(ctx, "tenant-1", "api.rate_limit", "", false)
+	assertStatus(t, err, http.StatusNotFound)
+
+	// the create can be retried once versions can be written again
+	fail = false
+	entry, err := svc.Create(ctx, "tenant-1", "alice", req())
+	require.NoError(t, err)
+	assert.Equal(t, 1, entry.ActiveVersion)
+	assert.Equal(t, int64(100), entry.Value)
+}
+
+func TestConfigService_Resolve(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestConfigService(t)
+	secret := true
+
+	write := func(tenantID, key, environment string, value interface{}) {
+		t.Helper()
+		_, err := svc.Create(ctx, tenantID, "alice", &domain.ConfigWriteRequest{Key: key, Environment: environment, Value: value})
+		require.NoError(t, err)
+	}
+	write("", "db.timeout", "", "30s")
+	write("", "db.timeout", "production", "20s")
+	write("tenant-1", "db.timeout", "", "10s")
+	write("tenant-1", "db.timeout", "production", "5s")
+	write("", "db.pool", "", 10.0)
+	write("tenant-1", "db.pool", "staging", 50.0)
+	write("tenant-2", "db.pool", "production", 99.0)
+	write("tenant-1", "api.limit", "production", 100.0)
+	_, err := svc.Create(ctx, "", "alice", &domain.ConfigWriteRequest{Key: "db.password", Environment: "production", Value: "global", Secret: &secret})
+	require.NoError(t, err)
+	_, err = svc.Create(ctx, "tenant-1", "alice", &domain.ConfigWriteRequest{Key: "db.password", Environment: "production", Value: "plain"})
+	require.NoError(t, err)
+	_, err = svc.Create(ctx, "tenant-1", "alice", &domain.ConfigWriteRequest{Key: "db.draft", Environment: "production", Value: "x", Draft: true})
+	require.NoError(t, err)
+
+	resolved, err := svc.Resolve(ctx, "tenant-1", &domain.ConfigResolveQuery{Environment: "Production", Prefix: "db."}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "production", resolved.Environment)
+	require.Len(t, resolved.Values, 3, "drafts, other prefixes, environments and tenants are left out")
+
+	password, pool, timeout := resolved.Values[0], resolved.Values[1], resolved.Values[2]
+	assert.Equal(t, "db.pool", pool.Key)
+	assert.Equal(t, int64(10), pool.Value)
+	assert.Equal(t, domain.ConfigLayerDefault, pool.Layer)
+	assert.Empty(t, pool.Shadowed)
+
+	// Secrets win over plain environment configs, even those of the tenant, and are masked
+	// along with the values they shadow unless revealed
+	assert.Equal(t, domain.MaskedConfigValue, password.Value)
+	assert.Equal(t, domain.ConfigLayerSecret, password.Layer)
+	require.Len(t, password.Shadowed, 1)
+	assert.Equal(t, domain.MaskedConfigValue, password.Shadowed[0].Value)
+	revealed, err := svc.Resolve(ctx, "tenant-1", &domain.ConfigResolveQuery{Environment: "production", Prefix: "db.password"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, "global", revealed.Values[0].Value)
+	assert.Equal(t, "plain", revealed.Values[0].Shadowed[0].Value)
+
+	assert.Equal(t, "5s", timeout.Value)
+	assert.Equal(t, domain.ConfigLayerEnvironment, timeout.Layer)
+	assert.Equal(t, "tenant-1", timeout.TenantID)
+	assert.Equal(t, 1, timeout.Version)
+	require.Len(t, timeout.Shadowed, 3)
+	assert.Equal(t, []string{domain.ConfigLayerTenant, domain.ConfigLayerGlobal, domain.ConfigLayerDefault},
+		[]string{timeout.Shadowed[0].Layer, timeout.Shadowed[1].Layer, timeout.Shadowed[2].Layer})
+
+	// Runtime overrides win over everything until they are removed
+	override, err := svc.SetOverride(ctx, "tenant-1", "bob", "db.timeout", &domain.ConfigOverrideRequest{Environment: "production", Value: "1s", Reason: "incident", TTL: "15m"})
+	require.NoError(t, err)
+	require.NotNil(t, override.ExpiresAt)
+	_, err = svc.SetOverride(ctx, "tenant-1", "bob", "db.timeout", &domain.ConfigOverrideRequest{Value: "1s", TTL: "-1m"})
+	assertStatus(t, err, http.StatusBadRequest)
+
+	resolved, err = svc.Resolve(ctx, "tenant-1", &domain.ConfigResolveQuery{Environment: "production", Prefix: "db.timeout"}, false)
+	require.NoError(t, err)
+	require.Len(t, resolved.Values, 1)
+	assert.Equal(t, "1s", resolved.Values[0].Value)
+	assert.Equal(t, domain.ConfigLayerOverride, resolved.Values[0].Layer)
+	assert.Equal(t, "5s", resolved.Values[0].Shadowed[0].Value)
+
+	require.NoError(t, svc.DeleteOverride(ctx, "tenant-1", "db.timeout", "production"))
+	assertStatus(t, svc.DeleteOverride(ctx, "tenant-1", "db.timeout", "production"), http.StatusNotFound)
+
+	// Without an environment only the values for every environment apply
+	resolved, err = svc.Resolve(ctx, "tenant-1", &domain.ConfigResolveQuery{Prefix: "db.timeout"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "10s", resolved.Values[0].Value)
+	assert.Equal(t, domain.ConfigLayerTenant, resolved.Values[0].Layer)
+
+	// Without a tenant only global configs apply
+	resolved, err = svc.Resolve(ctx, "", &domain.ConfigResolveQuery{Environment: "production", Prefix: "db.timeout"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "20s", resolved.Values[0].Value)
+	assert.Equal(t, domain.ConfigLayerGlobal, resolved.Values[0].Layer)
+
+	_, err = svc.Resolve(ctx, "tenant-1", &domain.ConfigResolveQuery{Environment: "prod env"}, false)
+	assertStatus(t, err, http.StatusBadRequest)
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+// CountryService handles country business logic
+type CountryService struct {
+	repo        repository.CountryRepository
+	provinces   repository.ProvinceRepository
+	currencies  repository.CurrencyRepository
+	ethnicities repository.EthnicityRepository
+	cache       cacheStore
+	logger      *logger.Logger
+}
+
+// NewCountryService creates a new country service.
+// Provinces and ethnicities are consulted so a country cannot be deleted while they still reference
+// it, and currencies so that Country.Currency always names an existing currency listing the country.
+func NewCountryService(repo repository.CountryRepository, provinces repository.ProvinceRepository, currencies repository.CurrencyRepository, ethnicities repository.EthnicityRepository, cache Cache, log *logger.Logger) *CountryService {
+	return &CountryService{
+		repo:        repo,
+		provinces:   provinces,
+		currencies:  currencies,
+		ethnicities: ethnicities,
+		cache:       cacheStore{cache: cache, logger: log},
+		logger:      log,
+	}
+}
+
+// Create creates a new country
+func (s *CountryService) Create(ctx context.Context, country *domain.Country) error {
+	country.Code = strings.ToUpper(strings.TrimSpace(country.Code))
+	country.Currency = strings.ToUpper(strings.TrimSpace(country.Currency))
+	if err := country.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	if err := s.requireCurrency(ctx, country.Currency); err != nil {
+		return err
+	}
+
+	existing, err := s.repo.FindByCode(ctx, country.Code)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.Conflict(fmt.Sprintf("Country with code '%s' already exists", country.Code))
+	}
+
+	if country.Status == "" {
+		country.Status = "active"
+	}
+
+	if err := s.repo.Create(ctx, country); err != nil {
+		if stderrors.Is(err, repository.ErrDuplicateKey) {
+			return errors.Conflict(fmt.Sprintf("Country with code '%s' already exists", country.Code))
+		}
+		return err
+	}
+
+	if err := s.relinkCurrency(ctx, country.Code, "", country.Currency); err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, countryListKey())
+	s.logger.Info("Country created", zap.String("code", country.Code))
+	return nil
+}
+
+// GetByCode gets a country by code
+func (s *CountryService) GetByCode(ctx context.Context, code string) (*domain.Country, error) {
+	code = strings.ToUpper(code)
+
+	key := countryKey(code)
+	var cached domain.Country
+	if s.cache.get(ctx, key, &cached) {
+		return &cached, nil
+	}
+
+	country, err := s.repo.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if country == nil {
+		return nil, errors.NotFound("Country not found")
+	}
+
+	s.cache.set(ctx, key, country, masterDataTTL)
+	return country, nil
+}
+
+// List lists active countries with pagination and names localized for l10n
+func (s *CountryService) List(ctx context.Context, l10n domain.Localization, page, perPage int) ([]*domain.Country, int64, error) {
+	return listLocalized(l10n, page, perPage, func(page, perPage int) ([]*domain.Country, int64, error) {
+		return s.list(ctx, page, perPage)
+	})
+}
+
+// list reads a page of active countries, caching the first one
+func (s *CountryService) list(ctx context.Context, page, perPage int) ([]*domain.Country, int64, error) {
+	key := countryListKey()
+	if page == 1 {
+		var cached cachedPage[*domain.Country]
+		if s.cache.get(ctx, key, &cached) && cached.PerPage == perPage {
+			return cached.Items, cached.Total, nil
+		}
+	}
+
+	countries, total, err := s.repo.List(ctx, page, perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if page == 1 {
+		s.cache.set(ctx, key, cachedPage[*domain.Country]{PerPage: perPage, Items: countries, Total: total}, masterDataTTL)
+	}
+	return countries, total, nil
+}
+
+// Update updates the country identified by country.Code
+func (s *CountryService) Update(ctx context.Context, country *domain.Country) error {
+	country.Code = strings.ToUpper(country.Code)
+	country.Currency = strings.ToUpper(strings.TrimSpace(country.Currency))
+
+	existing, err := s.repo.FindByCode(ctx, country.Code)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.NotFound("Country not found")
+	}
+
+	country.ID = existing.ID
+	country.CreatedAt = existing.CreatedAt
+	if country.Status == "" {
+		country.Status = existing.Status
+	}
+
+	if err := country.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	if country.Currency != existing.Currency {
+		if err := s.requireCurrency(ctx, country.Currency); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.Update(ctx, country); err != nil {
+		if stderrors.Is(err, repository.ErrNotFound) {
+			return errors.NotFound("Country not found")
+		}
+		return err
+	}
+
+	if err := s.relinkCurrency(ctx, country.Code, existing.Currency, country.Currency); err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, countryKey(country.Code), countryListKey())
+	return nil
+}
+
+// Delete deletes a country by code
+func (s *CountryService) Delete(ctx context.Context, code string) error {
+	code = strings.ToUpper(code)
+
+	existing, err := s.repo.FindByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.NotFound("Country not found")
+	}
+
+	provinces, err := s.provinces.CountByCountry(ctx, code)
+	if err != nil {
+		return err
+	}
+	if provinces > 0 {
+		return errors.Conflict(fmt.Sprintf("Cannot delete country '%s': it still has %d provinces", code, provinces))
+	}
+	ethnicities, err := s.ethnicities.CountByCountry(ctx, code)
+	if err != nil {
+		return err
+	}
+	if ethnicities > 0 {
+		return errors.Conflict(fmt.Sprintf("Cannot delete country '%s': it still has %d ethnicities", code, ethnicities))
+	}
+
+	if err := s.repo.Delete(ctx, code); err != nil {
+		return err
+	}
+
+	if err := s.relinkCurrency(ctx, code, existing.Currency, ""); err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, countryKey(code), countryListKey())
+	s.logger.Info("Country deleted", zap.String("code", code))
+	return nil
+}
+
+// requireCurrency fails when a currency code is given but no such currency exists
+func (s *CountryService) requireCurrency(ctx context.Context, code string) error {
+	if code == "" {
+		return nil
+	}
+	currency, err := s.currencies.FindByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	if currency == nil {
+		return errors.BadRequest(fmt.Sprintf("Currency '%s' does not exist", code))
+	}
+	return nil
+}
+
+// relinkCurrency moves a country from the countries of its previous currency to those of its new one
+func (s *CountryService) relinkCurrency(ctx context.Context, countryCode, previous, current string) error {
+	if previous == current {
+		return nil
+	}
+
+	stale := []string{currencyListKey()}
+	if previous != "" {
+		if err := unlinkCurrencyCountry(ctx, s.currencies, previous, countryCode); err != nil {
+			return err
+		}
+		stale = append(stale, currencyKey(previous))
+	}
+	if current != "" {
+		if err := linkCurrencyCountry(ctx, s.currencies, current, countryCode); err != nil {
+			return err
+		}
+		stale = append(stale, currencyKey(current))
+	}
+
+	s.cache.invalidate(ctx, stale...)
+	return nil
+}
+
+func countryKey(code string) string {
+	return cacheKey("countries", code)
+}
+
+func countryListKey() string {
+	return cacheKey("countries", "list")
+}
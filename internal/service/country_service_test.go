@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+)
+
+func newTestCountryService(t *testing.T) (*CountryService, *memoryCache) {
+	cache := newMemoryCache()
+	return NewCountryService(repository.NewMemoryCountryRepository(), repository.NewMemoryProvinceRepository(),
+		repository.NewMemoryCurrencyRepository(), repository.NewMemoryEthnicityRepository(), cache, newTestLogger(t)), cache
+}
+
+func TestCountryService_CRUD(t *testing.T) {
+	ctx := context.Background()
+	svc, cache := newTestCountryService(t)
+
+	country := &domain.Country{Code: "vn", Name: map[string]string{"en": "Vietnam"}}
+	require.NoError(t, svc.Create(ctx, country))
+	assert.Equal(t, "VN", country.Code)
+	assert.Equal(t, "active", country.Status)
+
+	err := svc.Create(ctx, &domain.Country{Code: "VN", Name: map[string]string{"en": "Vietnam"}})
+	assertStatus(t, err, http.StatusConflict)
+
+	err = svc.Create(ctx, &domain.Country{Code: "US"})
+	assertStatus(t, err, http.StatusBadRequest)
+
+	found, err := svc.GetByCode(ctx, "VN")
+	require.NoError(t, err)
+	assert.Equal(t, "Vietnam", found.Name["en"])
+	assert.True(t, cache.has(countryKey("VN")))
+
+	update := &domain.Country{Code: "VN", Name: map[string]string{"en": "Vietnam", "vi": "Việt Nam"}}
+	require.NoError(t, svc.Update(ctx, update))
+	assert.Equal(t, country.ID, update.ID)
+	assert.False(t, cache.has(countryKey("VN")))
+
+	found, err = svc.GetByCode(ctx, "VN")
+	require.NoError(t, err)
+	assert.Equal(t, "Việt Nam", found.Name["vi"])
+
+	require.NoError(t, svc.Delete(ctx, "VN"))
+	_, err = svc.GetByCode(ctx, "VN")
+	assertStatus(t, err, http.StatusNotFound)
+
+	err = svc.Delete(ctx, "VN")
+	assertStatus(t, err, http.StatusNotFound)
+}
+
+func TestCountryService_List(t *testing.T) {
+	ctx := context.Background()
+	svc, cache := newTestCountryService(t)
+
+	for _, code := range []string{"VN", "US"} {
+		require.NoError(t, svc.Create(ctx, &domain.Country{Code: code, Name: map[string]string{"en": code}}))
+	}
+
+	countries, total, err := svc.List(ctx, domain.Localization{}, 1, 30)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, countries, 2)
+	assert.Equal(t, "US", countries[0].Code)
+
+	countries, total, err = svc.List(ctx, domain.Localization{}, 1, 30)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, countries, 2)
+	assert.Equal(t, 1, cache.hits)
+}
+
+func TestCountryService_DeleteBlockedByProvinces(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestCountryService(t)
+
+	require.NoError(t, svc.Create(ctx, &domain.Country{Code: "VN", Name: map[string]string{"en": "Vietnam"}}))
+	require.NoError(t, svc.provinces.Create(ctx, &domain.Province{Code: "01", Name: map[string]string{"vi": "Hà Nội"}, CountryCode: "VN"}))
+
+	assertStatus(t, svc.Delete(ctx, "VN"), http.StatusConflict)
+
+	require.NoError(t, svc.provinces.Delete(ctx, "01"))
+	require.NoError(t, svc.Delete(ctx, "VN"))
+}
+
+func TestCountryService_DeleteBlockedByEthnicities(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestCountryService(t)
+
+	require.NoError(t, svc.Create(ctx, &domain.Country{Code: "VN", Name: map[string]string{"en": "Vietnam"}}))
+	ethnicity := &domain.Ethnicity{Code: "01", Name: map[string]string{"vi": "Kinh"}, CountryCode: "VN", Status: "inactive"}
+	require.NoError(t, svc.ethnicities.Create(ctx, ethnicity))
+
+	assertStatus(t, svc.Delete(ctx, "VN"), http.StatusConflict)
+
+	require.NoError(t, svc.ethnicities.Delete(ctx, ethnicity.ID.Hex()))
+	require.NoError(t, svc.Delete(ctx, "VN"))
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/pkg/money"
+)
+
+// Format writes an amount of a currency the way the locale does, rounded to the currency's
+// decimal digits. Unknown locales fall back to money.DefaultLocale.
+func (s *CurrencyService) Format(ctx context.Context, code string, amount float64, locale string) (*domain.FormattedAmount, error) {
+	currency, err := s.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	conventions, _ := money.LookupLocale(locale)
+	return &domain.FormattedAmount{
+		Currency:  currency.Code,
+		Locale:    conventions.Tag,
+		Amount:    currency.Round(amount),
+		Formatted: conventions.Format(amount, currency.Money()),
+	}, nil
+}
+
+// Parse reads an amount of a currency written the way the locale does and returns it along
+// with its canonical formatting
+func (s *CurrencyService) Parse(ctx context.Context, code, text, locale string) (*domain.FormattedAmount, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, errors.BadRequest("text is required")
+	}
+
+	currency, err := s.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	conventions, _ := money.LookupLocale(locale)
+	amount, err := conventions.Parse(text, currency.Money())
+	if err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+
+	return &domain.FormattedAmount{
+		Currency:  currency.Code,
+		Locale:    conventions.Tag,
+		Amount:    amount,
+		Formatted: conventions.Format(amount, currency.Money()),
+	}, nil
+}
@@ -0,0 +1,357 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+// CurrencyService handles currency business logic.
+// Currency.Countries and Country.Currency describe the same link from both ends; every write
+// through this service or CountryService updates the other end so they stay consistent.
+type CurrencyService struct {
+	repo      repository.CurrencyRepository
+	countries repository.CountryRepository
+	packages  repository.ServicePackageRepository
+	cache     cacheStore
+	logger    *logger.Logger
+}
+
+// NewCurrencyService creates a new currency service.
+// Service packages are consulted so a currency cannot be deleted while a package is priced in it.
+func NewCurrencyService(repo repository.CurrencyRepository, countries repository.CountryRepository, packages repository.ServicePackageRepository, cache Cache, log *logger.Logger) *CurrencyService {
+	return &CurrencyService{
+		repo:      repo,
+		countries: countries,
+		packages:  packages,
+		cache:     cacheStore{cache: cache, logger: log},
+		logger:    log,
+	}
+}
+
+// Create creates a new currency and points each of its countries at it
+func (s *CurrencyService) Create(ctx context.Context, currency *domain.Currency) error {
+	normalizeCurrency(currency)
+	if err := currency.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	existing, err := s.repo.FindByCode(ctx, currency.Code)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.Conflict(fmt.Sprintf("Currency with code '%s' already exists", currency.Code))
+	}
+
+	if currency.Status == "" {
+		currency.Status = "active"
+	}
+
+	countries, err := s.requireCountries(ctx, currency.Countries)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Create(ctx, currency); err != nil {
+		if stderrors.Is(err, repository.ErrDuplicateKey) {
+			return errors.Conflict(fmt.Sprintf("Currency with code '%s' already exists", currency.Code))
+		}
+		return err
+	}
+
+	if err := s.linkCountries(ctx, currency.Code, countries); err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, currencyListKey())
+	s.logger.Info("Currency created", zap.String("code", currency.Code))
+	return nil
+}
+
+// GetByCode gets a currency by code
+func (s *CurrencyService) GetByCode(ctx context.Context, code string) (*domain.Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	key := currencyKey(code)
+	var cached domain.Currency
+	if s.cache.get(ctx, key, &cached) {
+		return &cached, nil
+	}
+
+	currency, err := s.repo.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if currency == nil {
+		return nil, errors.NotFound("Currency not found")
+	}
+
+	s.cache.set(ctx, key, currency, masterDataTTL)
+	return currency, nil
+}
+
+// List lists active currencies with pagination and names localized for l10n
+func (s *CurrencyService) List(ctx context.Context, l10n domain.Localization, page, perPage int) ([]*domain.Currency, int64, error) {
+	return listLocalized(l10n, page, perPage, func(page, perPage int) ([]*domain.Currency, int64, error) {
+		return s.list(ctx, page, perPage)
+	})
+}
+
+// list reads a page of active currencies, caching the first one
+func (s *CurrencyService) list(ctx context.Context, page, perPage int) ([]*domain.Currency, int64, error) {
+	key := currencyListKey()
+	if page == 1 {
+		var cached cachedPage[*domain.Currency]
+		if s.cache.get(ctx, key, &cached) && cached.PerPage == perPage {
+			return cached.Items, cached.Total, nil
+		}
+	}
+
+	currencies, total, err := s.repo.List(ctx, page, perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if page == 1 {
+		s.cache.set(ctx, key, cachedPage[*domain.Currency]{PerPage: perPage, Items: currencies, Total: total}, masterDataTTL)
+	}
+	return currencies, total, nil
+}
+
+// Update updates the currency identified by currency.Code. When countries is omitted the stored
+// list is kept; otherwise added countries are pointed at the currency and removed ones that
+// still use it are cleared.
+func (s *CurrencyService) Update(ctx context.Context, currency *domain.Currency) error {
+	normalizeCurrency(currency)
+
+	existing, err := s.repo.FindByCode(ctx, currency.Code)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.NotFound("Currency not found")
+	}
+
+	currency.ID = existing.ID
+	currency.CreatedAt = existing.CreatedAt
+	if currency.Status == "" {
+		currency.Status = existing.Status
+	}
+	if currency.Countries == nil {
+		currency.Countries = existing.Countries
+	}
+
+	if err := currency.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	var added, removed []string
+	for _, code := range currency.Countries {
+		if !slices.Contains(existing.Countries, code) {
+			added = append(added, code)
+		}
+	}
+	for _, code := range existing.Countries {
+		if !slices.Contains(currency.Countries, code) {
+			removed = append(removed, code)
+		}
+	}
+
+	countries, err := s.requireCountries(ctx, added)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Update(ctx, currency); err != nil {
+		if stderrors.Is(err, repository.ErrNotFound) {
+			return errors.NotFound("Currency not found")
+		}
+		return err
+	}
+
+	if err := s.linkCountries(ctx, currency.Code, countries); err != nil {
+		return err
+	}
+	if err := s.unlinkCountries(ctx, currency.Code, removed); err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, currencyKey(currency.Code), currencyListKey())
+	return nil
+}
+
+// Delete deletes a currency by code unless a country or a service package still uses it
+func (s *CurrencyService) Delete(ctx context.Context, code string) error {
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	existing, err := s.repo.FindByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.NotFound("Currency not found")
+	}
+
+	countries, err := s.countries.CountByCurrency(ctx, code)
+	if err != nil {
+		return err
+	}
+	if countries > 0 {
+		return errors.Conflict(fmt.Sprintf("Cannot delete currency '%s': it is still used by %d countries", code, countries))
+	}
+
+	packages, err := s.packages.CountByCurrency(ctx, code)
+	if err != nil {
+		return err
+	}
+	if packages > 0 {
+		return errors.Conflict(fmt.Sprintf("Cannot delete currency '%s': it is still used by %d service packages", code, packages))
+	}
+
+	if err := s.repo.Delete(ctx, code); err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, currencyKey(code), currencyListKey())
+	s.logger.Info("Currency deleted", zap.String("code", code))
+	return nil
+}
+
+// requireCountries loads the given countries, failing when any of them does not exist
+func (s *CurrencyService) requireCountries(ctx context.Context, codes []string) ([]*domain.Country, error) {
+	if len(codes) == 0 {
+		return nil, nil
+	}
+
+	countries, err := s.countries.FindByCodes(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+	if len(countries) != len(codes) {
+		found := make(map[string]bool, len(countries))
+		for _, country := range countries {
+			found[country.Code] = true
+		}
+		var missing []string
+		for _, code := range codes {
+			if !found[code] {
+				missing = append(missing, code)
+			}
+		}
+		return nil, errors.BadRequest(fmt.Sprintf("Unknown countries: %s", strings.Join(missing, ", ")))
+	}
+	return countries, nil
+}
+
+// linkCountries points the countries at the currency, taking them off the currency they used before
+func (s *CurrencyService) linkCountries(ctx context.Context, code string, countries []*domain.Country) error {
+	var stale []string
+	for _, country := range countries {
+		if country.Currency == code {
+			continue
+		}
+		if country.Currency != "" {
+			if err := unlinkCurrencyCountry(ctx, s.repo, country.Currency, country.Code); err != nil {
+				return err
+			}
+			stale = append(stale, currencyKey(country.Currency))
+		}
+
+		country.Currency = code
+		if err := s.countries.Update(ctx, country); err != nil {
+			return err
+		}
+		stale = append(stale, countryKey(country.Code))
+	}
+
+	if len(stale) > 0 {
+		s.cache.invalidate(ctx, append(stale, countryListKey())...)
+	}
+	return nil
+}
+
+// unlinkCountries clears the currency of the countries that still use it
+func (s *CurrencyService) unlinkCountries(ctx context.Context, code string, codes []string) error {
+	if len(codes) == 0 {
+		return nil
+	}
+
+	countries, err := s.countries.FindByCodes(ctx, codes)
+	if err != nil {
+		return err
+	}
+
+	var stale []string
+	for _, country := range countries {
+		if country.Currency != code {
+			continue
+		}
+		country.Currency = ""
+		if err := s.countries.Update(ctx, country); err != nil {
+			return err
+		}
+		stale = append(stale, countryKey(country.Code))
+	}
+
+	if len(stale) > 0 {
+		s.cache.invalidate(ctx, append(stale, countryListKey())...)
+	}
+	return nil
+}
+
+// linkCurrencyCountry adds a country to the countries of a currency
+func linkCurrencyCountry(ctx context.Context, currencies repository.CurrencyRepository, code, countryCode string) error {
+	currency, err := currencies.FindByCode(ctx, code)
+	if err != nil || currency == nil || slices.Contains(currency.Countries, countryCode) {
+		return err
+	}
+
+	currency.Countries = append(currency.Countries, countryCode)
+	slices.Sort(currency.Countries)
+	return currencies.Update(ctx, currency)
+}
+
+// unlinkCurrencyCountry removes a country from the countries of a currency
+func unlinkCurrencyCountry(ctx context.Context, currencies repository.CurrencyRepository, code, countryCode string) error {
+	currency, err := currencies.FindByCode(ctx, code)
+	if err != nil || currency == nil || !slices.Contains(currency.Countries, countryCode) {
+		return err
+	}
+
+	currency.Countries = slices.DeleteFunc(currency.Countries, func(c string) bool { return c == countryCode })
+	return currencies.Update(ctx, currency)
+}
+
+// normalizeCurrency upper-cases the codes of a currency and dedupes and sorts its countries
+func normalizeCurrency(currency *domain.Currency) {
+	currency.Code = strings.ToUpper(strings.TrimSpace(currency.Code))
+	if currency.Countries == nil {
+		return
+	}
+
+	countries := make([]string, 0, len(currency.Countries))
+	for _, code := range currency.Countries {
+		if code = strings.ToUpper(strings.TrimSpace(code)); code != "" && !slices.Contains(countries, code) {
+			countries = append(countries, code)
+		}
+	}
+	slices.Sort(countries)
+	currency.Countries = countries
+}
+
+func currencyKey(code string) string {
+	return cacheKey("currencies", code)
+}
+
+func currencyListKey() string {
+	return cacheKey("currencies", "list")
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+)
+
+type currencyFixture struct {
+	currencies *CurrencyService
+	countries  *CountryService
+	packages   repository.ServicePackageRepository
+	cache      *memoryCache
+}
+
+// newTestCurrencyService wires the currency and country services over shared repositories so
+// links written through either of them can be observed from the other
+func newTestCurrencyService(t *testing.T) *currencyFixture {
+	cache := newMemoryCache()
+	countryRepo := repository.NewMemoryCountryRepository()
+	currencyRepo := repository.NewMemoryCurrencyRepository()
+	packageRepo := repository.NewMemoryServicePackageRepository()
+	log := newTestLogger(t)
+
+	return &currencyFixture{
+		currencies: NewCurrencyService(currencyRepo, countryRepo, packageRepo, cache, log),
+		countries:  NewCountryService(countryRepo, repository.NewMemoryProvinceRepository(), currencyRepo, repository.NewMemoryEthnicityRepository(), cache, log),
+		packages:   packageRepo,
+		cache:      cache,
+	}
+}
+
+func (f *currencyFixture) countryCurrency(t *testing.T, code string) string {
+	country, err := f.countries.GetByCode(context.Background(), code)
+	require.NoError(t, err)
+	return country.Currency
+}
+
+func (f *currencyFixture) currencyCountries(t *testing.T, code string) []string {
+	currency, err := f.currencies.GetByCode(context.Background(), code)
+	require.NoError(t, err)
+	return currency.Countries
+}
+
+func TestCurrencyService_CRUD(t *testing.T) {
+	ctx := context.Background()
+	f := newTestCurrencyService(t)
+	svc := f.currencies
+
+	currency := &domain.Currency{Code: "vnd", Name: map[string]string{"vi": "Đồng Việt Nam"}, Symbol: "₫"}
+	require.NoError(t, svc.Create(ctx, currency))
+	assert.Equal(t, "VND", currency.Code)
+	assert.Equal(t, "active", currency.Status)
+
+	err := svc.Create(ctx, &domain.Currency{Code: "VND", Name: map[string]string{"en": "Dong"}})
+	assertStatus(t, err, http.StatusConflict)
+	err = svc.Create(ctx, &domain.Currency{Code: "DONG", Name: map[string]string{"en": "Dong"}})
+	assertStatus(t, err, http.StatusBadRequest)
+	err = svc.Create(ctx, &domain.Currency{Code: "USD", Name: map[string]string{"en": "US Dollar"}, DecimalDigits: 5})
+	assertStatus(t, err, http.StatusBadRequest)
+
+	found, err := svc.GetByCode(ctx, "vnd")
+	require.NoError(t, err)
+	assert.Equal(t, "₫", found.Symbol)
+	assert.True(t, f.cache.has(currencyKey("VND")))
+
+	require.NoError(t, svc.Create(ctx, &domain.Currency{Code: "USD", Name: map[string]string{"en": "US Dollar"}, DecimalDigits: 2}))
+	currencies, total, err := svc.List(ctx, domain.Localization{}, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, "USD", currencies[0].Code)
+	assert.True(t, f.cache.has(currencyListKey()))
+
+	require.NoError(t, svc.Update(ctx, &domain.Currency{Code: "VND", Name: map[string]string{"vi": "Đồng"}, Symbol: "đ"}))
+	assert.False(t, f.cache.has(currencyKey("VND")))
+	assert.False(t, f.cache.has(currencyListKey()))
+	found, err = svc.GetByCode(ctx, "VND")
+	require.NoError(t, err)
+	assert.Equal(t, "đ", found.Symbol)
+	assert.Equal(t, "active", found.Status)
+
+	err = svc.Update(ctx, &domain.Currency{Code: "EUR", Name: map[string]string{"en": "Euro"}})
+	assertStatus(t, err, http.StatusNotFound)
+
+	require.NoError(t, svc.Delete(ctx, "VND"))
+	_, err = svc.GetByCode(ctx, "VND")
+	assertStatus(t, err, http.StatusNotFound)
+	assertStatus(t, svc.Delete(ctx, "VND"), http.StatusNotFound)
+}
+
+func TestCurrencyService_CountryLinks(t *testing.T) {
+	ctx := context.Background()
+	f := newTestCurrencyService(t)
+
+	for _, code := range []string{"VN", "US"} {
+		require.NoError(t, f.countries.Create(ctx, &domain.Country{Code: code, Name: map[string]string{"en": code}}))
+	}
+
+	// Currency side: listing a country points it at the currency
+	require.NoError(t, f.currencies.Create(ctx, &domain.Currency{Code: "VND", Name: map[string]string{"en": "Dong"}, Countries: []string{"vn"}}))
+	require.NoError(t, f.currencies.Create(ctx, &domain.Currency{Code: "USD", Name: map[string]string{"en": "US Dollar"}, Countries: []string{"US"}}))
+	assert.Equal(t, "VND", f.countryCurrency(t, "VN"))
+	assert.Equal(t, []string{"VN"}, f.currencyCountries(t, "VND"))
+
+	err := f.currencies.Create(ctx, &domain.Currency{Code: "EUR", Name: map[string]string{"en": "Euro"}, Countries: []string{"DE", "FR"}})
+	assertStatus(t, err, http.StatusBadRequest)
+
+	// Moving a country to another currency takes it off the previous one
+	require.NoError(t, f.currencies.Update(ctx, &domain.Currency{Code: "USD", Name: map[string]string{"en": "US Dollar"}, Countries: []string{"US", "VN"}}))
+	assert.Equal(t, "USD", f.countryCurrency(t, "VN"))
+	assert.Empty(t, f.currencyCountries(t, "VND"))
+	assert.Equal(t, []string{"US", "VN"}, f.currencyCountries(t, "USD"))
+
+	// Dropping a country clears its currency
+	require.NoError(t, f.currencies.Update(ctx, &domain.Currency{Code: "USD", Name: map[string]string{"en": "US Dollar"}, Countries: []string{"US"}}))
+	assert.Empty(t, f.countryCurrency(t, "VN"))
+
+	// Country side: setting, changing and deleting a country updates the currency lists
+	require.NoError(t, f.countries.Update(ctx, &domain.Country{Code: "VN", Name: map[string]string{"en": "VN"}, Currency: "vnd"}))
+	assert.Equal(t, []string{"VN"}, f.currencyCountries(t, "VND"))
+
+	require.NoError(t, f.countries.Update(ctx, &domain.Country{Code: "VN", Name: map[string]string{"en": "VN"}, Currency: "USD"}))
+	assert.Empty(t, f.currencyCountries(t, "VND"))
+	assert.Equal(t, []string{"US", "VN"}, f.currencyCountries(t, "USD"))
+
+	require.NoError(t, f.countries.Delete(ctx, "VN"))
+	assert.Equal(t, []string{"US"}, f.currencyCountries(t, "USD"))
+
+	err = f.countries.Create(ctx, &domain.Country{Code: "GB", Name: map[string]string{"en": "United Kingdom"}, Currency: "GBP"})
+	assertStatus(t, err, http.StatusBadRequest)
+	require.NoError(t, f.countries.Create(ctx, &domain.Country{Code: "GB", Name: map[string]string{"en": "United Kingdom"}, Currency: "USD"}))
+	assert.Equal(t, []string{"GB", "US"}, f.currencyCountries(t, "USD"))
+}
+
+func TestCurrencyService_DeleteReferenced(t *testing.T) {
+	ctx := context.Background()
+	f := newTestCurrencyService(t)
+
+	require.NoError(t, f.currencies.Create(ctx, &domain.Currency{Code: "USD", Name: map[string]string{"en": "US Dollar"}}))
+	require.NoError(t, f.countries.Create(ctx, &domain.Country{Code: "US", Name: map[string]string{"en": "United States"}, Currency: "USD"}))
+
+	assertStatus(t, f.currencies.Delete(ctx, "USD"), http.StatusConflict)
+
+	require.NoError(t, f.countries.Update(ctx, &domain.Country{Code: "US", Name: map[string]string{"en": "United States"}}))
+	require.NoError(t, f.packages.Create(ctx, &domain.ServicePackage{TenantID: "tenant-1", Code: "pro", Name: "Pro", Currency: "USD"}))
+	assertStatus(t, f.currencies.Delete(ctx, "USD"), http.StatusConflict)
+
+	pkg, err := f.packages.FindByCode(ctx, "tenant-1", "pro")
+	require.NoError(t, err)
+	require.NoError(t, f.packages.Delete(ctx, pkg.ID.Hex()))
+	require.NoError(t, f.currencies.Delete(ctx, "USD"))
+}
+
+func TestCurrencyService_FormatAndParse(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestCurrencyService(t).currencies
+	require.NoError(t, svc.Create(ctx, &domain.Currency{Code: "VND", Name: map[string]string{"vi": "Đồng"}, Symbol: "₫"}))
+	require.NoError(t, svc.Create(ctx, &domain.Currency{Code: "USD", Name: map[string]string{"en": "US Dollar"}, Symbol: "$", DecimalDigits: 2}))
+
+	formatted, err := svc.Format(ctx, "vnd", 1234567.8, "vi-VN")
+	require.NoError(t, err)
+	assert.Equal(t, &domain.FormattedAmount{Currency: "VND", Locale: "vi-VN", Amount: 1234568, Formatted: "1.234.568 ₫"}, formatted)
+
+	// Unknown locales fall back to English
+	formatted, err = svc.Format(ctx, "USD", 1000, "xx")
+	require.NoError(t, err)
+	assert.Equal(t, "en", formatted.Locale)
+	assert.Equal(t, "$1,000.00", formatted.Formatted)
+
+	parsed, err := svc.Parse(ctx, "VND", "25.000 ₫", "vi")
+	require.NoError(t, err)
+	assert.Equal(t, 25000.0, parsed.Amount)
+	assert.Equal(t, "25.000 ₫", parsed.Formatted)
+
+	_, err = svc.Parse(ctx, "VND", "25,5", "vi")
+	assertStatus(t, err, http.StatusBadRequest)
+	_, err = svc.Parse(ctx, "VND", " ", "vi")
+	assertStatus(t, err, http.StatusBadRequest)
+	_, err = svc.Format(ctx, "EUR", 1, "en")
+	assertStatus(t, err, http.StatusNotFound)
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// EthnicityService handles ethnicity business logic
+type EthnicityService struct {
+	repo      repository.EthnicityRepository
+	countries repository.CountryRepository
+	cache     cacheStore
+	logger    *logger.Logger
+}
+
+// NewEthnicityService creates a new ethnicity service.
+// Countries are consulted so that Ethnicity.CountryCode always names an existing country.
+func NewEthnicityService(repo repository.EthnicityRepository, countries repository.CountryRepository, cache Cache, log *logger.Logger) *EthnicityService {
+	return &EthnicityService{
+		repo:      repo,
+		countries: countries,
+		cache:     cacheStore{cache: cache, logger: log},
+		logger:    log,
+	}
+}
+
+// Create creates a new ethnicity
+func (s *EthnicityService) Create(ctx context.Context, ethnicity *domain.Ethnicity) error {
+	ethnicity.Code = strings.TrimSpace(ethnicity.Code)
+	ethnicity.CountryCode = strings.ToUpper(strings.TrimSpace(ethnicity.CountryCode))
+	if err := ethnicity.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	if err := s.requireCountry(ctx, ethnicity.CountryCode); err != nil {
+		return err
+	}
+
+	existing, err := s.repo.FindByCode(ctx, ethnicity.CountryCode, ethnicity.Code)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.Conflict(fmt.Sprintf("Ethnicity with code '%s' already exists in country '%s'", ethnicity.Code, ethnicity.CountryCode))
+	}
+
+	if ethnicity.Status == "" {
+		ethnicity.Status = "active"
+	}
+
+	if err := s.repo.Create(ctx, ethnicity); err != nil {
+		if stderrors.Is(err, repository.ErrDuplicateKey) {
+			return errors.Conflict(fmt.Sprintf("Ethnicity with code '%s' already exists in country '%s'", ethnicity.Code, ethnicity.CountryCode))
+		}
+		return err
+	}
+
+	s.cache.invalidate(ctx, ethnicityListKey(""), ethnicityListKey(ethnicity.CountryCode))
+	s.logger.Info("Ethnicity created",
+		zap.String("country_code", ethnicity.CountryCode),
+		zap.String("code", ethnicity.Code),
+	)
+	return nil
+}
+
+// GetByID gets an ethnicity by ID
+func (s *EthnicityService) GetByID(ctx context.Context, id string) (*domain.Ethnicity, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, errors.BadRequest("Invalid ID format")
+	}
+
+	key := ethnicityKey(id)
+	var cached domain.Ethnicity
+	if s.cache.get(ctx, key, &cached) {
+		return &cached, nil
+	}
+
+	ethnicity, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ethnicity == nil {
+		return nil, errors.NotFound("Ethnicity not found")
+	}
+
+	s.cache.set(ctx, key, ethnicity, masterDataTTL)
+	return ethnicity, nil
+}
+
+// List lists active ethnicities with pagination and names localized for l10n, all of them when
+// countryCode is empty
+func (s *EthnicityService) List(ctx context.Context, countryCode string, l10n domain.Localization, page, perPage int) ([]*domain.Ethnicity, int64, error) {
+	countryCode = strings.ToUpper(strings.TrimSpace(countryCode))
+
+	ethnicities, total, err := listLocalized(l10n, page, perPage, func(page, perPage int) ([]*domain.Ethnicity, int64, error) {
+		return s.list(ctx, countryCode, page, perPage)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	// An empty listing is only an error when the country itself is unknown
+	if countryCode != "" && total == 0 {
+		country, err := s.countries.FindByCode(ctx, countryCode)
+		if err != nil {
+			return nil, 0, err
+		}
+		if country == nil {
+			return nil, 0, errors.NotFound("Country not found")
+		}
+	}
+	return ethnicities, total, nil
+}
+
+// list reads a page of active ethnicities of a country, or of all countries, caching the first one
+func (s *EthnicityService) list(ctx context.Context, countryCode string, page, perPage int) ([]*domain.Ethnicity, int64, error) {
+	key := ethnicityListKey(countryCode)
+	if page == 1 {
+		var cached cachedPage[*domain.Ethnicity]
+		if s.cache.get(ctx, key, &cached) && cached.PerPage == perPage {
+			return cached.Items, cached.Total, nil
+		}
+	}
+
+	ethnicities, total, err := s.repo.List(ctx, countryCode, page, perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if page == 1 {
+		s.cache.set(ctx, key, cachedPage[*domain.Ethnicity]{PerPage: perPage, Items: ethnicities, Total: total}, masterDataTTL)
+	}
+	return ethnicities, total, nil
+}
+
+// Update updates an ethnicity.
+// The country and code of an ethnicity are immutable and are kept from the stored document.
+func (s *EthnicityService) Update(ctx context.Context, ethnicity *domain.Ethnicity) error {
+	existing, err := s.repo.FindByID(ctx, ethnicity.ID.Hex())
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.NotFound("Ethnicity not found")
+	}
+
+	ethnicity.CountryCode = existing.CountryCode
+	ethnicity.Code = existing.Code
+	ethnicity.CreatedAt = existing.CreatedAt
+	if ethnicity.Status == "" {
+		ethnicity.Status = existing.Status
+	}
+
+	if err := ethnicity.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	if err := s.repo.Update(ctx, ethnicity); err != nil {
+		if stderrors.Is(err, repository.ErrNotFound) {
+			return errors.NotFound("Ethnicity not found")
+		}
+		return err
+	}
+
+	s.cache.invalidate(ctx, ethnicityKey(ethnicity.ID.Hex()), ethnicityListKey(""), ethnicityListKey(ethnicity.CountryCode))
+	return nil
+}
+
+// Delete deletes an ethnicity by ID
+func (s *EthnicityService) Delete(ctx context.Context, id string) error {
+	if !primitive.IsValidObjectID(id) {
+		return errors.BadRequest("Invalid ID format")
+	}
+
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.NotFound("Ethnicity not found")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, ethnicityKey(id), ethnicityListKey(""), ethnicityListKey(existing.CountryCode))
+	s.logger.Info("Ethnicity deleted",
+		zap.String("country_code", existing.CountryCode),
+		zap.String("code", existing.Code),
+	)
+	return nil
+}
+
+// requireCountry fails when no country with the code exists
+func (s *EthnicityService) requireCountry(ctx context.Context, code string) error {
+	country, err := s.countries.FindByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	if country == nil {
+		return errors.BadRequest(fmt.Sprintf("Country '%s' does not exist", code))
+	}
+	return nil
+}
+
+func ethnicityKey(id string) string {
+	return cacheKey("ethnicities", "id", id)
+}
+
+// ethnicityListKey keys the first page of the ethnicities of a country, or of all countries
+func ethnicityListKey(countryCode string) string {
+	if countryCode == "" {
+		return cacheKey("ethnicities", "list")
+	}
+	return cacheKey("ethnicities", "country", countryCode, "list")
+}
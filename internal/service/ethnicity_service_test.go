@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newTestEthnicityService(t *testing.T) (*EthnicityService, *memoryCache) {
+	cache := newMemoryCache()
+	countries := repository.NewMemoryCountryRepository()
+	for _, code := range []string{"VN", "LA"} {
+		require.NoError(t, countries.Create(context.Background(), &domain.Country{Code: code, Name: map[string]string{"en": code}, Status: "active"}))
+	}
+	return NewEthnicityService(repository.NewMemoryEthnicityRepository(), countries, cache, newTestLogger(t)), cache
+}
+
+func TestEthnicityService_CRUD(t *testing.T) {
+	ctx := context.Background()
+	svc, cache := newTestEthnicityService(t)
+
+	ethnicity := &domain.Ethnicity{Code: " 01 ", CountryCode: "vn", Name: map[string]string{"en": "Kinh (Viet)", "vi": "Kinh"}}
+	require.NoError(t, svc.Create(ctx, ethnicity))
+	assert.Equal(t, "01", ethnicity.Code)
+	assert.Equal(t, "VN", ethnicity.CountryCode)
+	assert.Equal(t, "active", ethnicity.Status)
+
+	err := svc.Create(ctx, &domain.Ethnicity{Code: "01", CountryCode: "VN", Name: map[string]string{"vi": "Kinh"}})
+	assertStatus(t, err, http.StatusConflict)
+	err = svc.Create(ctx, &domain.Ethnicity{Code: "01", CountryCode: "TH", Name: map[string]string{"en": "Thai"}})
+	assertStatus(t, err, http.StatusBadRequest)
+	err = svc.Create(ctx, &domain.Ethnicity{Code: "01", CountryCode: "VN"})
+	assertStatus(t, err, http.StatusBadRequest)
+	require.NoError(t, svc.Create(ctx, &domain.Ethnicity{Code: "01", CountryCode: "LA", Name: map[string]string{"en": "Lao"}}))
+
+	found, err := svc.GetByID(ctx, ethnicity.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, "Kinh", found.Name["vi"])
+	assert.True(t, cache.has(ethnicityKey(ethnicity.ID.Hex())))
+	_, err = svc.GetByID(ctx, "not-an-id")
+	assertStatus(t, err, http.StatusBadRequest)
+	_, err = svc.GetByID(ctx, primitive.NewObjectID().Hex())
+	assertStatus(t, err, http.StatusNotFound)
+
+	// The country and code are kept from the stored document
+	update := &domain.Ethnicity{ID: ethnicity.ID, Code: "99", CountryCode: "LA", Name: map[string]string{"vi": "Việt"}}
+	require.NoError(t, svc.Update(ctx, update))
+	assert.False(t, cache.has(ethnicityKey(ethnicity.ID.Hex())))
+	found, err = svc.GetByID(ctx, ethnicity.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, "01", found.Code)
+	assert.Equal(t, "VN", found.CountryCode)
+	assert.Equal(t, map[string]string{"vi": "Việt"}, found.Name)
+	assert.Equal(t, "active", found.Status)
+
+	err = svc.Update(ctx, &domain.Ethnicity{ID: primitive.NewObjectID(), Name: map[string]string{"vi": "x"}})
+	assertStatus(t, err, http.StatusNotFound)
+
+	require.NoError(t, svc.Delete(ctx, ethnicity.ID.Hex()))
+	_, err = svc.GetByID(ctx, ethnicity.ID.Hex())
+	assertStatus(t, err, http.StatusNotFound)
+	assertStatus(t, svc.Delete(ctx, ethnicity.ID.Hex()), http.StatusNotFound)
+}
+
+func TestEthnicityService_ListByCountry(t *testing.T) {
+	ctx := context.Background()
+	svc, cache := newTestEthnicityService(t)
+
+	require.NoError(t, svc.Create(ctx, &domain.Ethnicity{Code: "02", CountryCode: "VN", Name: map[string]string{"en": "Tay", "vi": "Tày"}}))
+	require.NoError(t, svc.Create(ctx, &domain.Ethnicity{Code: "01", CountryCode: "VN", Name: map[string]string{"en": "Kinh (Viet)", "vi": "Kinh"}}))
+	require.NoError(t, svc.Create(ctx, &domain.Ethnicity{Code: "11", CountryCode: "VN", Name: map[string]string{"vi": "Ngái"}}))
+	require.NoError(t, svc.Create(ctx, &domain.Ethnicity{Code: "01", CountryCode: "LA", Name: map[string]string{"en": "Lao"}}))
+
+	ethnicities, total, err := svc.List(ctx, "vn", domain.Localization{Locales: []string{"en-US"}}, 1, 30)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, ethnicities, 3)
+	assert.Equal(t, "01", ethnicities[0].Code)
+	assert.Equal(t, "Kinh (Viet)", ethnicities[0].LocalizedName)
+	assert.Equal(t, "Ngái", ethnicities[2].LocalizedName)
+	assert.True(t, cache.has(ethnicityListKey("VN")))
+
+	// The cached page is served without the previous locale
+	ethnicities, _, err = svc.List(ctx, "VN", domain.Localization{Locales: []string{"vi"}}, 1, 30)
+	require.NoError(t, err)
+	assert.Equal(t, "Tày", ethnicities[1].LocalizedName)
+	ethnicities, _, err = svc.List(ctx, "VN", domain.Localization{}, 1, 30)
+	require.NoError(t, err)
+	assert.Equal(t, "Tay", ethnicities[1].LocalizedName)
+
+	// Sorting by name orders the whole listing before it is paginated
+	sorted := domain.Localization{Locales: []string{"vi"}, SortByName: true}
+	ethnicities, total, err = svc.List(ctx, "VN", sorted, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, ethnicities, 2)
+	assert.Equal(t, "Kinh", ethnicities[0].LocalizedName)
+	assert.Equal(t, "Ngái", ethnicities[1].LocalizedName)
+	ethnicities, _, err = svc.List(ctx, "VN", sorted, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, ethnicities, 1)
+	assert.Equal(t, "Tày", ethnicities[0].LocalizedName)
+
+	_, total, err = svc.List(ctx, "", domain.Localization{}, 1, 30)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+
+	require.NoError(t, svc.Create(ctx, &domain.Ethnicity{Code: "03", CountryCode: "VN", Name: map[string]string{"vi": "Thái"}}))
+	assert.False(t, cache.has(ethnicityListKey("VN")))
+	assert.False(t, cache.has(ethnicityListKey("")))
+
+	_, _, err = svc.List(ctx, "TH", domain.Localization{}, 1, 30)
+	assertStatus(t, err, http.StatusNotFound)
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// ExchangeRateService handles exchange rates between currencies of the catalog.
+// Rates are stored per pair and day; a lookup on a day without a rate uses the most recent
+// earlier one, and a pair stored only the other way round is answered with the reciprocal.
+type ExchangeRateService struct {
+	repo       repository.ExchangeRateRepository
+	currencies repository.CurrencyRepository
+	logger     *logger.Logger
+}
+
+// NewExchangeRateService creates a new exchange rate service
+func NewExchangeRateService(repo repository.ExchangeRateRepository, currencies repository.CurrencyRepository, log *logger.Logger) *ExchangeRateService {
+	return &ExchangeRateService{
+		repo:       repo,
+		currencies: currencies,
+		logger:     log,
+	}
+}
+
+// Record stores a manually entered rate, replacing the rate of the same pair and day.
+// It reports whether a new rate was created.
+func (s *ExchangeRateService) Record(ctx context.Context, rate *domain.ExchangeRate) (bool, error) {
+	rate.Source = domain.ExchangeRateSourceManual
+	normalizeExchangeRate(rate)
+	if err := rate.Validate(); err != nil {
+		return false, errors.BadRequest(err.Error())
+	}
+	if err := s.requireCurrencies(ctx, rate.Base, rate.Quote); err != nil {
+		return false, err
+	}
+
+	created, err := s.repo.Upsert(ctx, rate)
+	if err != nil {
+		return false, err
+	}
+
+	s.logger.Info("Exchange rate recorded", zap.String("rate", rate.String()), zap.Float64("value", rate.Rate))
+	return created, nil
+}
+
+// Ingest stores a batch of rates pushed by a provider. The whole batch is validated before
+// anything is written, so a bad entry rejects the batch.
+func (s *ExchangeRateService) Ingest(ctx context.Context, req *domain.ExchangeRateIngestRequest) (*domain.ExchangeRateIngestReport, error) {
+	source := strings.TrimSpace(req.Source)
+	if source == "" || source == domain.ExchangeRateSourceManual {
+		return nil, errors.BadRequest("source must name the provider")
+	}
+
+	var codes []string
+	for i, rate := range req.Rates {
+		if rate == nil {
+			return nil, errors.BadRequest(fmt.Sprintf("rates[%d]: rate is required", i))
+		}
+		rate.Source = source
+		normalizeExchangeRate(rate)
+		if err := rate.Validate(); err != nil {
+			return nil, errors.BadRequest(fmt.Sprintf("rates[%d]: %v", i, err))
+		}
+		codes = append(codes, rate.Base, rate.Quote)
+	}
+	if err := s.requireCurrencies(ctx, codes...); err != nil {
+		return nil, err
+	}
+
+	report := &domain.ExchangeRateIngestReport{Source: source}
+	for _, rate := range req.Rates {
+		created, err := s.repo.Upsert(ctx, rate)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			report.Created++
+		} else {
+			report.Updated++
+		}
+	}
+
+	s.logger.Info("Exchange rates ingested",
+		zap.String("source", source),
+		zap.Int("created", report.Created),
+		zap.Int("updated", report.Updated),
+	)
+	return report, nil
+}
+
+// List lists stored rates matching the filter, newest first
+func (s *ExchangeRateService) List(ctx context.Context, filter domain.ExchangeRateFilter, page, perPage int) ([]*domain.ExchangeRate, int64, error) {
+	filter.Base = strings.ToUpper(strings.TrimSpace(filter.Base))
+	filter.Quote = strings.ToUpper(strings.TrimSpace(filter.Quote))
+	return s.repo.List(ctx, filter, page, perPage)
+}
+
+// Delete deletes a stored rate by ID
+func (s *ExchangeRateService) Delete(ctx context.Context, id string) error {
+	if !primitive.IsValidObjectID(id) {
+		return errors.BadRequest("Invalid ID format")
+	}
+
+	rate, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if rate == nil {
+		return errors.NotFound("Exchange rate not found")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.logger.Info("Exchange rate deleted", zap.String("rate", rate.String()))
+	return nil
+}
+
+// Rate returns the rate from base to quote in effect on the day of at
+func (s *ExchangeRateService) Rate(ctx context.Context, base, quote string, at time.Time) (*domain.ExchangeRateQuote, error) {
+	base = strings.ToUpper(strings.TrimSpace(base))
+	quote = strings.ToUpper(strings.TrimSpace(quote))
+	if !domain.IsCurrencyCode(base) || !domain.IsCurrencyCode(quote) {
+		return nil, errors.BadRequest("base and quote must be three letter ISO 4217 codes")
+	}
+	day := domain.RateDay(at)
+
+	if base == quote {
+		return &domain.ExchangeRateQuote{Base: base, Quote: quote, Rate: 1, Date: day, RateDate: day}, nil
+	}
+
+	direct, err := s.repo.FindLatest(ctx, base, quote, day)
+	if err != nil {
+		return nil, err
+	}
+	inverse, err := s.repo.FindLatest(ctx, quote, base, day)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case direct != nil && (inverse == nil || !inverse.Date.After(direct.Date)):
+		return &domain.ExchangeRateQuote{Base: base, Quote: quote, Rate: direct.Rate, Date: day, RateDate: direct.Date, Source: direct.Source}, nil
+	case inverse != nil:
+		return &domain.ExchangeRateQuote{Base: base, Quote: quote, Rate: 1 / inverse.Rate, Date: day, RateDate: inverse.Date, Source: inverse.Source, Inverted: true}, nil
+	default:
+		return nil, errors.NotFound(fmt.Sprintf("No exchange rate from %s to %s on or before %s", base, quote, day.Format(time.DateOnly)))
+	}
+}
+
+// Convert expresses an amount in another currency at the rate in effect on the requested day
+// (today by default), rounded to the decimal digits of the target currency
+func (s *ExchangeRateService) Convert(ctx context.Context, req *domain.ConversionRequest) (*domain.Conversion, error) {
+	req.From = strings.ToUpper(strings.TrimSpace(req.From))
+	req.To = strings.ToUpper(strings.TrimSpace(req.To))
+	if err := req.Validate(); err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+
+	target, err := s.currencies.FindByCode(ctx, req.To)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, errors.NotFound(fmt.Sprintf("Currency '%s' not found", req.To))
+	}
+
+	at := time.Now()
+	if req.Date != nil {
+		at = *req.Date
+	}
+	rate, err := s.Rate(ctx, req.From, req.To, at)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Conversion{
+		Amount:    req.Amount,
+		From:      req.From,
+		To:        req.To,
+		Converted: target.Round(req.Amount * rate.Rate),
+		Rate:      rate,
+	}, nil
+}
+
+// requireCurrencies fails when any of the codes is not in the currency catalog
+func (s *ExchangeRateService) requireCurrencies(ctx context.Context, codes ...string) error {
+	checked := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		if checked[code] {
+			continue
+		}
+		checked[code] = true
+
+		currency, err := s.currencies.FindByCode(ctx, code)
+		if err != nil {
+			return err
+		}
+		if currency == nil {
+			return errors.BadRequest(fmt.Sprintf("Currency '%s' does not exist", code))
+		}
+	}
+	return nil
+}
+
+// normalizeExchangeRate upper-cases the pair and moves the date to the start of its UTC day
+func normalizeExchangeRate(rate *domain.ExchangeRate) {
+	rate.Base = strings.ToUpper(strings.TrimSpace(rate.Base))
+	rate.Quote = strings.ToUpper(strings.TrimSpace(rate.Quote))
+	if !rate.Date.IsZero() {
+		rate.Date = domain.RateDay(rate.Date)
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+)
+
+func newTestExchangeRateService(t *testing.T) *ExchangeRateService {
+	currencies := repository.NewMemoryCurrencyRepository()
+	for code, digits := range map[string]int{"USD": 2, "VND": 0, "EUR": 2, "KWD": 3} {
+		require.NoError(t, currencies.Create(context.Background(), &domain.Currency{
+			Code: code, Name: map[string]string{"en": code}, DecimalDigits: digits, Status: "active",
+		}))
+	}
+	return NewExchangeRateService(repository.NewMemoryExchangeRateRepository(), currencies, newTestLogger(t))
+}
+
+func rateDay(d int) time.Time {
+	return time.Date(2025, time.January, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestExchangeRateService_Record(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestExchangeRateService(t)
+
+	rate := &domain.ExchangeRate{Base: "usd", Quote: "vnd", Rate: 25000, Date: time.Date(2025, 1, 2, 15, 30, 0, 0, time.UTC), Source: "vcb"}
+	created, err := svc.Record(ctx, rate)
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, "USD", rate.Base)
+	assert.True(t, rate.Date.Equal(rateDay(2)), "dates are kept per day")
+	assert.Equal(t, domain.ExchangeRateSourceManual, rate.Source)
+
+	created, err = svc.Record(ctx, &domain.ExchangeRate{Base: "USD", Quote: "VND", Rate: 25100, Date: rateDay(2)})
+	require.NoError(t, err)
+	assert.False(t, created)
+
+	invalid := []*domain.ExchangeRate{
+		{Base: "USD", Quote: "USD", Rate: 1, Date: rateDay(2)},
+		{Base: "USD", Quote: "VND", Rate: 0, Date: rateDay(2)},
+		{Base: "USD", Quote: "VND", Rate: 25000},
+		{Base: "USD", Quote: "JPY", Rate: 150, Date: rateDay(2)},
+	}
+	for _, rate := range invalid {
+		_, err := svc.Record(ctx, rate)
+		assertStatus(t, err, http.StatusBadRequest)
+	}
+
+	rates, total, err := svc.List(ctx, domain.ExchangeRateFilter{Base: "usd"}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, 25100.0, rates[0].Rate)
+
+	require.NoError(t, svc.Delete(ctx, rates[0].ID.Hex()))
+	assertStatus(t, svc.Delete(ctx, rates[0].ID.Hex()), http.StatusNotFound)
+	assertStatus(t, svc.Delete(ctx, "not-an-id"), http.StatusBadRequest)
+}
+
+func TestExchangeRateService_Ingest(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestExchangeRateService(t)
+
+	report, err := svc.Ingest(ctx, &domain.ExchangeRateIngestRequest{Source: "ecb", Rates: []*domain.ExchangeRate{
+		{Base: "EUR", Quote: "USD", Rate: 1.03, Date: rateDay(2)},
+		{Base: "EUR", Quote: "VND", Rate: 26500, Date: rateDay(2)},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, &domain.ExchangeRateIngestReport{Source: "ecb", Created: 2}, report)
+
+	report, err = svc.Ingest(ctx, &domain.ExchangeRateIngestRequest{Source: "ecb", Rates: []*domain.ExchangeRate{
+		{Base: "EUR", Quote: "USD", Rate: 1.04, Date: rateDay(2)},
+		{Base: "EUR", Quote: "USD", Rate: 1.05, Date: rateDay(3)},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Updated)
+
+	// A bad entry rejects the whole batch
+	_, err = svc.Ingest(ctx, &domain.ExchangeRateIngestRequest{Source: "ecb", Rates: []*domain.ExchangeRate{
+		{Base: "EUR", Quote: "USD", Rate: 1.06, Date: rateDay(4)},
+		{Base: "EUR", Quote: "GBP", Rate: 0.83, Date: rateDay(4)},
+	}})
+	assertStatus(t, err, http.StatusBadRequest)
+	_, total, err := svc.List(ctx, domain.ExchangeRateFilter{}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+
+	_, err = svc.Ingest(ctx, &domain.ExchangeRateIngestRequest{Source: "manual", Rates: []*domain.ExchangeRate{}})
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+func TestExchangeRateService_RateAndConvert(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestExchangeRateService(t)
+
+	_, err := svc.Ingest(ctx, &domain.ExchangeRateIngestRequest{Source: "vcb", Rates: []*domain.ExchangeRate{
+		{Base: "USD", Quote: "VND", Rate: 25000, Date: rateDay(2)},
+		{Base: "USD", Quote: "VND", Rate: 25400, Date: rateDay(6)},
+		{Base: "KWD", Quote: "USD", Rate: 3.2456, Date: rateDay(2)},
+	}})
+	require.NoError(t, err)
+
+	// Falls back to the most recent earlier rate
+	quote, err := svc.Rate(ctx, "USD", "VND", rateDay(5).Add(10*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 25000.0, quote.Rate)
+	assert.True(t, quote.Date.Equal(rateDay(5)))
+	assert.True(t, quote.RateDate.Equal(rateDay(2)))
+	assert.Equal(t, "vcb", quote.Source)
+
+	quote, err = svc.Rate(ctx, "USD", "VND", rateDay(6))
+	require.NoError(t, err)
+	assert.Equal(t, 25400.0, quote.Rate)
+
+	_, err = svc.Rate(ctx, "USD", "VND", rateDay(1))
+	assertStatus(t, err, http.StatusNotFound)
+
+	// Only the opposite pair is stored
+	quote, err = svc.Rate(ctx, "VND", "USD", rateDay(6))
+	require.NoError(t, err)
+	assert.True(t, quote.Inverted)
+	assert.InDelta(t, 1/25400.0, quote.Rate, 1e-12)
+
+	quote, err = svc.Rate(ctx, "EUR", "EUR", rateDay(6))
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, quote.Rate)
+
+	// Converted amounts are rounded to the decimal digits of the target currency
+	date := rateDay(3)
+	conversion, err := svc.Convert(ctx, &domain.ConversionRequest{Amount: 19.99, From: "usd", To: "vnd", Date: &date})
+	require.NoError(t, err)
+	assert.Equal(t, 499750.0, conversion.Converted)
+
+	conversion, err = svc.Convert(ctx, &domain.ConversionRequest{Amount: 1000000, From: "VND", To: "USD", Date: &date})
+	require.NoError(t, err)
+	assert.Equal(t, 40.0, conversion.Converted)
+
+	conversion, err = svc.Convert(ctx, &domain.ConversionRequest{Amount: 100, From: "USD", To: "KWD", Date: &date})
+	require.NoError(t, err)
+	assert.Equal(t, 30.811, conversion.Converted)
+
+	_, err = svc.Convert(ctx, &domain.ConversionRequest{Amount: 1, From: "USD", To: "JPY"})
+	assertStatus(t, err, http.StatusNotFound)
+	_, err = svc.Convert(ctx, &domain.ConversionRequest{Amount: 1, From: "USD", To: "YE"})
+	assertStatus(t, err, http.StatusBadRequest)
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+)
+
+// listLocalized returns a page of a master data listing with localized names. Listings ordered
+// by localized name are read in full, which master data is small enough for, and paginated
+// after sorting; otherwise list is asked for the page directly.
+func listLocalized[T domain.Localizable](l10n domain.Localization, page, perPage int, list func(page, perPage int) ([]T, int64, error)) ([]T, int64, error) {
+	if !l10n.SortByName {
+		items, total, err := list(page, perPage)
+		if err != nil {
+			return nil, 0, err
+		}
+		domain.LocalizeAll(items, l10n)
+		return items, total, nil
+	}
+
+	items, total, err := list(1, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	domain.LocalizeAll(items, l10n)
+
+	start := (page - 1) * perPage
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + perPage
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end], total, nil
+}
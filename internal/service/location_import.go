@@ -0,0 +1,481 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.uber.org/zap"
+)
+
+// gsoWardTypes maps the folded "Cấp" column of the GSO list to ward types
+var gsoWardTypes = map[string]string{
+	"phuong":   "ward",
+	"xa":       "commune",
+	"thi tran": "township",
+}
+
+// ImportLocations loads provinces, districts and wards from a CSV or JSON file and upserts them by
+// code, top-down so rows can reference parents defined earlier in the same file. Rows that fail
+// validation or reference a missing parent are rejected with a reason instead of aborting the
+// import. Names are merged per locale into the stored ones; unlisted locales are kept.
+func (s *LocationService) ImportLocations(ctx context.Context, r io.Reader, opts *domain.LocationImportOptions) (*domain.LocationImportReport, error) {
+	countryCode := normalizeCountryCode(opts.CountryCode)
+	if countryCode == "" {
+		countryCode = "VN"
+	}
+
+	var rows []domain.LocationImportRow
+	var err error
+	switch strings.ToLower(opts.Format) {
+	case domain.LocationImportCSV:
+		rows, err = parseLocationCSV(r, countryCode)
+	case domain.LocationImportJSON:
+		rows, err = parseLocationJSON(r)
+	default:
+		return nil, errors.BadRequest("format must be one of csv, json")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	run := &locationImport{
+		service: s,
+		dryRun:  opts.DryRun,
+		report: &domain.LocationImportReport{
+			DryRun: opts.DryRun,
+			Levels: map[string]*domain.LocationImportCounts{
+				domain.LocationLevelProvince: {},
+				domain.LocationLevelDistrict: {},
+				domain.LocationLevelWard:     {},
+			},
+			Rejected: []domain.LocationImportRejection{},
+		},
+		accepted: make(map[string]map[string]locationLineage),
+	}
+
+	byLevel := run.group(rows)
+	for _, level := range []string{domain.LocationLevelProvince, domain.LocationLevelDistrict, domain.LocationLevelWard} {
+		if err := run.importLevel(ctx, level, byLevel[level]); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(run.report.Rejected, func(i, j int) bool { return run.report.Rejected[i].Line < run.report.Rejected[j].Line })
+	if len(run.staleKeys) > 0 {
+		s.cache.invalidate(ctx, run.staleKeys...)
+	}
+
+	s.logger.Info("Locations imported",
+		zap.Bool("dry_run", opts.DryRun),
+		zap.Int("rows", len(rows)),
+		zap.Int("rejected", len(run.report.Rejected)),
+	)
+	return run.report, nil
+}
+
+// locationImport carries the state of one import run
+type locationImport struct {
+	service   *LocationService
+	dryRun    bool
+	report    *domain.LocationImportReport
+	accepted  map[string]map[string]locationLineage // level -> code -> lineage of units created, updated or unchanged so far
+	staleKeys []string
+}
+
+func (imp *locationImport) reject(row domain.LocationImportRow, reason string) {
+	if counts, ok := imp.report.Levels[row.Level]; ok {
+		counts.Rejected++
+	}
+	imp.report.Rejected = append(imp.report.Rejected, domain.LocationImportRejection{
+		Line: row.Line, Level: row.Level, Code: row.Code, Reason: reason,
+	})
+}
+
+// group normalizes rows and buckets them by level. Repeated rows for the same unit, as in the
+// GSO list where every ward row repeats its province and district, collapse into the first;
+// repeats that disagree with it are rejected.
+func (imp *locationImport) group(rows []domain.LocationImportRow) map[string][]domain.LocationImportRow {
+	byLevel := make(map[string][]domain.LocationImportRow)
+	first := make(map[string]domain.LocationImportRow)
+	for _, row := range rows {
+		row.Level = strings.ToLower(strings.TrimSpace(row.Level))
+		row.Code = strings.TrimSpace(row.Code)
+		row.ParentCode = strings.TrimSpace(row.ParentCode)
+		row.Type = strings.TrimSpace(row.Type)
+		if row.Level == domain.LocationLevelProvince {
+			row.ParentCode = normalizeCountryCode(row.ParentCode)
+		}
+		names := make(map[string]string, len(row.Name))
+		for locale, value := range row.Name {
+			if value = strings.TrimSpace(value); value != "" {
+				names[strings.TrimSpace(locale)] = value
+			}
+		}
+		row.Name = names
+
+		if _, ok := imp.report.Levels[row.Level]; !ok {
+			imp.reject(row, "level must be one of province, district, ward")
+			continue
+		}
+
+		key := row.Level + ":" + row.Code
+		if earlier, ok := first[key]; ok && row.Code != "" {
+			if earlier.ParentCode != row.ParentCode || earlier.Type != row.Type || !maps.Equal(earlier.Name, row.Name) {
+				imp.reject(row, fmt.Sprintf("conflicts with line %d", earlier.Line))
+			}
+			continue
+		}
+		first[key] = row
+		byLevel[row.Level] = append(byLevel[row.Level], row)
+	}
+	return byLevel
+}
+
+// importLevel upserts the rows of one level after the levels above it
+func (imp *locationImport) importLevel(ctx context.Context, level string, rows []domain.LocationImportRow) error {
+	imp.accepted[level] = make(map[string]locationLineage)
+	if len(rows) == 0 {
+		return nil
+	}
+
+	codes := make([]string, 0, len(rows))
+	var parentCodes []string
+	for _, row := range rows {
+		codes = append(codes, row.Code)
+		if _, ok := imp.accepted[parentLevel(level)][row.ParentCode]; row.ParentCode != "" && !ok {
+			parentCodes = append(parentCodes, row.ParentCode)
+		}
+	}
+
+	existing, err := imp.service.findLocationsByCodes(ctx, level, codes)
+	if err != nil {
+		return err
+	}
+	parents, err := imp.service.existingParents(ctx, level, parentCodes)
+	if err != nil {
+		return err
+	}
+
+	counts := imp.report.Levels[level]
+	now := time.Now()
+	for _, row := range rows {
+		parent, found := imp.accepted[parentLevel(level)][row.ParentCode]
+		if !found {
+			parent, found = parents[row.ParentCode]
+		}
+
+		switch {
+		case row.Code == "":
+			imp.reject(row, "code is required")
+			continue
+		case len(row.Name) == 0:
+			imp.reject(row, "name is required")
+			continue
+		case row.ParentCode == "":
+			imp.reject(row, "parent_code is required")
+			continue
+		case !found:
+			imp.reject(row, fmt.Sprintf("%s '%s' does not exist", parentLevel(level), row.ParentCode))
+			continue
+		}
+
+		lineage := parent.below(level, row.Code)
+		stored, ok := existing[row.Code]
+		if !ok {
+			if !imp.dryRun {
+				if err := imp.service.createImported(ctx, level, row, lineage); err != nil {
+					return err
+				}
+				imp.staleKeys = append(imp.staleKeys, locationListKey(level, row.ParentCode))
+			}
+			counts.Created++
+			imp.accepted[level][row.Code] = lineage
+			continue
+		}
+
+		if !stored.validity.IsCurrentAt(now) && stored.validity.EffectiveTo != nil {
+			imp.reject(row, "retired by a reorganization; import its successors instead")
+			continue
+		}
+
+		name := maps.Clone(stored.name)
+		if name == nil {
+			name = make(map[string]string, len(row.Name))
+		}
+		maps.Copy(name, row.Name)
+		unitType := stored.unitType
+		if row.Type != "" {
+			unitType = row.Type
+		}
+
+		if maps.Equal(name, stored.name) && unitType == stored.unitType && row.ParentCode == stored.parentCode && lineage == stored.lineage {
+			counts.Unchanged++
+			imp.accepted[level][row.Code] = lineage
+			continue
+		}
+
+		if !imp.dryRun {
+			updated := *stored
+			updated.name, updated.unitType, updated.parentCode, updated.lineage = name, unitType, row.ParentCode, lineage
+			if err := imp.service.updateImported(ctx, stored, &updated); err != nil {
+				return err
+			}
+			imp.staleKeys = append(imp.staleKeys, locationKey(level, row.Code),
+				locationListKey(level, stored.parentCode), locationListKey(level, row.ParentCode))
+		}
+		counts.Updated++
+		imp.accepted[level][row.Code] = lineage
+	}
+	return nil
+}
+
+// findLocationsByCodes loads the stored units of a level keyed by code
+func (s *LocationService) findLocationsByCodes(ctx context.Context, level string, codes []string) (map[string]*storedLocation, error) {
+	found := make(map[string]*storedLocation, len(codes))
+	switch level {
+	case domain.LocationLevelProvince:
+		provinces, err := s.provinces.FindByCodes(ctx, codes)
+		if err != nil {
+			return nil, err
+		}
+		for _, province := range provinces {
+			found[province.Code] = provinceLocation(province)
+		}
+	case domain.LocationLevelDistrict:
+		districts, err := s.districts.FindByCodes(ctx, codes)
+		if err != nil {
+			return nil, err
+		}
+		for _, district := range districts {
+			found[district.Code] = districtLocation(district)
+		}
+	case domain.LocationLevelWard:
+		wards, err := s.wards.FindByCodes(ctx, codes)
+		if err != nil {
+			return nil, err
+		}
+		for _, ward := range wards {
+			found[ward.Code] = wardLocation(ward)
+		}
+	}
+	return found, nil
+}
+
+// existingParents loads the lineage of the codes that exist one level above level
+func (s *LocationService) existingParents(ctx context.Context, level string, codes []string) (map[string]locationLineage, error) {
+	exists := make(map[string]locationLineage, len(codes))
+	if len(codes) == 0 {
+		return exists, nil
+	}
+
+	if level == domain.LocationLevelProvince {
+		countries, err := s.countries.FindByCodes(ctx, codes)
+		if err != nil {
+			return nil, err
+		}
+		for _, country := range countries {
+			exists[country.Code] = locationLineage{countryCode: country.Code}
+		}
+		return exists, nil
+	}
+
+	parents, err := s.findLocationsByCodes(ctx, parentLevel(level), codes)
+	if err != nil {
+		return nil, err
+	}
+	for code, parent := range parents {
+		exists[code] = parent.lineage
+	}
+	return exists, nil
+}
+
+func (s *LocationService) createImported(ctx context.Context, level string, row domain.LocationImportRow, lineage locationLineage) error {
+	switch level {
+	case domain.LocationLevelProvince:
+		return s.provinces.Create(ctx, &domain.Province{Code: row.Code, Name: row.Name, Type: row.Type, CountryCode: row.ParentCode, Status: "active"})
+	case domain.LocationLevelDistrict:
+		return s.districts.Create(ctx, &domain.District{Code: row.Code, Name: row.Name, Type: row.Type, ProvinceCode: row.ParentCode,
+			CountryCode: lineage.countryCode, Status: "active"})
+	default:
+		return s.wards.Create(ctx, &domain.Ward{Code: row.Code, Name: row.Name, Type: row.Type, DistrictCode: row.ParentCode,
+			ProvinceCode: lineage.provinceCode, CountryCode: lineage.countryCode, Status: "active"})
+	}
+}
+
+// updateImported writes an updated unit and, when it moved, the lineage recorded on the units below it
+func (s *LocationService) updateImported(ctx context.Context, stored, unit *storedLocation) error {
+	switch unit.level {
+	case domain.LocationLevelProvince:
+		if err := s.provinces.Update(ctx, &domain.Province{ID: unit.id, Code: unit.code, Name: unit.name, Type: unit.unitType, CountryCode: unit.parentCode, Status: unit.status}); err != nil {
+			return err
+		}
+		if unit.lineage == stored.lineage {
+			return nil
+		}
+		if err := s.districts.SetCountryByProvince(ctx, unit.code, unit.lineage.countryCode); err != nil {
+			return err
+		}
+		return s.wards.SetCountryByProvince(ctx, unit.code, unit.lineage.countryCode)
+	case domain.LocationLevelDistrict:
+		if err := s.districts.Update(ctx, &domain.District{ID: unit.id, Code: unit.code, Name: unit.name, Type: unit.unitType, ProvinceCode: unit.parentCode,
+			CountryCode: unit.lineage.countryCode, Status: unit.status}); err != nil {
+			return err
+		}
+		if unit.lineage == stored.lineage {
+			return nil
+		}
+		return s.wards.SetAncestorsByDistrict(ctx, unit.code, unit.lineage.provinceCode, unit.lineage.countryCode)
+	default:
+		return s.wards.Update(ctx, &domain.Ward{ID: unit.id, Code: unit.code, Name: unit.name, Type: unit.unitType, DistrictCode: unit.parentCode,
+			ProvinceCode: unit.lineage.provinceCode, CountryCode: unit.lineage.countryCode, Status: unit.status})
+	}
+}
+
+// locationLineage is the country and province a unit lies in, the unit itself included. Districts and
+// wards store the lineage of their parent so search can scope them without walking the hierarchy.
+type locationLineage struct {
+	countryCode  string
+	provinceCode string
+}
+
+// below returns the lineage of a unit at level with the given code placed under a unit of this lineage
+func (l locationLineage) below(level, code string) locationLineage {
+	if level == domain.LocationLevelProvince {
+		return locationLineage{countryCode: l.countryCode, provinceCode: code}
+	}
+	return l
+}
+
+// parseLocationCSV reads either the GSO administrative unit list, whose header is
+// "Tỉnh Thành Phố, Mã TP, Quận Huyện, Mã QH, Phường Xã, Mã PX, Cấp, Tên Tiếng Anh" with one
+// row per ward, or one unit per row with the columns level, code, parent_code, type and a
+// name_<locale> column per locale. Headers match regardless of case and diacritics.
+func parseLocationCSV(r io.Reader, countryCode string) ([]domain.LocationImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.BadRequest(fmt.Sprintf("Invalid CSV: %v", err))
+	}
+	columns := make(map[string]int, len(header))
+	nameColumns := make(map[string]int)
+	for i, column := range header {
+		folded := domain.FoldText(column) // also drops a byte order mark
+		columns[folded] = i
+		if locale, ok := strings.CutPrefix(folded, "name "); ok {
+			nameColumns[locale] = i
+		}
+	}
+
+	_, gso := columns["ma tp"]
+	_, hasLevel := columns["level"]
+	_, hasCode := columns["code"]
+	if !gso && !(hasLevel && hasCode) {
+		return nil, errors.BadRequest("Unrecognized CSV header: expected the GSO columns (Mã TP, Mã QH, Mã PX) or level, code, parent_code")
+	}
+
+	var rows []domain.LocationImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.BadRequest(fmt.Sprintf("Invalid CSV: %v", err))
+		}
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		if !gso {
+			row := domain.LocationImportRow{
+				Line:       line,
+				Level:      field("level"),
+				Code:       field("code"),
+				ParentCode: field("parent code"),
+				Type:       field("type"),
+				Name:       make(map[string]string, len(nameColumns)),
+			}
+			for locale, i := range nameColumns {
+				if i < len(record) {
+					row.Name[locale] = record[i]
+				}
+			}
+			rows = append(rows, row)
+			continue
+		}
+
+		provinceCode, districtCode, wardCode := field("ma tp"), field("ma qh"), field("ma px")
+		if provinceCode != "" {
+			rows = append(rows, domain.LocationImportRow{Line: line, Level: domain.LocationLevelProvince, Code: provinceCode,
+				ParentCode: countryCode, Name: map[string]string{"vi": field("tinh thanh pho")}})
+		}
+		if districtCode != "" {
+			rows = append(rows, domain.LocationImportRow{Line: line, Level: domain.LocationLevelDistrict, Code: districtCode,
+				ParentCode: provinceCode, Name: map[string]string{"vi": field("quan huyen")}})
+		}
+		if wardCode != "" {
+			ward := domain.LocationImportRow{Line: line, Level: domain.LocationLevelWard, Code: wardCode, ParentCode: districtCode,
+				Type: field("cap"), Name: map[string]string{"vi": field("phuong xa")}}
+			if wardType, ok := gsoWardTypes[domain.FoldText(ward.Type)]; ok {
+				ward.Type = wardType
+			}
+			if english := field("ten tieng anh"); english != "" {
+				ward.Name["en"] = english
+			}
+			rows = append(rows, ward)
+		}
+	}
+	return rows, nil
+}
+
+// parseLocationJSON reads an array of rows; a row's line is its position in the array
+func parseLocationJSON(r io.Reader) ([]domain.LocationImportRow, error) {
+	var rows []domain.LocationImportRow
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		return nil, errors.BadRequest(fmt.Sprintf("Invalid JSON: %v", err))
+	}
+	for i := range rows {
+		rows[i].Line = i + 1
+	}
+	return rows, nil
+}
+
+// locationKey returns the cache key of a unit of any level
+func locationKey(level, code string) string {
+	switch level {
+	case domain.LocationLevelProvince:
+		return provinceKey(code)
+	case domain.LocationLevelDistrict:
+		return districtKey(code)
+	default:
+		return wardKey(code)
+	}
+}
+
+// locationListKey returns the cache key of the listing a unit of any level appears in
+func locationListKey(level, parentCode string) string {
+	switch level {
+	case domain.LocationLevelProvince:
+		return provinceListKey(parentCode)
+	case domain.LocationLevelDistrict:
+		return districtListKey(parentCode)
+	default:
+		return wardListKey(parentCode)
+	}
+}
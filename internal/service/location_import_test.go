@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+)
+
+const gsoCSV = "\ufeffTỉnh Thành Phố,Mã TP,Quận Huyện,Mã QH,Phường Xã,Mã PX,Cấp,Tên Tiếng Anh\n" +
+	"Thành phố Hà Nội,01,Quận Ba Đình,001,Phường Phúc Xá,00001,Phường,Phuc Xa Ward\n" +
+	"Thành phố Hà Nội,01,Quận Ba Đình,001,Phường Trúc Bạch,00004,Phường,\n" +
+	"Thành phố Hà Nội,01,Huyện Sóc Sơn,016,Thị trấn Sóc Sơn,00376,Thị trấn,\n"
+
+func TestLocationService_ImportGSOList(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestLocationService(t)
+
+	report, err := svc.ImportLocations(ctx, strings.NewReader(gsoCSV), &domain.LocationImportOptions{Format: "csv"})
+	require.NoError(t, err)
+	assert.Equal(t, &domain.LocationImportCounts{Created: 1}, report.Levels[domain.LocationLevelProvince])
+	assert.Equal(t, &domain.LocationImportCounts{Created: 2}, report.Levels[domain.LocationLevelDistrict])
+	assert.Equal(t, &domain.LocationImportCounts{Created: 3}, report.Levels[domain.LocationLevelWard])
+	assert.Empty(t, report.Rejected)
+
+	ward, err := svc.GetWard(ctx, "00001")
+	require.NoError(t, err)
+	assert.Equal(t, "001", ward.DistrictCode)
+	assert.Equal(t, "ward", ward.Type)
+	assert.Equal(t, map[string]string{"vi": "Phường Phúc Xá", "en": "Phuc Xa Ward"}, ward.Name)
+	township, err := svc.GetWard(ctx, "00376")
+	require.NoError(t, err)
+	assert.Equal(t, "township", township.Type)
+
+	// imported wards record the province and country they lie in, as search scoping needs
+	stored, err := svc.wards.FindByCode(ctx, "00001")
+	require.NoError(t, err)
+	assert.Equal(t, "01", stored.ProvinceCode)
+	assert.Equal(t, "VN", stored.CountryCode)
+
+	// Re-importing the same list changes nothing; a renamed ward is updated in place and keeps
+	// the locales the file does not mention.
+	renamed := strings.Replace(gsoCSV, "Phường Phúc Xá", "Phường Phúc Xá Mới", 1)
+	report, err = svc.ImportLocations(ctx, strings.NewReader(renamed), &domain.LocationImportOptions{Format: "csv"})
+	require.NoError(t, err)
+	assert.Equal(t, &domain.LocationImportCounts{Unchanged: 1}, report.Levels[domain.LocationLevelProvince])
+	assert.Equal(t, &domain.LocationImportCounts{Unchanged: 2}, report.Levels[domain.LocationLevelDistrict])
+	assert.Equal(t, &domain.LocationImportCounts{Updated: 1, Unchanged: 2}, report.Levels[domain.LocationLevelWard])
+
+	ward, err = svc.GetWard(ctx, "00001")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"vi": "Phường Phúc Xá Mới", "en": "Phuc Xa Ward"}, ward.Name)
+}
+
+func TestLocationService_ImportRejectsRows(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestLocationService(t)
+
+	input := "level,code,parent_code,type,name_vi,name_en\n" +
+		"province,01,VN,city,Hà Nội,Hanoi\n" +
+		"district,001,01,district,Ba Đình,\n" +
+		"district,001,01,district,Ba Dinh,\n" +
+		"ward,00001,999,ward,Phúc Xá,\n" +
+		"ward,00004,001,ward,,\n" +
+		"hamlet,x1,00001,,Thôn,\n" +
+		"district,,01,,Không mã,\n"
+	report, err := svc.ImportLocations(ctx, strings.NewReader(input), &domain.LocationImportOptions{Format: "csv"})
+	require.NoError(t, err)
+
+	assert.Equal(t, &domain.LocationImportCounts{Created: 1}, report.Levels[domain.LocationLevelProvince])
+	assert.Equal(t, &domain.LocationImportCounts{Created: 1, Rejected: 2}, report.Levels[domain.LocationLevelDistrict])
+	assert.Equal(t, &domain.LocationImportCounts{Rejected: 2}, report.Levels[domain.LocationLevelWard])
+
+	lines := []int{}
+	for _, rejection := range report.Rejected {
+		lines = append(lines, rejection.Line)
+		assert.NotEmpty(t, rejection.Reason)
+	}
+	assert.Equal(t, []int{4, 5, 6, 7, 8}, lines)
+	assert.Equal(t, "conflicts with line 3", report.Rejected[0].Reason)
+	assert.Equal(t, "district '999' does not exist", report.Rejected[1].Reason)
+	assert.Equal(t, "name is required", report.Rejected[2].Reason)
+
+	district, err := svc.GetDistrict(ctx, "001")
+	require.NoError(t, err)
+	assert.Equal(t, "Ba Đình", district.Name["vi"])
+}
+
+func TestLocationService_ImportDryRun(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestLocationService(t)
+
+	report, err := svc.ImportLocations(ctx, strings.NewReader(gsoCSV), &domain.LocationImportOptions{Format: "csv", DryRun: true})
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 3, report.Levels[domain.LocationLevelWard].Created)
+	assert.Empty(t, report.Rejected)
+
+	_, err = svc.GetProvince(ctx, "01")
+	assertStatus(t, err, http.StatusNotFound)
+}
+
+func TestLocationService_ImportJSON(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestLocationService(t)
+
+	input := `[
+		{"level": "ward", "code": "00001", "parent_code": "001", "name": {"vi": "Phúc Xá"}},
+		{"level": "district", "code": "001", "parent_code": "01", "name": {"vi": "Ba Đình"}},
+		{"level": "province", "code": "01", "parent_code": "vn", "name": {"vi": "Hà Nội"}},
+		{"level": "province", "code": "02", "parent_code": "XX", "name": {"vi": "Hà Giang"}}
+	]`
+	report, err := svc.ImportLocations(ctx, strings.NewReader(input), &domain.LocationImportOptions{Format: "json"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Levels[domain.LocationLevelWard].Created)
+	require.Len(t, report.Rejected, 1)
+	assert.Equal(t, 4, report.Rejected[0].Line)
+
+	province, err := svc.GetProvince(ctx, "01")
+	require.NoError(t, err)
+	assert.Equal(t, "VN", province.CountryCode)
+}
+
+func TestLocationService_ImportInvalidInput(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestLocationService(t)
+
+	_, err := svc.ImportLocations(ctx, strings.NewReader(gsoCSV), &domain.LocationImportOptions{Format: "xml"})
+	assertStatus(t, err, http.StatusBadRequest)
+	_, err = svc.ImportLocations(ctx, strings.NewReader("a,b,c\n1,2,3\n"), &domain.LocationImportOptions{Format: "csv"})
+	assertStatus(t, err, http.StatusBadRequest)
+	_, err = svc.ImportLocations(ctx, strings.NewReader(`{"level":`), &domain.LocationImportOptions{Format: "json"})
+	assertStatus(t, err, http.StatusBadRequest)
+}
@@ -0,0 +1,442 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// storedLocation is the level-independent view of a stored province, district or ward
+type storedLocation struct {
+	id         primitive.ObjectID
+	level      string
+	code       string
+	name       map[string]string
+	unitType   string
+	parentCode string
+	status     string
+	lineage    locationLineage
+	validity   domain.LocationValidity
+}
+
+func provinceLocation(p *domain.Province) *storedLocation {
+	return &storedLocation{id: p.ID, level: domain.LocationLevelProvince, code: p.Code, name: p.Name, unitType: p.Type,
+		parentCode: p.CountryCode, status: p.Status, lineage: locationLineage{countryCode: p.CountryCode, provinceCode: p.Code},
+		validity: p.LocationValidity}
+}
+
+func districtLocation(d *domain.District) *storedLocation {
+	return &storedLocation{id: d.ID, level: domain.LocationLevelDistrict, code: d.Code, name: d.Name, unitType: d.Type,
+		parentCode: d.ProvinceCode, status: d.Status, lineage: locationLineage{countryCode: d.CountryCode, provinceCode: d.ProvinceCode},
+		validity: d.LocationValidity}
+}
+
+func wardLocation(w *domain.Ward) *storedLocation {
+	return &storedLocation{id: w.ID, level: domain.LocationLevelWard, code: w.Code, name: w.Name, unitType: w.Type,
+		parentCode: w.DistrictCode, status: w.Status, lineage: locationLineage{countryCode: w.CountryCode, provinceCode: w.ProvinceCode},
+		validity: w.LocationValidity}
+}
+
+func (l *storedLocation) ref() domain.LocationRef {
+	return domain.LocationRef{Level: l.level, Code: l.code, Name: l.name}
+}
+
+// Reorganize applies a reorganization decree. Changes are applied top-down, provinces before
+// districts before wards, so units created for a higher level can parent units created below.
+// The whole decree is checked before anything is written: every From unit must be in effect on
+// the effective date, new units need a name and an existing (or newly created) parent, and a
+// unit split into several successors must have its children reassigned by the decree itself.
+// Children of a unit merged into a single successor move under that successor. The decree is
+// checked and applied in one transaction, so either every change is written or none is, and the
+// cached units and listings it touches are invalidated once it has committed.
+func (s *LocationService) Reorganize(ctx context.Context, decree *domain.ReorganizationDecree) (*domain.ReorganizationReport, error) {
+	if err := decree.Validate(); err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+
+	changes := slices.Clone(decree.Changes)
+	sort.SliceStable(changes, func(i, j int) bool { return locationDepth(changes[i].Level) < locationDepth(changes[j].Level) })
+
+	var report *domain.ReorganizationReport
+	txCtx, invalidated := deferInvalidation(ctx)
+	err := s.tx.WithTransaction(txCtx, func(ctx context.Context) error {
+		var err error
+		report, err = s.applyReorganization(ctx, decree, changes)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.cache.flush(ctx, invalidated)
+
+	s.logger.Info("Locations reorganized",
+		zap.String("reference", decree.Reference),
+		zap.Time("effective_date", decree.EffectiveDate),
+		zap.Int("created", len(report.Created)),
+		zap.Int("retired", len(report.Retired)),
+		zap.Int("moved", len(report.Moved)),
+	)
+	return report, nil
+}
+
+// applyReorganization checks and writes the sorted changes of a decree, reporting what changed
+func (s *LocationService) applyReorganization(ctx context.Context, decree *domain.ReorganizationDecree, changes []domain.LocationChange) (*domain.ReorganizationReport, error) {
+	reassigned, err := s.checkReorganization(ctx, decree.EffectiveDate, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	at := decree.EffectiveDate
+	report := &domain.ReorganizationReport{
+		Reference:     decree.Reference,
+		EffectiveDate: at,
+		Created:       []domain.LocationRef{},
+		Updated:       []domain.LocationRef{},
+		Retired:       []domain.LocationRef{},
+		Moved:         []domain.LocationRef{},
+	}
+
+	for _, change := range changes {
+		successors := make([]string, 0, len(change.To))
+		for _, unit := range change.To {
+			successors = append(successors, unit.Code)
+
+			existing, err := s.findLocation(ctx, change.Level, unit.Code)
+			if err != nil {
+				return nil, err
+			}
+			if existing == nil {
+				if err := s.createLocation(ctx, change.Level, unit, at); err != nil {
+					return nil, err
+				}
+				report.Created = append(report.Created, domain.LocationRef{Level: change.Level, Code: unit.Code, Name: unit.Name})
+				continue
+			}
+			if len(unit.Name) > 0 || unit.Type != "" || unit.ParentCode != "" {
+				if err := s.updateLocation(ctx, existing, unit); err != nil {
+					return nil, err
+				}
+				report.Updated = append(report.Updated, existing.ref())
+			}
+		}
+
+		for _, code := range change.From {
+			if slices.Contains(successors, code) {
+				continue
+			}
+			retired, err := s.findLocation(ctx, change.Level, code)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.retireLocation(ctx, retired, at, successors); err != nil {
+				return nil, err
+			}
+			report.Retired = append(report.Retired, retired.ref())
+
+			if len(successors) != 1 {
+				continue
+			}
+			children, err := s.childLocations(ctx, retired)
+			if err != nil {
+				return nil, err
+			}
+			for _, child := range children {
+				if reassigned[child.level][child.code] {
+					continue
+				}
+				if err := s.updateLocation(ctx, child, domain.LocationUnit{Code: child.code, ParentCode: successors[0]}); err != nil {
+					return nil, err
+				}
+				report.Moved = append(report.Moved, child.ref())
+			}
+		}
+	}
+	return report, nil
+}
+
+// checkReorganization validates sorted changes against the stored units without writing anything.
+// It returns, per level, the codes the decree reassigns explicitly.
+func (s *LocationService) checkReorganization(ctx context.Context, at time.Time, changes []domain.LocationChange) (map[string]map[string]bool, error) {
+	reassigned := make(map[string]map[string]bool)
+	created := make(map[string]map[string]bool)
+	for _, change := range changes {
+		if reassigned[change.Level] == nil {
+			reassigned[change.Level] = make(map[string]bool)
+			created[change.Level] = make(map[string]bool)
+		}
+		for _, code := range change.From {
+			if reassigned[change.Level][code] {
+				return nil, errors.BadRequest(fmt.Sprintf("%s '%s' appears in more than one change", change.Level, code))
+			}
+			reassigned[change.Level][code] = true
+		}
+	}
+
+	date := at.Format(time.DateOnly)
+	for _, change := range changes {
+		successors := make(map[string]bool, len(change.To))
+		for _, unit := range change.To {
+			if successors[unit.Code] {
+				return nil, errors.BadRequest(fmt.Sprintf("%s '%s' is listed twice in one change", change.Level, unit.Code))
+			}
+			successors[unit.Code] = true
+
+			existing, err := s.findLocation(ctx, change.Level, unit.Code)
+			if err != nil {
+				return nil, err
+			}
+			if existing == nil {
+				if created[change.Level][unit.Code] {
+					return nil, errors.BadRequest(fmt.Sprintf("%s '%s' is created more than once", change.Level, unit.Code))
+				}
+				if len(unit.Name) == 0 || unit.ParentCode == "" {
+					return nil, errors.BadRequest(fmt.Sprintf("new %s '%s' needs a name and a parent_code", change.Level, unit.Code))
+				}
+				created[change.Level][unit.Code] = true
+			}
+			if unit.ParentCode != "" {
+				if err := s.checkParent(ctx, change.Level, unit.ParentCode, created); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		for _, code := range change.From {
+			unit, err := s.findLocation(ctx, change.Level, code)
+			if err != nil {
+				return nil, err
+			}
+			if unit == nil {
+				return nil, errors.BadRequest(fmt.Sprintf("%s '%s' does not exist", change.Level, code))
+			}
+			if successors[code] {
+				continue
+			}
+			if unit.validity.EffectiveTo != nil || !unit.validity.IsCurrentAt(at) {
+				return nil, errors.BadRequest(fmt.Sprintf("%s '%s' is not in effect on %s", change.Level, code, date))
+			}
+			if len(change.To) == 1 {
+				continue
+			}
+
+			children, err := s.childLocations(ctx, unit)
+			if err != nil {
+				return nil, err
+			}
+			var pending []string
+			for _, child := range children {
+				if !reassigned[child.level][child.code] {
+					pending = append(pending, child.code)
+				}
+			}
+			if len(pending) > 0 {
+				return nil, errors.BadRequest(fmt.Sprintf("%s '%s' is split; the decree must reassign its units %s",
+					change.Level, code, strings.Join(pending, ", ")))
+			}
+		}
+	}
+	return reassigned, nil
+}
+
+// checkParent fails with BadRequest unless the parent of a unit at level exists or is created by the decree
+func (s *LocationService) checkParent(ctx context.Context, level, parentCode string, created map[string]map[string]bool) error {
+	if level == domain.LocationLevelProvince {
+		return s.requireCountry(ctx, normalizeCountryCode(parentCode))
+	}
+
+	parent := parentLevel(level)
+	if created[parent][parentCode] {
+		return nil
+	}
+	existing, err := s.findLocation(ctx, parent, parentCode)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.BadRequest(fmt.Sprintf("%s '%s' does not exist", parent, parentCode))
+	}
+	return nil
+}
+
+// Resolve maps a code, possibly retired by reorganizations, to the units in effect at the given
+// time by following successor codes. Without a level the code is looked up as a province, then
+// a district, then a ward.
+func (s *LocationService) Resolve(ctx context.Context, code, level string, at time.Time) (*domain.LocationResolution, error) {
+	levels := []string{domain.LocationLevelProvince, domain.LocationLevelDistrict, domain.LocationLevelWard}
+	if level != "" {
+		if !slices.Contains(levels, level) {
+			return nil, errors.BadRequest("level must be one of province, district, ward")
+		}
+		levels = []string{level}
+	}
+
+	var start *storedLocation
+	for _, candidate := range levels {
+		found, err := s.findLocation(ctx, candidate, code)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			start = found
+			break
+		}
+	}
+	if start == nil {
+		return nil, errors.NotFound("Location not found")
+	}
+
+	resolution := &domain.LocationResolution{
+		Code:     start.code,
+		Level:    start.level,
+		Current:  start.validity.IsCurrentAt(at),
+		Via:      []string{},
+		Resolved: []domain.LocationRef{},
+	}
+
+	queue := []*storedLocation{start}
+	visited := map[string]bool{start.code: true}
+	for len(queue) > 0 {
+		unit := queue[0]
+		queue = queue[1:]
+
+		if unit.validity.IsCurrentAt(at) {
+			resolution.Resolved = append(resolution.Resolved, unit.ref())
+			continue
+		}
+		if unit.validity.EffectiveTo == nil || unit.validity.EffectiveTo.After(at) {
+			continue // not in effect yet, so there is nothing to follow
+		}
+
+		resolution.Via = append(resolution.Via, unit.code)
+		for _, successorCode := range unit.validity.SuccessorCodes {
+			if visited[successorCode] {
+				continue
+			}
+			visited[successorCode] = true
+
+			successor, err := s.findLocation(ctx, unit.level, successorCode)
+			if err != nil {
+				return nil, err
+			}
+			if successor != nil {
+				queue = append(queue, successor)
+			}
+		}
+	}
+	return resolution, nil
+}
+
+// findLocation loads a unit of the given level, returning nil when it does not exist
+func (s *LocationService) findLocation(ctx context.Context, level, code string) (*storedLocation, error) {
+	switch level {
+	case domain.LocationLevelProvince:
+		province, err := s.provinces.FindByCode(ctx, code)
+		if err != nil || province == nil {
+			return nil, err
+		}
+		return provinceLocation(province), nil
+	case domain.LocationLevelDistrict:
+		district, err := s.districts.FindByCode(ctx, code)
+		if err != nil || district == nil {
+			return nil, err
+		}
+		return districtLocation(district), nil
+	case domain.LocationLevelWard:
+		ward, err := s.wards.FindByCode(ctx, code)
+		if err != nil || ward == nil {
+			return nil, err
+		}
+		return wardLocation(ward), nil
+	}
+	return nil, nil
+}
+
+// childLocations lists the current units directly below a unit; wards have none
+func (s *LocationService) childLocations(ctx context.Context, parent *storedLocation) ([]*storedLocation, error) {
+	var children []*storedLocation
+	switch parent.level {
+	case domain.LocationLevelProvince:
+		districts, err := s.districts.ListByProvince(ctx, parent.code)
+		if err != nil {
+			return nil, err
+		}
+		for _, district := range districts {
+			children = append(children, districtLocation(district))
+		}
+	case domain.LocationLevelDistrict:
+		wards, err := s.wards.ListByDistrict(ctx, parent.code)
+		if err != nil {
+			return nil, err
+		}
+		for _, ward := range wards {
+			children = append(children, wardLocation(ward))
+		}
+	}
+	return children, nil
+}
+
+// createLocation creates a unit that comes into effect at the given time
+func (s *LocationService) createLocation(ctx context.Context, level string, unit domain.LocationUnit, from time.Time) error {
+	validity := domain.LocationValidity{EffectiveFrom: &from}
+	switch level {
+	case domain.LocationLevelProvince:
+		return s.CreateProvince(ctx, &domain.Province{Code: unit.Code, Name: unit.Name, Type: unit.Type, CountryCode: unit.ParentCode, LocationValidity: validity})
+	case domain.LocationLevelDistrict:
+		return s.CreateDistrict(ctx, &domain.District{Code: unit.Code, Name: unit.Name, Type: unit.Type, ProvinceCode: unit.ParentCode, LocationValidity: validity})
+	default:
+		return s.CreateWard(ctx, &domain.Ward{Code: unit.Code, Name: unit.Name, Type: unit.Type, DistrictCode: unit.ParentCode, LocationValidity: validity})
+	}
+}
+
+// updateLocation overlays the name, type and parent given in unit onto a stored unit
+func (s *LocationService) updateLocation(ctx context.Context, existing *storedLocation, unit domain.LocationUnit) error {
+	name, unitType, parentCode := existing.name, existing.unitType, existing.parentCode
+	if len(unit.Name) > 0 {
+		name = unit.Name
+	}
+	if unit.Type != "" {
+		unitType = unit.Type
+	}
+	if unit.ParentCode != "" {
+		parentCode = unit.ParentCode
+	}
+
+	switch existing.level {
+	case domain.LocationLevelProvince:
+		return s.updateProvince(ctx, &domain.Province{Code: existing.code, Name: name, Type: unitType, CountryCode: parentCode})
+	case domain.LocationLevelDistrict:
+		return s.updateDistrict(ctx, &domain.District{Code: existing.code, Name: name, Type: unitType, ProvinceCode: parentCode})
+	default:
+		return s.UpdateWard(ctx, &domain.Ward{Code: existing.code, Name: name, Type: unitType, DistrictCode: parentCode})
+	}
+}
+
+// retireLocation ends the validity of a unit and drops it from the cached listings
+func (s *LocationService) retireLocation(ctx context.Context, unit *storedLocation, at time.Time, successors []string) error {
+	switch unit.level {
+	case domain.LocationLevelProvince:
+		if err := s.provinces.Retire(ctx, unit.code, at, successors); err != nil {
+			return err
+		}
+		s.cache.invalidate(ctx, provinceKey(unit.code), provinceListKey(unit.parentCode))
+	case domain.LocationLevelDistrict:
+		if err := s.districts.Retire(ctx, unit.code, at, successors); err != nil {
+			return err
+		}
+		s.cache.invalidate(ctx, districtKey(unit.code), districtListKey(unit.parentCode))
+	default:
+		if err := s.wards.Retire(ctx, unit.code, at, successors); err != nil {
+			return err
+		}
+		s.cache.invalidate(ctx, wardKey(unit.code), wardListKey(unit.parentCode))
+	}
+	return nil
+}
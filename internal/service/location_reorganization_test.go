@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+)
+
+// seedDistricts creates province 01 with districts 001 and 002, each with two wards
+func seedDistricts(t *testing.T, svc *LocationService) {
+	ctx := context.Background()
+	require.NoError(t, svc.CreateProvince(ctx, &domain.Province{Code: "01", Name: map[string]string{"vi": "Hà Nội"}, CountryCode: "VN"}))
+	for _, district := range []string{"001", "002"} {
+		require.NoError(t, svc.CreateDistrict(ctx, &domain.District{Code: district, Name: map[string]string{"vi": "Quận " + district}, ProvinceCode: "01"}))
+		for _, ward := range []string{"1", "2"} {
+			code := district + "0" + ward
+			require.NoError(t, svc.CreateWard(ctx, &domain.Ward{Code: code, Name: map[string]string{"vi": "Phường " + code}, DistrictCode: district}))
+		}
+	}
+}
+
+func wardCodes(t *testing.T, svc *LocationService, districtCode string) []string {
+	wards, err := svc.ListWards(context.Background(), districtCode, domain.Localization{})
+	require.NoError(t, err)
+	codes := []string{}
+	for _, ward := range wards {
+		codes = append(codes, ward.Code)
+	}
+	return codes
+}
+
+func refCodes(refs []domain.LocationRef) []string {
+	codes := []string{}
+	for _, ref := range refs {
+		codes = append(codes, ref.Code)
+	}
+	return codes
+}
+
+func TestLocationService_ReorganizeMergesIntoNewUnit(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestLocationService(t)
+	seedDistricts(t, svc)
+	effective := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	_, err := svc.ListDistricts(ctx, "01", domain.Localization{})
+	require.NoError(t, err)
+
+	report, err := svc.Reorganize(ctx, &domain.ReorganizationDecree{
+		Reference:     "NQ-01",
+		EffectiveDate: effective,
+		Changes: []domain.LocationChange{{
+			Level: domain.LocationLevelDistrict,
+			From:  []string{"001", "002"},
+			To:    []domain.LocationUnit{{Code: "900", Name: map[string]string{"vi": "Quận Mới"}, ParentCode: "01"}},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"900"}, refCodes(report.Created))
+	assert.Equal(t, []string{"001", "002"}, refCodes(report.Retired))
+	assert.Equal(t, []string{"00101", "00102", "00201", "00202"}, refCodes(report.Moved))
+
+	districts, err := svc.ListDistricts(ctx, "01", domain.Localization{})
+	require.NoError(t, err)
+	require.Len(t, districts, 1, "the cached listing is invalidated and retired districts drop out")
+	assert.Equal(t, "900", districts[0].Code)
+	assert.Equal(t, []string{"00101", "00102", "00201", "00202"}, wardCodes(t, svc, "900"))
+
+	retired, err := svc.GetDistrict(ctx, "001")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"900"}, retired.SuccessorCodes)
+	require.NotNil(t, retired.EffectiveTo)
+	assert.True(t, effective.Equal(*retired.EffectiveTo))
+
+	resolution, err := svc.Resolve(ctx, "001", "", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, domain.LocationLevelDistrict, resolution.Level)
+	assert.False(t, resolution.Current)
+	assert.Equal(t, []string{"001"}, resolution.Via)
+	assert.Equal(t, []string{"900"}, refCodes(resolution.Resolved))
+
+	resolution, err = svc.Resolve(ctx, "001", domain.LocationLevelDistrict, effective.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.True(t, resolution.Current)
+	assert.Equal(t, []string{"001"}, refCodes(resolution.Resolved))
+
+	_, err = svc.Reorganize(ctx, &domain.ReorganizationDecree{
+		Reference:     "NQ-02",
+		EffectiveDate: time.Now(),
+		Changes:       []domain.LocationChange{{Level: domain.LocationLevelDistrict, From: []string{"001"}, To: []domain.LocationUnit{{Code: "900"}}}},
+	})
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+func TestLocationService_ReorganizeAbsorbsAndChains(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestLocationService(t)
+	seedDistricts(t, svc)
+
+	report, err := svc.Reorganize(ctx, &domain.ReorganizationDecree{
+		Reference:     "NQ-01",
+		EffectiveDate: time.Now().Add(-2 * time.Hour),
+		Changes: []domain.LocationChange{{
+			Level: domain.LocationLevelWard,
+			From:  []string{"00101", "00102"},
+			To:    []domain.LocationUnit{{Code: "00101", Name: map[string]string{"vi": "Phường Hợp Nhất"}}},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"00101"}, refCodes(report.Updated))
+	assert.Equal(t, []string{"00102"}, refCodes(report.Retired))
+	assert.Empty(t, report.Created)
+	assert.Equal(t, []string{"00101"}, wardCodes(t, svc, "001"))
+
+	_, err = svc.Reorganize(ctx, &domain.ReorganizationDecree{
+		Reference:     "NQ-02",
+		EffectiveDate: time.Now().Add(-time.Hour),
+		Changes: []domain.LocationChange{{
+			Level: domain.LocationLevelWard,
+			From:  []string{"00101"},
+			To:    []domain.LocationUnit{{Code: "00199", Name: map[string]string{"vi": "Phường Mới"}, ParentCode: "001"}},
+		}},
+	})
+	require.NoError(t, err)
+
+	resolution, err := svc.Resolve(ctx, "00102", "", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"00102", "00101"}, resolution.Via)
+	assert.Equal(t, []string{"00199"}, refCodes(resolution.Resolved))
+
+	_, err = svc.Resolve(ctx, "99999", "", time.Now())
+	assertStatus(t, err, http.StatusNotFound)
+	_, err = svc.Resolve(ctx, "00102", "country", time.Now())
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+func TestLocationService_ReorganizeSplitRequiresReassignment(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestLocationService(t)
+	seedDistricts(t, svc)
+
+	split := domain.LocationChange{
+		Level: domain.LocationLevelDistrict,
+		From:  []string{"001"},
+		To: []domain.LocationUnit{
+			{Code: "901", Name: map[string]string{"vi": "Quận Bắc"}, ParentCode: "01"},
+			{Code: "902", Name: map[string]string{"vi": "Quận Nam"}, ParentCode: "01"},
+		},
+	}
+	decree := &domain.ReorganizationDecree{Reference: "NQ-03", EffectiveDate: time.Now().Add(-time.Hour), Changes: []domain.LocationChange{split}}
+	_, err := svc.Reorganize(ctx, decree)
+	assertStatus(t, err, http.StatusBadRequest)
+
+	_, err = svc.GetDistrict(ctx, "901")
+	assertStatus(t, err, http.StatusNotFound)
+
+	// wards are listed first on purpose; changes are applied top-down regardless of order
+	decree.Changes = []domain.LocationChange{
+		{Level: domain.LocationLevelWard, From: []string{"00101"}, To: []domain.LocationUnit{{Code: "00101", ParentCode: "901"}}},
+		{Level: domain.LocationLevelWard, From: []string{"00102"}, To: []domain.LocationUnit{{Code: "00102", ParentCode: "902"}}},
+		split,
+	}
+	report, err := svc.Reorganize(ctx, decree)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"901", "902"}, refCodes(report.Created))
+	assert.Equal(t, []string{"00101", "00102"}, refCodes(report.Updated))
+	assert.Empty(t, report.Moved)
+	assert.Equal(t, []string{"00101"}, wardCodes(t, svc, "901"))
+	assert.Equal(t, []string{"00102"}, wardCodes(t, svc, "902"))
+
+	resolution, err := svc.Resolve(ctx, "001", "", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"901", "902"}, refCodes(resolution.Resolved))
+}
+
+func TestLocationService_ReorganizeInTheFuture(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestLocationService(t)
+	seedDistricts(t, svc)
+	effective := time.Now().Add(30 * 24 * time.Hour)
+
+	_, err := svc.Reorganize(ctx, &domain.ReorganizationDecree{
+		Reference:     "NQ-04",
+		EffectiveDate: effective,
+		Changes: []domain.LocationChange{{
+			Level: domain.LocationLevelWard,
+			From:  []string{"00201", "00202"},
+			To:    []domain.LocationUnit{{Code: "00299", Name: map[string]string{"vi": "Phường Tương Lai"}, ParentCode: "002"}},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"00201", "00202"}, wardCodes(t, svc, "002"), "the decree is not in effect yet")
+
+	resolution, err := svc.Resolve(ctx, "00201", "", time.Now())
+	require.NoError(t, err)
+	assert.True(t, resolution.Current)
+	assert.Equal(t, []string{"00201"}, refCodes(resolution.Resolved))
+
+	resolution, err = svc.Resolve(ctx, "00201", "", effective)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"00299"}, refCodes(resolution.Resolved))
+}
+
+func TestLocationService_ReorganizeValidation(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestLocationService(t)
+	seedDistricts(t, svc)
+	at := time.Now()
+
+	tests := []struct {
+		name   string
+		change domain.LocationChange
+	}{
+		{
+			name:   "unknown unit",
+			change: domain.LocationChange{Level: domain.LocationLevelWard, From: []string{"99999"}, To: []domain.LocationUnit{{Code: "00101"}}},
+		},
+		{
+			name:   "new unit without parent",
+			change: domain.LocationChange{Level: domain.LocationLevelWard, From: []string{"00101"}, To: []domain.LocationUnit{{Code: "00150", Name: map[string]string{"vi": "X"}}}},
+		},
+		{
+			name:   "missing parent",
+			change: domain.LocationChange{Level: domain.LocationLevelWard, From: []string{"00101"}, To: []domain.LocationUnit{{Code: "00150", Name: map[string]string{"vi": "X"}, ParentCode: "777"}}},
+		},
+		{
+			name:   "duplicate successor",
+			change: domain.LocationChange{Level: domain.LocationLevelWard, From: []string{"00101"}, To: []domain.LocationUnit{{Code: "00102"}, {Code: "00102"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Reorganize(ctx, &domain.ReorganizationDecree{Reference: "NQ", EffectiveDate: at, Changes: []domain.LocationChange{tt.change}})
+			assertStatus(t, err, http.StatusBadRequest)
+		})
+	}
+
+	_, err := svc.Reorganize(ctx, &domain.ReorganizationDecree{Reference: "NQ", EffectiveDate: at, Changes: []domain.LocationChange{
+		{Level: domain.LocationLevelWard, From: []string{"00101"}, To: []domain.LocationUnit{{Code: "00102"}}},
+		{Level: domain.LocationLevelWard, From: []string{"00101"}, To: []domain.LocationUnit{{Code: "00201"}}},
+	}})
+	assertStatus(t, err, http.StatusBadRequest)
+	assert.Equal(t, []string{"00101", "00102"}, wardCodes(t, svc, "001"), "nothing is written when the decree is rejected")
+}
+
+// failingWardRepository fails to retire one ward; during runs inside the transaction just before
+type failingWardRepository struct {
+	repository.WardRepository
+	code   string
+	during func()
+}
+
+func (r failingWardRepository) Retire(ctx context.Context, code string, at time.Time, successorCodes []string) error {
+	if code == r.code {
+		r.during()
+		return stderrors.New("write failed")
+	}
+	return r.WardRepository.Retire(ctx, code, at, successorCodes)
+}
+
+func TestLocationService_ReorganizeFailingPartwayAppliesNothing(t *testing.T) {
+	ctx := context.Background()
+	countries := repository.NewMemoryCountryRepository()
+	require.NoError(t, countries.Create(ctx, &domain.Country{Code: "VN", Name: map[string]string{"en": "Vietnam"}, Status: "active"}))
+	provinces, districts, wards := repository.NewMemoryProvinceRepository(), repository.NewMemoryDistrictRepository(), repository.NewMemoryWardRepository()
+	cache := newMemoryCache()
+	failing := failingWardRepository{WardRepository: wards, code: "00202", during: func() {
+		assert.True(t, cache.has(districtListKey("01")), "cached listings are only invalidated once the decree has committed")
+	}}
+	svc := NewLocationService(countries, provinces, districts, failing, repository.NewMemoryTransactor(provinces, districts, wards), cache, newTestLogger(t))
+	seedDistricts(t, svc)
+
+	_, err := svc.ListDistricts(ctx, "01", domain.Localization{})
+	require.NoError(t, err)
+
+	// the districts are merged and 00201 retired before retiring 00202 fails
+	_, err = svc.Reorganize(ctx, &domain.ReorganizationDecree{
+		Reference:     "NQ-05",
+		EffectiveDate: time.Now().Add(-time.Hour),
+		Changes: []domain.LocationChange{
+			{
+				Level: domain.LocationLevelDistrict,
+				From:  []string{"001", "002"},
+				To:    []domain.LocationUnit{{Code: "900", Name: map[string]string{"vi": "Quận Mới"}, ParentCode: "01"}},
+			},
+			{
+				Level: domain.LocationLevelWard,
+				From:  []string{"00201", "00202"},
+				To:    []domain.LocationUnit{{Code: "00299", Name: map[string]string{"vi": "Phường Mới"}, ParentCode: "900"}},
+			},
+		},
+	})
+	require.Error(t, err)
+
+	_, err = svc.GetDistrict(ctx, "900")
+	assertStatus(t, err, http.StatusNotFound)
+	_, err = svc.GetWard(ctx, "00299")
+	assertStatus(t, err, http.StatusNotFound)
+	for _, code := range []string{"001", "002"} {
+		district, err := svc.GetDistrict(ctx, code)
+		require.NoError(t, err)
+		assert.Nil(t, district.EffectiveTo, code)
+	}
+	ward, err := svc.GetWard(ctx, "00201")
+	require.NoError(t, err)
+	assert.Nil(t, ward.EffectiveTo)
+	assert.Equal(t, []string{"00101", "00102"}, wardCodes(t, svc, "001"))
+	assert.Equal(t, []string{"00201", "00202"}, wardCodes(t, svc, "002"))
+}
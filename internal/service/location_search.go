@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+)
+
+// searchCandidate is a matched unit before ranking and path resolution
+type searchCandidate struct {
+	hit        *domain.LocationHit
+	parentCode string
+	terms      []string
+}
+
+// Search finds provinces, districts and wards whose names match the query in any locale,
+// ignoring case and diacritics. Names starting with the query rank first, then names with
+// a word starting with it, then names merely containing it. Every hit carries its ancestors.
+func (s *LocationService) Search(ctx context.Context, req *domain.LocationSearchRequest) ([]*domain.LocationHit, error) {
+	req.SetDefaults()
+	if err := req.Validate(); err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+	text := domain.FoldText(req.Query)
+
+	scope, err := s.searchScope(ctx, strings.TrimSpace(req.ParentCode))
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*searchCandidate
+	for _, level := range []string{domain.LocationLevelProvince, domain.LocationLevelDistrict, domain.LocationLevelWard} {
+		if req.Level != "" && req.Level != level {
+			continue
+		}
+		within, ok := scope[level]
+		if !ok {
+			continue
+		}
+		found, err := s.searchLevel(ctx, level, text, within, req.Limit)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, found...)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ri, rj := searchRank(candidates[i].terms, text), searchRank(candidates[j].terms, text)
+		if ri != rj {
+			return ri < rj
+		}
+		return locationDepth(candidates[i].hit.Level) < locationDepth(candidates[j].hit.Level)
+	})
+	if len(candidates) > req.Limit {
+		candidates = candidates[:req.Limit]
+	}
+
+	paths := newLocationPaths(s)
+	hits := make([]*domain.LocationHit, 0, len(candidates))
+	for _, candidate := range candidates {
+		path, err := paths.resolve(ctx, candidate.hit.Level, candidate.parentCode)
+		if err != nil {
+			return nil, err
+		}
+		candidate.hit.Path = path
+		hits = append(hits, candidate.hit)
+	}
+	return hits, nil
+}
+
+// searchScope returns, per searchable level, the query fields restricting hits to units below
+// parentCode. Districts and wards store the codes of all their ancestors, so any parent is a
+// single filter. Levels missing from the map cannot match at all.
+func (s *LocationService) searchScope(ctx context.Context, parentCode string) (map[string]domain.LocationQuery, error) {
+	if parentCode == "" {
+		return map[string]domain.LocationQuery{
+			domain.LocationLevelProvince: {},
+			domain.LocationLevelDistrict: {},
+			domain.LocationLevelWard:     {},
+		}, nil
+	}
+
+	country, err := s.countries.FindByCode(ctx, normalizeCountryCode(parentCode))
+	if err != nil {
+		return nil, err
+	}
+	if country != nil {
+		within := domain.LocationQuery{CountryCode: country.Code}
+		return map[string]domain.LocationQuery{
+			domain.LocationLevelProvince: within,
+			domain.LocationLevelDistrict: within,
+			domain.LocationLevelWard:     within,
+		}, nil
+	}
+
+	province, err := s.provinces.FindByCode(ctx, parentCode)
+	if err != nil {
+		return nil, err
+	}
+	if province != nil {
+		within := domain.LocationQuery{ProvinceCode: province.Code}
+		return map[string]domain.LocationQuery{
+			domain.LocationLevelDistrict: within,
+			domain.LocationLevelWard:     within,
+		}, nil
+	}
+
+	district, err := s.districts.FindByCode(ctx, parentCode)
+	if err != nil {
+		return nil, err
+	}
+	if district != nil {
+		return map[string]domain.LocationQuery{domain.LocationLevelWard: {DistrictCode: district.Code}}, nil
+	}
+
+	return nil, errors.NotFound("Parent location not found")
+}
+
+// searchLevel runs a prefix query and, when it does not fill the limit, a substring query on one level
+// restricted to the ancestors set in within
+func (s *LocationService) searchLevel(ctx context.Context, level, text string, within domain.LocationQuery, limit int) ([]*searchCandidate, error) {
+	var candidates []*searchCandidate
+	seen := make(map[string]bool)
+	for _, prefix := range []bool{true, false} {
+		if len(candidates) >= limit {
+			break
+		}
+		query := within
+		query.Text, query.Prefix, query.Limit = text, prefix, limit
+
+		var found []*searchCandidate
+		switch level {
+		case domain.LocationLevelProvince:
+			provinces, err := s.provinces.Search(ctx, query)
+			if err != nil {
+				return nil, err
+			}
+			for _, province := range provinces {
+				found = append(found, newSearchCandidate(level, province.Code, province.Name, province.Type, province.CountryCode, province.SearchText))
+			}
+		case domain.LocationLevelDistrict:
+			districts, err := s.districts.Search(ctx, query)
+			if err != nil {
+				return nil, err
+			}
+			for _, district := range districts {
+				found = append(found, newSearchCandidate(level, district.Code, district.Name, district.Type, district.ProvinceCode, district.SearchText))
+			}
+		case domain.LocationLevelWard:
+			wards, err := s.wards.Search(ctx, query)
+			if err != nil {
+				return nil, err
+			}
+			for _, ward := range wards {
+				found = append(found, newSearchCandidate(level, ward.Code, ward.Name, ward.Type, ward.DistrictCode, ward.SearchText))
+			}
+		}
+
+		for _, candidate := range found {
+			if !seen[candidate.hit.Code] {
+				seen[candidate.hit.Code] = true
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	return candidates, nil
+}
+
+func newSearchCandidate(level, code string, name map[string]string, unitType, parentCode string, terms []string) *searchCandidate {
+	return &searchCandidate{
+		hit: &domain.LocationHit{
+			LocationRef: domain.LocationRef{Level: level, Code: code, Name: name},
+			Type:        unitType,
+		},
+		parentCode: parentCode,
+		terms:      terms,
+	}
+}
+
+// searchRank orders matches: 0 when a name starts with text, 1 when a word in it does, 2 otherwise
+func searchRank(terms []string, text string) int {
+	rank := 2
+	for _, term := range terms {
+		if strings.HasPrefix(term, text) {
+			return 0
+		}
+		if strings.Contains(term, " "+text) {
+			rank = 1
+		}
+	}
+	return rank
+}
+
+// locationDepth orders levels from the top of the hierarchy down
+func locationDepth(level string) int {
+	switch level {
+	case domain.LocationLevelCountry:
+		return 0
+	case domain.LocationLevelProvince:
+		return 1
+	case domain.LocationLevelDistrict:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// locationPaths resolves ancestor paths, memoizing the units shared between hits
+type locationPaths struct {
+	service *LocationService
+	refs    map[string]*domain.LocationRef
+	parents map[string]string
+}
+
+func newLocationPaths(s *LocationService) *locationPaths {
+	return &locationPaths{service: s, refs: make(map[string]*domain.LocationRef), parents: make(map[string]string)}
+}
+
+// resolve returns the ancestors of a unit at level whose direct parent is parentCode, country first
+func (p *locationPaths) resolve(ctx context.Context, level, parentCode string) ([]domain.LocationRef, error) {
+	path := []domain.LocationRef{}
+	for level != domain.LocationLevelCountry && parentCode != "" {
+		level = parentLevel(level)
+		ref, grandparent, err := p.lookup(ctx, level, parentCode)
+		if err != nil {
+			return nil, err
+		}
+		if ref == nil {
+			break
+		}
+		path = append([]domain.LocationRef{*ref}, path...)
+		parentCode = grandparent
+	}
+	return path, nil
+}
+
+func (p *locationPaths) lookup(ctx context.Context, level, code string) (*domain.LocationRef, string, error) {
+	key := level + ":" + code
+	if ref, ok := p.refs[key]; ok {
+		return ref, p.parents[key], nil
+	}
+
+	var ref *domain.LocationRef
+	var parentCode string
+	switch level {
+	case domain.LocationLevelCountry:
+		country, err := p.service.countries.FindByCode(ctx, code)
+		if err != nil {
+			return nil, "", err
+		}
+		if country != nil {
+			ref = &domain.LocationRef{Level: level, Code: country.Code, Name: country.Name}
+		}
+	case domain.LocationLevelProvince:
+		province, err := p.service.provinces.FindByCode(ctx, code)
+		if err != nil {
+			return nil, "", err
+		}
+		if province != nil {
+			ref = &domain.LocationRef{Level: level, Code: province.Code, Name: province.Name}
+			parentCode = province.CountryCode
+		}
+	case domain.LocationLevelDistrict:
+		district, err := p.service.districts.FindByCode(ctx, code)
+		if err != nil {
+			return nil, "", err
+		}
+		if district != nil {
+			ref = &domain.LocationRef{Level: level, Code: district.Code, Name: district.Name}
+			parentCode = district.ProvinceCode
+		}
+	}
+
+	p.refs[key] = ref
+	p.parents[key] = parentCode
+	return ref, parentCode, nil
+}
+
+// parentLevel returns the level directly above level
+func parentLevel(level string) string {
+	switch level {
+	case domain.LocationLevelWard:
+		return domain.LocationLevelDistrict
+	case domain.LocationLevelDistrict:
+		return domain.LocationLevelProvince
+	default:
+		return domain.LocationLevelCountry
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+)
+
+// seedLocations creates Hà Nội and Hà Nam with a few districts and wards under them
+func seedLocations(t *testing.T, svc *LocationService) {
+	ctx := context.Background()
+	for _, province := range []*domain.Province{
+		{Code: "01", Name: map[string]string{"vi": "Hà Nội", "en": "Hanoi"}, CountryCode: "VN", Type: "city"},
+		{Code: "35", Name: map[string]string{"vi": "Hà Nam", "en": "Ha Nam"}, CountryCode: "VN", Type: "province"},
+	} {
+		require.NoError(t, svc.CreateProvince(ctx, province))
+	}
+	for _, district := range []*domain.District{
+		{Code: "001", Name: map[string]string{"vi": "Ba Đình"}, ProvinceCode: "01"},
+		{Code: "268", Name: map[string]string{"vi": "Hà Đông"}, ProvinceCode: "01"},
+		{Code: "347", Name: map[string]string{"vi": "Phủ Lý"}, ProvinceCode: "35"},
+	} {
+		require.NoError(t, svc.CreateDistrict(ctx, district))
+	}
+	for _, ward := range []*domain.Ward{
+		{Code: "00001", Name: map[string]string{"vi": "Phúc Xá"}, DistrictCode: "001"},
+		{Code: "00004", Name: map[string]string{"vi": "Trúc Bạch"}, DistrictCode: "001"},
+		{Code: "00103", Name: map[string]string{"vi": "Nhật Tân"}, DistrictCode: "001"},
+		{Code: "13321", Name: map[string]string{"vi": "Thanh Hà"}, DistrictCode: "347"},
+	} {
+		require.NoError(t, svc.CreateWard(ctx, ward))
+	}
+}
+
+func TestLocationService_SearchFoldsDiacritics(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestLocationService(t)
+	seedLocations(t, svc)
+
+	hits, err := svc.Search(ctx, &domain.LocationSearchRequest{Query: "ha noi"})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, "01", hits[0].Code)
+	assert.Equal(t, domain.LocationLevelProvince, hits[0].Level)
+	assert.Equal(t, "Hà Nội", hits[0].Name["vi"])
+	require.Len(t, hits[0].Path, 1)
+	assert.Equal(t, "VN", hits[0].Path[0].Code)
+
+	hits, err = svc.Search(ctx, &domain.LocationSearchRequest{Query: "TRUC BACH"})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	var path []string
+	for _, ref := range hits[0].Path {
+		path = append(path, ref.Level+":"+ref.Code)
+	}
+	assert.Equal(t, []string{"country:VN", "province:01", "district:001"}, path)
+}
+
+func TestLocationService_SearchRanksPrefixMatchesFirst(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestLocationService(t)
+	seedLocations(t, svc)
+
+	hits, err := svc.Search(ctx, &domain.LocationSearchRequest{Query: "ha"})
+	require.NoError(t, err)
+	var codes []string
+	for _, hit := range hits {
+		codes = append(codes, hit.Code)
+	}
+	// prefix matches by level, then the word match "Thanh Hà", then the substring match "Nhật Tân"
+	assert.Equal(t, []string{"01", "35", "268", "13321", "00103"}, codes)
+
+	hits, err = svc.Search(ctx, &domain.LocationSearchRequest{Query: "ha", Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, hits, 2)
+}
+
+func TestLocationService_SearchFilters(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestLocationService(t)
+	seedLocations(t, svc)
+
+	hits, err := svc.Search(ctx, &domain.LocationSearchRequest{Query: "ha", Level: domain.LocationLevelWard})
+	require.NoError(t, err)
+	require.Len(t, hits, 2)
+	assert.Equal(t, "13321", hits[0].Code)
+
+	hits, err = svc.Search(ctx, &domain.LocationSearchRequest{Query: "ha", ParentCode: "01"})
+	require.NoError(t, err)
+	var codes []string
+	for _, hit := range hits {
+		codes = append(codes, hit.Code)
+	}
+	assert.Equal(t, []string{"268", "00103"}, codes)
+
+	hits, err = svc.Search(ctx, &domain.LocationSearchRequest{Query: "ha", ParentCode: "vn", Level: domain.LocationLevelProvince})
+	require.NoError(t, err)
+	assert.Len(t, hits, 2)
+
+	hits, err = svc.Search(ctx, &domain.LocationSearchRequest{Query: "ha", ParentCode: "001", Level: domain.LocationLevelDistrict})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+
+	_, err = svc.Search(ctx, &domain.LocationSearchRequest{Query: "ha", ParentCode: "99"})
+	assertStatus(t, err, http.StatusNotFound)
+	_, err = svc.Search(ctx, &domain.LocationSearchRequest{Query: " - "})
+	assertStatus(t, err, http.StatusBadRequest)
+	_, err = svc.Search(ctx, &domain.LocationSearchRequest{Query: "ha", Level: "country"})
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+func TestLocationService_SearchFollowsMovedUnits(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestLocationService(t)
+	seedLocations(t, svc)
+	require.NoError(t, svc.countries.Create(ctx, &domain.Country{Code: "LA", Name: map[string]string{"en": "Laos"}, Status: "active"}))
+
+	search := func(parentCode string) []string {
+		hits, err := svc.Search(ctx, &domain.LocationSearchRequest{Query: "truc bach", ParentCode: parentCode})
+		require.NoError(t, err)
+		codes := []string{}
+		for _, hit := range hits {
+			codes = append(codes, hit.Code)
+		}
+		return codes
+	}
+
+	// the wards of a moved district move along with it
+	require.NoError(t, svc.UpdateDistrict(ctx, &domain.District{Code: "001", Name: map[string]string{"vi": "Ba Đình"}, ProvinceCode: "35"}))
+	assert.Empty(t, search("01"))
+	assert.Equal(t, []string{"00004"}, search("35"))
+	assert.Equal(t, []string{"00004"}, search("VN"))
+
+	// and so do the districts and wards of a province moved to another country
+	require.NoError(t, svc.UpdateProvince(ctx, &domain.Province{Code: "35", Name: map[string]string{"vi": "Hà Nam"}, CountryCode: "LA"}))
+	assert.Empty(t, search("VN"))
+	assert.Equal(t, []string{"00004"}, search("LA"))
+
+	hits, err := svc.Search(ctx, &domain.LocationSearchRequest{Query: "phu ly", ParentCode: "LA"})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, "347", hits[0].Code)
+}
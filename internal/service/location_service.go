@@ -0,0 +1,667 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+// LocationService handles the administrative unit hierarchy country → province → district → ward.
+// Writes keep the hierarchy referentially intact: a unit can only be placed under an existing
+// parent, and a unit cannot be deleted while units below it still reference it.
+type LocationService struct {
+	countries repository.CountryRepository
+	provinces repository.ProvinceRepository
+	districts repository.DistrictRepository
+	wards     repository.WardRepository
+	tx        repository.Transactor
+	cache     cacheStore
+	logger    *logger.Logger
+}
+
+// NewLocationService creates a new location service
+func NewLocationService(
+	countries repository.CountryRepository,
+	provinces repository.ProvinceRepository,
+	districts repository.DistrictRepository,
+	wards repository.WardRepository,
+	tx repository.Transactor,
+	cache Cache,
+	log *logger.Logger,
+) *LocationService {
+	return &LocationService{
+		countries: countries,
+		provinces: provinces,
+		districts: districts,
+		wards:     wards,
+		tx:        tx,
+		cache:     cacheStore{cache: cache, logger: log},
+		logger:    log,
+	}
+}
+
+// CreateProvince creates a province under an existing country
+func (s *LocationService) CreateProvince(ctx context.Context, province *domain.Province) error {
+	province.Code = strings.TrimSpace(province.Code)
+	province.CountryCode = normalizeCountryCode(province.CountryCode)
+	if err := province.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	if err := s.requireCountry(ctx, province.CountryCode); err != nil {
+		return err
+	}
+
+	existing, err := s.provinces.FindByCode(ctx, province.Code)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.Conflict(fmt.Sprintf("Province with code '%s' already exists", province.Code))
+	}
+
+	if province.Status == "" {
+		province.Status = "active"
+	}
+
+	if err := s.provinces.Create(ctx, province); err != nil {
+		if stderrors.Is(err, repository.ErrDuplicateKey) {
+			return errors.Conflict(fmt.Sprintf("Province with code '%s' already exists", province.Code))
+		}
+		return err
+	}
+
+	s.cache.invalidate(ctx, provinceListKey(province.CountryCode))
+	s.logger.Info("Province created", zap.String("code", province.Code), zap.String("country_code", province.CountryCode))
+	return nil
+}
+
+// GetProvince gets a province by code
+func (s *LocationService) GetProvince(ctx context.Context, code string) (*domain.Province, error) {
+	key := provinceKey(code)
+	var cached domain.Province
+	if s.cache.get(ctx, key, &cached) {
+		return &cached, nil
+	}
+
+	province, err := s.provinces.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if province == nil {
+		return nil, errors.NotFound("Province not found")
+	}
+
+	s.cache.set(ctx, key, province, masterDataTTL)
+	return province, nil
+}
+
+// ListProvinces lists the active provinces of a country with names localized for l10n
+func (s *LocationService) ListProvinces(ctx context.Context, countryCode string, l10n domain.Localization) ([]*domain.Province, error) {
+	provinces, err := s.listProvinces(ctx, countryCode)
+	if err != nil {
+		return nil, err
+	}
+	domain.LocalizeAll(provinces, l10n)
+	return provinces, nil
+}
+
+// listProvinces lists the active provinces of a country
+func (s *LocationService) listProvinces(ctx context.Context, countryCode string) ([]*domain.Province, error) {
+	countryCode = normalizeCountryCode(countryCode)
+
+	key := provinceListKey(countryCode)
+	var cached []*domain.Province
+	if s.cache.get(ctx, key, &cached) {
+		return cached, nil
+	}
+
+	country, err := s.countries.FindByCode(ctx, countryCode)
+	if err != nil {
+		return nil, err
+	}
+	if country == nil {
+		return nil, errors.NotFound("Country not found")
+	}
+
+	provinces, err := s.provinces.ListByCountry(ctx, countryCode)
+	if err != nil {
+		return nil, err
+	}
+	next, err := s.provinces.NextValidityChange(ctx, countryCode, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	s.cacheListing(ctx, key, provinces, next)
+	return provinces, nil
+}
+
+// UpdateProvince updates the province identified by province.Code. Validity periods and successors are kept;
+// they change through reorganizations only.
+// Moving it to another country requires that country to exist, and moves its districts and wards along
+// in the same transaction.
+func (s *LocationService) UpdateProvince(ctx context.Context, province *domain.Province) error {
+	txCtx, invalidated := deferInvalidation(ctx)
+	if err := s.tx.WithTransaction(txCtx, func(ctx context.Context) error {
+		return s.updateProvince(ctx, province)
+	}); err != nil {
+		return err
+	}
+	s.cache.flush(ctx, invalidated)
+	return nil
+}
+
+// updateProvince updates a province and the country recorded on the units below it
+func (s *LocationService) updateProvince(ctx context.Context, province *domain.Province) error {
+	existing, err := s.provinces.FindByCode(ctx, province.Code)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.NotFound("Province not found")
+	}
+
+	province.ID = existing.ID
+	province.CreatedAt = existing.CreatedAt
+	province.LocationValidity = existing.LocationValidity
+	province.CountryCode = normalizeCountryCode(province.CountryCode)
+	if province.CountryCode == "" {
+		province.CountryCode = existing.CountryCode
+	}
+	if province.Status == "" {
+		province.Status = existing.Status
+	}
+
+	if err := province.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	if province.CountryCode != existing.CountryCode {
+		if err := s.requireCountry(ctx, province.CountryCode); err != nil {
+			return err
+		}
+	}
+
+	if err := s.provinces.Update(ctx, province); err != nil {
+		if stderrors.Is(err, repository.ErrNotFound) {
+			return errors.NotFound("Province not found")
+		}
+		return err
+	}
+	if province.CountryCode != existing.CountryCode {
+		if err := s.districts.SetCountryByProvince(ctx, province.Code, province.CountryCode); err != nil {
+			return err
+		}
+		if err := s.wards.SetCountryByProvince(ctx, province.Code, province.CountryCode); err != nil {
+			return err
+		}
+	}
+
+	s.cache.invalidate(ctx, provinceKey(province.Code), provinceListKey(existing.CountryCode), provinceListKey(province.CountryCode))
+	return nil
+}
+
+// DeleteProvince deletes a province that has no districts left
+func (s *LocationService) DeleteProvince(ctx context.Context, code string) error {
+	existing, err := s.provinces.FindByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.NotFound("Province not found")
+	}
+
+	children, err := s.districts.CountByProvince(ctx, code)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return errors.Conflict(fmt.Sprintf("Cannot delete province '%s': it still has %d districts", code, children))
+	}
+
+	if err := s.provinces.Delete(ctx, code); err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, provinceKey(code), provinceListKey(existing.CountryCode))
+	s.logger.Info("Province deleted", zap.String("code", code))
+	return nil
+}
+
+// CreateDistrict creates a district under an existing province
+func (s *LocationService) CreateDistrict(ctx context.Context, district *domain.District) error {
+	district.Code = strings.TrimSpace(district.Code)
+	district.ProvinceCode = strings.TrimSpace(district.ProvinceCode)
+	if err := district.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	province, err := s.requireProvince(ctx, district.ProvinceCode)
+	if err != nil {
+		return err
+	}
+	district.CountryCode = province.CountryCode
+
+	existing, err := s.districts.FindByCode(ctx, district.Code)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.Conflict(fmt.Sprintf("District with code '%s' already exists", district.Code))
+	}
+
+	if district.Status == "" {
+		district.Status = "active"
+	}
+
+	if err := s.districts.Create(ctx, district); err != nil {
+		if stderrors.Is(err, repository.ErrDuplicateKey) {
+			return errors.Conflict(fmt.Sprintf("District with code '%s' already exists", district.Code))
+		}
+		return err
+	}
+
+	s.cache.invalidate(ctx, districtListKey(district.ProvinceCode))
+	s.logger.Info("District created", zap.String("code", district.Code), zap.String("province_code", district.ProvinceCode))
+	return nil
+}
+
+// GetDistrict gets a district by code
+func (s *LocationService) GetDistrict(ctx context.Context, code string) (*domain.District, error) {
+	key := districtKey(code)
+	var cached domain.District
+	if s.cache.get(ctx, key, &cached) {
+		return &cached, nil
+	}
+
+	district, err := s.districts.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if district == nil {
+		return nil, errors.NotFound("District not found")
+	}
+
+	s.cache.set(ctx, key, district, masterDataTTL)
+	return district, nil
+}
+
+// ListDistricts lists the active districts of a province with names localized for l10n
+func (s *LocationService) ListDistricts(ctx context.Context, provinceCode string, l10n domain.Localization) ([]*domain.District, error) {
+	districts, err := s.listDistricts(ctx, provinceCode)
+	if err != nil {
+		return nil, err
+	}
+	domain.LocalizeAll(districts, l10n)
+	return districts, nil
+}
+
+// listDistricts lists the active districts of a province
+func (s *LocationService) listDistricts(ctx context.Context, provinceCode string) ([]*domain.District, error) {
+	provinceCode = strings.TrimSpace(provinceCode)
+
+	key := districtListKey(provinceCode)
+	var cached []*domain.District
+	if s.cache.get(ctx, key, &cached) {
+		return cached, nil
+	}
+
+	province, err := s.provinces.FindByCode(ctx, provinceCode)
+	if err != nil {
+		return nil, err
+	}
+	if province == nil {
+		return nil, errors.NotFound("Province not found")
+	}
+
+	districts, err := s.districts.ListByProvince(ctx, provinceCode)
+	if err != nil {
+		return nil, err
+	}
+	next, err := s.districts.NextValidityChange(ctx, provinceCode, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	s.cacheListing(ctx, key, districts, next)
+	return districts, nil
+}
+
+// UpdateDistrict updates the district identified by district.Code. Validity periods and successors are kept;
+// they change through reorganizations only.
+// Moving it to another province requires that province to exist, and moves its wards along in the
+// same transaction.
+func (s *LocationService) UpdateDistrict(ctx context.Context, district *domain.District) error {
+	txCtx, invalidated := deferInvalidation(ctx)
+	if err := s.tx.WithTransaction(txCtx, func(ctx context.Context) error {
+		return s.updateDistrict(ctx, district)
+	}); err != nil {
+		return err
+	}
+	s.cache.flush(ctx, invalidated)
+	return nil
+}
+
+// updateDistrict updates a district and the province and country recorded on its wards
+func (s *LocationService) updateDistrict(ctx context.Context, district *domain.District) error {
+	existing, err := s.districts.FindByCode(ctx, district.Code)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.NotFound("District not found")
+	}
+
+	district.ID = existing.ID
+	district.CreatedAt = existing.CreatedAt
+	district.LocationValidity = existing.LocationValidity
+	district.ProvinceCode = strings.TrimSpace(district.ProvinceCode)
+	if district.ProvinceCode == "" {
+		district.ProvinceCode = existing.ProvinceCode
+	}
+	district.CountryCode = existing.CountryCode
+	if district.Status == "" {
+		district.Status = existing.Status
+	}
+
+	if err := district.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	if district.ProvinceCode != existing.ProvinceCode {
+		province, err := s.requireProvince(ctx, district.ProvinceCode)
+		if err != nil {
+			return err
+		}
+		district.CountryCode = province.CountryCode
+	}
+
+	if err := s.districts.Update(ctx, district); err != nil {
+		if stderrors.Is(err, repository.ErrNotFound) {
+			return errors.NotFound("District not found")
+		}
+		return err
+	}
+	if district.ProvinceCode != existing.ProvinceCode || district.CountryCode != existing.CountryCode {
+		if err := s.wards.SetAncestorsByDistrict(ctx, district.Code, district.ProvinceCode, district.CountryCode); err != nil {
+			return err
+		}
+	}
+
+	s.cache.invalidate(ctx, districtKey(district.Code), districtListKey(existing.ProvinceCode), districtListKey(district.ProvinceCode))
+	return nil
+}
+
+// DeleteDistrict deletes a district that has no wards left
+func (s *LocationService) DeleteDistrict(ctx context.Context, code string) error {
+	existing, err := s.districts.FindByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.NotFound("District not found")
+	}
+
+	children, err := s.wards.CountByDistrict(ctx, code)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return errors.Conflict(fmt.Sprintf("Cannot delete district '%s': it still has %d wards", code, children))
+	}
+
+	if err := s.districts.Delete(ctx, code); err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, districtKey(code), districtListKey(existing.ProvinceCode))
+	s.logger.Info("District deleted", zap.String("code", code))
+	return nil
+}
+
+// CreateWard creates a ward under an existing district
+func (s *LocationService) CreateWard(ctx context.Context, ward *domain.Ward) error {
+	ward.Code = strings.TrimSpace(ward.Code)
+	ward.DistrictCode = strings.TrimSpace(ward.DistrictCode)
+	if err := ward.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	district, err := s.requireDistrict(ctx, ward.DistrictCode)
+	if err != nil {
+		return err
+	}
+	ward.ProvinceCode, ward.CountryCode = district.ProvinceCode, district.CountryCode
+
+	existing, err := s.wards.FindByCode(ctx, ward.Code)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.Conflict(fmt.Sprintf("Ward with code '%s' already exists", ward.Code))
+	}
+
+	if ward.Status == "" {
+		ward.Status = "active"
+	}
+
+	if err := s.wards.Create(ctx, ward); err != nil {
+		if stderrors.Is(err, repository.ErrDuplicateKey) {
+			return errors.Conflict(fmt.Sprintf("Ward with code '%s' already exists", ward.Code))
+		}
+		return err
+	}
+
+	s.cache.invalidate(ctx, wardListKey(ward.DistrictCode))
+	s.logger.Info("Ward created", zap.String("code", ward.Code), zap.String("district_code", ward.DistrictCode))
+	return nil
+}
+
+// GetWard gets a ward by code
+func (s *LocationService) GetWard(ctx context.Context, code string) (*domain.Ward, error) {
+	key := wardKey(code)
+	var cached domain.Ward
+	if s.cache.get(ctx, key, &cached) {
+		return &cached, nil
+	}
+
+	ward, err := s.wards.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if ward == nil {
+		return nil, errors.NotFound("Ward not found")
+	}
+
+	s.cache.set(ctx, key, ward, masterDataTTL)
+	return ward, nil
+}
+
+// ListWards lists the active wards of a district with names localized for l10n
+func (s *LocationService) ListWards(ctx context.Context, districtCode string, l10n domain.Localization) ([]*domain.Ward, error) {
+	wards, err := s.listWards(ctx, districtCode)
+	if err != nil {
+		return nil, err
+	}
+	domain.LocalizeAll(wards, l10n)
+	return wards, nil
+}
+
+// listWards lists the active wards of a district
+func (s *LocationService) listWards(ctx context.Context, districtCode string) ([]*domain.Ward, error) {
+	districtCode = strings.TrimSpace(districtCode)
+
+	key := wardListKey(districtCode)
+	var cached []*domain.Ward
+	if s.cache.get(ctx, key, &cached) {
+		return cached, nil
+	}
+
+	district, err := s.districts.FindByCode(ctx, districtCode)
+	if err != nil {
+		return nil, err
+	}
+	if district == nil {
+		return nil, errors.NotFound("District not found")
+	}
+
+	wards, err := s.wards.ListByDistrict(ctx, districtCode)
+	if err != nil {
+		return nil, err
+	}
+	next, err := s.wards.NextValidityChange(ctx, districtCode, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	s.cacheListing(ctx, key, wards, next)
+	return wards, nil
+}
+
+// UpdateWard updates the ward identified by ward.Code. Validity periods and successors are kept;
+// they change through reorganizations only.
+// Moving it to another district requires that district to exist.
+func (s *LocationService) UpdateWard(ctx context.Context, ward *domain.Ward) error {
+	existing, err := s.wards.FindByCode(ctx, ward.Code)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.NotFound("Ward not found")
+	}
+
+	ward.ID = existing.ID
+	ward.CreatedAt = existing.CreatedAt
+	ward.LocationValidity = existing.LocationValidity
+	ward.DistrictCode = strings.TrimSpace(ward.DistrictCode)
+	if ward.DistrictCode == "" {
+		ward.DistrictCode = existing.DistrictCode
+	}
+	ward.ProvinceCode, ward.CountryCode = existing.ProvinceCode, existing.CountryCode
+	if ward.Status == "" {
+		ward.Status = existing.Status
+	}
+
+	if err := ward.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	if ward.DistrictCode != existing.DistrictCode {
+		district, err := s.requireDistrict(ctx, ward.DistrictCode)
+		if err != nil {
+			return err
+		}
+		ward.ProvinceCode, ward.CountryCode = district.ProvinceCode, district.CountryCode
+	}
+
+	if err := s.wards.Update(ctx, ward); err != nil {
+		if stderrors.Is(err, repository.ErrNotFound) {
+			return errors.NotFound("Ward not found")
+		}
+		return err
+	}
+
+	s.cache.invalidate(ctx, wardKey(ward.Code), wardListKey(existing.DistrictCode), wardListKey(ward.DistrictCode))
+	return nil
+}
+
+// DeleteWard deletes a ward
+func (s *LocationService) DeleteWard(ctx context.Context, code string) error {
+	existing, err := s.wards.FindByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.NotFound("Ward not found")
+	}
+
+	if err := s.wards.Delete(ctx, code); err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, wardKey(code), wardListKey(existing.DistrictCode))
+	s.logger.Info("Ward deleted", zap.String("code", code))
+	return nil
+}
+
+// cacheListing caches a listing of the units in effect now until the next change of validity
+// among the listed parent's units, at most for masterDataTTL. A listing whose units change
+// sooner than a second from now is not cached.
+func (s *LocationService) cacheListing(ctx context.Context, key string, listing interface{}, next *time.Time) {
+	ttl := masterDataTTL
+	if next != nil {
+		ttl = min(ttl, time.Until(*next))
+	}
+	if ttl < time.Second {
+		return
+	}
+	s.cache.set(ctx, key, listing, ttl)
+}
+
+// requireCountry fails with BadRequest unless the country exists
+func (s *LocationService) requireCountry(ctx context.Context, code string) error {
+	country, err := s.countries.FindByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	if country == nil {
+		return errors.BadRequest(fmt.Sprintf("Country '%s' does not exist", code))
+	}
+	return nil
+}
+
+// requireProvince loads the province, failing with BadRequest unless it exists
+func (s *LocationService) requireProvince(ctx context.Context, code string) (*domain.Province, error) {
+	province, err := s.provinces.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if province == nil {
+		return nil, errors.BadRequest(fmt.Sprintf("Province '%s' does not exist", code))
+	}
+	return province, nil
+}
+
+// requireDistrict loads the district, failing with BadRequest unless it exists
+func (s *LocationService) requireDistrict(ctx context.Context, code string) (*domain.District, error) {
+	district, err := s.districts.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if district == nil {
+		return nil, errors.BadRequest(fmt.Sprintf("District '%s' does not exist", code))
+	}
+	return district, nil
+}
+
+// normalizeCountryCode matches the upper-case ISO codes countries are stored under
+func normalizeCountryCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func provinceKey(code string) string {
+	return cacheKey("locations", "provinces", code)
+}
+
+func provinceListKey(countryCode string) string {
+	return cacheKey("locations", "countries", countryCode, "provinces")
+}
+
+func districtKey(code string) string {
+	return cacheKey("locations", "districts", code)
+}
+
+func districtListKey(provinceCode string) string {
+	return cacheKey("locations", "provinces", provinceCode, "districts")
+}
+
+func wardKey(code string) string {
+	return cacheKey("locations", "wards", code)
+}
+
+func wardListKey(districtCode string) string {
+	return cacheKey("locations", "districts", districtCode, "wards")
+}
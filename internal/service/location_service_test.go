@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+)
+
+func newTestLocationService(t *testing.T) (*LocationService, *memoryCache) {
+	cache := newMemoryCache()
+	countries := repository.NewMemoryCountryRepository()
+	require.NoError(t, countries.Create(context.Background(), &domain.Country{
+		Code: "VN", Name: map[string]string{"en": "Vietnam"}, Status: "active",
+	}))
+
+	return NewLocationService(countries, repository.NewMemoryProvinceRepository(), repository.NewMemoryDistrictRepository(),
+		repository.NewMemoryWardRepository(), repository.NewMemoryTransactor(), cache, newTestLogger(t)), cache
+}
+
+func TestLocationService_Hierarchy(t *testing.T) {
+	ctx := context.Background()
+	svc, cache := newTestLocationService(t)
+
+	province := &domain.Province{Code: "01", Name: map[string]string{"vi": "Hà Nội"}, CountryCode: "vn"}
+	require.NoError(t, svc.CreateProvince(ctx, province))
+	assert.Equal(t, "VN", province.CountryCode)
+	assert.Equal(t, "active", province.Status)
+
+	district := &domain.District{Code: "001", Name: map[string]string{"vi": "Ba Đình"}, ProvinceCode: "01"}
+	require.NoError(t, svc.CreateDistrict(ctx, district))
+	ward := &domain.Ward{Code: "00001", Name: map[string]string{"vi": "Phúc Xá"}, DistrictCode: "001"}
+	require.NoError(t, svc.CreateWard(ctx, ward))
+
+	provinces, err := svc.ListProvinces(ctx, "vn", domain.Localization{})
+	require.NoError(t, err)
+	require.Len(t, provinces, 1)
+	assert.True(t, cache.has(provinceListKey("VN")))
+
+	districts, err := svc.ListDistricts(ctx, "01", domain.Localization{})
+	require.NoError(t, err)
+	require.Len(t, districts, 1)
+
+	wards, err := svc.ListWards(ctx, "001", domain.Localization{})
+	require.NoError(t, err)
+	require.Len(t, wards, 1)
+	assert.Equal(t, "Phúc Xá", wards[0].Name["vi"])
+
+	require.NoError(t, svc.CreateProvince(ctx, &domain.Province{Code: "79", Name: map[string]string{"vi": "Hồ Chí Minh"}, CountryCode: "VN"}))
+	assert.False(t, cache.has(provinceListKey("VN")))
+
+	_, err = svc.ListDistricts(ctx, "99", domain.Localization{})
+	assertStatus(t, err, http.StatusNotFound)
+	_, err = svc.GetWard(ctx, "99999")
+	assertStatus(t, err, http.StatusNotFound)
+
+	// Listings are localized and, on request, ordered by localized name, cached or not
+	require.NoError(t, svc.CreateProvince(ctx, &domain.Province{Code: "48", Name: map[string]string{"vi": "Đà Nẵng"}, CountryCode: "VN"}))
+	sorted := domain.Localization{Locales: []string{"vi"}, SortByName: true}
+	for i := 0; i < 2; i++ {
+		provinces, err = svc.ListProvinces(ctx, "VN", sorted)
+		require.NoError(t, err)
+		require.Len(t, provinces, 3)
+		assert.Equal(t, []string{"Đà Nẵng", "Hà Nội", "Hồ Chí Minh"},
+			[]string{provinces[0].LocalizedName, provinces[1].LocalizedName, provinces[2].LocalizedName})
+	}
+	provinces, err = svc.ListProvinces(ctx, "VN", domain.Localization{})
+	require.NoError(t, err)
+	assert.Equal(t, "01", provinces[0].Code, "the cache keeps listings in code order")
+}
+
+func TestLocationService_ReferentialIntegrity(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestLocationService(t)
+
+	err := svc.CreateProvince(ctx, &domain.Province{Code: "01", Name: map[string]string{"vi": "Hà Nội"}, CountryCode: "XX"})
+	assertStatus(t, err, http.StatusBadRequest)
+	err = svc.CreateDistrict(ctx, &domain.District{Code: "001", Name: map[string]string{"vi": "Ba Đình"}, ProvinceCode: "01"})
+	assertStatus(t, err, http.StatusBadRequest)
+	err = svc.CreateWard(ctx, &domain.Ward{Code: "00001", Name: map[string]string{"vi": "Phúc Xá"}, DistrictCode: "001"})
+	assertStatus(t, err, http.StatusBadRequest)
+
+	require.NoError(t, svc.CreateProvince(ctx, &domain.Province{Code: "01", Name: map[string]string{"vi": "Hà Nội"}, CountryCode: "VN"}))
+	require.NoError(t, svc.CreateDistrict(ctx, &domain.District{Code: "001", Name: map[string]string{"vi": "Ba Đình"}, ProvinceCode: "01"}))
+	require.NoError(t, svc.CreateWard(ctx, &domain.Ward{Code: "00001", Name: map[string]string{"vi": "Phúc Xá"}, DistrictCode: "001"}))
+
+	err = svc.CreateDistrict(ctx, &domain.District{Code: "001", Name: map[string]string{"vi": "Ba Đình"}, ProvinceCode: "01"})
+	assertStatus(t, err, http.StatusConflict)
+
+	err = svc.UpdateDistrict(ctx, &domain.District{Code: "001", Name: map[string]string{"vi": "Ba Đình"}, ProvinceCode: "79"})
+	assertStatus(t, err, http.StatusBadRequest)
+
+	assertStatus(t, svc.DeleteProvince(ctx, "01"), http.StatusConflict)
+	assertStatus(t, svc.DeleteDistrict(ctx, "001"), http.StatusConflict)
+
+	require.NoError(t, svc.DeleteWard(ctx, "00001"))
+	require.NoError(t, svc.DeleteDistrict(ctx, "001"))
+	require.NoError(t, svc.DeleteProvince(ctx, "01"))
+	assertStatus(t, svc.DeleteProvince(ctx, "01"), http.StatusNotFound)
+}
+
+func TestLocationService_UpdateMovesBetweenParents(t *testing.T) {
+	ctx := context.Background()
+	svc, cache := newTestLocationService(t)
+
+	for _, code := range []string{"01", "79"} {
+		require.NoError(t, svc.CreateProvince(ctx, &domain.Province{Code: code, Name: map[string]string{"en": code}, CountryCode: "VN"}))
+	}
+	require.NoError(t, svc.CreateDistrict(ctx, &domain.District{Code: "001", Name: map[string]string{"en": "001"}, ProvinceCode: "01"}))
+
+	_, err := svc.ListDistricts(ctx, "01", domain.Localization{})
+	require.NoError(t, err)
+	_, err = svc.GetDistrict(ctx, "001")
+	require.NoError(t, err)
+
+	update := &domain.District{Code: "001", Name: map[string]string{"en": "District 1"}, ProvinceCode: "79"}
+	require.NoError(t, svc.UpdateDistrict(ctx, update))
+	assert.False(t, cache.has(districtListKey("01")))
+	assert.False(t, cache.has(districtKey("001")))
+
+	districts, err := svc.ListDistricts(ctx, "01", domain.Localization{})
+	require.NoError(t, err)
+	assert.Empty(t, districts)
+	districts, err = svc.ListDistricts(ctx, "79", domain.Localization{})
+	require.NoError(t, err)
+	require.Len(t, districts, 1)
+	assert.Equal(t, "District 1", districts[0].Name["en"])
+	assert.Equal(t, "active", districts[0].Status)
+}
+
+func TestLocationService_ListingsExpireAtValidityChanges(t *testing.T) {
+	ctx := context.Background()
+	svc, cache := newTestLocationService(t)
+
+	require.NoError(t, svc.CreateProvince(ctx, &domain.Province{Code: "01", Name: map[string]string{"vi": "Hà Nội"}, CountryCode: "VN"}))
+	_, err := svc.ListProvinces(ctx, "VN", domain.Localization{})
+	require.NoError(t, err)
+	assert.Equal(t, masterDataTTL, cache.ttl(provinceListKey("VN")))
+
+	from := time.Now().Add(time.Hour)
+	require.NoError(t, svc.CreateProvince(ctx, &domain.Province{
+		Code: "02", Name: map[string]string{"vi": "Hà Giang"}, CountryCode: "VN",
+		LocationValidity: domain.LocationValidity{EffectiveFrom: &from},
+	}))
+	provinces, err := svc.ListProvinces(ctx, "VN", domain.Localization{})
+	require.NoError(t, err)
+	require.Len(t, provinces, 1)
+	assert.InDelta(t, time.Hour, cache.ttl(provinceListKey("VN")), float64(time.Second),
+		"the listing is cached until province 02 comes into effect")
+
+	soon := time.Now().Add(100 * time.Millisecond)
+	require.NoError(t, svc.CreateDistrict(ctx, &domain.District{
+		Code: "001", Name: map[string]string{"vi": "Ba Đình"}, ProvinceCode: "01",
+		LocationValidity: domain.LocationValidity{EffectiveTo: &soon},
+	}))
+	_, err = svc.ListDistricts(ctx, "01", domain.Localization{})
+	require.NoError(t, err)
+	assert.False(t, cache.has(districtListKey("01")), "a listing changing within a second is not cached")
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+)
+
+// buildMenuTree assembles the flat admin menu list of a tenant into a navigation tree.
+// Items that are inactive, hidden or require permissions the caller lacks are dropped
+// together with their subtrees, and group items left without visible children are pruned.
+// Items whose parent does not exist are reported as orphans; items caught in a parent
+// cycle are reported as cycles. Neither is rendered.
+func buildMenuTree(menus []*domain.AdminMenu, locale string, perms permissionSet) *domain.MenuTree {
+	byID := make(map[string]*domain.AdminMenu, len(menus))
+	for _, menu := range menus {
+		byID[menu.ID.Hex()] = menu
+	}
+
+	children := make(map[string][]*domain.AdminMenu)
+	var roots []*domain.AdminMenu
+	tree := &domain.MenuTree{Locale: locale, Items: []*domain.MenuNode{}}
+	for _, menu := range menus {
+		switch {
+		case menu.ParentID == "":
+			roots = append(roots, menu)
+		case byID[menu.ParentID] == nil:
+			tree.Orphans = append(tree.Orphans, menu.Code)
+		default:
+			children[menu.ParentID] = append(children[menu.ParentID], menu)
+		}
+	}
+	sort.Strings(tree.Orphans)
+
+	reached := make(map[string]bool, len(menus))
+	var render func(menu *domain.AdminMenu) *domain.MenuNode
+	render = func(menu *domain.AdminMenu) *domain.MenuNode {
+		reached[menu.ID.Hex()] = true
+		kids := children[menu.ID.Hex()]
+		sortMenus(kids)
+
+		node := &domain.MenuNode{
+			ID:          menu.ID.Hex(),
+			Code:        menu.Code,
+			ModuleCode:  menu.ModuleCode,
+			Title:       menuTitle(menu, locale),
+			Icon:        menu.Icon,
+			Path:        menu.Path,
+			Component:   menu.Component,
+			Order:       menu.Order,
+			Permissions: menu.Permissions,
+			Children:    []*domain.MenuNode{},
+		}
+		for _, kid := range kids {
+			if child := render(kid); child != nil {
+				node.Children = append(node.Children, child)
+			}
+		}
+
+		if !menuRenderable(menu, perms) {
+			return nil
+		}
+		if len(kids) > 0 && len(node.Children) == 0 && menu.Path == "" {
+			return nil
+		}
+		return node
+	}
+
+	sortMenus(roots)
+	for _, root := range roots {
+		if node := render(root); node != nil {
+			tree.Items = append(tree.Items, node)
+		}
+	}
+
+	tree.Cycles = findMenuCycles(menus, byID, reached)
+	return tree
+}
+
+// findMenuCycles walks the parent chain of every item not reached from a root
+// and returns each parent cycle once, as codes starting from the smallest one
+func findMenuCycles(menus []*domain.AdminMenu, byID map[string]*domain.AdminMenu, reached map[string]bool) [][]string {
+	var cycles [][]string
+	inCycle := make(map[string]bool)
+
+	for _, menu := range menus {
+		if reached[menu.ID.Hex()] || inCycle[menu.ID.Hex()] {
+			continue
+		}
+
+		position := make(map[string]int)
+		var chain []*domain.AdminMenu
+		current := menu
+		for current != nil && !inCycle[current.ID.Hex()] {
+			if start, seen := position[current.ID.Hex()]; seen {
+				cycle := chain[start:]
+				codes := make([]string, len(cycle))
+				first := 0
+				for i, item := range cycle {
+					inCycle[item.ID.Hex()] = true
+					if item.Code < cycle[first].Code {
+						first = i
+					}
+				}
+				for i := range cycle {
+					codes[i] = cycle[(first+i)%len(cycle)].Code
+				}
+				cycles = append(cycles, codes)
+				break
+			}
+			position[current.ID.Hex()] = len(chain)
+			chain = append(chain, current)
+			if current.ParentID == "" {
+				break
+			}
+			current = byID[current.ParentID]
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// menuRenderable reports whether a menu item itself may be shown to the caller
+func menuRenderable(menu *domain.AdminMenu, perms permissionSet) bool {
+	if menu.Status != "" && menu.Status != "active" {
+		return false
+	}
+	return menu.IsVisible && perms.allows(menu.Permissions)
+}
+
+// menuTitle localizes the title of a menu item, falling back to its name
+func menuTitle(menu *domain.AdminMenu, locale string) string {
+	if title := domain.Localize(menu.Title, locale); title != "" {
+		return title
+	}
+	return menu.Name
+}
+
+// sortMenus orders sibling menu items by order, then code
+func sortMenus(menus []*domain.AdminMenu) {
+	sort.SliceStable(menus, func(i, j int) bool {
+		if menus[i].Order != menus[j].Order {
+			return menus[i].Order < menus[j].Order
+		}
+		return menus[i].Code < menus[j].Code
+	})
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+)
+
+// moduleCatalog indexes the modules of a tenant by code
+type moduleCatalog map[string]*domain.SaaSModule
+
+func newModuleCatalog(modules []*domain.SaaSModule) moduleCatalog {
+	catalog := make(moduleCatalog, len(modules))
+	for _, module := range modules {
+		catalog[module.Code] = module
+	}
+	return catalog
+}
+
+// resolve returns the transitive closure of the given module codes in install order,
+// dependencies first. It fails on unknown modules, dependencies on deprecated modules
+// and dependency cycles.
+func (c moduleCatalog) resolve(codes []string) ([]*domain.SaaSModule, error) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	state := make(map[string]int, len(c))
+	ordered := make([]*domain.SaaSModule, 0, len(codes))
+	var path []string
+
+	var visit func(code string) error
+	visit = func(code string) error {
+		switch state[code] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := append(append([]string{}, path[indexOf(path, code):]...), code)
+			return fmt.Errorf("dependency cycle detected: %s", strings.Join(cycle, " -> "))
+		}
+
+		module := c[code]
+		state[code] = visiting
+		path = append(path, code)
+
+		for _, dependency := range module.Dependencies {
+			target, ok := c[dependency]
+			if !ok {
+				return fmt.Errorf("module '%s' depends on unknown module '%s'", code, dependency)
+			}
+			if target.Status == "deprecated" {
+				return fmt.Errorf("module '%s' depends on deprecated module '%s'", code, dependency)
+			}
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[code] = visited
+		ordered = append(ordered, module)
+		return nil
+	}
+
+	for _, code := range codes {
+		if _, ok := c[code]; !ok {
+			return nil, fmt.Errorf("unknown module '%s'", code)
+		}
+		if err := visit(code); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
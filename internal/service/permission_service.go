@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// PermissionService handles permission registry business logic
+type PermissionService struct {
+	repo   repository.PermissionRepository
+	cache  cacheStore
+	logger *logger.Logger
+}
+
+// NewPermissionService creates a new permission service
+func NewPermissionService(repo repository.PermissionRepository, cache Cache, log *logger.Logger) *PermissionService {
+	return &PermissionService{
+		repo:   repo,
+		cache:  cacheStore{cache: cache, logger: log},
+		logger: log,
+	}
+}
+
+// Create creates a new permission
+func (s *PermissionService) Create(ctx context.Context, permission *domain.Permission) error {
+	if permission.Status == "" {
+		permission.Status = "active"
+	}
+	if err := permission.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	existing, err := s.repo.FindByCode(ctx, permission.TenantID, permission.Code)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.Conflict(fmt.Sprintf("Permission with code '%s' already exists", permission.Code))
+	}
+
+	if err := s.repo.Create(ctx, permission); err != nil {
+		if stderrors.Is(err, repository.ErrDuplicateKey) {
+			return errors.Conflict(fmt.Sprintf("Permission with code '%s' already exists", permission.Code))
+		}
+		return err
+	}
+
+	s.cache.invalidate(ctx, permissionListKey(permission.TenantID))
+	s.logger.Info("Permission created",
+		zap.String("tenant_id", permission.TenantID),
+		zap.String("code", permission.Code),
+	)
+	return nil
+}
+
+// GetByID gets a permission by ID
+func (s *PermissionService) GetByID(ctx context.Context, id string) (*domain.Permission, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, errors.BadRequest("Invalid ID format")
+	}
+
+	permission, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if permission == nil {
+		return nil, errors.NotFound("Permission not found")
+	}
+	return permission, nil
+}
+
+// List lists permissions of a tenant with pagination
+func (s *PermissionService) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.Permission, int64, error) {
+	return s.repo.List(ctx, tenantID, page, perPage)
+}
+
+// ListByModule lists the permissions registered by a module
+func (s *PermissionService) ListByModule(ctx context.Context, tenantID, moduleCode string) ([]*domain.Permission, error) {
+	return s.repo.ListByModule(ctx, tenantID, moduleCode)
+}
+
+// ListByResource lists the permissions on a resource
+func (s *PermissionService) ListByResource(ctx context.Context, tenantID, resource string) ([]*domain.Permission, error) {
+	return s.repo.ListByResource(ctx, tenantID, resource)
+}
+
+// Update updates the descriptive fields and status of a permission.
+// The tenant, module, code, resource and action are immutable.
+func (s *PermissionService) Update(ctx context.Context, permission *domain.Permission) error {
+	existing, err := s.repo.FindByID(ctx, permission.ID.Hex())
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.TenantID != permission.TenantID {
+		return errors.NotFound("Permission not found")
+	}
+
+	permission.ModuleCode = existing.ModuleCode
+	permission.Code = existing.Code
+	permission.Resource = existing.Resource
+	permission.Action = existing.Action
+	permission.CreatedAt = existing.CreatedAt
+	if permission.Status == "" {
+		permission.Status = existing.Status
+	}
+	if err := permission.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	if err := s.repo.Update(ctx, permission); err != nil {
+		if stderrors.Is(err, repository.ErrNotFound) {
+			return errors.NotFound("Permission not found")
+		}
+		return err
+	}
+
+	s.cache.invalidate(ctx, permissionListKey(permission.TenantID))
+	return nil
+}
+
+// Delete deletes a permission
+func (s *PermissionService) Delete(ctx context.Context, id, tenantID string) error {
+	if !primitive.IsValidObjectID(id) {
+		return errors.BadRequest("Invalid ID format")
+	}
+
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.TenantID != tenantID {
+		return errors.NotFound("Permission not found")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, permissionListKey(tenantID))
+	s.logger.Info("Permission deleted",
+		zap.String("tenant_id", tenantID),
+		zap.String("code", existing.Code),
+	)
+	return nil
+}
+
+// Register upserts the permission manifest of a module and reports which permissions
+// were added, changed or left unchanged, and which stored ones the manifest no longer lists.
+// Registering the same manifest again writes nothing.
+func (s *PermissionService) Register(ctx context.Context, tenantID string, manifest *domain.PermissionManifest) (*domain.PermissionSyncReport, error) {
+	if manifest.ModuleCode == "" {
+		return nil, errors.BadRequest("module_code is required")
+	}
+
+	stored, err := s.repo.ListAll(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	byCode := make(map[string]*domain.Permission, len(stored))
+	for _, permission := range stored {
+		byCode[permission.Code] = permission
+	}
+
+	report := &domain.PermissionSyncReport{
+		ModuleCode: manifest.ModuleCode,
+		Added:      []string{},
+		Changed:    []string{},
+		Unchanged:  []string{},
+		Stale:      []string{},
+	}
+	seen := make(map[string]bool, len(manifest.Permissions))
+	var writes []*domain.Permission
+	for _, permission := range manifest.Permissions {
+		if permission == nil {
+			continue
+		}
+		permission.TenantID = tenantID
+		if permission.ModuleCode == "" {
+			permission.ModuleCode = manifest.ModuleCode
+		}
+		if permission.Status == "" {
+			permission.Status = "active"
+		}
+		if permission.ModuleCode != manifest.ModuleCode {
+			return nil, errors.BadRequest(fmt.Sprintf("permission '%s' declares module '%s' in the manifest of '%s'",
+				permission.Code, permission.ModuleCode, manifest.ModuleCode))
+		}
+		if err := permission.Validate(); err != nil {
+			return nil, errors.BadRequest(fmt.Sprintf("permission '%s': %s", permission.Code, err.Error()))
+		}
+		if seen[permission.Code] {
+			return nil, errors.BadRequest(fmt.Sprintf("permission '%s' is listed more than once", permission.Code))
+		}
+		seen[permission.Code] = true
+
+		existing := byCode[permission.Code]
+		switch {
+		case existing == nil:
+			report.Added = append(report.Added, permission.Code)
+			writes = append(writes, permission)
+		case existing.ModuleCode != manifest.ModuleCode:
+			return nil, errors.Conflict(fmt.Sprintf("Permission '%s' is already registered by module '%s'",
+				permission.Code, existing.ModuleCode))
+		case permissionChanged(existing, permission):
+			report.Changed = append(report.Changed, permission.Code)
+			writes = append(writes, permission)
+		default:
+			report.Unchanged = append(report.Unchanged, permission.Code)
+			permission.ID = existing.ID
+		}
+	}
+
+	for _, permission := range stored {
+		if permission.ModuleCode == manifest.ModuleCode && !seen[permission.Code] {
+			report.Stale = append(report.Stale, permission.Code)
+		}
+	}
+
+	if err := s.repo.Upsert(ctx, writes); err != nil {
+		if stderrors.Is(err, repository.ErrDuplicateKey) {
+			return nil, errors.Conflict("Permissions changed during registration, please retry")
+		}
+		return nil, err
+	}
+
+	if len(writes) > 0 {
+		s.cache.invalidate(ctx, permissionListKey(tenantID))
+	}
+
+	sort.Strings(report.Added)
+	sort.Strings(report.Changed)
+	sort.Strings(report.Unchanged)
+	s.logger.Info("Permission manifest registered",
+		zap.String("tenant_id", tenantID),
+		zap.String("module_code", manifest.ModuleCode),
+		zap.Int("added", len(report.Added)),
+		zap.Int("changed", len(report.Changed)),
+		zap.Int("stale", len(report.Stale)),
+	)
+	return report, nil
+}
+
+// Catalog returns the active permissions visible to a tenant: the global registry
+// merged with the tenant's own permissions, which take precedence by code
+func (s *PermissionService) Catalog(ctx context.Context, tenantID string) ([]*domain.Permission, error) {
+	permissions, err := s.listAll(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if tenantID != "" {
+		tenantPermissions, err := s.listAll(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, tenantPermissions...)
+	}
+
+	byCode := make(map[string]*domain.Permission, len(permissions))
+	for _, permission := range permissions {
+		byCode[permission.Code] = permission
+	}
+
+	catalog := make([]*domain.Permission, 0, len(byCode))
+	for _, permission := range byCode {
+		if permission.Status == "active" {
+			catalog = append(catalog, permission)
+		}
+	}
+	sort.Slice(catalog, func(i, j int) bool { return catalog[i].Code < catalog[j].Code })
+	return catalog, nil
+}
+
+// listAll loads every permission of a single scope through the cache
+func (s *PermissionService) listAll(ctx context.Context, tenantID string) ([]*domain.Permission, error) {
+	key := permissionListKey(tenantID)
+
+	var permissions []*domain.Permission
+	if s.cache.get(ctx, key, &permissions) {
+		return permissions, nil
+	}
+
+	permissions, err := s.repo.ListAll(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.set(ctx, key, permissions, configDataTTL)
+	return permissions, nil
+}
+
+// permissionChanged reports whether a manifest entry differs from the stored permission
+func permissionChanged(stored, manifest *domain.Permission) bool {
+	return stored.Name != manifest.Name ||
+		stored.Description != manifest.Description ||
+		stored.Resource != manifest.Resource ||
+		stored.Action != manifest.Action ||
+		stored.Category != manifest.Category ||
+		stored.Status != manifest.Status
+}
+
+func permissionListKey(tenantID string) string {
+	return cacheKey("permissions", tenantScope(tenantID), "all")
+}
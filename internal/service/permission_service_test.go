@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+)
+
+func newTestPermissionService(t *testing.T) *PermissionService {
+	return NewPermissionService(repository.NewMemoryPermissionRepository(), newMemoryCache(), newTestLogger(t))
+}
+
+func manifestPermission(resource, action string) *domain.Permission {
+	return &domain.Permission{
+		Code:     resource + "." + action,
+		Name:     resource + " " + action,
+		Resource: resource,
+		Action:   action,
+		Category: "data",
+	}
+}
+
+func TestPermissionService_Register(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestPermissionService(t)
+
+	report, err := svc.Register(ctx, "", &domain.PermissionManifest{
+		ModuleCode: "users",
+		Permissions: []*domain.Permission{
+			manifestPermission("users", "read"),
+			manifestPermission("users", "create"),
+			manifestPermission("users", "delete"),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"users.create", "users.delete", "users.read"}, report.Added)
+	assert.Empty(t, report.Changed)
+	assert.Empty(t, report.Stale)
+
+	renamed := manifestPermission("users", "read")
+	renamed.Name = "Read users"
+	manifest := &domain.PermissionManifest{
+		ModuleCode: "users",
+		Permissions: []*domain.Permission{
+			renamed,
+			manifestPermission("users", "create"),
+			manifestPermission("users", "export"),
+		},
+	}
+	report, err = svc.Register(ctx, "", manifest)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"users.export"}, report.Added)
+	assert.Equal(t, []string{"users.read"}, report.Changed)
+	assert.Equal(t, []string{"users.create"}, report.Unchanged)
+	assert.Equal(t, []string{"users.delete"}, report.Stale)
+
+	report, err = svc.Register(ctx, "", manifest)
+	require.NoError(t, err)
+	assert.Empty(t, report.Added)
+	assert.Empty(t, report.Changed)
+	assert.Len(t, report.Unchanged, 3)
+
+	stored, err := svc.ListByModule(ctx, "", "users")
+	require.NoError(t, err)
+	assert.Len(t, stored, 4)
+}
+
+func TestPermissionService_RegisterValidatesManifest(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestPermissionService(t)
+
+	_, err := svc.Register(ctx, "", &domain.PermissionManifest{
+		ModuleCode:  "users",
+		Permissions: []*domain.Permission{manifestPermission("users", "read")},
+	})
+	require.NoError(t, err)
+
+	mismatched := manifestPermission("users", "update")
+	mismatched.Code = "users.edit"
+	tests := []struct {
+		name     string
+		manifest *domain.PermissionManifest
+		status   int
+	}{
+		{"Missing module", &domain.PermissionManifest{}, http.StatusBadRequest},
+		{"Code mismatch", &domain.PermissionManifest{ModuleCode: "users", Permissions: []*domain.Permission{mismatched}}, http.StatusBadRequest},
+		{"Duplicate entry", &domain.PermissionManifest{ModuleCode: "crm", Permissions: []*domain.Permission{
+			manifestPermission("customers", "read"), manifestPermission("customers", "read"),
+		}}, http.StatusBadRequest},
+		{"Owned by another module", &domain.PermissionManifest{ModuleCode: "crm", Permissions: []*domain.Permission{
+			manifestPermission("users", "read"),
+		}}, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Register(ctx, "", tt.manifest)
+			assertStatus(t, err, tt.status)
+		})
+	}
+
+	stored, err := svc.ListByModule(ctx, "", "crm")
+	require.NoError(t, err)
+	assert.Empty(t, stored)
+}
+
+func TestPermissionService_UpdateKeepsIdentity(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestPermissionService(t)
+
+	permission := manifestPermission("users", "read")
+	permission.ModuleCode = "users"
+	require.NoError(t, svc.Create(ctx, permission))
+
+	duplicate := manifestPermission("users", "read")
+	duplicate.ModuleCode = "users"
+	err := svc.Create(ctx, duplicate)
+	assertStatus(t, err, http.StatusConflict)
+
+	update := &domain.Permission{ID: permission.ID, Code: "users.write", Name: "Read users", Status: "inactive"}
+	require.NoError(t, svc.Update(ctx, update))
+
+	found, err := svc.GetByID(ctx, permission.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, "users.read", found.Code)
+	assert.Equal(t, "Read users", found.Name)
+	assert.Equal(t, "inactive", found.Status)
+}
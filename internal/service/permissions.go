@@ -0,0 +1,65 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+)
+
+// permissionSet is the set of permission grants held by a caller.
+// Grants are permission codes, "<prefix>.*" wildcards or "*".
+type permissionSet map[string]struct{}
+
+func newPermissionSet(grants []string) permissionSet {
+	set := make(permissionSet, len(grants))
+	for _, grant := range grants {
+		grant = strings.TrimSpace(grant)
+		if grant != "" {
+			set[grant] = struct{}{}
+		}
+	}
+	return set
+}
+
+// allows reports whether every required permission is granted
+func (s permissionSet) allows(required []string) bool {
+	for _, code := range required {
+		if _, ok := s.match(code); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// match returns the most specific grant covering a permission code:
+// the code itself, then the longest "<prefix>.*" wildcard, then "*"
+func (s permissionSet) match(code string) (string, bool) {
+	if _, ok := s[code]; ok {
+		return code, true
+	}
+	for prefix := code; ; {
+		dot := strings.LastIndex(prefix, ".")
+		if dot < 0 {
+			break
+		}
+		prefix = prefix[:dot]
+		if _, ok := s[prefix+".*"]; ok {
+			return prefix + ".*", true
+		}
+	}
+	if _, ok := s[domain.WildcardGrant]; ok {
+		return domain.WildcardGrant, true
+	}
+	return "", false
+}
+
+// grantCovers reports whether a single grant covers a permission code
+func grantCovers(grant, code string) bool {
+	if grant == domain.WildcardGrant {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(grant, ".*"); ok {
+		return strings.HasPrefix(code, prefix+".")
+	}
+	return grant == code
+}
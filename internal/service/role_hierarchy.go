@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+)
+
+// maxRoleDepth bounds parent chains so corrupted data cannot loop forever
+const maxRoleDepth = 32
+
+// callerLevel returns the privilege level of a caller: the lowest Level among
+// the caller's active roles. Lower levels are more privileged.
+func (s *RoleService) callerLevel(ctx context.Context, tenantID string, callerRoles []string) (int, error) {
+	level := 0
+	for _, code := range callerRoles {
+		role, err := s.findRole(ctx, tenantID, code)
+		if err != nil {
+			return 0, err
+		}
+		if role == nil || role.Status != "active" {
+			continue
+		}
+		if level == 0 || role.Level < level {
+			level = role.Level
+		}
+	}
+	if level == 0 {
+		return 0, errors.Forbidden("Caller has no active role")
+	}
+	return level, nil
+}
+
+// ensureBelowCaller refuses to let a caller act on a role that is not at a strictly
+// lower privilege level (a higher Level number) than the caller's own
+func ensureBelowCaller(action string, level, callerLevel int) error {
+	if level <= callerLevel {
+		return errors.Forbidden(fmt.Sprintf("Cannot %s a role at level %d: callers at level %d may only manage levels above %d",
+			action, level, callerLevel, callerLevel))
+	}
+	return nil
+}
+
+// roleChain returns a role followed by the roles it inherits from, nearest first.
+// Parents are looked up in the role's tenant with the global role as fallback.
+func (s *RoleService) roleChain(ctx context.Context, role *domain.Role) ([]*domain.Role, error) {
+	chain := []*domain.Role{role}
+	seen := map[string]bool{role.Code: true}
+	for current := role; current.ParentCode != "" && len(chain) < maxRoleDepth; {
+		parent, err := s.findRole(ctx, role.TenantID, current.ParentCode)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil || seen[parent.Code] {
+			break
+		}
+		seen[parent.Code] = true
+		chain = append(chain, parent)
+		current = parent
+	}
+	return chain, nil
+}
+
+// validateParent checks that the parent of a role exists, is not more privileged than
+// the role itself, and does not already inherit from the role
+func (s *RoleService) validateParent(ctx context.Context, role *domain.Role) error {
+	if role.ParentCode == "" {
+		return nil
+	}
+
+	parent, err := s.findRole(ctx, role.TenantID, role.ParentCode)
+	if err != nil {
+		return err
+	}
+	if parent == nil {
+		return errors.BadRequest(fmt.Sprintf("parent role '%s' not found", role.ParentCode))
+	}
+	if parent.Level < role.Level {
+		return errors.BadRequest(fmt.Sprintf("role at level %d cannot inherit from '%s' at the more privileged level %d",
+			role.Level, parent.Code, parent.Level))
+	}
+
+	chain, err := s.roleChain(ctx, parent)
+	if err != nil {
+		return err
+	}
+	for _, ancestor := range chain {
+		if ancestor.Code == role.Code {
+			return errors.BadRequest(fmt.Sprintf("role '%s' already inherits from '%s'", parent.Code, role.Code))
+		}
+	}
+	return nil
+}
+
+// ensureChildrenNotAbove refuses to make a role more privileged than the roles inheriting from it
+func (s *RoleService) ensureChildrenNotAbove(ctx context.Context, role *domain.Role) error {
+	children, err := s.repo.FindByParent(ctx, role.TenantID, role.Code)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		if child.Level > role.Level {
+			return errors.BadRequest(fmt.Sprintf("role '%s' at level %d inherits from this role and cannot be above level %d",
+				child.Code, child.Level, role.Level))
+		}
+	}
+	return nil
+}
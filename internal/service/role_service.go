@@ -0,0 +1,529 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// RoleService handles role business logic
+type RoleService struct {
+	repo        repository.RoleRepository
+	permissions *PermissionService
+	cache       cacheStore
+	logger      *logger.Logger
+}
+
+// NewRoleService creates a new role service.
+// Permission grants are validated and expanded against the permission registry.
+func NewRoleService(repo repository.RoleRepository, permissions *PermissionService, cache Cache, log *logger.Logger) *RoleService {
+	return &RoleService{
+		repo:        repo,
+		permissions: permissions,
+		cache:       cacheStore{cache: cache, logger: log},
+		logger:      log,
+	}
+}
+
+// Create creates a new role below the privilege level of the caller
+func (s *RoleService) Create(ctx context.Context, role *domain.Role, callerRoles []string) error {
+	if role.Status == "" {
+		role.Status = "active"
+	}
+	if err := role.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	callerLevel, err := s.callerLevel(ctx, role.TenantID, callerRoles)
+	if err != nil {
+		return err
+	}
+	if err := ensureBelowCaller("create", role.Level, callerLevel); err != nil {
+		return err
+	}
+	if err := s.validateParent(ctx, role); err != nil {
+		return err
+	}
+
+	grants, err := s.validateGrants(ctx, role.TenantID, role.Permissions)
+	if err != nil {
+		return err
+	}
+	role.Permissions = grants
+
+	return s.create(ctx, role)
+}
+
+// create stores a validated role unless its code is taken in the tenant
+func (s *RoleService) create(ctx context.Context, role *domain.Role) error {
+	existing, err := s.repo.FindByCode(ctx, role.TenantID, role.Code)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.Conflict(fmt.Sprintf("Role with code '%s' already exists", role.Code))
+	}
+
+	if err := s.repo.Create(ctx, role); err != nil {
+		if stderrors.Is(err, repository.ErrDuplicateKey) {
+			return errors.Conflict(fmt.Sprintf("Role with code '%s' already exists", role.Code))
+		}
+		return err
+	}
+
+	s.logger.Info("Role created",
+		zap.String("tenant_id", role.TenantID),
+		zap.String("code", role.Code),
+	)
+	return nil
+}
+
+// GetByID gets a role by ID
+func (s *RoleService) GetByID(ctx context.Context, id string) (*domain.Role, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, errors.BadRequest("Invalid ID format")
+	}
+
+	role, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, errors.NotFound("Role not found")
+	}
+	return role, nil
+}
+
+// List lists roles of a tenant with pagination
+func (s *RoleService) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.Role, int64, error) {
+	return s.repo.List(ctx, tenantID, page, perPage)
+}
+
+// Update updates a role below the privilege level of the caller.
+// The tenant, code and system flag are immutable and system roles cannot be renamed.
+func (s *RoleService) Update(ctx context.Context, role *domain.Role, callerRoles []string) error {
+	existing, err := s.repo.FindByID(ctx, role.ID.Hex())
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.TenantID != role.TenantID {
+		return errors.NotFound("Role not found")
+	}
+
+	role.Code = existing.Code
+	role.IsSystem = existing.IsSystem
+	role.CreatedAt = existing.CreatedAt
+	if role.Status == "" {
+		role.Status = existing.Status
+	}
+	if err := role.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	if existing.IsSystem && role.Name != existing.Name {
+		return errors.Forbidden("System roles cannot be renamed")
+	}
+
+	callerLevel, err := s.callerLevel(ctx, role.TenantID, callerRoles)
+	if err != nil {
+		return err
+	}
+	if err := ensureBelowCaller("edit", existing.Level, callerLevel); err != nil {
+		return err
+	}
+	if err := ensureBelowCaller("edit", role.Level, callerLevel); err != nil {
+		return err
+	}
+	if err := s.validateParent(ctx, role); err != nil {
+		return err
+	}
+	if role.Level < existing.Level {
+		if err := s.ensureChildrenNotAbove(ctx, role); err != nil {
+			return err
+		}
+	}
+
+	grants, err := s.validateGrants(ctx, role.TenantID, role.Permissions)
+	if err != nil {
+		return err
+	}
+	role.Permissions = grants
+
+	if err := s.repo.Update(ctx, role); err != nil {
+		if stderrors.Is(err, repository.ErrNotFound) {
+			return errors.NotFound("Role not found")
+		}
+		return err
+	}
+
+	s.cache.invalidate(ctx, roleKey(role.TenantID, role.Code))
+	return nil
+}
+
+// Delete deletes a role below the privilege level of the caller.
+// System roles and roles other roles inherit from cannot be deleted.
+func (s *RoleService) Delete(ctx context.Context, id, tenantID string, callerRoles []string) error {
+	if !primitive.IsValidObjectID(id) {
+		return errors.BadRequest("Invalid ID format")
+	}
+
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.TenantID != tenantID {
+		return errors.NotFound("Role not found")
+	}
+	if existing.IsSystem {
+		return errors.Forbidden("System roles cannot be deleted")
+	}
+
+	callerLevel, err := s.callerLevel(ctx, tenantID, callerRoles)
+	if err != nil {
+		return err
+	}
+	if err := ensureBelowCaller("delete", existing.Level, callerLevel); err != nil {
+		return err
+	}
+
+	children, err := s.repo.FindByParent(ctx, tenantID, existing.Code)
+	if err != nil {
+		return err
+	}
+	if len(children) > 0 {
+		codes := make([]string, 0, len(children))
+		for _, child := range children {
+			codes = append(codes, child.Code)
+		}
+		return errors.Conflict(fmt.Sprintf("Cannot delete role '%s': inherited by roles %s",
+			existing.Code, strings.Join(codes, ", ")))
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, roleKey(tenantID, existing.Code))
+	s.logger.Info("Role deleted",
+		zap.String("tenant_id", tenantID),
+		zap.String("code", existing.Code),
+	)
+	return nil
+}
+
+// Clone copies a role and its permission grants into a tenant under a new code.
+// Platform callers (without a tenant) may pick any target tenant; tenant callers clone
+// into their own tenant and may only clone global roles or roles of their tenant.
+// Grants and the parent are copied as they are and the copy is never a system role. The source
+// is global or in the target tenant, so its parent resolves there too, but the target tenant
+// may override a global parent with a role the copy cannot inherit from, which is refused.
+func (s *RoleService) Clone(ctx context.Context, id, callerTenantID string, req *domain.CloneRoleRequest, callerRoles []string) (*domain.Role, error) {
+	source, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if source.TenantID != "" && source.TenantID != callerTenantID {
+		return nil, errors.NotFound("Role not found")
+	}
+
+	targetTenantID := req.TenantID
+	if callerTenantID != "" {
+		if targetTenantID != "" && targetTenantID != callerTenantID {
+			return nil, errors.Forbidden("Cannot clone roles into another tenant")
+		}
+		targetTenantID = callerTenantID
+	}
+
+	clone := &domain.Role{
+		TenantID:    targetTenantID,
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: source.Description,
+		ParentCode:  source.ParentCode,
+		Level:       source.Level,
+		Permissions: append([]string{}, source.Permissions...),
+		Status:      "active",
+	}
+	if clone.Name == "" {
+		clone.Name = source.Name
+	}
+	if err := clone.Validate(); err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+
+	callerLevel, err := s.callerLevel(ctx, callerTenantID, callerRoles)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureBelowCaller("create", clone.Level, callerLevel); err != nil {
+		return nil, err
+	}
+	if err := s.validateParent(ctx, clone); err != nil {
+		return nil, err
+	}
+
+	if err := s.create(ctx, clone); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// SystemRoles lists the global system roles that every tenant receives
+func (s *RoleService) SystemRoles(ctx context.Context) ([]*domain.Role, error) {
+	roles, err := s.repo.ListAll(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	system := make([]*domain.Role, 0, len(roles))
+	for _, role := range roles {
+		if role.IsSystem {
+			system = append(system, role)
+		}
+	}
+	return system, nil
+}
+
+// Assignable lists the roles of a tenant the caller may assign: those at a strictly
+// lower privilege level than the caller's own
+func (s *RoleService) Assignable(ctx context.Context, tenantID string, callerRoles []string) ([]*domain.Role, error) {
+	callerLevel, err := s.callerLevel(ctx, tenantID, callerRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := s.repo.ListAll(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignable := make([]*domain.Role, 0, len(roles))
+	for _, role := range roles {
+		if role.Level > callerLevel && role.Status == "active" {
+			assignable = append(assignable, role)
+		}
+	}
+	return assignable, nil
+}
+
+// SetPermissions replaces the permission grants of a role below the privilege level of the caller
+func (s *RoleService) SetPermissions(ctx context.Context, id, tenantID string, grants []string, callerRoles []string) (*domain.Role, error) {
+	role, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if role.TenantID != tenantID {
+		return nil, errors.NotFound("Role not found")
+	}
+
+	callerLevel, err := s.callerLevel(ctx, tenantID, callerRoles)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureBelowCaller("edit", role.Level, callerLevel); err != nil {
+		return nil, err
+	}
+
+	grants, err = s.validateGrants(ctx, tenantID, grants)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdatePermissions(ctx, id, grants); err != nil {
+		if stderrors.Is(err, repository.ErrNotFound) {
+			return nil, errors.NotFound("Role not found")
+		}
+		return nil, err
+	}
+	role.Permissions = grants
+
+	s.cache.invalidate(ctx, roleKey(tenantID, role.Code))
+	s.logger.Info("Role permissions updated",
+		zap.String("tenant_id", tenantID),
+		zap.String("code", role.Code),
+		zap.Int("grants", len(grants)),
+	)
+	return role, nil
+}
+
+// EffectivePermissions expands the grants of a role and the roles it inherits from
+// against the permission registry
+func (s *RoleService) EffectivePermissions(ctx context.Context, id string) (*domain.EffectivePermissions, error) {
+	role, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	chain, err := s.grantingChain(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	catalog, err := s.permissions.Catalog(ctx, role.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	effective := &domain.EffectivePermissions{
+		RoleCode:    role.Code,
+		Chain:       make([]string, 0, len(chain)),
+		Grants:      []string{},
+		Permissions: []string{},
+		Unmatched:   []string{},
+	}
+	seen := make(map[string]bool)
+	for _, member := range chain {
+		effective.Chain = append(effective.Chain, member.Code)
+		for _, grant := range member.Permissions {
+			if !seen[grant] {
+				seen[grant] = true
+				effective.Grants = append(effective.Grants, grant)
+			}
+		}
+	}
+
+	grants := newPermissionSet(effective.Grants)
+	for _, permission := range catalog {
+		if grants.allows([]string{permission.Code}) {
+			effective.Permissions = append(effective.Permissions, permission.Code)
+		}
+	}
+	for _, grant := range effective.Grants {
+		if !coversAny(grant, effective.Permissions) {
+			effective.Unmatched = append(effective.Unmatched, grant)
+		}
+	}
+	return effective, nil
+}
+
+// Check decides whether any of the given roles of a tenant, directly or through the
+// roles they inherit from, grants a permission. Roles missing from the tenant fall back
+// to the global role with the same code; inactive and unknown roles grant nothing.
+func (s *RoleService) Check(ctx context.Context, tenantID string, req *domain.PermissionCheckRequest) (*domain.PermissionCheckResult, error) {
+	code := strings.TrimSpace(req.Permission)
+	if code == "" {
+		return nil, errors.BadRequest("permission is required")
+	}
+
+	result := &domain.PermissionCheckResult{Permission: code}
+	for _, roleCode := range req.RoleCodes {
+		role, err := s.findRole(ctx, tenantID, roleCode)
+		if err != nil {
+			return nil, err
+		}
+		if role == nil {
+			continue
+		}
+
+		chain, err := s.grantingChain(ctx, role)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range chain {
+			if grant, ok := newPermissionSet(member.Permissions).match(code); ok {
+				result.Allowed = true
+				result.Role = role.Code
+				result.Grant = grant
+				if member.Code != role.Code {
+					result.InheritedFrom = member.Code
+				}
+				return result, nil
+			}
+		}
+	}
+	return result, nil
+}
+
+// grantingChain returns the part of a role's inheritance chain whose grants apply:
+// an inactive role grants nothing, including what it would inherit
+func (s *RoleService) grantingChain(ctx context.Context, role *domain.Role) ([]*domain.Role, error) {
+	chain, err := s.roleChain(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	for i, member := range chain {
+		if member.Status != "active" {
+			return chain[:i], nil
+		}
+	}
+	return chain, nil
+}
+
+// findRole loads a role by code through the cache, falling back to the global role
+func (s *RoleService) findRole(ctx context.Context, tenantID, code string) (*domain.Role, error) {
+	key := roleKey(tenantID, code)
+
+	var role *domain.Role
+	if s.cache.get(ctx, key, &role) {
+		return role, nil
+	}
+
+	role, err := s.repo.FindByCode(ctx, tenantID, code)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil && tenantID != "" {
+		return s.findRole(ctx, "", code)
+	}
+	if role != nil {
+		s.cache.set(ctx, key, role, configDataTTL)
+	}
+	return role, nil
+}
+
+// validateGrants checks the syntax of every grant and that exact permission codes are registered.
+// It returns the grants trimmed and without duplicates, in their original order.
+func (s *RoleService) validateGrants(ctx context.Context, tenantID string, grants []string) ([]string, error) {
+	var catalog map[string]bool
+	cleaned := make([]string, 0, len(grants))
+	seen := make(map[string]bool, len(grants))
+	for _, grant := range grants {
+		grant = strings.TrimSpace(grant)
+		if seen[grant] {
+			continue
+		}
+		if err := domain.ValidateGrant(grant); err != nil {
+			return nil, errors.BadRequest(err.Error())
+		}
+
+		if grant != domain.WildcardGrant && !strings.HasSuffix(grant, ".*") {
+			if catalog == nil {
+				permissions, err := s.permissions.Catalog(ctx, tenantID)
+				if err != nil {
+					return nil, err
+				}
+				catalog = make(map[string]bool, len(permissions))
+				for _, permission := range permissions {
+					catalog[permission.Code] = true
+				}
+			}
+			if !catalog[grant] {
+				return nil, errors.BadRequest(fmt.Sprintf("unknown permission '%s'", grant))
+			}
+		}
+
+		seen[grant] = true
+		cleaned = append(cleaned, grant)
+	}
+	return cleaned, nil
+}
+
+// coversAny reports whether a grant covers at least one of the permission codes
+func coversAny(grant string, codes []string) bool {
+	for _, code := range codes {
+		if grantCovers(grant, code) {
+			return true
+		}
+	}
+	return false
+}
+
+func roleKey(tenantID, code string) string {
+	return cacheKey("roles", tenantScope(tenantID), code)
+}
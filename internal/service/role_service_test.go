@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+)
+
+func newTestRoleService(t *testing.T) (*RoleService, *memoryCache) {
+	cache := newMemoryCache()
+	permissions := NewPermissionService(repository.NewMemoryPermissionRepository(), cache, newTestLogger(t))
+
+	_, err := permissions.Register(context.Background(), "", &domain.PermissionManifest{
+		ModuleCode: "core",
+		Permissions: []*domain.Permission{
+			manifestPermission("users", "read"),
+			manifestPermission("users", "update"),
+			manifestPermission("admin.users", "read"),
+			manifestPermission("admin.settings", "update"),
+			manifestPermission("profile", "read"),
+		},
+	})
+	require.NoError(t, err)
+
+	repo := repository.NewMemoryRoleRepository()
+	require.NoError(t, repo.Create(context.Background(), &domain.Role{
+		Code: "root", Name: "Root", Level: 1, Permissions: []string{"*"}, Status: "active",
+	}))
+
+	return NewRoleService(repo, permissions, cache, newTestLogger(t)), cache
+}
+
+// rootCaller is the caller role seeded by newTestRoleService
+var rootCaller = []string{"root"}
+
+func TestPermissionSet_Match(t *testing.T) {
+	set := newPermissionSet([]string{"admin.*", "admin.users.*", "users.read"})
+
+	grant, ok := set.match("admin.users.read")
+	assert.True(t, ok)
+	assert.Equal(t, "admin.users.*", grant)
+
+	grant, ok = set.match("admin.settings.update")
+	assert.True(t, ok)
+	assert.Equal(t, "admin.*", grant)
+
+	grant, ok = set.match("users.read")
+	assert.True(t, ok)
+	assert.Equal(t, "users.read", grant)
+
+	_, ok = set.match("users.update")
+	assert.False(t, ok)
+	_, ok = set.match("administrator.read")
+	assert.False(t, ok)
+
+	grant, ok = newPermissionSet([]string{"*"}).match("anything.at.all")
+	assert.True(t, ok)
+	assert.Equal(t, "*", grant)
+}
+
+func TestRoleService_SetPermissionsValidatesGrants(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestRoleService(t)
+
+	role := &domain.Role{Code: "manager", Name: "Manager", Level: 3}
+	require.NoError(t, svc.Create(ctx, role, rootCaller))
+
+	updated, err := svc.SetPermissions(ctx, role.ID.Hex(), "", []string{"users.read", " users.read ", "admin.*"}, rootCaller)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"users.read", "admin.*"}, updated.Permissions)
+
+	_, err = svc.SetPermissions(ctx, role.ID.Hex(), "", []string{"users.export"}, rootCaller)
+	assertStatus(t, err, http.StatusBadRequest)
+
+	_, err = svc.SetPermissions(ctx, role.ID.Hex(), "", []string{"users.*.read"}, rootCaller)
+	assertStatus(t, err, http.StatusBadRequest)
+
+	_, err = svc.SetPermissions(ctx, role.ID.Hex(), "tenant-1", []string{"users.read"}, rootCaller)
+	assertStatus(t, err, http.StatusNotFound)
+
+	err = svc.Create(ctx, &domain.Role{Code: "auditor", Name: "Auditor", Level: 3, Permissions: []string{"audit.read"}}, rootCaller)
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+func TestRoleService_EffectivePermissions(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestRoleService(t)
+
+	admin := &domain.Role{Code: "admin", Name: "Administrator", Level: 2, Permissions: []string{"admin.*", "users.read", "billing.*"}}
+	require.NoError(t, svc.Create(ctx, admin, rootCaller))
+
+	effective, err := svc.EffectivePermissions(ctx, admin.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin.settings.update", "admin.users.read", "users.read"}, effective.Permissions)
+	assert.Equal(t, []string{"billing.*"}, effective.Unmatched)
+
+	root, err := svc.repo.FindByCode(ctx, "", "root")
+	require.NoError(t, err)
+
+	effective, err = svc.EffectivePermissions(ctx, root.ID.Hex())
+	require.NoError(t, err)
+	assert.Len(t, effective.Permissions, 5)
+	assert.Empty(t, effective.Unmatched)
+}
+
+func TestRoleService_Check(t *testing.T) {
+	ctx := context.Background()
+	svc, cache := newTestRoleService(t)
+
+	manager := &domain.Role{Code: "manager", Name: "Manager", Level: 3, Permissions: []string{"users.read"}}
+	require.NoError(t, svc.Create(ctx, manager, rootCaller))
+	require.NoError(t, svc.Create(ctx, &domain.Role{Code: "admin", Name: "Administrator", Level: 2, Permissions: []string{"admin.*"}}, rootCaller))
+	require.NoError(t, svc.Create(ctx, &domain.Role{TenantID: "tenant-1", Code: "admin", Name: "Administrator", Level: 2}, rootCaller))
+
+	result, err := svc.Check(ctx, "tenant-1", &domain.PermissionCheckRequest{
+		RoleCodes:  []string{"admin", "manager"},
+		Permission: "users.read",
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, "manager", result.Role)
+	assert.Equal(t, "users.read", result.Grant)
+
+	result, err = svc.Check(ctx, "tenant-1", &domain.PermissionCheckRequest{
+		RoleCodes:  []string{"admin"},
+		Permission: "admin.users.read",
+	})
+	require.NoError(t, err)
+	assert.False(t, result.Allowed, "the tenant's own admin role has no grants")
+
+	result, err = svc.Check(ctx, "", &domain.PermissionCheckRequest{
+		RoleCodes:  []string{"unknown", "admin"},
+		Permission: "admin.users.read",
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, "admin.*", result.Grant)
+
+	assert.True(t, cache.has(roleKey("", "manager")))
+	_, err = svc.SetPermissions(ctx, manager.ID.Hex(), "", []string{"profile.read"}, rootCaller)
+	require.NoError(t, err)
+	assert.False(t, cache.has(roleKey("", "manager")))
+
+	result, err = svc.Check(ctx, "", &domain.PermissionCheckRequest{RoleCodes: []string{"manager"}, Permission: "users.read"})
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+}
+
+func TestRoleService_Clone(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestRoleService(t)
+
+	admin := &domain.Role{Code: "admin", Name: "Administrator", IsSystem: true, Level: 2, Permissions: []string{"admin.*"}}
+	require.NoError(t, svc.Create(ctx, admin, rootCaller))
+
+	clone, err := svc.Clone(ctx, admin.ID.Hex(), "", &domain.CloneRoleRequest{TenantID: "tenant-1", Code: "site_admin"}, rootCaller)
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-1", clone.TenantID)
+	assert.Equal(t, "Administrator", clone.Name)
+	assert.Equal(t, []string{"admin.*"}, clone.Permissions)
+	assert.Equal(t, 2, clone.Level)
+	assert.False(t, clone.IsSystem)
+
+	_, err = svc.Clone(ctx, admin.ID.Hex(), "", &domain.CloneRoleRequest{TenantID: "tenant-1", Code: "site_admin"}, rootCaller)
+	assertStatus(t, err, http.StatusConflict)
+
+	_, err = svc.Clone(ctx, admin.ID.Hex(), "tenant-2", &domain.CloneRoleRequest{TenantID: "tenant-1", Code: "other"}, rootCaller)
+	assertStatus(t, err, http.StatusForbidden)
+
+	_, err = svc.Clone(ctx, clone.ID.Hex(), "tenant-2", &domain.CloneRoleRequest{Code: "copy"}, rootCaller)
+	assertStatus(t, err, http.StatusNotFound)
+
+	own, err := svc.Clone(ctx, clone.ID.Hex(), "tenant-1", &domain.CloneRoleRequest{Code: "deputy_admin", Name: "Deputy"}, rootCaller)
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-1", own.TenantID)
+	assert.Equal(t, "Deputy", own.Name)
+}
+
+func TestRoleService_CloneKeepsInheritance(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestRoleService(t)
+
+	require.NoError(t, svc.Create(ctx, &domain.Role{Code: "viewer", Name: "Viewer", Level: 4, Permissions: []string{"users.read"}}, rootCaller))
+	editor := &domain.Role{Code: "editor", Name: "Editor", ParentCode: "viewer", Level: 3, Permissions: []string{"users.update"}}
+	require.NoError(t, svc.Create(ctx, editor, rootCaller))
+
+	clone, err := svc.Clone(ctx, editor.ID.Hex(), "", &domain.CloneRoleRequest{TenantID: "tenant-2", Code: "editor"}, rootCaller)
+	require.NoError(t, err)
+	assert.Equal(t, "viewer", clone.ParentCode)
+
+	effective, err := svc.EffectivePermissions(ctx, clone.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"editor", "viewer"}, effective.Chain)
+	assert.Equal(t, []string{"users.read", "users.update"}, effective.Permissions)
+
+	deputy, err := svc.Clone(ctx, clone.ID.Hex(), "tenant-2", &domain.CloneRoleRequest{Code: "deputy_editor"}, rootCaller)
+	require.NoError(t, err)
+	effective, err = svc.EffectivePermissions(ctx, deputy.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deputy_editor", "viewer"}, effective.Chain)
+	assert.Equal(t, []string{"users.read", "users.update"}, effective.Permissions)
+
+	// tenant-3 overrides the global viewer with a role more privileged than the copy
+	require.NoError(t, svc.Create(ctx, &domain.Role{TenantID: "tenant-3", Code: "viewer", Name: "Viewer", Level: 2}, rootCaller))
+	_, err = svc.Clone(ctx, editor.ID.Hex(), "", &domain.CloneRoleRequest{TenantID: "tenant-3", Code: "editor"}, rootCaller)
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+func TestRoleService_HierarchyEnforcement(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestRoleService(t)
+
+	admin := &domain.Role{Code: "admin", Name: "Administrator", IsSystem: true, Level: 2, Permissions: []string{"admin.*"}}
+	require.NoError(t, svc.Create(ctx, admin, rootCaller))
+	adminCaller := []string{"admin"}
+
+	err := svc.Create(ctx, &domain.Role{Code: "peer", Name: "Peer", Level: 2}, adminCaller)
+	assertStatus(t, err, http.StatusForbidden)
+	err = svc.Create(ctx, &domain.Role{Code: "anyone", Name: "Anyone", Level: 5}, nil)
+	assertStatus(t, err, http.StatusForbidden)
+
+	manager := &domain.Role{Code: "manager", Name: "Manager", Level: 3}
+	require.NoError(t, svc.Create(ctx, manager, adminCaller))
+
+	manager.Level = 2
+	assertStatus(t, svc.Update(ctx, manager, adminCaller), http.StatusForbidden)
+
+	_, err = svc.SetPermissions(ctx, admin.ID.Hex(), "", []string{"users.read"}, adminCaller)
+	assertStatus(t, err, http.StatusForbidden)
+
+	renamed := *admin
+	renamed.Name = "Admins"
+	assertStatus(t, svc.Update(ctx, &renamed, rootCaller), http.StatusForbidden)
+	assertStatus(t, svc.Delete(ctx, admin.ID.Hex(), "", rootCaller), http.StatusForbidden)
+
+	assignable, err := svc.Assignable(ctx, "", adminCaller)
+	require.NoError(t, err)
+	require.Len(t, assignable, 1)
+	assert.Equal(t, "manager", assignable[0].Code)
+
+	require.NoError(t, svc.Delete(ctx, manager.ID.Hex(), "", adminCaller))
+}
+
+func TestRoleService_ParentInheritance(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestRoleService(t)
+
+	viewer := &domain.Role{Code: "viewer", Name: "Viewer", Level: 4, Permissions: []string{"profile.read"}}
+	require.NoError(t, svc.Create(ctx, viewer, rootCaller))
+	editor := &domain.Role{Code: "editor", Name: "Editor", Level: 3, ParentCode: "viewer", Permissions: []string{"users.update"}}
+	require.NoError(t, svc.Create(ctx, editor, rootCaller))
+
+	effective, err := svc.EffectivePermissions(ctx, editor.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"editor", "viewer"}, effective.Chain)
+	assert.Equal(t, []string{"profile.read", "users.update"}, effective.Permissions)
+
+	result, err := svc.Check(ctx, "", &domain.PermissionCheckRequest{RoleCodes: []string{"editor"}, Permission: "profile.read"})
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, "editor", result.Role)
+	assert.Equal(t, "viewer", result.InheritedFrom)
+
+	err = svc.Create(ctx, &domain.Role{Code: "intern", Name: "Intern", Level: 5, ParentCode: "editor"}, rootCaller)
+	assertStatus(t, err, http.StatusBadRequest)
+	err = svc.Create(ctx, &domain.Role{Code: "orphan", Name: "Orphan", Level: 5, ParentCode: "missing"}, rootCaller)
+	assertStatus(t, err, http.StatusBadRequest)
+
+	viewer.ParentCode = "editor"
+	assertStatus(t, svc.Update(ctx, viewer, rootCaller), http.StatusBadRequest)
+
+	viewer.ParentCode = ""
+	viewer.Level = 2
+	assertStatus(t, svc.Update(ctx, viewer, rootCaller), http.StatusBadRequest)
+
+	assertStatus(t, svc.Delete(ctx, viewer.ID.Hex(), "", rootCaller), http.StatusConflict)
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// SaaSModuleService handles SaaS module business logic
+type SaaSModuleService struct {
+	repo     repository.SaaSModuleRepository
+	packages repository.ServicePackageRepository
+	cache    cacheStore
+	logger   *logger.Logger
+}
+
+// NewSaaSModuleService creates a new SaaS module service
+func NewSaaSModuleService(repo repository.SaaSModuleRepository, packages repository.ServicePackageRepository, cache Cache, log *logger.Logger) *SaaSModuleService {
+	return &SaaSModuleService{
+		repo:     repo,
+		packages: packages,
+		cache:    cacheStore{cache: cache, logger: log},
+		logger:   log,
+	}
+}
+
+// Create creates a new SaaS module after validating its dependencies
+func (s *SaaSModuleService) Create(ctx context.Context, module *domain.SaaSModule) error {
+	if module.Status == "" {
+		module.Status = "active"
+	}
+	if err := module.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	existing, err := s.repo.FindByCode(ctx, module.TenantID, module.Code)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.Conflict(fmt.Sprintf("Module with code '%s' already exists", module.Code))
+	}
+
+	if err := s.validateDependencies(ctx, module); err != nil {
+		return err
+	}
+
+	if err := s.repo.Create(ctx, module); err != nil {
+		if stderrors.Is(err, repository.ErrDuplicateKey) {
+			return errors.Conflict(fmt.Sprintf("Module with code '%s' already exists", module.Code))
+		}
+		return err
+	}
+
+	s.cache.invalidate(ctx, moduleCatalogKey(module.TenantID))
+	s.logger.Info("SaaS module created",
+		zap.String("tenant_id", module.TenantID),
+		zap.String("code", module.Code),
+	)
+	return nil
+}
+
+// GetByID gets a SaaS module by ID
+func (s *SaaSModuleService) GetByID(ctx context.Context, id string) (*domain.SaaSModule, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, errors.BadRequest("Invalid ID format")
+	}
+
+	module, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if module == nil {
+		return nil, errors.NotFound("Module not found")
+	}
+	return module, nil
+}
+
+// List lists SaaS modules of a tenant with pagination
+func (s *SaaSModuleService) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.SaaSModule, int64, error) {
+	return s.repo.List(ctx, tenantID, page, perPage)
+}
+
+// Update updates a SaaS module.
+// The tenant and code are immutable; deprecating a module that active modules depend on or active
+// service packages include is refused, since entitlements cannot be resolved through deprecated modules.
+func (s *SaaSModuleService) Update(ctx context.Context, module *domain.SaaSModule) error {
+	existing, err := s.repo.FindByID(ctx, module.ID.Hex())
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.TenantID != module.TenantID {
+		return errors.NotFound("Module not found")
+	}
+
+	module.Code = existing.Code
+	module.CreatedAt = existing.CreatedAt
+	if module.Status == "" {
+		module.Status = existing.Status
+	}
+	if err := module.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	if module.Status == "deprecated" && existing.Status != "deprecated" {
+		if err := s.ensureNoActiveDependents(ctx, existing, "deprecate"); err != nil {
+			return err
+		}
+	}
+	if err := s.validateDependencies(ctx, module); err != nil {
+		return err
+	}
+
+	if err := s.repo.Update(ctx, module); err != nil {
+		if stderrors.Is(err, repository.ErrNotFound) {
+			return errors.NotFound("Module not found")
+		}
+		return err
+	}
+
+	s.cache.invalidate(ctx, moduleCatalogKey(module.TenantID))
+	return nil
+}
+
+// Delete deletes a SaaS module unless active modules still depend on it or active service packages include it
+func (s *SaaSModuleService) Delete(ctx context.Context, id, tenantID string) error {
+	if !primitive.IsValidObjectID(id) {
+		return errors.BadRequest("Invalid ID format")
+	}
+
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.TenantID != tenantID {
+		return errors.NotFound("Module not found")
+	}
+
+	if err := s.ensureNoActiveDependents(ctx, existing, "delete"); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.cache.invalidate(ctx, moduleCatalogKey(tenantID))
+	s.logger.Info("SaaS module deleted",
+		zap.String("tenant_id", tenantID),
+		zap.String("code", existing.Code),
+	)
+	return nil
+}
+
+// Resolve expands the given module codes through their dependencies and returns
+// the transitive closure together with a topological install order
+func (s *SaaSModuleService) Resolve(ctx context.Context, tenantID string, codes []string) (*domain.ModuleResolution, error) {
+	if len(codes) == 0 {
+		return nil, errors.BadRequest("At least one module code is required")
+	}
+
+	catalog, err := s.catalog(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range codes {
+		if module, ok := catalog[code]; ok && module.Status == "deprecated" {
+			return nil, errors.BadRequest(fmt.Sprintf("module '%s' is deprecated", code))
+		}
+	}
+
+	modules, err := catalog.resolve(codes)
+	if err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+
+	resolution := &domain.ModuleResolution{
+		Requested:    codes,
+		InstallOrder: make([]string, 0, len(modules)),
+		Modules:      modules,
+	}
+	for _, module := range modules {
+		resolution.InstallOrder = append(resolution.InstallOrder, module.Code)
+	}
+	return resolution, nil
+}
+
+// catalog loads the module catalog of a tenant through the cache
+func (s *SaaSModuleService) catalog(ctx context.Context, tenantID string) (moduleCatalog, error) {
+	key := moduleCatalogKey(tenantID)
+
+	var modules []*domain.SaaSModule
+	if !s.cache.get(ctx, key, &modules) {
+		var err error
+		modules, err = s.repo.ListAll(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		s.cache.set(ctx, key, modules, configDataTTL)
+	}
+
+	return newModuleCatalog(modules), nil
+}
+
+// validateDependencies checks the dependency graph as it would be after writing the module
+func (s *SaaSModuleService) validateDependencies(ctx context.Context, module *domain.SaaSModule) error {
+	modules, err := s.repo.ListAll(ctx, module.TenantID)
+	if err != nil {
+		return err
+	}
+
+	catalog := newModuleCatalog(modules)
+	catalog[module.Code] = module
+	if _, err := catalog.resolve([]string{module.Code}); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	return nil
+}
+
+// ensureNoActiveDependents refuses an operation on a module that active modules depend on
+// or active service packages include
+func (s *SaaSModuleService) ensureNoActiveDependents(ctx context.Context, module *domain.SaaSModule, action string) error {
+	dependents, err := s.repo.FindDependents(ctx, module.TenantID, module.Code)
+	if err != nil {
+		return err
+	}
+
+	var active []string
+	for _, dependent := range dependents {
+		if dependent.Status == "active" {
+			active = append(active, dependent.Code)
+		}
+	}
+	if len(active) > 0 {
+		return errors.Conflict(fmt.Sprintf("Cannot %s module '%s': required by active modules %s",
+			action, module.Code, strings.Join(active, ", ")))
+	}
+
+	packages, err := s.packages.FindByModule(ctx, module.TenantID, module.Code)
+	if err != nil {
+		return err
+	}
+	var included []string
+	for _, pkg := range packages {
+		if pkg.Status == "active" {
+			included = append(included, pkg.Code)
+		}
+	}
+	if len(included) > 0 {
+		return errors.Conflict(fmt.Sprintf("Cannot %s module '%s': included in active service packages %s",
+			action, module.Code, strings.Join(included, ", ")))
+	}
+	return nil
+}
+
+func moduleCatalogKey(tenantID string) string {
+	return cacheKey("modules", tenantScope(tenantID), "catalog")
+}
+
+// tenantScope names the scope of tenant-specific data in cache keys; empty tenants are global
+func tenantScope(tenantID string) string {
+	if tenantID == "" {
+		return "global"
+	}
+	return tenantID
+}
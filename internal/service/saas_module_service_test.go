@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+)
+
+func newTestSaaSModuleService(t *testing.T) *SaaSModuleService {
+	return NewSaaSModuleService(repository.NewMemorySaaSModuleRepository(), repository.NewMemoryServicePackageRepository(), newMemoryCache(), newTestLogger(t))
+}
+
+func createModule(t *testing.T, svc *SaaSModuleService, code string, dependencies ...string) *domain.SaaSModule {
+	t.Helper()
+
+	module := &domain.SaaSModule{Code: code, Name: code, Dependencies: dependencies}
+	require.NoError(t, svc.Create(context.Background(), module))
+	return module
+}
+
+func TestModuleCatalog_Resolve(t *testing.T) {
+	catalog := newModuleCatalog([]*domain.SaaSModule{
+		{Code: "core", Status: "active"},
+		{Code: "users", Status: "active", Dependencies: []string{"core"}},
+		{Code: "crm", Status: "active", Dependencies: []string{"users", "core"}},
+		{Code: "billing", Status: "active", Dependencies: []string{"crm", "users"}},
+		{Code: "legacy", Status: "deprecated"},
+		{Code: "reports", Status: "active", Dependencies: []string{"legacy"}},
+		{Code: "a", Status: "active", Dependencies: []string{"b"}},
+		{Code: "b", Status: "active", Dependencies: []string{"c"}},
+		{Code: "c", Status: "active", Dependencies: []string{"a"}},
+		{Code: "broken", Status: "active", Dependencies: []string{"missing"}},
+	})
+
+	codes := func(modules []*domain.SaaSModule) []string {
+		out := make([]string, 0, len(modules))
+		for _, module := range modules {
+			out = append(out, module.Code)
+		}
+		return out
+	}
+
+	modules, err := catalog.resolve([]string{"billing"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"core", "users", "crm", "billing"}, codes(modules))
+
+	modules, err = catalog.resolve([]string{"crm", "users"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"core", "users", "crm"}, codes(modules))
+
+	_, err = catalog.resolve([]string{"reports"})
+	assert.ErrorContains(t, err, "deprecated module 'legacy'")
+
+	_, err = catalog.resolve([]string{"a"})
+	assert.ErrorContains(t, err, "a -> b -> c -> a")
+
+	_, err = catalog.resolve([]string{"broken"})
+	assert.ErrorContains(t, err, "unknown module 'missing'")
+
+	_, err = catalog.resolve([]string{"nope"})
+	assert.ErrorContains(t, err, "unknown module 'nope'")
+}
+
+func TestSaaSModuleService_CreateValidatesDependencies(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestSaaSModuleService(t)
+
+	createModule(t, svc, "core")
+	createModule(t, svc, "crm", "core")
+
+	err := svc.Create(ctx, &domain.SaaSModule{Code: "billing", Name: "Billing", Dependencies: []string{"missing"}})
+	assertStatus(t, err, http.StatusBadRequest)
+
+	err = svc.Create(ctx, &domain.SaaSModule{Code: "crm", Name: "CRM"})
+	assertStatus(t, err, http.StatusConflict)
+}
+
+func TestSaaSModuleService_UpdateRejectsCycles(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestSaaSModuleService(t)
+
+	core := createModule(t, svc, "core")
+	createModule(t, svc, "crm", "core")
+
+	err := svc.Update(ctx, &domain.SaaSModule{ID: core.ID, Name: "Core", Dependencies: []string{"crm"}})
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+func TestSaaSModuleService_DependentsBlockDeleteAndDeprecate(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestSaaSModuleService(t)
+
+	core := createModule(t, svc, "core")
+	crm := createModule(t, svc, "crm", "core")
+
+	err := svc.Delete(ctx, core.ID.Hex(), "")
+	assertStatus(t, err, http.StatusConflict)
+
+	err = svc.Update(ctx, &domain.SaaSModule{ID: core.ID, Name: "Core", Status: "deprecated"})
+	assertStatus(t, err, http.StatusConflict)
+
+	// Once the dependent is inactive the module can be deprecated
+	require.NoError(t, svc.Update(ctx, &domain.SaaSModule{ID: crm.ID, Name: "CRM", Status: "inactive", Dependencies: []string{"core"}}))
+	require.NoError(t, svc.Update(ctx, &domain.SaaSModule{ID: core.ID, Name: "Core", Status: "deprecated"}))
+
+	// New modules can no longer depend on it
+	err = svc.Create(ctx, &domain.SaaSModule{Code: "billing", Name: "Billing", Dependencies: []string{"core"}})
+	assertStatus(t, err, http.StatusBadRequest)
+
+	require.NoError(t, svc.Delete(ctx, crm.ID.Hex(), ""))
+	require.NoError(t, svc.Delete(ctx, core.ID.Hex(), ""))
+}
+
+func TestSaaSModuleService_Resolve(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestSaaSModuleService(t)
+
+	createModule(t, svc, "core")
+	createModule(t, svc, "users", "core")
+	createModule(t, svc, "crm", "users")
+
+	resolution, err := svc.Resolve(ctx, "", []string{"crm"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"core", "users", "crm"}, resolution.InstallOrder)
+	assert.Len(t, resolution.Modules, 3)
+
+	// A module added later must be visible despite the cached catalog
+	createModule(t, svc, "billing", "crm")
+	resolution, err = svc.Resolve(ctx, "", []string{"billing"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"core", "users", "crm", "billing"}, resolution.InstallOrder)
+
+	_, err = svc.Resolve(ctx, "", []string{"unknown"})
+	assertStatus(t, err, http.StatusBadRequest)
+
+	_, err = svc.Resolve(ctx, "", nil)
+	assertStatus(t, err, http.StatusBadRequest)
+}
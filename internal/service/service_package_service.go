@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// ServicePackageService handles service package business logic and entitlement resolution
+type ServicePackageService struct {
+	repo    repository.ServicePackageRepository
+	modules *SaaSModuleService
+	schemas *ConfigSchemaService
+	logger  *logger.Logger
+}
+
+// NewServicePackageService creates a new service package service.
+// Package limits are checked against the package_limits config schema when there is one.
+func NewServicePackageService(repo repository.ServicePackageRepository, modules *SaaSModuleService, schemas *ConfigSchemaService, log *logger.Logger) *ServicePackageService {
+	return &ServicePackageService{
+		repo:    repo,
+		modules: modules,
+		schemas: schemas,
+		logger:  log,
+	}
+}
+
+// Create creates a new service package
+func (s *ServicePackageService) Create(ctx context.Context, pkg *domain.ServicePackage) error {
+	if err := pkg.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	if err := s.checkLimits(ctx, pkg.Limits); err != nil {
+		return err
+	}
+	if pkg.Status == "" {
+		pkg.Status = "active"
+	}
+
+	existing, err := s.repo.FindByCode(ctx, pkg.TenantID, pkg.Code)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.Conflict(fmt.Sprintf("Package with code '%s' already exists", pkg.Code))
+	}
+
+	if err := s.validateModules(ctx, pkg); err != nil {
+		return err
+	}
+
+	if err := s.repo.Create(ctx, pkg); err != nil {
+		if stderrors.Is(err, repository.ErrDuplicateKey) {
+			return errors.Conflict(fmt.Sprintf("Package with code '%s' already exists", pkg.Code))
+		}
+		return err
+	}
+
+	s.logger.Info("Service package created",
+		zap.String("tenant_id", pkg.TenantID),
+		zap.String("code", pkg.Code),
+	)
+	return nil
+}
+
+// GetByID gets a service package by ID
+func (s *ServicePackageService) GetByID(ctx context.Context, id string) (*domain.ServicePackage, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, errors.BadRequest("Invalid ID format")
+	}
+
+	pkg, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pkg == nil {
+		return nil, errors.NotFound("Package not found")
+	}
+	return pkg, nil
+}
+
+// List lists service packages of a tenant with pagination
+func (s *ServicePackageService) List(ctx context.Context, tenantID string, page, perPage int) ([]*domain.ServicePackage, int64, error) {
+	return s.repo.List(ctx, tenantID, page, perPage)
+}
+
+// Update updates a service package. The tenant and code are immutable.
+func (s *ServicePackageService) Update(ctx context.Context, pkg *domain.ServicePackage) error {
+	existing, err := s.repo.FindByID(ctx, pkg.ID.Hex())
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.TenantID != pkg.TenantID {
+		return errors.NotFound("Package not found")
+	}
+
+	pkg.Code = existing.Code
+	pkg.CreatedAt = existing.CreatedAt
+	if pkg.Status == "" {
+		pkg.Status = existing.Status
+	}
+	if err := pkg.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	if err := s.checkLimits(ctx, pkg.Limits); err != nil {
+		return err
+	}
+
+	if err := s.validateModules(ctx, pkg); err != nil {
+		return err
+	}
+
+	if err := s.repo.Update(ctx, pkg); err != nil {
+		if stderrors.Is(err, repository.ErrNotFound) {
+			return errors.NotFound("Package not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete deletes a service package owned by the given tenant
+func (s *ServicePackageService) Delete(ctx context.Context, id, tenantID string) error {
+	if !primitive.IsValidObjectID(id) {
+		return errors.BadRequest("Invalid ID format")
+	}
+
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.TenantID != tenantID {
+		return errors.NotFound("Package not found")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.logger.Info("Service package deleted",
+		zap.String("tenant_id", tenantID),
+		zap.String("code", existing.Code),
+	)
+	return nil
+}
+
+// Entitlements resolves what a package grants: its modules expanded through their
+// dependencies, the union of package and module features, and typed limits
+func (s *ServicePackageService) Entitlements(ctx context.Context, tenantID, code string) (*domain.Entitlements, error) {
+	pkg, err := s.repo.FindByCode(ctx, tenantID, code)
+	if err != nil {
+		return nil, err
+	}
+	if pkg == nil {
+		return nil, errors.NotFound("Package not found")
+	}
+
+	limits, err := domain.ParsePackageLimits(pkg.Limits)
+	if err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+
+	entitlements := &domain.Entitlements{
+		PackageCode: pkg.Code,
+		Tier:        pkg.Tier,
+		Modules:     []string{},
+		Limits:      limits,
+	}
+
+	features := make(map[string]bool)
+	for _, feature := range pkg.Features {
+		features[feature] = true
+	}
+
+	if len(pkg.Modules) > 0 {
+		resolution, err := s.modules.Resolve(ctx, pkg.TenantID, pkg.Modules)
+		if err != nil {
+			return nil, err
+		}
+		entitlements.Modules = resolution.InstallOrder
+		for _, module := range resolution.Modules {
+			for _, feature := range module.Features {
+				features[feature] = true
+			}
+		}
+	}
+
+	entitlements.Features = make([]string, 0, len(features))
+	for feature := range features {
+		entitlements.Features = append(entitlements.Features, feature)
+	}
+	sort.Strings(entitlements.Features)
+
+	return entitlements, nil
+}
+
+// MigrateLimits brings the limits of the packages of every tenant in line with the current
+// version of the package_limits schema: limits lacking properties that have schema defaults gain
+// them when they then conform. Limits that do not conform are left as they are and reported.
+func (s *ServicePackageService) MigrateLimits(ctx context.Context, opts *domain.SchemaMigrationOptions) (*domain.SchemaMigrationReport, error) {
+	schema, err := s.schemas.Find(ctx, domain.SchemaTargetPackageLimits, "")
+	if err != nil {
+		return nil, err
+	}
+	if schema == nil {
+		return nil, errors.NotFound("Config schema not found")
+	}
+	compiled, err := schema.Compile()
+	if err != nil {
+		return nil, errors.Internal(fmt.Sprintf("Config schema '%s' does not compile: %v", schema.Subject(), err))
+	}
+
+	packages, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.SchemaMigrationReport{
+		DryRun:        opts.DryRun,
+		Schema:        schema.Subject(),
+		SchemaVersion: schema.Version,
+		Nonconforming: []domain.SchemaNonconformance{},
+	}
+	for _, pkg := range packages {
+		report.Checked++
+		nonconforming := func(fields []domain.FieldError) {
+			report.Nonconforming = append(report.Nonconforming, domain.SchemaNonconformance{
+				ID:         pkg.ID.Hex(),
+				TenantID:   pkg.TenantID,
+				Code:       pkg.Code,
+				Violations: fields,
+			})
+		}
+
+		// Stored limits holding values that JSON cannot represent cannot conform
+		limits := map[string]interface{}{}
+		if pkg.Limits != nil {
+			plain, err := domain.PlainConfigValue(pkg.Limits)
+			if err != nil {
+				nonconforming([]domain.FieldError{{Field: "limits", Pointer: "/limits", Code: "type", Message: err.Error(), Rule: schema.Subject()}})
+				continue
+			}
+			limits = plain.(map[string]interface{})
+		}
+		_, filled := compiled.ApplyDefaults(limits)
+
+		fields := schema.Violations(compiled, "limits", limits)
+		if _, err := domain.ParsePackageLimits(limits); err != nil {
+			fields = append(fields, domain.FieldError{Field: "limits", Pointer: "/limits", Code: "limits", Message: err.Error()})
+		}
+		if len(fields) > 0 {
+			nonconforming(fields)
+			continue
+		}
+		if !filled {
+			continue
+		}
+
+		report.Migrated++
+		if opts.DryRun {
+			continue
+		}
+		pkg.Limits = limits
+		if err := s.repo.Update(ctx, pkg); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Info("Service package limits migrated",
+		zap.Int("schema_version", schema.Version),
+		zap.Int("checked", report.Checked),
+		zap.Int("migrated", report.Migrated),
+		zap.Int("nonconforming", len(report.Nonconforming)),
+		zap.Bool("dry_run", opts.DryRun),
+	)
+	return report, nil
+}
+
+// checkLimits checks package limits against the package_limits schema
+func (s *ServicePackageService) checkLimits(ctx context.Context, limits map[string]interface{}) error {
+	if limits == nil {
+		limits = map[string]interface{}{}
+	}
+	return s.schemas.Check(ctx, domain.SchemaTargetPackageLimits, "", "limits", limits)
+}
+
+// validateModules ensures every package module resolves in the module catalog
+func (s *ServicePackageService) validateModules(ctx context.Context, pkg *domain.ServicePackage) error {
+	if len(pkg.Modules) == 0 {
+		return nil
+	}
+	_, err := s.modules.Resolve(ctx, pkg.TenantID, pkg.Modules)
+	return err
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+)
+
+func newTestServicePackageService(t *testing.T) (*ServicePackageService, *SaaSModuleService) {
+	packages := repository.NewMemoryServicePackageRepository()
+	modules := NewSaaSModuleService(repository.NewMemorySaaSModuleRepository(), packages, newMemoryCache(), newTestLogger(t))
+	return NewServicePackageService(packages, modules,
+		NewConfigSchemaService(repository.NewMemoryConfigSchemaRepository(), nil, newTestLogger(t)), newTestLogger(t)), modules
+}
+
+func TestServicePackageService_Entitlements(t *testing.T) {
+	ctx := context.Background()
+	svc, modules := newTestServicePackageService(t)
+
+	require.NoError(t, modules.Create(ctx, &domain.SaaSModule{Code: "core", Name: "Core", Features: []string{"audit_log"}}))
+	require.NoError(t, modules.Create(ctx, &domain.SaaSModule{Code: "crm", Name: "CRM", Dependencies: []string{"core"}, Features: []string{"contacts", "deals"}}))
+
+	require.NoError(t, svc.Create(ctx, &domain.ServicePackage{
+		Code:     "pro",
+		Name:     "Professional",
+		Tier:     "professional",
+		Modules:  []string{"crm"},
+		Features: []string{"sso", "contacts"},
+		Limits:   map[string]interface{}{"users": 50, "storage": "100GB"},
+	}))
+
+	entitlements, err := svc.Entitlements(ctx, "", "pro")
+	require.NoError(t, err)
+	assert.Equal(t, "professional", entitlements.Tier)
+	assert.Equal(t, []string{"core", "crm"}, entitlements.Modules)
+	assert.Equal(t, []string{"audit_log", "contacts", "deals", "sso"}, entitlements.Features)
+	assert.Equal(t, int64(50), entitlements.Limits.Users)
+	assert.Equal(t, int64(100<<30), entitlements.Limits.Storage)
+	assert.Equal(t, domain.Unlimited, entitlements.Limits.APICalls)
+
+	_, err = svc.Entitlements(ctx, "", "missing")
+	assertStatus(t, err, http.StatusNotFound)
+}
+
+func TestServicePackageService_ValidatesModulesAndLimits(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestServicePackageService(t)
+
+	err := svc.Create(ctx, &domain.ServicePackage{Code: "pro", Name: "Pro", Modules: []string{"unknown"}})
+	assertStatus(t, err, http.StatusBadRequest)
+
+	err = svc.Create(ctx, &domain.ServicePackage{Code: "pro", Name: "Pro", Limits: map[string]interface{}{"users": "many"}})
+	assertStatus(t, err, http.StatusBadRequest)
+
+	require.NoError(t, svc.Create(ctx, &domain.ServicePackage{Code: "free", Name: "Free"}))
+	err = svc.Create(ctx, &domain.ServicePackage{Code: "free", Name: "Free"})
+	assertStatus(t, err, http.StatusConflict)
+
+	entitlements, err := svc.Entitlements(ctx, "", "free")
+	require.NoError(t, err)
+	assert.Empty(t, entitlements.Modules)
+	assert.Empty(t, entitlements.Features)
+}
+
+func TestServicePackageService_IncludedModulesCannotBeDeprecated(t *testing.T) {
+	ctx := context.Background()
+	svc, modules := newTestServicePackageService(t)
+
+	crm := createModule(t, modules, "crm")
+	pkg := &domain.ServicePackage{Code: "pro", Name: "Pro", Modules: []string{"crm"}}
+	require.NoError(t, svc.Create(ctx, pkg))
+
+	err := modules.Update(ctx, &domain.SaaSModule{ID: crm.ID, Code: "crm", Name: "CRM", Status: "deprecated"})
+	assertStatus(t, err, http.StatusConflict)
+	err = modules.Delete(ctx, crm.ID.Hex(), "")
+	assertStatus(t, err, http.StatusConflict)
+
+	entitlements, err := svc.Entitlements(ctx, "", "pro")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"crm"}, entitlements.Modules)
+
+	require.NoError(t, svc.Delete(ctx, pkg.ID.Hex(), ""))
+	require.NoError(t, modules.Update(ctx, &domain.SaaSModule{ID: crm.ID, Code: "crm", Name: "CRM", Status: "deprecated"}))
+}
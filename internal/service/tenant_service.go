@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"maps"
+	"net/http"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.uber.org/zap"
+)
+
+// TenantService provisions the configuration a new tenant starts with
+type TenantService struct {
+	roles   *RoleService
+	modules *SaaSModuleService
+	menus   *AdminMenuService
+	logger  *logger.Logger
+}
+
+// NewTenantService creates a new tenant service
+func NewTenantService(roles *RoleService, modules *SaaSModuleService, menus *AdminMenuService, log *logger.Logger) *TenantService {
+	return &TenantService{
+		roles:   roles,
+		modules: modules,
+		menus:   menus,
+		logger:  log,
+	}
+}
+
+// Provision materializes the global system roles, the core modules with their dependencies
+// and the default menus for a tenant. Items the tenant already has, matched by code, are
+// left untouched, so provisioning is safe to re-run.
+func (s *TenantService) Provision(ctx context.Context, tenantID string) (*domain.TenantProvisioning, error) {
+	if tenantID == "" {
+		return nil, errors.BadRequest("tenant_id is required")
+	}
+
+	result := &domain.TenantProvisioning{
+		TenantID: tenantID,
+		Roles:    newProvisionedItems(),
+		Modules:  newProvisionedItems(),
+		Menus:    newProvisionedItems(),
+	}
+	if err := s.provisionRoles(ctx, tenantID, &result.Roles); err != nil {
+		return nil, err
+	}
+	if err := s.provisionModules(ctx, tenantID, &result.Modules); err != nil {
+		return nil, err
+	}
+	if err := s.provisionMenus(ctx, tenantID, &result.Menus); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Tenant provisioned",
+		zap.String("tenant_id", tenantID),
+		zap.Int("roles_created", len(result.Roles.Created)),
+		zap.Int("modules_created", len(result.Modules.Created)),
+		zap.Int("menus_created", len(result.Menus.Created)),
+	)
+	return result, nil
+}
+
+// provisionRoles copies every global system role into the tenant, keeping its code, parent and grants.
+// Parents resolve to the tenant's copy once it exists and to the global role until then.
+func (s *TenantService) provisionRoles(ctx context.Context, tenantID string, items *domain.ProvisionedItems) error {
+	roles, err := s.roles.SystemRoles(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, role := range roles {
+		copied := &domain.Role{
+			TenantID:    tenantID,
+			Code:        role.Code,
+			Name:        role.Name,
+			Description: role.Description,
+			IsSystem:    true,
+			ParentCode:  role.ParentCode,
+			Level:       role.Level,
+			Permissions: append([]string{}, role.Permissions...),
+			Status:      role.Status,
+		}
+		if err := recordProvisioned(items, role.Code, s.roles.create(ctx, copied)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// provisionModules copies the active global core modules and their dependencies into the
+// tenant in install order, so each module's dependencies exist before it is created
+func (s *TenantService) provisionModules(ctx context.Context, tenantID string, items *domain.ProvisionedItems) error {
+	catalog, err := s.modules.catalog(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	var core []string
+	for code, module := range catalog {
+		if (module.IsCore || module.Category == "core") && module.Status == "active" {
+			core = append(core, code)
+		}
+	}
+	if len(core) == 0 {
+		return nil
+	}
+
+	resolution, err := s.modules.Resolve(ctx, "", core)
+	if err != nil {
+		return err
+	}
+
+	for _, module := range resolution.Modules {
+		copied := &domain.SaaSModule{
+			TenantID:     tenantID,
+			Code:         module.Code,
+			Name:         module.Name,
+			Description:  module.Description,
+			Icon:         module.Icon,
+			Category:     module.Category,
+			IsCore:       module.IsCore,
+			Dependencies: append([]string{}, module.Dependencies...),
+			Price:        module.Price,
+			Status:       module.Status,
+			Features:     append([]string{}, module.Features...),
+		}
+		if err := recordProvisioned(items, module.Code, s.modules.Create(ctx, copied)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// provisionMenus copies the global default menus into the tenant parents first,
+// pointing each copy at the tenant's item for its parent code
+func (s *TenantService) provisionMenus(ctx context.Context, tenantID string, items *domain.ProvisionedItems) error {
+	defaults, err := s.menus.listAll(ctx, "")
+	if err != nil {
+		return err
+	}
+	existing, err := s.menus.listAll(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	tenantIDs := make(map[string]string, len(existing))
+	for _, menu := range existing {
+		tenantIDs[menu.Code] = menu.ID.Hex()
+	}
+	children := make(map[string][]*domain.AdminMenu)
+	for _, menu := range defaults {
+		children[menu.ParentID] = append(children[menu.ParentID], menu)
+	}
+
+	// copies maps the ID of a default menu to the ID of its copy in the tenant
+	copies := make(map[string]string, len(defaults))
+	queue := children[""]
+	sortMenus(queue)
+	for len(queue) > 0 {
+		menu := queue[0]
+		queue = queue[1:]
+
+		if id, ok := tenantIDs[menu.Code]; ok {
+			copies[menu.ID.Hex()] = id
+			items.Existing = append(items.Existing, menu.Code)
+		} else {
+			copied := &domain.AdminMenu{
+				TenantID:    tenantID,
+				ModuleCode:  menu.ModuleCode,
+				ParentID:    copies[menu.ParentID],
+				Code:        menu.Code,
+				Name:        menu.Name,
+				Title:       maps.Clone(menu.Title),
+				Icon:        menu.Icon,
+				Path:        menu.Path,
+				Component:   menu.Component,
+				Order:       menu.Order,
+				Permissions: append([]string{}, menu.Permissions...),
+				IsVisible:   menu.IsVisible,
+				Status:      menu.Status,
+			}
+			if err := s.menus.Create(ctx, copied); err != nil {
+				return err
+			}
+			copies[menu.ID.Hex()] = copied.ID.Hex()
+			items.Created = append(items.Created, menu.Code)
+		}
+
+		next := children[menu.ID.Hex()]
+		sortMenus(next)
+		queue = append(queue, next...)
+	}
+	return nil
+}
+
+// recordProvisioned records the outcome of creating a provisioned item;
+// a conflict means the tenant already has an item with that code
+func recordProvisioned(items *domain.ProvisionedItems, code string, err error) error {
+	if err == nil {
+		items.Created = append(items.Created, code)
+		return nil
+	}
+	if errors.FromError(err).StatusCode == http.StatusConflict {
+		items.Existing = append(items.Existing, code)
+		return nil
+	}
+	return err
+}
+
+func newProvisionedItems() domain.ProvisionedItems {
+	return domain.ProvisionedItems{Created: []string{}, Existing: []string{}}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+)
+
+func TestTenantService_Provision(t *testing.T) {
+	ctx := context.Background()
+	roles, _ := newTestRoleService(t)
+	modules := newTestSaaSModuleService(t)
+	menus, _ := newTestAdminMenuService(t)
+	svc := NewTenantService(roles, modules, menus, newTestLogger(t))
+
+	require.NoError(t, roles.Create(ctx, &domain.Role{Code: "user", Name: "User", IsSystem: true, Level: 4}, rootCaller))
+	require.NoError(t, roles.Create(ctx, &domain.Role{Code: "admin", Name: "Administrator", IsSystem: true, ParentCode: "user", Level: 2, Permissions: []string{"admin.*"}}, rootCaller))
+	require.NoError(t, roles.Create(ctx, &domain.Role{Code: "auditor", Name: "Auditor", Level: 3}, rootCaller))
+
+	createModule(t, modules, "platform")
+	require.NoError(t, modules.Create(ctx, &domain.SaaSModule{Code: "core", Name: "Core", IsCore: true, Dependencies: []string{"platform"}}))
+	createModule(t, modules, "crm", "core")
+
+	settings := createMenu(t, menus, &domain.AdminMenu{Code: "settings", Order: 2})
+	createMenu(t, menus, &domain.AdminMenu{Code: "dashboard", Order: 1})
+	createMenu(t, menus, &domain.AdminMenu{Code: "users", ParentID: settings.ID.Hex()})
+
+	result, err := svc.Provision(ctx, "tenant-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin", "user"}, result.Roles.Created)
+	assert.Equal(t, []string{"platform", "core"}, result.Modules.Created)
+	assert.Equal(t, []string{"dashboard", "settings", "users"}, result.Menus.Created)
+
+	admin, err := roles.repo.FindByCode(ctx, "tenant-1", "admin")
+	require.NoError(t, err)
+	require.NotNil(t, admin)
+	assert.True(t, admin.IsSystem)
+	assert.Equal(t, "user", admin.ParentCode)
+	assert.Equal(t, []string{"admin.*"}, admin.Permissions)
+
+	tenantSettings, err := menus.repo.FindByCode(ctx, "tenant-1", "settings")
+	require.NoError(t, err)
+	tenantUsers, err := menus.repo.FindByCode(ctx, "tenant-1", "users")
+	require.NoError(t, err)
+	assert.Equal(t, tenantSettings.ID.Hex(), tenantUsers.ParentID)
+
+	result, err = svc.Provision(ctx, "tenant-1")
+	require.NoError(t, err)
+	assert.Empty(t, result.Roles.Created)
+	assert.Empty(t, result.Modules.Created)
+	assert.Empty(t, result.Menus.Created)
+	assert.Equal(t, []string{"admin", "user"}, result.Roles.Existing)
+	assert.Equal(t, []string{"platform", "core"}, result.Modules.Existing)
+	assert.Equal(t, []string{"dashboard", "settings", "users"}, result.Menus.Existing)
+}
+
+func TestTenantService_ProvisionRequiresTenant(t *testing.T) {
+	roles, _ := newTestRoleService(t)
+	menus := NewAdminMenuService(repository.NewMemoryAdminMenuRepository(), repository.NewMemoryTransactor(), nil, newTestLogger(t))
+	svc := NewTenantService(roles, newTestSaaSModuleService(t), menus, newTestLogger(t))
+
+	_, err := svc.Provision(context.Background(), "")
+	assertStatus(t, err, http.StatusBadRequest)
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"golang.org/x/text/language"
+)
+
+// xliffNamespace is the namespace of XLIFF 1.2 documents
+const xliffNamespace = "urn:oasis:names:tc:xliff:document:1.2"
+
+// translationRow is the translations of one unit read from an import file
+type translationRow struct {
+	line   int // CSV line; 0 for XLIFF
+	id     string
+	values map[string]string // locale -> non-empty text
+}
+
+// rowEntities returns the entity types referenced by rows
+func rowEntities(rows []translationRow) []string {
+	var entities []string
+	for _, row := range rows {
+		entity, _, _, err := domain.ParseTranslationUnitID(row.id)
+		if err == nil && !slices.Contains(entities, entity) {
+			entities = append(entities, entity)
+		}
+	}
+	return entities
+}
+
+// writeTranslationCSV writes units as entity, key, field and one column per locale
+func writeTranslationCSV(units []domain.TranslationUnit, locales []string) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(append([]string{"entity", "key", "field"}, locales...)); err != nil {
+		return nil, err
+	}
+	for _, unit := range units {
+		record := []string{unit.Entity, unit.Key, unit.Field}
+		for _, locale := range locales {
+			record = append(record, unit.Values[locale])
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// parseTranslationCSV reads a file written by writeTranslationCSV. Every column after entity, key
+// and field is a locale; the field column may be omitted for files edited by hand.
+func parseTranslationCSV(r io.Reader) ([]translationRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.BadRequest(fmt.Sprintf("Invalid CSV: %v", err))
+	}
+	columns := make(map[string]int, len(header))
+	localeColumns := make(map[string]int)
+	for i, column := range header {
+		folded := domain.FoldText(column) // also drops a byte order mark
+		switch folded {
+		case "entity", "key", "field":
+			columns[folded] = i
+			continue
+		}
+		locale := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		if _, err := language.Parse(locale); err != nil {
+			return nil, errors.BadRequest(fmt.Sprintf("Column '%s' is not a locale", column))
+		}
+		localeColumns[locale] = i
+	}
+	_, hasEntity := columns["entity"]
+	_, hasKey := columns["key"]
+	if !hasEntity || !hasKey || len(localeColumns) == 0 {
+		return nil, errors.BadRequest("Unrecognized CSV header: expected entity, key, field and one column per locale")
+	}
+
+	var rows []translationRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.BadRequest(fmt.Sprintf("Invalid CSV: %v", err))
+		}
+		line, _ := reader.FieldPos(0)
+		field := func(i int, ok bool) string {
+			if ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		entity := strings.ToLower(field(columns["entity"], true))
+		i, ok := columns["field"]
+		name := field(i, ok)
+		if name == "" {
+			name = defaultTranslationField(entity)
+		}
+		row := translationRow{
+			line:   line,
+			id:     domain.TranslationUnit{Entity: entity, Key: field(columns["key"], true), Field: name}.ID(),
+			values: make(map[string]string, len(localeColumns)),
+		}
+		for locale, i := range localeColumns {
+			if value := field(i, true); value != "" {
+				row.values[locale] = value
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// defaultTranslationField returns the translatable field of an entity type
+func defaultTranslationField(entity string) string {
+	if entity == domain.TranslationEntityAdminMenu {
+		return "title"
+	}
+	return "name"
+}
+
+// xliffDocument is the subset of XLIFF 1.2 used to exchange translations
+type xliffDocument struct {
+	XMLName xml.Name    `xml:"xliff"`
+	Xmlns   string      `xml:"xmlns,attr,omitempty"`
+	Version string      `xml:"version,attr"`
+	Files   []xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	Original       string      `xml:"original,attr"`
+	SourceLanguage string      `xml:"source-language,attr"`
+	TargetLanguage string      `xml:"target-language,attr,omitempty"`
+	Datatype       string      `xml:"datatype,attr"`
+	Units          []xliffUnit `xml:"body>trans-unit"`
+}
+
+type xliffUnit struct {
+	ID     string       `xml:"id,attr"`
+	Source string       `xml:"source"`
+	Target *xliffTarget `xml:"target"`
+}
+
+type xliffTarget struct {
+	Value string `xml:",chardata"`
+}
+
+// writeTranslationXLIFF writes units as an XLIFF 1.2 document from the source to the target
+// locale. Units without a source text fall back to another locale so translators have context.
+func writeTranslationXLIFF(units []domain.TranslationUnit, source, target string) ([]byte, error) {
+	file := xliffFile{
+		Original:       "system-config",
+		SourceLanguage: source,
+		TargetLanguage: target,
+		Datatype:       "plaintext",
+		Units:          make([]xliffUnit, 0, len(units)),
+	}
+	for _, unit := range units {
+		entry := xliffUnit{ID: unit.ID(), Source: domain.Localize(unit.Values, source)}
+		if value := unit.Values[target]; value != "" {
+			entry.Target = &xliffTarget{Value: value}
+		}
+		file.Units = append(file.Units, entry)
+	}
+
+	out, err := xml.MarshalIndent(xliffDocument{Xmlns: xliffNamespace, Version: "1.2", Files: []xliffFile{file}}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
+
+// parseTranslationXLIFF reads the targets of an XLIFF 1.2 document; units without a target are skipped
+func parseTranslationXLIFF(r io.Reader) ([]translationRow, error) {
+	var doc xliffDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, errors.BadRequest(fmt.Sprintf("Invalid XLIFF: %v", err))
+	}
+
+	var rows []translationRow
+	for _, file := range doc.Files {
+		locale := strings.ToLower(strings.TrimSpace(file.TargetLanguage))
+		if _, err := language.Parse(locale); err != nil {
+			return nil, errors.BadRequest(fmt.Sprintf("Invalid XLIFF: file '%s' has no valid target-language", file.Original))
+		}
+		for _, unit := range file.Units {
+			if unit.Target == nil || strings.TrimSpace(unit.Target.Value) == "" {
+				continue
+			}
+			rows = append(rows, translationRow{
+				id:     strings.TrimSpace(unit.ID),
+				values: map[string]string{locale: strings.TrimSpace(unit.Target.Value)},
+			})
+		}
+	}
+	return rows, nil
+}
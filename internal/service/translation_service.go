@@ -0,0 +1,363 @@
+package service
+
+import (
+	"context"
+	"io"
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/vhvplatform/go-shared/errors"
+	"github.com/vhvplatform/go-shared/logger"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"go.uber.org/zap"
+)
+
+// TranslationService reports on, exports and imports the translations of the i18n fields of
+// master data and of the admin menus of a tenant
+type TranslationService struct {
+	countries   *CountryService
+	currencies  *CurrencyService
+	ethnicities *EthnicityService
+	locations   *LocationService
+	menus       *AdminMenuService
+	locales     []string
+	logger      *logger.Logger
+}
+
+// NewTranslationService creates a new translation service.
+// Records are read and written through the services owning them so that validation, search
+// text and caches stay consistent. locales are the locales every record is expected to have.
+func NewTranslationService(countries *CountryService, currencies *CurrencyService, ethnicities *EthnicityService, locations *LocationService, menus *AdminMenuService, locales []string, log *logger.Logger) *TranslationService {
+	query := domain.TranslationQuery{Locales: locales}
+	query.Normalize()
+	if len(query.Locales) == 0 {
+		query.Locales = []string{domain.DefaultLocale}
+	}
+
+	return &TranslationService{
+		countries:   countries,
+		currencies:  currencies,
+		ethnicities: ethnicities,
+		locations:   locations,
+		menus:       menus,
+		locales:     query.Locales,
+		logger:      log,
+	}
+}
+
+// Locales returns the configured locales
+func (s *TranslationService) Locales() []string {
+	return slices.Clone(s.locales)
+}
+
+// Report computes, per entity type and locale, how many records are translated and which are not
+func (s *TranslationService) Report(ctx context.Context, tenantID string, query *domain.TranslationQuery) (*domain.TranslationReport, error) {
+	if err := s.prepare(query); err != nil {
+		return nil, err
+	}
+
+	records, err := s.records(ctx, tenantID, query.Entities)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.TranslationReport{Locales: query.Locales, Entities: []*domain.TranslationEntityReport{}}
+	byEntity := make(map[string]*domain.TranslationEntityReport)
+	for _, entity := range query.Entities {
+		entry := &domain.TranslationEntityReport{Entity: entity, Locales: make(map[string]*domain.TranslationCoverage)}
+		for _, locale := range query.Locales {
+			entry.Locales[locale] = &domain.TranslationCoverage{Missing: []string{}}
+		}
+		byEntity[entity] = entry
+		report.Entities = append(report.Entities, entry)
+	}
+
+	for _, record := range records {
+		entry := byEntity[record.unit.Entity]
+		entry.Total++
+		missing := record.unit.Missing(query.Locales)
+		for _, locale := range query.Locales {
+			if slices.Contains(missing, locale) {
+				entry.Locales[locale].Missing = append(entry.Locales[locale].Missing, record.unit.Key)
+			} else {
+				entry.Locales[locale].Translated++
+			}
+		}
+	}
+
+	for _, entry := range report.Entities {
+		for _, coverage := range entry.Locales {
+			coverage.Percent = 100
+			if entry.Total > 0 {
+				coverage.Percent = math.Round(float64(coverage.Translated)*10000/float64(entry.Total)) / 100
+			}
+		}
+	}
+	return report, nil
+}
+
+// Export writes the translation units selected by the query as CSV, one column per locale, or as
+// an XLIFF 1.2 document from the source to the target locale
+func (s *TranslationService) Export(ctx context.Context, tenantID string, query *domain.TranslationQuery) ([]byte, error) {
+	if err := s.prepare(query); err != nil {
+		return nil, err
+	}
+	if query.Source == "" {
+		query.Source = domain.DefaultLocale
+	}
+
+	records, err := s.records(ctx, tenantID, query.Entities)
+	if err != nil {
+		return nil, err
+	}
+
+	required := query.Locales
+	if query.Format == domain.TranslationFormatXLIFF {
+		required = []string{query.Target}
+	}
+	units := make([]domain.TranslationUnit, 0, len(records))
+	for _, record := range records {
+		if !query.MissingOnly || len(record.unit.Missing(required)) > 0 {
+			units = append(units, record.unit)
+		}
+	}
+
+	if query.Format == domain.TranslationFormatXLIFF {
+		return writeTranslationXLIFF(units, query.Source, query.Target)
+	}
+	return writeTranslationCSV(units, query.Locales)
+}
+
+// Import merges translations from a CSV or XLIFF file into the stored records. Translations are
+// added or replaced per locale; empty cells and untouched locales are kept. Units that do not
+// exist or cannot be saved are rejected with a reason instead of aborting the import.
+func (s *TranslationService) Import(ctx context.Context, tenantID string, r io.Reader, opts *domain.TranslationImportOptions) (*domain.TranslationImportReport, error) {
+	var rows []translationRow
+	var err error
+	switch strings.ToLower(opts.Format) {
+	case domain.TranslationFormatCSV:
+		rows, err = parseTranslationCSV(r)
+	case domain.TranslationFormatXLIFF:
+		rows, err = parseTranslationXLIFF(r)
+	default:
+		return nil, errors.BadRequest("format must be one of csv, xliff")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.records(ctx, tenantID, rowEntities(rows))
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*translationRecord, len(records))
+	for _, record := range records {
+		byID[record.unit.ID()] = record
+	}
+
+	report := &domain.TranslationImportReport{DryRun: opts.DryRun, Rejected: []domain.TranslationImportRejection{}}
+	for _, row := range rows {
+		reject := func(reason string) {
+			report.Rejected = append(report.Rejected, domain.TranslationImportRejection{Line: row.line, ID: row.id, Reason: reason})
+		}
+
+		record, ok := byID[row.id]
+		if !ok {
+			reject("no such translation unit")
+			continue
+		}
+
+		merged := maps.Clone(record.unit.Values)
+		if merged == nil {
+			merged = make(map[string]string, len(row.values))
+		}
+		maps.Copy(merged, row.values)
+		if maps.Equal(merged, record.unit.Values) {
+			report.Unchanged++
+			continue
+		}
+
+		if !opts.DryRun {
+			if err := record.save(ctx, merged); err != nil {
+				appErr := errors.FromError(err)
+				if appErr.StatusCode >= http.StatusInternalServerError {
+					return nil, err
+				}
+				reject(appErr.Message)
+				continue
+			}
+		}
+		record.unit.Values = merged
+		report.Updated++
+	}
+
+	s.logger.Info("Translations imported",
+		zap.Bool("dry_run", opts.DryRun),
+		zap.Int("rows", len(rows)),
+		zap.Int("updated", report.Updated),
+		zap.Int("rejected", len(report.Rejected)),
+	)
+	return report, nil
+}
+
+// prepare normalizes and validates a query, defaulting to every entity type and the configured
+// locales
+func (s *TranslationService) prepare(query *domain.TranslationQuery) error {
+	query.Normalize()
+	if err := query.Validate(); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	if len(query.Entities) == 0 {
+		query.Entities = slices.Clone(domain.TranslationEntities)
+	}
+	if len(query.Locales) == 0 {
+		query.Locales = s.Locales()
+	}
+	return nil
+}
+
+// translationRecord is the translation unit of a stored record and how to save new values for it
+type translationRecord struct {
+	unit domain.TranslationUnit
+	save func(ctx context.Context, values map[string]string) error
+}
+
+// records loads the translation units of the given entity types, in domain.TranslationEntities order
+func (s *TranslationService) records(ctx context.Context, tenantID string, entities []string) ([]*translationRecord, error) {
+	var records []*translationRecord
+	add := func(entity, key, field string, values map[string]string, save func(ctx context.Context, values map[string]string) error) {
+		records = append(records, &translationRecord{
+			unit: domain.TranslationUnit{Entity: entity, Key: key, Field: field, Values: maps.Clone(values)},
+			save: save,
+		})
+	}
+	wanted := func(entity string) bool { return slices.Contains(entities, entity) }
+
+	countries, _, err := s.countries.List(ctx, domain.Localization{}, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+	if wanted(domain.TranslationEntityCountry) {
+		for _, country := range countries {
+			add(domain.TranslationEntityCountry, country.Code, "name", country.Name, func(ctx context.Context, values map[string]string) error {
+				country.Name = values
+				return s.countries.Update(ctx, country)
+			})
+		}
+	}
+
+	if wanted(domain.TranslationEntityCurrency) {
+		currencies, _, err := s.currencies.List(ctx, domain.Localization{}, 1, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, currency := range currencies {
+			add(domain.TranslationEntityCurrency, currency.Code, "name", currency.Name, func(ctx context.Context, values map[string]string) error {
+				currency.Name = values
+				return s.currencies.Update(ctx, currency)
+			})
+		}
+	}
+
+	if wanted(domain.TranslationEntityEthnicity) {
+		ethnicities, _, err := s.ethnicities.List(ctx, "", domain.Localization{}, 1, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, ethnicity := range ethnicities {
+			add(domain.TranslationEntityEthnicity, ethnicity.CountryCode+":"+ethnicity.Code, "name", ethnicity.Name, func(ctx context.Context, values map[string]string) error {
+				ethnicity.Name = values
+				return s.ethnicities.Update(ctx, ethnicity)
+			})
+		}
+	}
+
+	// Administrative units are listed a level at a time, no deeper than needed, keeping the units
+	// whose parent was listed
+	needDistricts := wanted(domain.TranslationEntityDistrict) || wanted(domain.TranslationEntityWard)
+	if wanted(domain.TranslationEntityProvince) || needDistricts {
+		listedCountries := make(map[string]bool, len(countries))
+		for _, country := range countries {
+			listedCountries[country.Code] = true
+		}
+
+		provinces, err := s.locations.provinces.ListCurrent(ctx)
+		if err != nil {
+			return nil, err
+		}
+		listedProvinces := make(map[string]bool, len(provinces))
+		for _, province := range provinces {
+			if !listedCountries[province.CountryCode] {
+				continue
+			}
+			listedProvinces[province.Code] = true
+			if wanted(domain.TranslationEntityProvince) {
+				add(domain.TranslationEntityProvince, province.Code, "name", province.Name, func(ctx context.Context, values map[string]string) error {
+					province.Name = values
+					return s.locations.UpdateProvince(ctx, province)
+				})
+			}
+		}
+
+		if needDistricts {
+			districts, err := s.locations.districts.ListCurrent(ctx)
+			if err != nil {
+				return nil, err
+			}
+			listedDistricts := make(map[string]bool, len(districts))
+			for _, district := range districts {
+				if !listedProvinces[district.ProvinceCode] {
+					continue
+				}
+				listedDistricts[district.Code] = true
+				if wanted(domain.TranslationEntityDistrict) {
+					add(domain.TranslationEntityDistrict, district.Code, "name", district.Name, func(ctx context.Context, values map[string]string) error {
+						district.Name = values
+						return s.locations.UpdateDistrict(ctx, district)
+					})
+				}
+			}
+
+			if wanted(domain.TranslationEntityWard) {
+				wards, err := s.locations.wards.ListCurrent(ctx)
+				if err != nil {
+					return nil, err
+				}
+				for _, ward := range wards {
+					if !listedDistricts[ward.DistrictCode] {
+						continue
+					}
+					add(domain.TranslationEntityWard, ward.Code, "name", ward.Name, func(ctx context.Context, values map[string]string) error {
+						ward.Name = values
+						return s.locations.UpdateWard(ctx, ward)
+					})
+				}
+			}
+		}
+	}
+
+	if wanted(domain.TranslationEntityAdminMenu) {
+		menus, err := s.menus.listAll(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		for _, menu := range menus {
+			add(domain.TranslationEntityAdminMenu, menu.Code, "title", menu.Title, func(ctx context.Context, values map[string]string) error {
+				menu.Title = values
+				return s.menus.Update(ctx, menu)
+			})
+		}
+	}
+
+	order := make(map[string]int, len(domain.TranslationEntities))
+	for i, entity := range domain.TranslationEntities {
+		order[entity] = i
+	}
+	slices.SortStableFunc(records, func(a, b *translationRecord) int {
+		return order[a.unit.Entity] - order[b.unit.Entity]
+	})
+	return records, nil
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vhvplatform/go-system-config-service/internal/domain"
+	"github.com/vhvplatform/go-system-config-service/internal/repository"
+)
+
+type translationFixture struct {
+	translations *TranslationService
+	countries    *CountryService
+	locations    *LocationService
+	menus        *AdminMenuService
+}
+
+// newTestTranslationService wires the translation service over the services of every
+// translatable entity type, seeded with a few records translated into en only or en and vi
+func newTestTranslationService(t *testing.T) *translationFixture {
+	ctx := context.Background()
+	cache := newMemoryCache()
+	log := newTestLogger(t)
+	countryRepo := repository.NewMemoryCountryRepository()
+	provinceRepo := repository.NewMemoryProvinceRepository()
+	currencyRepo := repository.NewMemoryCurrencyRepository()
+	ethnicityRepo := repository.NewMemoryEthnicityRepository()
+
+	f := &translationFixture{
+		countries: NewCountryService(countryRepo, provinceRepo, currencyRepo, ethnicityRepo, cache, log),
+		locations: NewLocationService(countryRepo, provinceRepo, repository.NewMemoryDistrictRepository(),
+			repository.NewMemoryWardRepository(), repository.NewMemoryTransactor(), cache, log),
+		menus: NewAdminMenuService(repository.NewMemoryAdminMenuRepository(), repository.NewMemoryTransactor(), cache, log),
+	}
+	currencies := NewCurrencyService(currencyRepo, countryRepo, repository.NewMemoryServicePackageRepository(), cache, log)
+	ethnicities := NewEthnicityService(ethnicityRepo, countryRepo, cache, log)
+	f.translations = NewTranslationService(f.countries, currencies, ethnicities, f.locations, f.menus, []string{"en", "VI", "en"}, log)
+
+	require.NoError(t, f.countries.Create(ctx, &domain.Country{Code: "VN", Name: map[string]string{"en": "Vietnam", "vi": "Việt Nam"}}))
+	require.NoError(t, f.countries.Create(ctx, &domain.Country{Code: "TH", Name: map[string]string{"en": "Thailand"}}))
+	require.NoError(t, currencies.Create(ctx, &domain.Currency{Code: "VND", Name: map[string]string{"en": "Vietnamese dong"}}))
+	require.NoError(t, ethnicities.Create(ctx, &domain.Ethnicity{Code: "01", CountryCode: "VN", Name: map[string]string{"en": "Kinh", "vi": "Kinh"}}))
+	require.NoError(t, f.locations.CreateProvince(ctx, &domain.Province{Code: "01", Name: map[string]string{"en": "Ha Noi", "vi": "Hà Nội"}, CountryCode: "VN"}))
+	require.NoError(t, f.locations.CreateDistrict(ctx, &domain.District{Code: "001", Name: map[string]string{"en": "Ba Dinh"}, ProvinceCode: "01"}))
+	require.NoError(t, f.locations.CreateWard(ctx, &domain.Ward{Code: "00001", Name: map[string]string{"en": "Phuc Xa"}, DistrictCode: "001"}))
+	require.NoError(t, f.menus.Create(ctx, &domain.AdminMenu{Code: "users", TenantID: "tenant-1", Title: map[string]string{"en": "Users"}, IsVisible: true}))
+	return f
+}
+
+func TestTranslationService_Report(t *testing.T) {
+	ctx := context.Background()
+	f := newTestTranslationService(t)
+	assert.Equal(t, []string{"en", "vi"}, f.translations.Locales())
+
+	report, err := f.translations.Report(ctx, "tenant-1", &domain.TranslationQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"en", "vi"}, report.Locales)
+	require.Len(t, report.Entities, len(domain.TranslationEntities))
+
+	countries := report.Entities[0]
+	assert.Equal(t, domain.TranslationEntityCountry, countries.Entity)
+	assert.Equal(t, 2, countries.Total)
+	assert.Equal(t, 100.0, countries.Locales["en"].Percent)
+	assert.Equal(t, 1, countries.Locales["vi"].Translated)
+	assert.Equal(t, 50.0, countries.Locales["vi"].Percent)
+	assert.Equal(t, []string{"TH"}, countries.Locales["vi"].Missing)
+
+	ethnicities := report.Entities[2]
+	assert.Equal(t, domain.TranslationEntityEthnicity, ethnicities.Entity)
+	assert.Empty(t, ethnicities.Locales["vi"].Missing)
+
+	menus := report.Entities[6]
+	assert.Equal(t, domain.TranslationEntityAdminMenu, menus.Entity)
+	assert.Equal(t, 1, menus.Total)
+	assert.Equal(t, []string{"users"}, menus.Locales["vi"].Missing)
+
+	report, err = f.translations.Report(ctx, "", &domain.TranslationQuery{Entities: []string{"Ward", "admin_menu"}, Locales: []string{"vi"}})
+	require.NoError(t, err)
+	require.Len(t, report.Entities, 2)
+	assert.Equal(t, []string{"00001"}, report.Entities[0].Locales["vi"].Missing)
+	assert.Equal(t, 0, report.Entities[1].Total)
+	assert.Equal(t, 100.0, report.Entities[1].Locales["vi"].Percent)
+
+	_, err = f.translations.Report(ctx, "", &domain.TranslationQuery{Entities: []string{"planet"}})
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+// perParentWardRepository counts the listings of wards by district
+type perParentWardRepository struct {
+	repository.WardRepository
+	calls *int
+}
+
+func (r perParentWardRepository) ListByDistrict(ctx context.Context, districtCode string) ([]*domain.Ward, error) {
+	*r.calls++
+	return r.WardRepository.ListByDistrict(ctx, districtCode)
+}
+
+func TestTranslationService_ListsLocationsPerLevel(t *testing.T) {
+	ctx := context.Background()
+	f := newTestTranslationService(t)
+	for _, district := range []string{"002", "003"} {
+		require.NoError(t, f.locations.CreateDistrict(ctx, &domain.District{Code: district, Name: map[string]string{"en": district}, ProvinceCode: "01"}))
+		for _, ward := range []string{"1", "2"} {
+			require.NoError(t, f.locations.CreateWard(ctx, &domain.Ward{Code: district + ward, Name: map[string]string{"en": ward}, DistrictCode: district}))
+		}
+	}
+	// units below a province of a country that is not listed are left out
+	require.NoError(t, f.locations.countries.Create(ctx, &domain.Country{Code: "LA", Name: map[string]string{"en": "Laos"}, Status: "inactive"}))
+	require.NoError(t, f.locations.CreateProvince(ctx, &domain.Province{Code: "LA1", Name: map[string]string{"en": "Vientiane"}, CountryCode: "LA"}))
+	require.NoError(t, f.locations.CreateDistrict(ctx, &domain.District{Code: "LA11", Name: map[string]string{"en": "Chanthabuly"}, ProvinceCode: "LA1"}))
+	require.NoError(t, f.locations.CreateWard(ctx, &domain.Ward{Code: "LA111", Name: map[string]string{"en": "Ban"}, DistrictCode: "LA11"}))
+
+	calls := 0
+	f.locations.wards = perParentWardRepository{WardRepository: f.locations.wards, calls: &calls}
+
+	report, err := f.translations.Report(ctx, "", &domain.TranslationQuery{Entities: []string{"district", "ward"}, Locales: []string{"vi"}})
+	require.NoError(t, err)
+	require.Len(t, report.Entities, 2)
+	assert.Equal(t, []string{"001", "002", "003"}, report.Entities[0].Locales["vi"].Missing)
+	assert.Equal(t, []string{"00001", "0021", "0022", "0031", "0032"}, report.Entities[1].Locales["vi"].Missing)
+	assert.Zero(t, calls, "wards are listed once for all districts")
+}
+
+func TestTranslationService_CSVRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	f := newTestTranslationService(t)
+
+	data, err := f.translations.Export(ctx, "tenant-1", &domain.TranslationQuery{Locales: []string{"en", "vi"}, MissingOnly: true})
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Equal(t, "entity,key,field,en,vi", lines[0])
+	assert.Contains(t, lines, "country,TH,name,Thailand,")
+	assert.NotContains(t, string(data), "country,VN,name")
+	assert.Contains(t, lines, "admin_menu,users,title,Users,")
+
+	file := "\ufeffentity,key,field,vi\n" +
+		"country,TH,name,Thái Lan\n" +
+		"country,VN,name,Việt Nam\n" +
+		"ward,00001,,Phúc Xá\n" +
+		"admin_menu,users,title,Người dùng\n" +
+		"country,XX,name,Không có\n"
+
+	report, err := f.translations.Import(ctx, "tenant-1", strings.NewReader(file), &domain.TranslationImportOptions{Format: "csv", DryRun: true})
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 3, report.Updated)
+	assert.Equal(t, 1, report.Unchanged)
+	require.Len(t, report.Rejected, 1)
+	assert.Equal(t, domain.TranslationImportRejection{Line: 6, ID: "country/XX/name", Reason: "no such translation unit"}, report.Rejected[0])
+
+	country, err := f.countries.GetByCode(ctx, "TH")
+	require.NoError(t, err)
+	assert.Empty(t, country.Name["vi"])
+
+	report, err = f.translations.Import(ctx, "tenant-1", strings.NewReader(file), &domain.TranslationImportOptions{Format: "csv"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Updated)
+
+	country, err = f.countries.GetByCode(ctx, "TH")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"en": "Thailand", "vi": "Thái Lan"}, country.Name)
+	ward, err := f.locations.GetWard(ctx, "00001")
+	require.NoError(t, err)
+	assert.Equal(t, "Phúc Xá", ward.Name["vi"])
+	tree, err := f.menus.Tree(ctx, "tenant-1", "vi", nil)
+	require.NoError(t, err)
+	require.Len(t, tree.Items, 1)
+	assert.Equal(t, "Người dùng", tree.Items[0].Title)
+
+	coverage, err := f.translations.Report(ctx, "tenant-1", &domain.TranslationQuery{Entities: []string{"country", "ward"}})
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, coverage.Entities[0].Locales["vi"].Percent)
+	assert.Equal(t, 100.0, coverage.Entities[1].Locales["vi"].Percent)
+
+	_, err = f.translations.Import(ctx, "", strings.NewReader("code,name\nVN,Vietnam\n"), &domain.TranslationImportOptions{Format: "csv"})
+	assertStatus(t, err, http.StatusBadRequest)
+	_, err = f.translations.Import(ctx, "", strings.NewReader(file), &domain.TranslationImportOptions{Format: "json"})
+	assertStatus(t, err, http.StatusBadRequest)
+}
+
+func TestTranslationService_XLIFF(t *testing.T) {
+	ctx := context.Background()
+	f := newTestTranslationService(t)
+
+	_, err := f.translations.Export(ctx, "", &domain.TranslationQuery{Format: "xliff"})
+	assertStatus(t, err, http.StatusBadRequest)
+
+	data, err := f.translations.Export(ctx, "", &domain.TranslationQuery{Format: "xliff", Target: "vi", MissingOnly: true, Entities: []string{"country", "district"}})
+	require.NoError(t, err)
+	doc := string(data)
+	assert.Contains(t, doc, `<xliff xmlns="urn:oasis:names:tc:xliff:document:1.2" version="1.2">`)
+	assert.Contains(t, doc, `source-language="en" target-language="vi"`)
+	assert.Contains(t, doc, `<trans-unit id="country/TH/name">`)
+	assert.Contains(t, doc, `<trans-unit id="district/001/name">`)
+	assert.NotContains(t, doc, `country/VN/name`)
+	assert.NotContains(t, doc, `<target>`)
+
+	// A translator fills in the targets
+	translated := strings.Replace(doc, "<source>Thailand</source>", "<source>Thailand</source>\n        <target>Thái Lan</target>", 1)
+	translated = strings.Replace(translated, "<source>Ba Dinh</source>", "<source>Ba Dinh</source>\n        <target>Ba Đình</target>", 1)
+
+	report, err := f.translations.Import(ctx, "", bytes.NewReader([]byte(translated)), &domain.TranslationImportOptions{Format: "XLIFF"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Updated)
+	assert.Empty(t, report.Rejected)
+
+	district, err := f.locations.GetDistrict(ctx, "001")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"en": "Ba Dinh", "vi": "Ba Đình"}, district.Name)
+
+	data, err = f.translations.Export(ctx, "", &domain.TranslationQuery{Format: "xliff", Target: "vi", Entities: []string{"country"}})
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "<target>Thái Lan</target>")
+
+	_, err = f.translations.Import(ctx, "", strings.NewReader(`<xliff version="1.2"><file original="x"><body/></file></xliff>`), &domain.TranslationImportOptions{Format: "xliff"})
+	assertStatus(t, err, http.StatusBadRequest)
+	_, err = f.translations.Import(ctx, "", strings.NewReader("not xml"), &domain.TranslationImportOptions{Format: "xliff"})
+	assertStatus(t, err, http.StatusBadRequest)
+}
@@ -18,6 +18,11 @@ func SeedData(db *mongo.Database) error {
 		return err
 	}
 
+	// Seed ethnicities
+	if err := seedEthnicities(ctx, db); err != nil {
+		return err
+	}
+
 	// Seed currencies
 	if err := seedCurrencies(ctx, db); err != nil {
 		return err
@@ -28,6 +33,11 @@ func SeedData(db *mongo.Database) error {
 		return err
 	}
 
+	// Record ancestor codes on districts and wards stored before search scoping used them
+	if err := backfillLocationAncestors(ctx, db); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -89,6 +99,95 @@ func seedCountries(ctx context.Context, db *mongo.Database) error {
 	return err
 }
 
+// vietnamEthnicities lists the 54 ethnic groups officially recognized in Vietnam with the codes
+// of the General Statistics Office classification (Decision 121-TCTK/PPCĐ)
+var vietnamEthnicities = []struct {
+	code, vi, en string
+}{
+	{"01", "Kinh", "Kinh (Viet)"},
+	{"02", "Tày", "Tay"},
+	{"03", "Thái", "Thai"},
+	{"04", "Hoa", "Hoa (Chinese)"},
+	{"05", "Khơ-me", "Khmer"},
+	{"06", "Mường", "Muong"},
+	{"07", "Nùng", "Nung"},
+	{"08", "HMông", "Hmong"},
+	{"09", "Dao", "Dao"},
+	{"10", "Gia-rai", "Gia Rai"},
+	{"11", "Ngái", "Ngai"},
+	{"12", "Ê-đê", "Ede"},
+	{"13", "Ba-na", "Ba Na"},
+	{"14", "Xơ-đăng", "Xo Dang"},
+	{"15", "Sán Chay", "San Chay"},
+	{"16", "Cơ-ho", "Co Ho"},
+	{"17", "Chăm", "Cham"},
+	{"18", "Sán Dìu", "San Diu"},
+	{"19", "Hrê", "Hre"},
+	{"20", "Mnông", "Mnong"},
+	{"21", "Ra-glai", "Ra Glai"},
+	{"22", "Xtiêng", "Xtieng"},
+	{"23", "Bru-Vân Kiều", "Bru-Van Kieu"},
+	{"24", "Thổ", "Tho"},
+	{"25", "Giáy", "Giay"},
+	{"26", "Cơ-tu", "Co Tu"},
+	{"27", "Gié-Triêng", "Gie Trieng"},
+	{"28", "Mạ", "Ma"},
+	{"29", "Khơ-mú", "Kho Mu"},
+	{"30", "Co", "Co"},
+	{"31", "Tà-ôi", "Ta Oi"},
+	{"32", "Chơ-ro", "Cho Ro"},
+	{"33", "Kháng", "Khang"},
+	{"34", "Xinh-mun", "Xinh Mun"},
+	{"35", "Hà Nhì", "Ha Nhi"},
+	{"36", "Chu-ru", "Chu Ru"},
+	{"37", "Lào", "Lao"},
+	{"38", "La Chí", "La Chi"},
+	{"39", "La Ha", "La Ha"},
+	{"40", "Phù Lá", "Phu La"},
+	{"41", "La Hủ", "La Hu"},
+	{"42", "Lự", "Lu"},
+	{"43", "Lô Lô", "Lo Lo"},
+	{"44", "Chứt", "Chut"},
+	{"45", "Mảng", "Mang"},
+	{"46", "Pà Thẻn", "Pa Then"},
+	{"47", "Cơ Lao", "Co Lao"},
+	{"48", "Cống", "Cong"},
+	{"49", "Bố Y", "Bo Y"},
+	{"50", "Si La", "Si La"},
+	{"51", "Pu Péo", "Pu Peo"},
+	{"52", "Brâu", "Brau"},
+	{"53", "Ơ Đu", "O Du"},
+	{"54", "Rơ-măm", "Ro Mam"},
+}
+
+func seedEthnicities(ctx context.Context, db *mongo.Database) error {
+	collection := db.Collection("ethnicities")
+
+	// Check if ethnicities already exist
+	count, err := collection.CountDocuments(ctx, bson.D{})
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil // Skip seeding if data already exists
+	}
+
+	ethnicities := make([]interface{}, 0, len(vietnamEthnicities))
+	for _, ethnicity := range vietnamEthnicities {
+		ethnicities = append(ethnicities, domain.Ethnicity{
+			Code:        ethnicity.code,
+			Name:        map[string]string{"en": ethnicity.en, "vi": ethnicity.vi},
+			CountryCode: "VN",
+			Status:      "active",
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		})
+	}
+
+	_, err = collection.InsertMany(ctx, ethnicities)
+	return err
+}
+
 func seedCurrencies(ctx context.Context, db *mongo.Database) error {
 	collection := db.Collection("currencies")
 
@@ -216,3 +315,44 @@ func seedRoles(ctx context.Context, db *mongo.Database) error {
 	_, err = collection.InsertMany(ctx, roles)
 	return err
 }
+
+// backfillLocationAncestors copies the country of each province onto its districts, then the province
+// and country of each district onto its wards, for units stored without them
+func backfillLocationAncestors(ctx context.Context, db *mongo.Database) error {
+	steps := []struct {
+		collection, from, localField string
+		set                          bson.M
+	}{
+		{"districts", "provinces", "provinceCode", bson.M{"countryCode": "$parent.countryCode"}},
+		{"wards", "districts", "districtCode", bson.M{"provinceCode": "$parent.provinceCode", "countryCode": "$parent.countryCode"}},
+	}
+
+	for _, step := range steps {
+		collection := db.Collection(step.collection)
+
+		// Skip when every unit already records its country
+		count, err := collection.CountDocuments(ctx, bson.M{"countryCode": bson.M{"$exists": false}})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			continue
+		}
+
+		pipeline := mongo.Pipeline{
+			{{Key: "$match", Value: bson.M{"countryCode": bson.M{"$exists": false}}}},
+			{{Key: "$lookup", Value: bson.M{"from": step.from, "localField": step.localField, "foreignField": "code", "as": "parent"}}},
+			{{Key: "$unwind", Value: "$parent"}},
+			{{Key: "$project", Value: step.set}},
+			{{Key: "$merge", Value: bson.M{"into": step.collection, "on": "_id", "whenMatched": "merge", "whenNotMatched": "discard"}}},
+		}
+		cursor, err := collection.Aggregate(ctx, pipeline)
+		if err != nil {
+			return err
+		}
+		if err := cursor.Close(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
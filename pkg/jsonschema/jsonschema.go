@@ -0,0 +1,673 @@
+// Package jsonschema validates JSON documents against JSON Schema.
+//
+// It implements the validation keywords of JSON Schema draft 2020-12 for self-contained schemas.
+// Keywords it cannot enforce, such as "$ref" or "if", are rejected by Compile rather than
+// silently ignored; annotations such as "title" and unknown keywords are ignored. Values are JSON
+// decoded values: maps, slices, strings, bools, nil and numbers of any Go numeric type.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// unsupported lists the keywords that Compile rejects
+var unsupported = []string{
+	"$ref", "$dynamicRef", "$recursiveRef", "$defs", "definitions", "if", "then", "else",
+	"dependentRequired", "dependentSchemas", "dependencies", "patternProperties", "propertyNames",
+	"prefixItems", "contains", "minContains", "maxContains", "unevaluatedItems", "unevaluatedProperties",
+}
+
+var types = []string{"null", "boolean", "object", "array", "number", "integer", "string"}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Schema is a compiled JSON Schema
+type Schema struct {
+	always *bool // boolean schemas accept or reject everything
+
+	types      []string
+	enum       []interface{}
+	constValue interface{}
+	hasConst   bool
+	def        interface{}
+	hasDefault bool
+
+	properties    map[string]*Schema
+	propertyNames []string // sorted, for stable reports
+	required      []string
+	additional    *Schema
+	minProperties *int
+	maxProperties *int
+
+	items       *Schema
+	minItems    *int
+	maxItems    *int
+	uniqueItems bool
+
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+	multipleOf       *float64
+
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+	format    string
+
+	allOf []*Schema
+	anyOf []*Schema
+	oneOf []*Schema
+	not   *Schema
+}
+
+// Violation is a value that does not conform to a schema
+type Violation struct {
+	Path    string `json:"path"`    // JSON pointer of the value, "" for the whole document
+	Keyword string `json:"keyword"` // keyword that failed, such as "type" or "required"
+	Message string `json:"message"`
+}
+
+// Compile compiles a schema document: an object, or true or false
+func Compile(doc interface{}) (*Schema, error) {
+	return compile(doc, "")
+}
+
+func compile(doc interface{}, path string) (*Schema, error) {
+	if b, ok := doc.(bool); ok {
+		return &Schema{always: &b}, nil
+	}
+	m, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, invalid(path, "schema must be an object or a boolean")
+	}
+	for _, keyword := range unsupported {
+		if _, found := m[keyword]; found {
+			return nil, invalid(path, "keyword %s is not supported", keyword)
+		}
+	}
+
+	s := &Schema{}
+	var err error
+	if s.types, err = compileTypes(m["type"], path); err != nil {
+		return nil, err
+	}
+	if value, found := m["enum"]; found {
+		list, ok := value.([]interface{})
+		if !ok || len(list) == 0 {
+			return nil, invalid(path, "enum must be a non-empty array")
+		}
+		s.enum = list
+	}
+	s.constValue, s.hasConst = m["const"]
+	s.def, s.hasDefault = m["default"]
+
+	if value, found := m["properties"]; found {
+		props, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, invalid(path, "properties must be an object")
+		}
+		s.properties = make(map[string]*Schema, len(props))
+		for name := range props {
+			s.propertyNames = append(s.propertyNames, name)
+		}
+		sort.Strings(s.propertyNames)
+		for _, name := range s.propertyNames {
+			if s.properties[name], err = compile(props[name], path+"/properties/"+escape(name)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if value, found := m["required"]; found {
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil, invalid(path, "required must be an array of property names")
+		}
+		for _, item := range list {
+			name, ok := item.(string)
+			if !ok {
+				return nil, invalid(path, "required must be an array of property names")
+			}
+			s.required = append(s.required, name)
+		}
+	}
+	if value, found := m["additionalProperties"]; found {
+		if s.additional, err = compile(value, path+"/additionalProperties"); err != nil {
+			return nil, err
+		}
+	}
+	if value, found := m["items"]; found {
+		if s.items, err = compile(value, path+"/items"); err != nil {
+			return nil, err
+		}
+	}
+	if value, found := m["uniqueItems"]; found {
+		if s.uniqueItems, ok = value.(bool); !ok {
+			return nil, invalid(path, "uniqueItems must be a boolean")
+		}
+	}
+	if value, found := m["pattern"]; found {
+		text, ok := value.(string)
+		if !ok {
+			return nil, invalid(path, "pattern must be a string")
+		}
+		if s.pattern, err = regexp.Compile(text); err != nil {
+			return nil, invalid(path, "pattern is not a valid regular expression")
+		}
+	}
+	if value, found := m["format"]; found {
+		if s.format, ok = value.(string); !ok {
+			return nil, invalid(path, "format must be a string")
+		}
+	}
+
+	for _, bound := range []struct {
+		keyword string
+		dest    **int
+	}{
+		{"minProperties", &s.minProperties}, {"maxProperties", &s.maxProperties},
+		{"minItems", &s.minItems}, {"maxItems", &s.maxItems},
+		{"minLength", &s.minLength}, {"maxLength", &s.maxLength},
+	} {
+		if value, found := m[bound.keyword]; found {
+			n, ok := number(value)
+			if !ok || n < 0 || n != math.Trunc(n) {
+				return nil, invalid(path, "%s must be a non-negative integer", bound.keyword)
+			}
+			count := int(n)
+			*bound.dest = &count
+		}
+	}
+	for _, bound := range []struct {
+		keyword string
+		dest    **float64
+	}{
+		{"minimum", &s.minimum}, {"maximum", &s.maximum},
+		{"exclusiveMinimum", &s.exclusiveMinimum}, {"exclusiveMaximum", &s.exclusiveMaximum},
+		{"multipleOf", &s.multipleOf},
+	} {
+		if value, found := m[bound.keyword]; found {
+			n, ok := number(value)
+			if !ok {
+				return nil, invalid(path, "%s must be a number", bound.keyword)
+			}
+			*bound.dest = &n
+		}
+	}
+	if s.multipleOf != nil && *s.multipleOf <= 0 {
+		return nil, invalid(path, "multipleOf must be greater than 0")
+	}
+
+	for _, combinator := range []struct {
+		keyword string
+		dest    *[]*Schema
+	}{{"allOf", &s.allOf}, {"anyOf", &s.anyOf}, {"oneOf", &s.oneOf}} {
+		value, found := m[combinator.keyword]
+		if !found {
+			continue
+		}
+		list, ok := value.([]interface{})
+		if !ok || len(list) == 0 {
+			return nil, invalid(path, "%s must be a non-empty array of schemas", combinator.keyword)
+		}
+		for i, item := range list {
+			sub, err := compile(item, path+"/"+combinator.keyword+"/"+strconv.Itoa(i))
+			if err != nil {
+				return nil, err
+			}
+			*combinator.dest = append(*combinator.dest, sub)
+		}
+	}
+	if value, found := m["not"]; found {
+		if s.not, err = compile(value, path+"/not"); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// compileTypes reads the type keyword: a type name or an array of them
+func compileTypes(value interface{}, path string) ([]string, error) {
+	var names []interface{}
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		names = []interface{}{v}
+	case []interface{}:
+		names = v
+	}
+	if len(names) == 0 {
+		return nil, invalid(path, "type must be a type name or an array of them")
+	}
+
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		text, ok := name.(string)
+		if !ok || !slices.Contains(types, text) {
+			return nil, invalid(path, "type must be one of %s", strings.Join(types, ", "))
+		}
+		out = append(out, text)
+	}
+	return out, nil
+}
+
+// Validate returns every violation of the schema by a value, in document order
+func (s *Schema) Validate(value interface{}) []Violation {
+	var violations []Violation
+	s.validate(value, "", &violations)
+	return violations
+}
+
+// Valid reports whether a value conforms to the schema
+func (s *Schema) Valid(value interface{}) bool {
+	return len(s.Validate(value)) == 0
+}
+
+func (s *Schema) validate(value interface{}, path string, out *[]Violation) {
+	fail := func(keyword, format string, args ...interface{}) {
+		*out = append(*out, Violation{Path: path, Keyword: keyword, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.always != nil {
+		if !*s.always {
+			fail("false", "is not allowed")
+		}
+		return
+	}
+
+	if len(s.types) > 0 && !matchesType(s.types, value) {
+		fail("type", "must be of type %s", strings.Join(s.types, " or "))
+	}
+	if s.enum != nil {
+		allowed := false
+		for _, candidate := range s.enum {
+			allowed = allowed || equal(candidate, value)
+		}
+		if !allowed {
+			fail("enum", "must be one of %s", describeAll(s.enum))
+		}
+	}
+	if s.hasConst && !equal(s.constValue, value) {
+		fail("const", "must be %s", describe(s.constValue))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		s.validateObject(v, path, out, fail)
+	case []interface{}:
+		s.validateArray(v, path, out, fail)
+	case string:
+		s.validateString(v, fail)
+	default:
+		if n, ok := number(value); ok {
+			s.validateNumber(n, fail)
+		}
+	}
+
+	for _, sub := range s.allOf {
+		sub.validate(value, path, out)
+	}
+	if len(s.anyOf) > 0 {
+		matched := false
+		for _, sub := range s.anyOf {
+			matched = matched || sub.Valid(value)
+		}
+		if !matched {
+			fail("anyOf", "must match at least one of the anyOf schemas")
+		}
+	}
+	if len(s.oneOf) > 0 {
+		matched := 0
+		for _, sub := range s.oneOf {
+			if sub.Valid(value) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			fail("oneOf", "must match exactly one of the oneOf schemas, matched %d", matched)
+		}
+	}
+	if s.not != nil && s.not.Valid(value) {
+		fail("not", "must not match the not schema")
+	}
+}
+
+// validateObject applies the object keywords. Missing required properties and properties that
+// are not allowed are reported at their own paths.
+func (s *Schema) validateObject(v map[string]interface{}, path string, out *[]Violation, fail func(string, string, ...interface{})) {
+	if s.minProperties != nil && len(v) < *s.minProperties {
+		fail("minProperties", "must have at least %d properties", *s.minProperties)
+	}
+	if s.maxProperties != nil && len(v) > *s.maxProperties {
+		fail("maxProperties", "must have at most %d properties", *s.maxProperties)
+	}
+	for _, name := range s.required {
+		if _, found := v[name]; !found {
+			*out = append(*out, Violation{Path: path + "/" + escape(name), Keyword: "required", Message: "is required"})
+		}
+	}
+
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if prop, found := s.properties[name]; found {
+			prop.validate(v[name], path+"/"+escape(name), out)
+		} else if s.additional != nil {
+			s.additional.validate(v[name], path+"/"+escape(name), out)
+		}
+	}
+}
+
+func (s *Schema) validateArray(v []interface{}, path string, out *[]Violation, fail func(string, string, ...interface{})) {
+	if s.minItems != nil && len(v) < *s.minItems {
+		fail("minItems", "must have at least %d items", *s.minItems)
+	}
+	if s.maxItems != nil && len(v) > *s.maxItems {
+		fail("maxItems", "must have at most %d items", *s.maxItems)
+	}
+	if s.uniqueItems {
+	unique:
+		for i := range v {
+			for j := 0; j < i; j++ {
+				if equal(v[i], v[j]) {
+					fail("uniqueItems", "must not contain duplicate items")
+					break unique
+				}
+			}
+		}
+	}
+	if s.items != nil {
+		for i, item := range v {
+			s.items.validate(item, path+"/"+strconv.Itoa(i), out)
+		}
+	}
+}
+
+func (s *Schema) validateString(v string, fail func(string, string, ...interface{})) {
+	length := utf8.RuneCountInString(v)
+	if s.minLength != nil && length < *s.minLength {
+		fail("minLength", "must be at least %d characters long", *s.minLength)
+	}
+	if s.maxLength != nil && length > *s.maxLength {
+		fail("maxLength", "must be at most %d characters long", *s.maxLength)
+	}
+	if s.pattern != nil && !s.pattern.MatchString(v) {
+		fail("pattern", "must match %s", s.pattern)
+	}
+	if s.format != "" && !validFormat(s.format, v) {
+		fail("format", "must be a valid %s", s.format)
+	}
+}
+
+func (s *Schema) validateNumber(n float64, fail func(string, string, ...interface{})) {
+	if s.minimum != nil && n < *s.minimum {
+		fail("minimum", "must be at least %s", formatNumber(*s.minimum))
+	}
+	if s.maximum != nil && n > *s.maximum {
+		fail("maximum", "must be at most %s", formatNumber(*s.maximum))
+	}
+	if s.exclusiveMinimum != nil && n <= *s.exclusiveMinimum {
+		fail("exclusiveMinimum", "must be greater than %s", formatNumber(*s.exclusiveMinimum))
+	}
+	if s.exclusiveMaximum != nil && n >= *s.exclusiveMaximum {
+		fail("exclusiveMaximum", "must be less than %s", formatNumber(*s.exclusiveMaximum))
+	}
+	if s.multipleOf != nil {
+		quotient := n / *s.multipleOf
+		if math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			fail("multipleOf", "must be a multiple of %s", formatNumber(*s.multipleOf))
+		}
+	}
+}
+
+// ApplyDefaults fills the properties missing from the objects of a value with the defaults their
+// schemas declare, descending through properties, additional properties, items and allOf, and
+// reports whether it added any. Objects and arrays are filled in place; a nil value is replaced
+// by the default of the schema.
+func (s *Schema) ApplyDefaults(value interface{}) (interface{}, bool) {
+	if value == nil && s.hasDefault {
+		return copyValue(s.def), true
+	}
+	return value, s.fill(value)
+}
+
+func (s *Schema) fill(value interface{}) bool {
+	changed := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.propertyNames {
+			prop := s.properties[name]
+			if current, found := v[name]; found {
+				changed = prop.fill(current) || changed
+			} else if prop.hasDefault {
+				v[name] = copyValue(prop.def)
+				changed = true
+			}
+		}
+		if s.additional != nil {
+			for name, current := range v {
+				if _, found := s.properties[name]; !found {
+					changed = s.additional.fill(current) || changed
+				}
+			}
+		}
+	case []interface{}:
+		if s.items != nil {
+			for _, item := range v {
+				changed = s.items.fill(item) || changed
+			}
+		}
+	}
+	for _, sub := range s.allOf {
+		changed = sub.fill(value) || changed
+	}
+	return changed
+}
+
+// Pointer builds the JSON pointer of a path of property names and array indexes
+func Pointer(tokens ...string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString("/")
+		b.WriteString(escape(token))
+	}
+	return b.String()
+}
+
+// Tokens splits a JSON pointer into its unescaped property names and array indexes
+func Tokens(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens
+}
+
+func escape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func invalid(path, format string, args ...interface{}) error {
+	if path == "" {
+		return fmt.Errorf(format, args...)
+	}
+	return fmt.Errorf("%s at %s", fmt.Sprintf(format, args...), path)
+}
+
+func matchesType(names []string, value interface{}) bool {
+	for _, name := range names {
+		switch name {
+		case "null":
+			if value == nil {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "object":
+			if _, ok := value.(map[string]interface{}); ok {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]interface{}); ok {
+				return true
+			}
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case "number":
+			if _, ok := number(value); ok {
+				return true
+			}
+		case "integer":
+			if n, ok := number(value); ok && n == math.Trunc(n) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func validFormat(format, v string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, v)
+		return err == nil
+	case "date":
+		_, err := time.Parse("2006-01-02", v)
+		return err == nil
+	case "email":
+		address, err := mail.ParseAddress(v)
+		return err == nil && address.Address == v
+	case "uri":
+		u, err := url.Parse(v)
+		return err == nil && u.Scheme != ""
+	case "uuid":
+		return uuidPattern.MatchString(v)
+	case "ipv4":
+		ip := net.ParseIP(v)
+		return ip != nil && ip.To4() != nil && !strings.Contains(v, ":")
+	case "ipv6":
+		ip := net.ParseIP(v)
+		return ip != nil && strings.Contains(v, ":")
+	default:
+		// Unknown formats are annotations
+		return true
+	}
+}
+
+// number reads a number however it was decoded
+func number(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// equal compares JSON values, numbers by value whatever their Go type
+func equal(a, b interface{}) bool {
+	if x, ok := number(a); ok {
+		y, ok := number(b)
+		return ok && x == y
+	}
+	switch x := a.(type) {
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, item := range x {
+			other, found := y[key]
+			if !found || !equal(item, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case nil, bool, string:
+		return a == b
+	}
+	return false
+}
+
+// copyValue deep-copies maps and slices so that defaults are never shared between documents
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = copyValue(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = copyValue(item)
+		}
+		return out
+	}
+	return value
+}
+
+func describe(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func describeAll(values []interface{}) string {
+	out := make([]string, len(values))
+	for i, value := range values {
+		out[i] = describe(value)
+	}
+	return strings.Join(out, ", ")
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
@@ -0,0 +1,165 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decode parses a JSON document the way request bodies are decoded
+func decode(t *testing.T, text string) interface{} {
+	t.Helper()
+
+	var value interface{}
+	require.NoError(t, json.Unmarshal([]byte(text), &value))
+	return value
+}
+
+func compileJSON(t *testing.T, text string) *Schema {
+	t.Helper()
+
+	schema, err := Compile(decode(t, text))
+	require.NoError(t, err)
+	return schema
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		schema string
+		err    string
+	}{
+		{`{"type": "object", "properties": {"theme": {"enum": ["light", "dark"]}}}`, ""},
+		{`true`, ""},
+		{`"object"`, "schema must be an object or a boolean"},
+		{`{"type": "decimal"}`, "type must be one of null, boolean, object, array, number, integer, string"},
+		{`{"properties": {"a/b": {"minimum": "1"}}}`, "minimum must be a number at /properties/a~1b"},
+		{`{"items": {"$ref": "#/$defs/item"}}`, "keyword $ref is not supported at /items"},
+		{`{"anyOf": []}`, "anyOf must be a non-empty array of schemas"},
+		{`{"minLength": -1}`, "minLength must be a non-negative integer"},
+		{`{"multipleOf": 0}`, "multipleOf must be greater than 0"},
+		{`{"pattern": "("}`, "pattern is not a valid regular expression"},
+		{`{"required": ["a", 1]}`, "required must be an array of property names"},
+	}
+
+	for _, tt := range tests {
+		_, err := Compile(decode(t, tt.schema))
+		if tt.err == "" {
+			assert.NoError(t, err, tt.schema)
+		} else {
+			assert.EqualError(t, err, tt.err, tt.schema)
+		}
+	}
+}
+
+func TestSchema_Validate(t *testing.T) {
+	schema := compileJSON(t, `{
+		"type": "object",
+		"required": ["theme", "widgets"],
+		"additionalProperties": false,
+		"properties": {
+			"theme": {"type": "string", "enum": ["light", "dark"]},
+			"refresh": {"type": "integer", "minimum": 5, "maximum": 3600},
+			"ratio": {"type": "number", "exclusiveMinimum": 0, "multipleOf": 0.25},
+			"contact": {"type": "string", "format": "email", "maxLength": 20},
+			"widgets": {
+				"type": "array", "minItems": 1, "uniqueItems": true,
+				"items": {"type": "object", "required": ["id"], "properties": {"id": {"type": "string", "pattern": "^[a-z]+$"}}}
+			},
+			"a/b": {"const": true}
+		}
+	}`)
+
+	assert.Empty(t, schema.Validate(decode(t, `{"theme": "dark", "refresh": 60, "ratio": 1.5, "contact": "ops@example.com", "widgets": [{"id": "sales"}]}`)))
+	assert.True(t, schema.Valid(decode(t, `{"theme": "light", "widgets": [{"id": "users"}], "a/b": true}`)))
+	assert.True(t, schema.Valid(map[string]interface{}{"theme": "light", "refresh": int64(60), "widgets": []interface{}{map[string]interface{}{"id": "x"}}}),
+		"numbers of any Go type are accepted")
+
+	violations := schema.Validate(decode(t, `{
+		"theme": "blue", "refresh": 2.5, "ratio": 0.3, "contact": "not an address",
+		"widgets": [{"id": "Sales"}, {}, {}], "extra": 1, "a/b": false
+	}`))
+	assert.Equal(t, []Violation{
+		{Path: "/a~1b", Keyword: "const", Message: "must be true"},
+		{Path: "/contact", Keyword: "format", Message: "must be a valid email"},
+		{Path: "/extra", Keyword: "false", Message: "is not allowed"},
+		{Path: "/ratio", Keyword: "multipleOf", Message: "must be a multiple of 0.25"},
+		{Path: "/refresh", Keyword: "type", Message: "must be of type integer"},
+		{Path: "/refresh", Keyword: "minimum", Message: "must be at least 5"},
+		{Path: "/theme", Keyword: "enum", Message: `must be one of "light", "dark"`},
+		{Path: "/widgets", Keyword: "uniqueItems", Message: "must not contain duplicate items"},
+		{Path: "/widgets/0/id", Keyword: "pattern", Message: "must match ^[a-z]+$"},
+		{Path: "/widgets/1/id", Keyword: "required", Message: "is required"},
+		{Path: "/widgets/2/id", Keyword: "required", Message: "is required"},
+	}, violations)
+
+	violations = schema.Validate(decode(t, `{}`))
+	assert.Equal(t, []Violation{
+		{Path: "/theme", Keyword: "required", Message: "is required"},
+		{Path: "/widgets", Keyword: "required", Message: "is required"},
+	}, violations)
+
+	violations = schema.Validate(decode(t, `["dark"]`))
+	assert.Equal(t, []Violation{{Path: "", Keyword: "type", Message: "must be of type object"}}, violations)
+}
+
+func TestSchema_Combinators(t *testing.T) {
+	schema := compileJSON(t, `{
+		"properties": {
+			"storage": {"anyOf": [{"type": "integer", "minimum": 0}, {"const": "unlimited"}, {"type": "string", "pattern": "^[0-9]+(MB|GB)$"}]},
+			"tier": {"oneOf": [{"type": "string"}, {"type": ["string", "null"]}]},
+			"name": {"allOf": [{"minLength": 2}, {"maxLength": 4}], "not": {"const": "root"}}
+		}
+	}`)
+
+	assert.True(t, schema.Valid(decode(t, `{"storage": "10GB", "tier": null, "name": "ops"}`)))
+	assert.True(t, schema.Valid(decode(t, `{"storage": 1024}`)))
+	assert.Equal(t, []Violation{
+		{Path: "/name", Keyword: "maxLength", Message: "must be at most 4 characters long"},
+		{Path: "/storage", Keyword: "anyOf", Message: "must match at least one of the anyOf schemas"},
+		{Path: "/tier", Keyword: "oneOf", Message: "must match exactly one of the oneOf schemas, matched 2"},
+	}, schema.Validate(decode(t, `{"storage": -1, "tier": "basic", "name": "admin"}`)))
+	assert.Equal(t, []Violation{{Path: "/name", Keyword: "not", Message: "must not match the not schema"}},
+		schema.Validate(decode(t, `{"name": "root"}`)))
+}
+
+func TestSchema_ApplyDefaults(t *testing.T) {
+	schema := compileJSON(t, `{
+		"type": "object",
+		"properties": {
+			"theme": {"type": "string", "default": "light"},
+			"layout": {"type": "object", "default": {"columns": 2}},
+			"widgets": {"type": "array", "items": {"type": "object", "properties": {"size": {"default": "md"}}}},
+			"refresh": {"type": "integer"}
+		}
+	}`)
+
+	value := decode(t, `{"theme": "dark", "widgets": [{"id": "sales"}, {"id": "users", "size": "lg"}], "refresh": null}`)
+	filled, changed := schema.ApplyDefaults(value)
+	assert.True(t, changed)
+	assert.Equal(t, decode(t, `{
+		"theme": "dark", "layout": {"columns": 2}, "refresh": null,
+		"widgets": [{"id": "sales", "size": "md"}, {"id": "users", "size": "lg"}]
+	}`), filled, "only missing properties are filled")
+
+	// Defaults are copied into every document
+	filled.(map[string]interface{})["layout"].(map[string]interface{})["columns"] = 3.0
+	other, _ := schema.ApplyDefaults(map[string]interface{}{})
+	assert.Equal(t, 2.0, other.(map[string]interface{})["layout"].(map[string]interface{})["columns"])
+
+	_, changed = schema.ApplyDefaults(filled)
+	assert.False(t, changed)
+
+	root := compileJSON(t, `{"type": "object", "default": {}}`)
+	filled, changed = root.ApplyDefaults(nil)
+	assert.True(t, changed)
+	assert.Equal(t, map[string]interface{}{}, filled)
+}
+
+func TestPointer(t *testing.T) {
+	assert.Equal(t, "", Pointer())
+	assert.Equal(t, "/limits/a~1b/m~0n/0", Pointer("limits", "a/b", "m~n", "0"))
+	assert.Equal(t, []string{"limits", "a/b", "m~n", "0"}, Tokens("/limits/a~1b/m~0n/0"))
+	assert.Nil(t, Tokens(""))
+}
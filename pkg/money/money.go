@@ -0,0 +1,200 @@
+// Package money formats and parses currency amounts for a locale.
+//
+// It is driven by the currency records of the system config service (code, symbol and decimal
+// digits) so that every frontend and service writes and reads amounts the same way, e.g.
+// "1.000.000 ₫" in Vietnamese and "$1,000.00" in US English.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DefaultLocale is used for tags that match no known locale
+const DefaultLocale = "en"
+
+// ErrInvalidAmount is returned by Parse for text that is not an amount in the locale
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// Currency describes how amounts of a currency are written
+type Currency struct {
+	Code          string `json:"code"` // ISO 4217
+	Symbol        string `json:"symbol"`
+	DecimalDigits int    `json:"decimal_digits"`
+}
+
+// Locale holds the number and currency conventions of a locale
+type Locale struct {
+	Tag         string `json:"tag"`
+	Group       string `json:"group"`        // thousands separator
+	Decimal     string `json:"decimal"`      // decimal separator
+	SymbolFirst bool   `json:"symbol_first"` // symbol before the number
+	SymbolSpace bool   `json:"symbol_space"` // space between symbol and number
+}
+
+// locales maps lower-cased tags to their conventions; regional tags override their language
+var locales = map[string]Locale{
+	"en":    {Tag: "en", Group: ",", Decimal: ".", SymbolFirst: true},
+	"en-us": {Tag: "en-US", Group: ",", Decimal: ".", SymbolFirst: true},
+	"en-gb": {Tag: "en-GB", Group: ",", Decimal: ".", SymbolFirst: true},
+	"vi":    {Tag: "vi", Group: ".", Decimal: ",", SymbolSpace: true},
+	"vi-vn": {Tag: "vi-VN", Group: ".", Decimal: ",", SymbolSpace: true},
+	"de":    {Tag: "de", Group: ".", Decimal: ",", SymbolSpace: true},
+	"de-ch": {Tag: "de-CH", Group: "’", Decimal: ".", SymbolFirst: true, SymbolSpace: true},
+	"fr":    {Tag: "fr", Group: "\u202f", Decimal: ",", SymbolSpace: true},
+	"es":    {Tag: "es", Group: ".", Decimal: ",", SymbolSpace: true},
+	"it":    {Tag: "it", Group: ".", Decimal: ",", SymbolSpace: true},
+	"pt":    {Tag: "pt", Group: ".", Decimal: ",", SymbolFirst: true, SymbolSpace: true},
+	"id":    {Tag: "id", Group: ".", Decimal: ",", SymbolFirst: true},
+	"ja":    {Tag: "ja", Group: ",", Decimal: ".", SymbolFirst: true},
+	"ko":    {Tag: "ko", Group: ",", Decimal: ".", SymbolFirst: true},
+	"zh":    {Tag: "zh", Group: ",", Decimal: ".", SymbolFirst: true},
+	"th":    {Tag: "th", Group: ",", Decimal: ".", SymbolFirst: true},
+}
+
+// LookupLocale returns the conventions of a BCP 47 tag such as "vi", "vi-VN" or "en_US",
+// falling back from the region to the language. It reports false, returning DefaultLocale,
+// when neither is known.
+func LookupLocale(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if locale, ok := locales[tag]; ok {
+		return locale, true
+	}
+	if language, _, found := strings.Cut(tag, "-"); found {
+		if locale, ok := locales[language]; ok {
+			return locale, true
+		}
+	}
+	return locales[DefaultLocale], false
+}
+
+// Round rounds an amount half away from zero to the given number of decimal digits. The amount
+// is taken at its shortest decimal representation, so 1.005 rounds to 1.01.
+func Round(amount float64, digits int) float64 {
+	rounded, _ := strconv.ParseFloat(decimalString(amount, digits), 64)
+	return rounded
+}
+
+// FormatNumber writes a number rounded to digits decimal places with the separators of the locale
+func (l Locale) FormatNumber(value float64, digits int) string {
+	text := decimalString(value, digits)
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(text, "-")
+
+	integer, fraction, _ := strings.Cut(text, ".")
+	var b strings.Builder
+	if negative && strings.Trim(text, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(l.Group)
+		}
+		b.WriteRune(digit)
+	}
+	if fraction != "" {
+		b.WriteString(l.Decimal)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// Format writes an amount of a currency rounded to its decimal digits, with its symbol placed
+// as the locale does. The ISO code, always set apart by a space, stands in for a missing symbol.
+func (l Locale) Format(amount float64, currency Currency) string {
+	number := l.FormatNumber(amount, currency.DecimalDigits)
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign, number = "-", number[1:]
+	}
+
+	symbol, space := currency.Symbol, ""
+	if symbol == "" {
+		symbol = currency.Code
+	}
+	if l.SymbolSpace || currency.Symbol == "" {
+		space = " "
+	}
+
+	if l.SymbolFirst {
+		return sign + symbol + space + number
+	}
+	return sign + number + space + symbol
+}
+
+// Parse reads an amount of a currency written in the locale, with or without its symbol or
+// code. Group separators must split the integer part into groups of three digits, and the
+// fraction may not have more digits than the currency uses.
+func (l Locale) Parse(text string, currency Currency) (float64, error) {
+	original := text
+	if currency.Symbol != "" {
+		text = strings.ReplaceAll(text, currency.Symbol, "")
+	}
+	if currency.Code != "" {
+		text = strings.ReplaceAll(text, currency.Code, "")
+		text = strings.ReplaceAll(text, strings.ToLower(currency.Code), "")
+	}
+	// Spaces, including a space group separator, carry no value
+	text = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\u2212': // minus sign
+			return '-'
+		case unicode.IsSpace(r):
+			return -1
+		}
+		return r
+	}, text)
+
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(text, "-")
+
+	integer, fraction, hasFraction := strings.Cut(text, l.Decimal)
+	if integer == "" || !digitsOnly(fraction) || (hasFraction && fraction == "") {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, original)
+	}
+	if len(fraction) > currency.DecimalDigits {
+		return 0, fmt.Errorf("%w: %q has more than %d decimal digits", ErrInvalidAmount, original, currency.DecimalDigits)
+	}
+
+	groups := []string{integer}
+	if group := strings.TrimSpace(l.Group); group != "" {
+		groups = strings.Split(integer, group)
+	}
+	for i, digits := range groups {
+		if digits == "" || !digitsOnly(digits) || (len(groups) > 1 && (len(digits) > 3 || i > 0 && len(digits) != 3)) {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, original)
+		}
+	}
+
+	value, err := strconv.ParseFloat(strings.Join(groups, "")+"."+fraction+"0", 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, original)
+	}
+	if negative {
+		value = -value
+	}
+	return value, nil
+}
+
+// decimalString writes value with digits decimal places, rounding its shortest decimal
+// representation half away from zero
+func decimalString(value float64, digits int) string {
+	exact, ok := new(big.Rat).SetString(strconv.FormatFloat(value, 'f', -1, 64))
+	if !ok {
+		return strconv.FormatFloat(value, 'f', digits, 64)
+	}
+	return exact.FloatString(digits)
+}
+
+func digitsOnly(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
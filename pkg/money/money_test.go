@@ -0,0 +1,123 @@
+package money
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	vnd = Currency{Code: "VND", Symbol: "₫", DecimalDigits: 0}
+	usd = Currency{Code: "USD", Symbol: "$", DecimalDigits: 2}
+	chf = Currency{Code: "CHF", DecimalDigits: 2}
+	eur = Currency{Code: "EUR", Symbol: "€", DecimalDigits: 2}
+)
+
+func TestLookupLocale(t *testing.T) {
+	locale, ok := LookupLocale("vi-VN")
+	assert.True(t, ok)
+	assert.Equal(t, "vi-VN", locale.Tag)
+
+	locale, ok = LookupLocale("en_us")
+	assert.True(t, ok)
+	assert.Equal(t, "en-US", locale.Tag)
+
+	// An unknown region falls back to the language, an unknown language to the default
+	locale, ok = LookupLocale("vi-XX")
+	assert.True(t, ok)
+	assert.Equal(t, "vi", locale.Tag)
+
+	locale, ok = LookupLocale("xx")
+	assert.False(t, ok)
+	assert.Equal(t, DefaultLocale, locale.Tag)
+}
+
+func TestRound(t *testing.T) {
+	assert.Equal(t, 1.01, Round(1.005, 2))
+	assert.Equal(t, -1.01, Round(-1.005, 2))
+	assert.Equal(t, 1001.0, Round(1000.5, 0))
+	assert.Equal(t, 0.1, Round(0.1234, 1))
+}
+
+func TestLocale_Format(t *testing.T) {
+	tests := []struct {
+		locale   string
+		amount   float64
+		currency Currency
+		want     string
+	}{
+		{"vi", 1000000, vnd, "1.000.000 ₫"},
+		{"vi", 999.6, vnd, "1.000 ₫"},
+		{"en-US", 1000, usd, "$1,000.00"},
+		{"en-US", -1234.567, usd, "-$1,234.57"},
+		{"en-US", -0.001, usd, "$0.00"},
+		{"de", 1234.5, eur, "1.234,50 €"},
+		{"de-CH", 1234567.891, chf, "CHF 1’234’567.89"},
+		{"fr", 1234.5, eur, "1 234,50 €"},
+		{"en", 12.3, chf, "CHF 12.30"},
+		{"en", 12, Currency{Code: "KWD", DecimalDigits: 3}, "KWD 12.000"},
+	}
+
+	for _, tt := range tests {
+		locale, _ := LookupLocale(tt.locale)
+		assert.Equal(t, tt.want, locale.Format(tt.amount, tt.currency), "%s %v %s", tt.locale, tt.amount, tt.currency.Code)
+	}
+}
+
+func TestLocale_Parse(t *testing.T) {
+	vi, _ := LookupLocale("vi")
+	en, _ := LookupLocale("en")
+	fr, _ := LookupLocale("fr")
+
+	tests := []struct {
+		locale   Locale
+		text     string
+		currency Currency
+		want     float64
+	}{
+		{vi, "1.000.000 ₫", vnd, 1000000},
+		{vi, "1000000", vnd, 1000000},
+		{vi, "VND 25.000", vnd, 25000},
+		{en, "$1,000.50", usd, 1000.5},
+		{en, "-$1,234.57", usd, -1234.57},
+		{en, "−5 usd", usd, -5},
+		{en, "0.5", usd, 0.5},
+		{fr, "1 234,50 €", eur, 1234.5},
+	}
+	for _, tt := range tests {
+		got, err := tt.locale.Parse(tt.text, tt.currency)
+		require.NoError(t, err, tt.text)
+		assert.Equal(t, tt.want, got, tt.text)
+	}
+
+	invalid := []struct {
+		locale   Locale
+		text     string
+		currency Currency
+	}{
+		{vi, "1.5", vnd},   // a group of one digit after the separator
+		{vi, "1,5 ₫", vnd}, // VND has no decimals
+		{en, "1,00.00", usd},
+		{en, "12.345", usd},
+		{en, "12.", usd},
+		{en, "$", usd},
+		{en, "abc", usd},
+	}
+	for _, tt := range invalid {
+		_, err := tt.locale.Parse(tt.text, tt.currency)
+		assert.True(t, errors.Is(err, ErrInvalidAmount), tt.text)
+	}
+}
+
+func TestLocale_FormatParseRoundTrip(t *testing.T) {
+	for _, tag := range []string{"en", "vi", "de", "de-CH", "fr", "pt"} {
+		locale, _ := LookupLocale(tag)
+		for _, amount := range []float64{0, 7.25, -1234.5, 9876543.21} {
+			got, err := locale.Parse(locale.Format(amount, usd), usd)
+			require.NoError(t, err, tag)
+			assert.Equal(t, amount, got, tag)
+		}
+	}
+}